@@ -1,6 +1,8 @@
 // Async provides tools for asynchronous callback processing using Goroutines
 package async
 
+import "sync"
+
 // An AsyncRunner is a helper class to spawn Go Routines to run
 // AsyncFunctions and to associate callbacks with them.  This builds
 // ontop of AsyncMailbox to make simplify the code that needs to be written.
@@ -42,12 +44,14 @@ package async
 //  func write (num int, address string) error { ... }
 //
 type Runner struct {
-	bx *Mailbox
+	bx      *Mailbox
+	running *sync.WaitGroup
 }
 
 func NewRunner() Runner {
 	return Runner{
-		bx: NewMailbox(),
+		bx:      NewMailbox(),
+		running: &sync.WaitGroup{},
 	}
 }
 
@@ -59,12 +63,20 @@ func (r *Runner) NumRunning() int {
 // The callback, cb, is invoked once f is completed by calling ProcessMessages.
 func (r *Runner) RunAsync(f func() error, cb AsyncErrorResponseHandler) {
 	asyncErr := r.bx.NewAsyncError(cb)
+	r.running.Add(1)
 	go func(rsp *AsyncError) {
+		defer r.running.Done()
 		err := f()
 		rsp.SetValue(err)
 	}(asyncErr)
 }
 
+// Blocks until every function started by RunAsync has completed.  Their
+// callbacks are still only invoked by ProcessMessages.
+func (r *Runner) Wait() {
+	r.running.Wait()
+}
+
 // Invokes all callbacks of completed asyncfunctions.
 // Callbacks are ran synchronously and by the calling go routine
 func (r *Runner) ProcessMessages() {
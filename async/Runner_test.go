@@ -39,3 +39,25 @@ func storeValue_withRunner(num int) error {
 		return errors.New("Could Not Durably Store Value")
 	}
 }
+
+func Test_Runner_Wait(t *testing.T) {
+	runner := NewRunner()
+	release := make(chan struct{})
+	completed := 0
+	for i := 0; i < 3; i++ {
+		runner.RunAsync(func() error {
+			<-release
+			return nil
+		}, func(err error) { completed++ })
+	}
+
+	close(release)
+	runner.Wait()
+	if completed != 0 {
+		t.Errorf("expected Wait not to invoke callbacks, %v were", completed)
+	}
+	runner.ProcessMessages()
+	if completed != 3 || runner.NumRunning() != 0 {
+		t.Errorf("expected all 3 callbacks to be invoked once completed, %v were", completed)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/scootdev/scoot/common/endpoints"
 	"github.com/scootdev/scoot/common/stats"
 	"github.com/scootdev/scoot/config/jsonconfig"
+	"github.com/scootdev/scoot/config/scootconfig"
 	"github.com/scootdev/scoot/ice"
 	"github.com/scootdev/scoot/os/temp"
 	"github.com/scootdev/scoot/scootapi"
@@ -23,6 +24,8 @@ import (
 func main() {
 	httpAddr := flag.String("http_addr", scootapi.DefaultApiBundlestore_HTTP, "'host:port' addr to serve http on")
 	configFlag := flag.String("config", "{}", "API Server Config (either a filename like local.local or JSON text")
+	bundleTTL := flag.Duration("bundle_ttl", 0, "Delete bundles that haven't been read or written in this long, unless they're pinned. Zero keeps them forever.")
+	gcInterval := flag.Duration("bundle_gc_interval", time.Hour, "With -bundle_ttl, how often to delete old bundles.")
 	flag.Parse()
 
 	// The same config will be used for both bundlestore and frontend (TODO: frontend).
@@ -41,7 +44,13 @@ func main() {
 	}
 
 	bag := ice.NewMagicBag()
-	schema := jsonconfig.EmptySchema()
+	schema := jsonconfig.Schema(map[string]jsonconfig.Implementations{
+		"Bundlestore": {
+			"file": &scootconfig.BundlestoreFileConfig{},
+			"s3":   &scootconfig.BundlestoreS3Config{},
+			"":     &scootconfig.BundlestoreFileConfig{Type: "file"},
+		},
+	})
 	bag.InstallModule(gitdb.Module())
 	bag.InstallModule(temp.Module())
 	bag.InstallModule(bundlestore.Module())
@@ -50,6 +59,9 @@ func main() {
 	bag.PutMany(
 		func() endpoints.StatScope { return "apiserver" },
 		func() endpoints.Addr { return endpoints.Addr(*httpAddr) },
+		func() bundlestore.RetentionPolicy {
+			return bundlestore.RetentionPolicy{TTL: *bundleTTL, Interval: *gcInterval}
+		},
 		func(bs *bundlestore.Server, vs *snapshots.ViewServer, sh *StoreAndHandler) map[string]http.Handler {
 			return map[string]http.Handler{
 				"/bundle/": bs,
@@ -60,7 +72,7 @@ func main() {
 				sh.endpoint: sh.handler,
 			}
 		},
-		func(fileStore *bundlestore.FileStore, stat stats.StatsReceiver, tmp *temp.TempDir) (*StoreAndHandler, error) {
+		func(backend bundlestore.Backend, stat stats.StatsReceiver, tmp *temp.TempDir) (*StoreAndHandler, error) {
 			cfg := &bundlestore.GroupcacheConfig{
 				Name:         "apiserver",
 				Memory_bytes: 2 * 1024 * 1024 * 1024, //2GB
@@ -68,7 +80,7 @@ func main() {
 				Endpoint:     "/groupcache",
 				Cluster:      createCluster(),
 			}
-			store, handler, err := bundlestore.MakeGroupcacheStore(fileStore, cfg, stat)
+			store, handler, err := bundlestore.MakeGroupcacheStore(backend, cfg, stat)
 			if err != nil {
 				return nil, err
 			}
@@ -14,13 +14,17 @@ import (
 	"github.com/scootdev/scoot/binaries/workerserver/config"
 	"github.com/scootdev/scoot/cloud/cluster/local"
 	"github.com/scootdev/scoot/common/endpoints"
+	"github.com/scootdev/scoot/common/stats"
 	"github.com/scootdev/scoot/config/jsonconfig"
 	"github.com/scootdev/scoot/ice"
 	"github.com/scootdev/scoot/os/temp"
 	"github.com/scootdev/scoot/runner"
 	"github.com/scootdev/scoot/runner/execer"
+	"github.com/scootdev/scoot/runner/execer/execers"
+	osexec "github.com/scootdev/scoot/runner/execer/os"
 	"github.com/scootdev/scoot/runner/runners"
 	"github.com/scootdev/scoot/scootapi"
+	"github.com/scootdev/scoot/snapshot"
 	"github.com/scootdev/scoot/snapshot/bundlestore"
 	"github.com/scootdev/scoot/snapshot/git/gitdb"
 	"github.com/scootdev/scoot/snapshot/git/repo"
@@ -32,6 +36,12 @@ var thriftAddr = flag.String("thrift_addr", scootapi.DefaultWorker_Thrift, "addr
 var httpAddr = flag.String("http_addr", scootapi.DefaultWorker_HTTP, "addr to serve http on")
 var configFlag = flag.String("config", "local.local", "Worker Server Config (either a filename like local.local or JSON text")
 var memCapFlag = flag.Uint64("mem_cap", 0, "Kill runs that exceed this amount of memory, in bytes. Zero means no limit.")
+var cgroupFlag = flag.String("cgroup", "", "Run each command in its own cgroup under this one, with the kernel enforcing mem_cap, cpu_cap and pids_cap (Linux only).")
+var cpuCapFlag = flag.Float64("cpu_cap", 0, "With -cgroup, limit runs to this many cores worth of CPU time. Zero means no limit.")
+var pidsCapFlag = flag.Int("pids_cap", 0, "With -cgroup, limit runs to this many processes and threads. Zero means no limit.")
+var sandboxFlag = flag.Bool("sandbox", false, "Run each command in fresh namespaces, with a read-only view of the filesystem but its checkout and no network unless the task asks for it (Linux only).")
+var slotsFlag = flag.Int("slots", 1, "Number of commands to run at once, each in its own checkout. The scheduler is offered as many cpu slots.")
+var runJournalFlag = flag.String("run_journal", "", "File to keep the worker's runs in, so their results can still be queried after it restarts. Runs in progress when it stopped are FAILED.")
 var repoDir = flag.String("repo", "", "Abs dir path to a git repo to run against (don't use important repos yet!).")
 var storeHandle = flag.String("bundlestore", "", "Abs file path or an http 'host:port' to store/get bundles.")
 
@@ -74,6 +84,9 @@ func main() {
 		func() execer.Memory {
 			return execer.Memory(*memCapFlag)
 		},
+		func() runners.Slots {
+			return runners.Slots(*slotsFlag)
+		},
 		// Use storeHandle if provided, else try Fetching, then GetScootApiAddr(), then fallback to tmp file store.
 		func(tmp *temp.TempDir) (bundlestore.Store, error) {
 			if *storeHandle != "" {
@@ -101,6 +114,33 @@ func main() {
 		},
 	)
 
+	if *cgroupFlag != "" || *sandboxFlag {
+		bag.Put(func(m execer.Memory, s stats.StatsReceiver) (execer.Execer, error) {
+			ex := osexec.NewBoundedExecer(m, s)
+			var err error
+			if *cgroupFlag != "" {
+				limits := osexec.CgroupLimits{Memory: m, CPUs: *cpuCapFlag, Pids: *pidsCapFlag}
+				if ex, err = osexec.NewCgroupExecer(*cgroupFlag, limits, s); err != nil {
+					return nil, err
+				}
+			}
+			if *sandboxFlag {
+				if ex, err = osexec.NewSandboxExecer(ex); err != nil {
+					return nil, err
+				}
+			}
+			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), ex), nil
+		})
+	}
+
+	if *runJournalFlag != "" {
+		bag.Put(func(
+			ex execer.Execer, filer snapshot.Filer, output runner.OutputCreator, tmp *temp.TempDir, slots runners.Slots,
+		) (runner.Service, error) {
+			return runners.NewDurableSlotsRunner(ex, filer, output, tmp, int(slots), 0, *runJournalFlag)
+		})
+	}
+
 	log.Println("Serving thrift on", *thriftAddr) //It's hard to access the thriftAddr value downstream, print it here.
 	server.RunServer(bag, schema, configText)
 }
@@ -0,0 +1,492 @@
+// Package kvstore is a small transactional key-value store kept in a single
+// file, for state that has to survive restarts without running a database.
+//
+// Keys are grouped into buckets.  The file is a log of committed
+// transactions, each written as one checksummed record of the puts and
+// deletes it made, so a transaction is either wholly in the file or, torn by
+// a crash, dropped when the file is next opened.  Where every key's latest
+// value is in the file is kept in memory, values are read from the file when
+// needed.  Once most of the file holds overwritten or deleted values it's
+// compacted by rewriting the live values to a new file.
+package kvstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"sync"
+)
+
+var ErrTxNotWritable = errors.New("kvstore: can't write in a read-only transaction")
+var ErrClosed = errors.New("kvstore: store is closed")
+
+// Each record starts with the length of its payload and the payload's CRC32
+const headerSize = 8
+
+const (
+	opPut    byte = 1
+	opDelete byte = 2
+)
+
+// Bytes the file may hold beyond twice the size of its live values before
+// it's compacted
+const compactionSlack = 4 << 20
+
+// Approximate size of a put in a record besides its bucket, key and value
+const opOverhead = 8
+
+// Size past which compaction starts a new record
+const maxCompactedRecordSize = 1 << 20
+
+// A store.  Safe for concurrent use, transactions that write are serialized,
+// those that only read run concurrently with each other.
+type DB struct {
+	fileName string
+
+	mutex   sync.RWMutex
+	file    *os.File // nil once closed
+	size    int64    // length of the committed records in the file
+	live    int64    // approximate size of the records needed for the live values
+	buckets map[string]map[string]location
+}
+
+// Where a value is in the file
+type location struct {
+	offset int64
+	length int64
+}
+
+type op struct {
+	kind   byte
+	bucket string
+	key    string
+	value  []byte
+
+	// offset of the value in the record's payload
+	valueOffset int64
+}
+
+// Opens the store in the specified file, creating it if it doesn't exist.
+// A torn record at the end of the file, from a crash while committing, is
+// truncated.
+func Open(fileName string) (*DB, error) {
+	file, err := os.OpenFile(fileName, os.O_CREATE|os.O_RDWR, 0666)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &DB{
+		fileName: fileName,
+		file:     file,
+		buckets:  make(map[string]map[string]location),
+	}
+	if err := db.load(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if db.needsCompaction() {
+		if err := db.compact(); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// Replays the records in the file
+func (db *DB) load() error {
+	info, err := db.file.Stat()
+	if err != nil {
+		return err
+	}
+	fileSize := info.Size()
+
+	r := bufio.NewReader(db.file)
+	header := make([]byte, headerSize)
+	var offset int64
+	for offset < fileSize {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				break
+			}
+			return err
+		}
+		length := int64(binary.BigEndian.Uint32(header[0:4]))
+		if offset+headerSize+length > fileSize {
+			break
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return err
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:8]) {
+			break
+		}
+		ops, err := decodeOps(payload)
+		if err != nil {
+			break
+		}
+		db.apply(ops, offset+headerSize)
+		offset += headerSize + length
+	}
+
+	if offset < fileSize {
+		log.Printf("Truncating %v at %v of %v bytes, dropping a torn or corrupt record",
+			db.fileName, offset, fileSize)
+		if err := db.file.Truncate(offset); err != nil {
+			return err
+		}
+		if err := db.file.Sync(); err != nil {
+			return err
+		}
+	}
+	db.size = offset
+	return nil
+}
+
+// Closes the store, transactions started after return ErrClosed
+func (db *DB) Close() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if db.file == nil {
+		return ErrClosed
+	}
+	err := db.file.Close()
+	db.file = nil
+	return err
+}
+
+// Runs fn in a read-only transaction, returning its error
+func (db *DB) View(fn func(tx *Tx) error) error {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	if db.file == nil {
+		return ErrClosed
+	}
+	return fn(&Tx{db: db})
+}
+
+// Runs fn in a transaction that writes.  If fn returns nil the writes are
+// committed, and durable, by the time Update returns.  If fn or committing
+// returns an error none of the writes are made.
+func (db *DB) Update(fn func(tx *Tx) error) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if db.file == nil {
+		return ErrClosed
+	}
+
+	tx := &Tx{db: db, writable: true}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if len(tx.ops) == 0 {
+		return nil
+	}
+	if err := db.commit(tx.ops); err != nil {
+		return err
+	}
+
+	if db.needsCompaction() {
+		if err := db.compact(); err != nil {
+			// the transaction is committed, compaction is retried next commit
+			log.Printf("Error compacting %v: %v", db.fileName, err)
+		}
+	}
+	return nil
+}
+
+// Appends a record with the specified ops to the file and syncs it.  The
+// caller holds the write lock.
+func (db *DB) commit(ops []*op) error {
+	record := encodeRecord(ops)
+	_, err := db.file.WriteAt(record, db.size)
+	if err == nil {
+		err = db.file.Sync()
+	}
+	if err != nil {
+		// drop what may have been written, replaying the file would too
+		db.file.Truncate(db.size)
+		return err
+	}
+	db.apply(ops, db.size+headerSize)
+	db.size += int64(len(record))
+	return nil
+}
+
+// Updates the locations of the values written by the ops of the record whose
+// payload starts at the specified offset
+func (db *DB) apply(ops []*op, payloadOffset int64) {
+	for _, o := range ops {
+		bucket := db.buckets[o.bucket]
+		if old, ok := bucket[o.key]; ok {
+			db.live -= opSize(o.bucket, o.key, old.length)
+			delete(bucket, o.key)
+		}
+		if o.kind == opDelete {
+			if bucket != nil && len(bucket) == 0 {
+				delete(db.buckets, o.bucket)
+			}
+			continue
+		}
+		if bucket == nil {
+			bucket = make(map[string]location)
+			db.buckets[o.bucket] = bucket
+		}
+		length := int64(len(o.value))
+		bucket[o.key] = location{offset: payloadOffset + o.valueOffset, length: length}
+		db.live += opSize(o.bucket, o.key, length)
+	}
+}
+
+func (db *DB) needsCompaction() bool {
+	return db.size > 2*db.live+compactionSlack
+}
+
+// Rewrites the live values to a new file which then replaces the store's
+// file.  The caller holds the write lock, or is opening the store.
+func (db *DB) compact() error {
+	tmpFileName := db.fileName + ".compact"
+	file, err := os.OpenFile(tmpFileName, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0666)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(file)
+	buckets := make(map[string]map[string]location)
+	var size int64
+	var ops []*op
+	var opsSize int64
+
+	// writes the pending ops as a record and notes where their values are
+	flush := func() error {
+		if len(ops) == 0 {
+			return nil
+		}
+		record := encodeRecord(ops)
+		if _, err := w.Write(record); err != nil {
+			return err
+		}
+		for _, o := range ops {
+			if buckets[o.bucket] == nil {
+				buckets[o.bucket] = make(map[string]location)
+			}
+			buckets[o.bucket][o.key] = location{
+				offset: size + headerSize + o.valueOffset,
+				length: int64(len(o.value)),
+			}
+		}
+		size += int64(len(record))
+		ops, opsSize = nil, 0
+		return nil
+	}
+
+	for bucketName, bucket := range db.buckets {
+		for key, loc := range bucket {
+			value := make([]byte, loc.length)
+			if _, err := db.file.ReadAt(value, loc.offset); err != nil {
+				file.Close()
+				return err
+			}
+			ops = append(ops, &op{kind: opPut, bucket: bucketName, key: key, value: value})
+			opsSize += opSize(bucketName, key, loc.length)
+			if opsSize >= maxCompactedRecordSize {
+				if err := flush(); err != nil {
+					file.Close()
+					return err
+				}
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := os.Rename(tmpFileName, db.fileName); err != nil {
+		file.Close()
+		return err
+	}
+	syncDir(path.Dir(db.fileName))
+
+	db.file.Close()
+	db.file = file
+	db.size = size
+	db.buckets = buckets
+	return nil
+}
+
+// Makes a rename in the directory durable, best effort
+func syncDir(dirName string) {
+	if dir, err := os.Open(dirName); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+}
+
+// A transaction, valid only during the function it's passed to.  Reads see
+// the transaction's own writes.
+type Tx struct {
+	db       *DB
+	writable bool
+	ops      []*op
+	pending  map[string]map[string]*op // latest op on each key written
+}
+
+// Returns the value of the key in the bucket, nil if there's no such key
+func (tx *Tx) Get(bucket, key string) ([]byte, error) {
+	if o := tx.pending[bucket][key]; o != nil {
+		if o.kind == opDelete {
+			return nil, nil
+		}
+		return append([]byte{}, o.value...), nil
+	}
+
+	loc, ok := tx.db.buckets[bucket][key]
+	if !ok {
+		return nil, nil
+	}
+	value := make([]byte, loc.length)
+	if _, err := tx.db.file.ReadAt(value, loc.offset); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Returns the keys in the bucket, in order
+func (tx *Tx) Keys(bucket string) []string {
+	keys := make([]string, 0, len(tx.db.buckets[bucket])+len(tx.pending[bucket]))
+	for key := range tx.db.buckets[bucket] {
+		if tx.pending[bucket][key] == nil {
+			keys = append(keys, key)
+		}
+	}
+	for key, o := range tx.pending[bucket] {
+		if o.kind == opPut {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Sets the value of the key in the bucket
+func (tx *Tx) Put(bucket, key string, value []byte) error {
+	return tx.write(&op{kind: opPut, bucket: bucket, key: key, value: append([]byte{}, value...)})
+}
+
+// Deletes the key from the bucket, if it's there
+func (tx *Tx) Delete(bucket, key string) error {
+	return tx.write(&op{kind: opDelete, bucket: bucket, key: key})
+}
+
+func (tx *Tx) write(o *op) error {
+	if !tx.writable {
+		return ErrTxNotWritable
+	}
+	if tx.pending == nil {
+		tx.pending = make(map[string]map[string]*op)
+	}
+	if tx.pending[o.bucket] == nil {
+		tx.pending[o.bucket] = make(map[string]*op)
+	}
+	tx.pending[o.bucket][o.key] = o
+	tx.ops = append(tx.ops, o)
+	return nil
+}
+
+func opSize(bucket, key string, valueLength int64) int64 {
+	return int64(len(bucket)+len(key)) + valueLength + opOverhead
+}
+
+// Encodes the ops as a record, setting the offset of each value in the
+// record's payload.  Each op is its kind, then its bucket, key and, for a
+// put, value, each preceded by its length as a uvarint.
+func encodeRecord(ops []*op) []byte {
+	var payload bytes.Buffer
+	lengthBuf := make([]byte, binary.MaxVarintLen64)
+	writeBytes := func(b []byte) {
+		n := binary.PutUvarint(lengthBuf, uint64(len(b)))
+		payload.Write(lengthBuf[:n])
+		payload.Write(b)
+	}
+	for _, o := range ops {
+		payload.WriteByte(o.kind)
+		writeBytes([]byte(o.bucket))
+		writeBytes([]byte(o.key))
+		if o.kind == opPut {
+			n := binary.PutUvarint(lengthBuf, uint64(len(o.value)))
+			payload.Write(lengthBuf[:n])
+			o.valueOffset = int64(payload.Len())
+			payload.Write(o.value)
+		}
+	}
+
+	record := make([]byte, headerSize+payload.Len())
+	binary.BigEndian.PutUint32(record[0:4], uint32(payload.Len()))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(payload.Bytes()))
+	copy(record[headerSize:], payload.Bytes())
+	return record
+}
+
+func decodeOps(payload []byte) ([]*op, error) {
+	var ops []*op
+	r := bytes.NewReader(payload)
+	readBytes := func() ([]byte, error) {
+		length, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if length > uint64(r.Len()) {
+			return nil, fmt.Errorf("length %v past the end of the record", length)
+		}
+		b := make([]byte, length)
+		_, err = io.ReadFull(r, b)
+		return b, err
+	}
+
+	for r.Len() > 0 {
+		kind, _ := r.ReadByte()
+		if kind != opPut && kind != opDelete {
+			return nil, fmt.Errorf("unknown op %v", kind)
+		}
+		bucket, err := readBytes()
+		if err != nil {
+			return nil, err
+		}
+		key, err := readBytes()
+		if err != nil {
+			return nil, err
+		}
+		o := &op{kind: kind, bucket: string(bucket), key: string(key)}
+		if kind == opPut {
+			length, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, err
+			}
+			if length > uint64(r.Len()) {
+				return nil, fmt.Errorf("length %v past the end of the record", length)
+			}
+			o.valueOffset = int64(len(payload) - r.Len())
+			o.value = payload[o.valueOffset : o.valueOffset+int64(length)]
+			r.Seek(int64(length), io.SeekCurrent)
+		}
+		ops = append(ops, o)
+	}
+	return ops, nil
+}
@@ -0,0 +1,196 @@
+package kvstore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func makeTestDB(t *testing.T) (*DB, string) {
+	dirName, err := ioutil.TempDir("", "kvstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileName := path.Join(dirName, "store")
+	db, err := Open(fileName)
+	if err != nil {
+		t.Fatalf("Unexpected Error opening store %v", err)
+	}
+	return db, fileName
+}
+
+func getValue(t *testing.T, db *DB, bucket, key string) []byte {
+	var value []byte
+	if err := db.View(func(tx *Tx) error {
+		var err error
+		value, err = tx.Get(bucket, key)
+		return err
+	}); err != nil {
+		t.Fatalf("Unexpected Error reading %v/%v %v", bucket, key, err)
+	}
+	return value
+}
+
+func TestUpdate_ReopenSeesCommittedWrites(t *testing.T) {
+	db, fileName := makeTestDB(t)
+	defer os.RemoveAll(path.Dir(fileName))
+
+	err := db.Update(func(tx *Tx) error {
+		tx.Put("b1", "k1", []byte("v1"))
+		tx.Put("b1", "k2", []byte("v2"))
+		tx.Put("b2", "k1", []byte{})
+		if v, _ := tx.Get("b1", "k1"); string(v) != "v1" {
+			t.Errorf("Expected the transaction to read its own write, got %q", v)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected Error updating %v", err)
+	}
+	db.Update(func(tx *Tx) error {
+		tx.Put("b1", "k1", []byte("v1.1"))
+		return tx.Delete("b1", "k2")
+	})
+	db.Close()
+
+	db, err = Open(fileName)
+	if err != nil {
+		t.Fatalf("Unexpected Error reopening store %v", err)
+	}
+	if v := getValue(t, db, "b1", "k1"); string(v) != "v1.1" {
+		t.Errorf("Expected the latest value, got %q", v)
+	}
+	if v := getValue(t, db, "b1", "k2"); v != nil {
+		t.Errorf("Expected the deleted key to be gone, got %q", v)
+	}
+	if v := getValue(t, db, "b2", "k1"); v == nil || len(v) != 0 {
+		t.Errorf("Expected an empty value, got %v", v)
+	}
+}
+
+func TestUpdate_ErrorDropsWrites(t *testing.T) {
+	db, fileName := makeTestDB(t)
+	defer os.RemoveAll(path.Dir(fileName))
+
+	failed := errors.New("failed")
+	err := db.Update(func(tx *Tx) error {
+		tx.Put("b", "k", []byte("v"))
+		return failed
+	})
+	if err != failed {
+		t.Errorf("Expected the transaction's error, got %v", err)
+	}
+	if v := getValue(t, db, "b", "k"); v != nil {
+		t.Errorf("Expected no value, got %q", v)
+	}
+
+	err = db.View(func(tx *Tx) error {
+		return tx.Put("b", "k", []byte("v"))
+	})
+	if err != ErrTxNotWritable {
+		t.Errorf("Expected ErrTxNotWritable, got %v", err)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	db, fileName := makeTestDB(t)
+	defer os.RemoveAll(path.Dir(fileName))
+
+	db.Update(func(tx *Tx) error {
+		tx.Put("b", "c", nil)
+		tx.Put("b", "a", nil)
+		return tx.Put("other", "x", nil)
+	})
+	db.Update(func(tx *Tx) error {
+		tx.Put("b", "b", nil)
+		tx.Delete("b", "c")
+		if keys := tx.Keys("b"); !reflect.DeepEqual(keys, []string{"a", "b"}) {
+			t.Errorf("Expected keys [a b], got %v", keys)
+		}
+		return nil
+	})
+}
+
+func TestOpen_TruncatesTornRecord(t *testing.T) {
+	db, fileName := makeTestDB(t)
+	defer os.RemoveAll(path.Dir(fileName))
+
+	db.Update(func(tx *Tx) error { return tx.Put("b", "k1", []byte("v1")) })
+	db.Update(func(tx *Tx) error { return tx.Put("b", "k2", []byte("v2")) })
+	db.Close()
+
+	// a crash part way through writing the second record
+	info, _ := os.Stat(fileName)
+	os.Truncate(fileName, info.Size()-1)
+
+	db, err := Open(fileName)
+	if err != nil {
+		t.Fatalf("Unexpected Error reopening store %v", err)
+	}
+	if v := getValue(t, db, "b", "k1"); string(v) != "v1" {
+		t.Errorf("Expected the first transaction to be kept, got %q", v)
+	}
+	if v := getValue(t, db, "b", "k2"); v != nil {
+		t.Errorf("Expected the torn transaction to be dropped, got %q", v)
+	}
+
+	// records are appended after the truncated one
+	db.Update(func(tx *Tx) error { return tx.Put("b", "k3", []byte("v3")) })
+	db.Close()
+	db, _ = Open(fileName)
+	if v := getValue(t, db, "b", "k3"); string(v) != "v3" {
+		t.Errorf("Expected the transaction after the truncation, got %q", v)
+	}
+}
+
+func TestCompact_KeepsLiveValues(t *testing.T) {
+	db, fileName := makeTestDB(t)
+	defer os.RemoveAll(path.Dir(fileName))
+
+	for i := 0; i < 10; i++ {
+		db.Update(func(tx *Tx) error {
+			tx.Put("b", "overwritten", []byte{byte(i)})
+			return tx.Put("b", string('a'+rune(i)), []byte{byte(i)})
+		})
+	}
+	db.Update(func(tx *Tx) error { return tx.Delete("b", "a") })
+
+	db.mutex.Lock()
+	before := db.size
+	err := db.compact()
+	after := db.size
+	db.mutex.Unlock()
+	if err != nil {
+		t.Fatalf("Unexpected Error compacting %v", err)
+	}
+	if after >= before {
+		t.Errorf("Expected compaction to shrink the file from %v, got %v", before, after)
+	}
+
+	checkCompacted(t, db)
+	checkCompacted(t, reopen(t, db, fileName))
+}
+
+func checkCompacted(t *testing.T, db *DB) {
+	if v := getValue(t, db, "b", "overwritten"); !reflect.DeepEqual(v, []byte{9}) {
+		t.Errorf("Expected the latest value, got %v", v)
+	}
+	if v := getValue(t, db, "b", "a"); v != nil {
+		t.Errorf("Expected the deleted key to be gone, got %v", v)
+	}
+	if v := getValue(t, db, "b", "j"); !reflect.DeepEqual(v, []byte{9}) {
+		t.Errorf("Expected the value of j, got %v", v)
+	}
+}
+
+func reopen(t *testing.T, db *DB, fileName string) *DB {
+	db.Close()
+	db, err := Open(fileName)
+	if err != nil {
+		t.Fatalf("Unexpected Error reopening store %v", err)
+	}
+	return db
+}
@@ -0,0 +1,1404 @@
+// Autogenerated by Thrift Compiler (0.9.3)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package raftthrift
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/apache/thrift/lib/go/thrift"
+)
+
+// (needed to ensure safety because of naive import list construction.)
+var _ = thrift.ZERO
+var _ = fmt.Printf
+var _ = bytes.Equal
+
+var GoUnusedProtection__ int
+
+// Attributes:
+//  - Term
+//  - Noop
+//  - Data
+type Entry struct {
+	Term int64  `thrift:"term,1,required" json:"term"`
+	Noop bool   `thrift:"noop,2,required" json:"noop"`
+	Data []byte `thrift:"data,3" json:"data,omitempty"`
+}
+
+func NewEntry() *Entry {
+	return &Entry{}
+}
+
+func (p *Entry) GetTerm() int64 {
+	return p.Term
+}
+
+func (p *Entry) GetNoop() bool {
+	return p.Noop
+}
+
+var Entry_Data_DEFAULT []byte
+
+func (p *Entry) GetData() []byte {
+	return p.Data
+}
+func (p *Entry) IsSetData() bool {
+	return p.Data != nil
+}
+
+func (p *Entry) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTerm bool = false
+	var issetNoop bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTerm = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetNoop = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	}
+	if !issetNoop {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Noop is not set"))
+	}
+	return nil
+}
+
+func (p *Entry) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Term = v
+	}
+	return nil
+}
+
+func (p *Entry) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Noop = v
+	}
+	return nil
+}
+
+func (p *Entry) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Data = v
+	}
+	return nil
+}
+
+func (p *Entry) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Entry"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Entry) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("term", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Term)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.term (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
+	}
+	return err
+}
+
+func (p *Entry) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("noop", thrift.BOOL, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:noop: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Noop)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.noop (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:noop: ", p), err)
+	}
+	return err
+}
+
+func (p *Entry) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetData() {
+		if err := oprot.WriteFieldBegin("data", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:data: ", p), err)
+		}
+		if err := oprot.WriteBinary(p.Data); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.data (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:data: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Entry) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Entry(%+v)", *p)
+}
+
+// Attributes:
+//  - Term
+//  - CandidateId
+//  - LastLogIndex
+//  - LastLogTerm
+type RequestVoteRequest struct {
+	Term         int64  `thrift:"term,1,required" json:"term"`
+	CandidateId  string `thrift:"candidateId,2,required" json:"candidateId"`
+	LastLogIndex int64  `thrift:"lastLogIndex,3,required" json:"lastLogIndex"`
+	LastLogTerm  int64  `thrift:"lastLogTerm,4,required" json:"lastLogTerm"`
+}
+
+func NewRequestVoteRequest() *RequestVoteRequest {
+	return &RequestVoteRequest{}
+}
+
+func (p *RequestVoteRequest) GetTerm() int64 {
+	return p.Term
+}
+
+func (p *RequestVoteRequest) GetCandidateId() string {
+	return p.CandidateId
+}
+
+func (p *RequestVoteRequest) GetLastLogIndex() int64 {
+	return p.LastLogIndex
+}
+
+func (p *RequestVoteRequest) GetLastLogTerm() int64 {
+	return p.LastLogTerm
+}
+func (p *RequestVoteRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTerm bool = false
+	var issetCandidateId bool = false
+	var issetLastLogIndex bool = false
+	var issetLastLogTerm bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTerm = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetCandidateId = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetLastLogIndex = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+			issetLastLogTerm = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	}
+	if !issetCandidateId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field CandidateId is not set"))
+	}
+	if !issetLastLogIndex {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LastLogIndex is not set"))
+	}
+	if !issetLastLogTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LastLogTerm is not set"))
+	}
+	return nil
+}
+
+func (p *RequestVoteRequest) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Term = v
+	}
+	return nil
+}
+
+func (p *RequestVoteRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.CandidateId = v
+	}
+	return nil
+}
+
+func (p *RequestVoteRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.LastLogIndex = v
+	}
+	return nil
+}
+
+func (p *RequestVoteRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.LastLogTerm = v
+	}
+	return nil
+}
+
+func (p *RequestVoteRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RequestVoteRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RequestVoteRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("term", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Term)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.term (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
+	}
+	return err
+}
+
+func (p *RequestVoteRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("candidateId", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:candidateId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.CandidateId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.candidateId (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:candidateId: ", p), err)
+	}
+	return err
+}
+
+func (p *RequestVoteRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("lastLogIndex", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:lastLogIndex: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.LastLogIndex)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.lastLogIndex (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:lastLogIndex: ", p), err)
+	}
+	return err
+}
+
+func (p *RequestVoteRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("lastLogTerm", thrift.I64, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:lastLogTerm: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.LastLogTerm)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.lastLogTerm (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:lastLogTerm: ", p), err)
+	}
+	return err
+}
+
+func (p *RequestVoteRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RequestVoteRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Term
+//  - VoteGranted
+type RequestVoteResponse struct {
+	Term        int64 `thrift:"term,1,required" json:"term"`
+	VoteGranted bool  `thrift:"voteGranted,2,required" json:"voteGranted"`
+}
+
+func NewRequestVoteResponse() *RequestVoteResponse {
+	return &RequestVoteResponse{}
+}
+
+func (p *RequestVoteResponse) GetTerm() int64 {
+	return p.Term
+}
+
+func (p *RequestVoteResponse) GetVoteGranted() bool {
+	return p.VoteGranted
+}
+func (p *RequestVoteResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTerm bool = false
+	var issetVoteGranted bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTerm = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetVoteGranted = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	}
+	if !issetVoteGranted {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field VoteGranted is not set"))
+	}
+	return nil
+}
+
+func (p *RequestVoteResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Term = v
+	}
+	return nil
+}
+
+func (p *RequestVoteResponse) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.VoteGranted = v
+	}
+	return nil
+}
+
+func (p *RequestVoteResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RequestVoteResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RequestVoteResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("term", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Term)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.term (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
+	}
+	return err
+}
+
+func (p *RequestVoteResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("voteGranted", thrift.BOOL, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:voteGranted: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.VoteGranted)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.voteGranted (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:voteGranted: ", p), err)
+	}
+	return err
+}
+
+func (p *RequestVoteResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RequestVoteResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - Term
+//  - LeaderId
+//  - PrevLogIndex
+//  - PrevLogTerm
+//  - Entries
+//  - LeaderCommit
+type AppendEntriesRequest struct {
+	Term         int64    `thrift:"term,1,required" json:"term"`
+	LeaderId     string   `thrift:"leaderId,2,required" json:"leaderId"`
+	PrevLogIndex int64    `thrift:"prevLogIndex,3,required" json:"prevLogIndex"`
+	PrevLogTerm  int64    `thrift:"prevLogTerm,4,required" json:"prevLogTerm"`
+	Entries      []*Entry `thrift:"entries,5,required" json:"entries"`
+	LeaderCommit int64    `thrift:"leaderCommit,6,required" json:"leaderCommit"`
+}
+
+func NewAppendEntriesRequest() *AppendEntriesRequest {
+	return &AppendEntriesRequest{}
+}
+
+func (p *AppendEntriesRequest) GetTerm() int64 {
+	return p.Term
+}
+
+func (p *AppendEntriesRequest) GetLeaderId() string {
+	return p.LeaderId
+}
+
+func (p *AppendEntriesRequest) GetPrevLogIndex() int64 {
+	return p.PrevLogIndex
+}
+
+func (p *AppendEntriesRequest) GetPrevLogTerm() int64 {
+	return p.PrevLogTerm
+}
+
+func (p *AppendEntriesRequest) GetEntries() []*Entry {
+	return p.Entries
+}
+
+func (p *AppendEntriesRequest) GetLeaderCommit() int64 {
+	return p.LeaderCommit
+}
+func (p *AppendEntriesRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTerm bool = false
+	var issetLeaderId bool = false
+	var issetPrevLogIndex bool = false
+	var issetPrevLogTerm bool = false
+	var issetEntries bool = false
+	var issetLeaderCommit bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTerm = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetLeaderId = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetPrevLogIndex = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+			issetPrevLogTerm = true
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+			issetEntries = true
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+			issetLeaderCommit = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	}
+	if !issetLeaderId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LeaderId is not set"))
+	}
+	if !issetPrevLogIndex {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field PrevLogIndex is not set"))
+	}
+	if !issetPrevLogTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field PrevLogTerm is not set"))
+	}
+	if !issetEntries {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Entries is not set"))
+	}
+	if !issetLeaderCommit {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LeaderCommit is not set"))
+	}
+	return nil
+}
+
+func (p *AppendEntriesRequest) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Term = v
+	}
+	return nil
+}
+
+func (p *AppendEntriesRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.LeaderId = v
+	}
+	return nil
+}
+
+func (p *AppendEntriesRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.PrevLogIndex = v
+	}
+	return nil
+}
+
+func (p *AppendEntriesRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.PrevLogTerm = v
+	}
+	return nil
+}
+
+func (p *AppendEntriesRequest) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*Entry, 0, size)
+	p.Entries = tSlice
+	for i := 0; i < size; i++ {
+		_elem0 := &Entry{}
+		if err := _elem0.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem0), err)
+		}
+		p.Entries = append(p.Entries, _elem0)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *AppendEntriesRequest) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.LeaderCommit = v
+	}
+	return nil
+}
+
+func (p *AppendEntriesRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AppendEntriesRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AppendEntriesRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("term", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Term)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.term (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
+	}
+	return err
+}
+
+func (p *AppendEntriesRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("leaderId", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:leaderId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.LeaderId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.leaderId (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:leaderId: ", p), err)
+	}
+	return err
+}
+
+func (p *AppendEntriesRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("prevLogIndex", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:prevLogIndex: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.PrevLogIndex)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.prevLogIndex (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:prevLogIndex: ", p), err)
+	}
+	return err
+}
+
+func (p *AppendEntriesRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("prevLogTerm", thrift.I64, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:prevLogTerm: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.PrevLogTerm)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.prevLogTerm (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:prevLogTerm: ", p), err)
+	}
+	return err
+}
+
+func (p *AppendEntriesRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("entries", thrift.LIST, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:entries: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Entries)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Entries {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:entries: ", p), err)
+	}
+	return err
+}
+
+func (p *AppendEntriesRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("leaderCommit", thrift.I64, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:leaderCommit: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.LeaderCommit)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.leaderCommit (6) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:leaderCommit: ", p), err)
+	}
+	return err
+}
+
+func (p *AppendEntriesRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AppendEntriesRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Term
+//  - Success
+//  - ConflictIndex
+type AppendEntriesResponse struct {
+	Term          int64  `thrift:"term,1,required" json:"term"`
+	Success       bool   `thrift:"success,2,required" json:"success"`
+	ConflictIndex *int64 `thrift:"conflictIndex,3" json:"conflictIndex,omitempty"`
+}
+
+func NewAppendEntriesResponse() *AppendEntriesResponse {
+	return &AppendEntriesResponse{}
+}
+
+func (p *AppendEntriesResponse) GetTerm() int64 {
+	return p.Term
+}
+
+func (p *AppendEntriesResponse) GetSuccess() bool {
+	return p.Success
+}
+
+var AppendEntriesResponse_ConflictIndex_DEFAULT int64
+
+func (p *AppendEntriesResponse) GetConflictIndex() int64 {
+	if !p.IsSetConflictIndex() {
+		return AppendEntriesResponse_ConflictIndex_DEFAULT
+	}
+	return *p.ConflictIndex
+}
+func (p *AppendEntriesResponse) IsSetConflictIndex() bool {
+	return p.ConflictIndex != nil
+}
+
+func (p *AppendEntriesResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTerm bool = false
+	var issetSuccess bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTerm = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetSuccess = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	}
+	if !issetSuccess {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Success is not set"))
+	}
+	return nil
+}
+
+func (p *AppendEntriesResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Term = v
+	}
+	return nil
+}
+
+func (p *AppendEntriesResponse) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Success = v
+	}
+	return nil
+}
+
+func (p *AppendEntriesResponse) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.ConflictIndex = &v
+	}
+	return nil
+}
+
+func (p *AppendEntriesResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AppendEntriesResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AppendEntriesResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("term", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Term)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.term (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
+	}
+	return err
+}
+
+func (p *AppendEntriesResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("success", thrift.BOOL, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:success: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Success)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.success (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:success: ", p), err)
+	}
+	return err
+}
+
+func (p *AppendEntriesResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetConflictIndex() {
+		if err := oprot.WriteFieldBegin("conflictIndex", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:conflictIndex: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.ConflictIndex)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.conflictIndex (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:conflictIndex: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AppendEntriesResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AppendEntriesResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - Term
+//  - LeaderId
+//  - LastIncludedIndex
+//  - LastIncludedTerm
+//  - Data
+type InstallSnapshotRequest struct {
+	Term              int64  `thrift:"term,1,required" json:"term"`
+	LeaderId          string `thrift:"leaderId,2,required" json:"leaderId"`
+	LastIncludedIndex int64  `thrift:"lastIncludedIndex,3,required" json:"lastIncludedIndex"`
+	LastIncludedTerm  int64  `thrift:"lastIncludedTerm,4,required" json:"lastIncludedTerm"`
+	Data              []byte `thrift:"data,5,required" json:"data"`
+}
+
+func NewInstallSnapshotRequest() *InstallSnapshotRequest {
+	return &InstallSnapshotRequest{}
+}
+
+func (p *InstallSnapshotRequest) GetTerm() int64 {
+	return p.Term
+}
+
+func (p *InstallSnapshotRequest) GetLeaderId() string {
+	return p.LeaderId
+}
+
+func (p *InstallSnapshotRequest) GetLastIncludedIndex() int64 {
+	return p.LastIncludedIndex
+}
+
+func (p *InstallSnapshotRequest) GetLastIncludedTerm() int64 {
+	return p.LastIncludedTerm
+}
+
+func (p *InstallSnapshotRequest) GetData() []byte {
+	return p.Data
+}
+func (p *InstallSnapshotRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTerm bool = false
+	var issetLeaderId bool = false
+	var issetLastIncludedIndex bool = false
+	var issetLastIncludedTerm bool = false
+	var issetData bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTerm = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetLeaderId = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetLastIncludedIndex = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+			issetLastIncludedTerm = true
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+			issetData = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	}
+	if !issetLeaderId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LeaderId is not set"))
+	}
+	if !issetLastIncludedIndex {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LastIncludedIndex is not set"))
+	}
+	if !issetLastIncludedTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LastIncludedTerm is not set"))
+	}
+	if !issetData {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Data is not set"))
+	}
+	return nil
+}
+
+func (p *InstallSnapshotRequest) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Term = v
+	}
+	return nil
+}
+
+func (p *InstallSnapshotRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.LeaderId = v
+	}
+	return nil
+}
+
+func (p *InstallSnapshotRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.LastIncludedIndex = v
+	}
+	return nil
+}
+
+func (p *InstallSnapshotRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.LastIncludedTerm = v
+	}
+	return nil
+}
+
+func (p *InstallSnapshotRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Data = v
+	}
+	return nil
+}
+
+func (p *InstallSnapshotRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("InstallSnapshotRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *InstallSnapshotRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("term", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Term)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.term (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
+	}
+	return err
+}
+
+func (p *InstallSnapshotRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("leaderId", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:leaderId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.LeaderId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.leaderId (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:leaderId: ", p), err)
+	}
+	return err
+}
+
+func (p *InstallSnapshotRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("lastIncludedIndex", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:lastIncludedIndex: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.LastIncludedIndex)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.lastIncludedIndex (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:lastIncludedIndex: ", p), err)
+	}
+	return err
+}
+
+func (p *InstallSnapshotRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("lastIncludedTerm", thrift.I64, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:lastIncludedTerm: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.LastIncludedTerm)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.lastIncludedTerm (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:lastIncludedTerm: ", p), err)
+	}
+	return err
+}
+
+func (p *InstallSnapshotRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("data", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:data: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Data); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.data (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:data: ", p), err)
+	}
+	return err
+}
+
+func (p *InstallSnapshotRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("InstallSnapshotRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Term
+type InstallSnapshotResponse struct {
+	Term int64 `thrift:"term,1,required" json:"term"`
+}
+
+func NewInstallSnapshotResponse() *InstallSnapshotResponse {
+	return &InstallSnapshotResponse{}
+}
+
+func (p *InstallSnapshotResponse) GetTerm() int64 {
+	return p.Term
+}
+func (p *InstallSnapshotResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTerm bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTerm = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	}
+	return nil
+}
+
+func (p *InstallSnapshotResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Term = v
+	}
+	return nil
+}
+
+func (p *InstallSnapshotResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("InstallSnapshotResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *InstallSnapshotResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("term", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Term)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.term (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
+	}
+	return err
+}
+
+func (p *InstallSnapshotResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("InstallSnapshotResponse(%+v)", *p)
+}
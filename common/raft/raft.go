@@ -0,0 +1,789 @@
+// Package raft replicates a log across a small group of replicas with the
+// Raft consensus algorithm (https://raft.github.io/raft.pdf), so the state
+// built from the log survives losing a minority of the replicas.
+//
+// One replica is elected leader and only it appends to the log.  An entry is
+// committed once a majority of the replicas have saved it, and committed
+// entries are applied in order on every replica to a state machine kept in
+// the same store as the log, so a replica that restarts carries on from its
+// state.  Once the log holds enough applied entries the oldest are dropped,
+// a replica missing entries that were dropped is sent a snapshot of the
+// leader's state instead.
+//
+// A leader that hasn't heard from a majority of the replicas for an election
+// timeout steps down, so a replica cut off from the others soon stops
+// acting as the leader while the others elect a new one.
+package raft
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/scootdev/scoot/common/kvstore"
+)
+
+var ErrNotLeader = errors.New("raft: not the leader")
+var ErrLeadershipLost = errors.New("raft: leadership lost before the entry was applied, it may or may not be committed")
+var ErrStopped = errors.New("raft: replica is stopped")
+
+// Parameters of a replica
+// Id - this replica's id
+// Peers - ids of the other replicas in the group
+// ElectionTimeout - how long a follower waits to hear from a leader before
+//     standing for election, randomized between this and twice this.
+//     Leaders send heartbeats five times as often.  DefaultElectionTimeout
+//     if 0.
+// RetainedEntries - how many applied entries are kept in the log for
+//     replicas that are a little behind, the older entries are dropped once
+//     there are twice as many.  DefaultRetainedEntries if 0.
+type Config struct {
+	Id              string
+	Peers           []string
+	ElectionTimeout time.Duration
+	RetainedEntries int
+}
+
+const DefaultElectionTimeout = time.Second
+const DefaultRetainedEntries = 1024
+
+// Most entries sent in one AppendEntries, or applied in one transaction
+const maxEntriesPerAppend = 64
+
+// The state built by applying the log, kept in the replica's store.  Each
+// method is called with a transaction that commits along with the replica's
+// own state, and should only fail if the store does, data it can't make
+// sense of must be handled the same way on every replica.
+type StateMachine interface {
+	// Applies the data of a committed entry
+	Apply(tx *kvstore.Tx, data []byte) error
+
+	// Returns the whole state, for a replica missing entries that were
+	// dropped from the log
+	Snapshot(tx *kvstore.Tx) ([]byte, error)
+
+	// Replaces the whole state with a snapshot
+	Restore(tx *kvstore.Tx, snapshot []byte) error
+}
+
+type role int
+
+const (
+	follower role = iota
+	candidate
+	leader
+)
+
+// A replica.  Applies the data of committed entries, in order, to the state
+// machine it was created with.
+type Node struct {
+	id                string
+	peers             []string
+	transport         Transport
+	storage           *storage
+	sm                StateMachine
+	electionTimeout   time.Duration
+	heartbeatInterval time.Duration
+	retainedEntries   int64
+
+	// serializes applying entries, dropping them from the log and installing
+	// snapshots.  Taken before mutex.
+	applyMutex sync.Mutex
+
+	mutex sync.Mutex
+	// broadcast when the role, term, commit index or applied index changes
+	cond             *sync.Cond
+	role             role
+	term             int64
+	votedFor         string
+	leaderId         string
+	log              []Entry // log[0] is a sentinel for the entry at snapshotIndex
+	snapshotIndex    int64   // the last entry dropped from the log, 0 if none were
+	commitIndex      int64
+	lastApplied      int64
+	electionDeadline time.Time
+	stopped          bool
+	stopCh           chan struct{}
+
+	// leader state, for the current term
+	termStart  int64 // index of the noop entry appended when elected
+	nextIndex  map[string]int64
+	matchIndex map[string]int64
+	lastAck    map[string]time.Time
+	replicate  map[string]chan struct{} // wakes up the peer's replicator
+	leadership chan struct{}            // closed once leadership is lost
+}
+
+// Creates a replica whose term, vote, log and state machine are kept in the
+// specified store, and starts it.  Entries in the log that weren't applied
+// before it stopped are applied once they're known to be committed.
+func NewNode(config Config, db *kvstore.DB, transport Transport, sm StateMachine) (*Node, error) {
+	s := &storage{db: db}
+	saved, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	electionTimeout := config.ElectionTimeout
+	if electionTimeout == 0 {
+		electionTimeout = DefaultElectionTimeout
+	}
+	retainedEntries := config.RetainedEntries
+	if retainedEntries == 0 {
+		retainedEntries = DefaultRetainedEntries
+	}
+	n := &Node{
+		id:                config.Id,
+		peers:             config.Peers,
+		transport:         transport,
+		storage:           s,
+		sm:                sm,
+		electionTimeout:   electionTimeout,
+		heartbeatInterval: electionTimeout / 5,
+		retainedEntries:   int64(retainedEntries),
+		term:              saved.term,
+		votedFor:          saved.votedFor,
+		log:               saved.log,
+		snapshotIndex:     saved.snapshotIndex,
+		commitIndex:       saved.appliedIndex,
+		lastApplied:       saved.appliedIndex,
+		stopCh:            make(chan struct{}),
+	}
+	n.cond = sync.NewCond(&n.mutex)
+	n.resetElectionDeadline()
+
+	go n.run()
+	go n.applyCommitted()
+	return n, nil
+}
+
+// Appends data to the log.  Returns once the entry is committed and applied
+// on this replica.  Returns ErrNotLeader if this replica isn't the leader,
+// and ErrLeadershipLost if it stopped being the leader before the entry was
+// applied, in which case the entry may still be committed by the next leader.
+func (n *Node) Propose(data []byte) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.stopped {
+		return ErrStopped
+	}
+	if n.role != leader {
+		return ErrNotLeader
+	}
+
+	term := n.term
+	entry := Entry{Term: term, Data: data}
+	index := n.lastIndex() + 1
+	if err := n.storage.saveEntries(index, index-1, index, []Entry{entry}); err != nil {
+		return err
+	}
+	n.log = append(n.log, entry)
+	for _, ch := range n.replicate {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	n.advanceCommit()
+
+	for n.lastApplied < index {
+		if n.stopped {
+			return ErrStopped
+		}
+		if n.role != leader || n.term != term {
+			return ErrLeadershipLost
+		}
+		n.cond.Wait()
+	}
+	if index <= n.snapshotIndex {
+		// dropped from the log once applied, it's the entry proposed if
+		// this replica is still the leader of the term
+		if n.term != term {
+			return ErrLeadershipLost
+		}
+		return nil
+	}
+	if n.termAt(index) != term {
+		return ErrLeadershipLost
+	}
+	return nil
+}
+
+// Blocks until this replica is the leader and has applied every entry
+// committed before it was elected.  Returns a channel that's closed once it
+// stops being the leader.
+func (n *Node) WaitForLeadership() <-chan struct{} {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for !n.stopped && (n.role != leader || n.lastApplied < n.termStart) {
+		n.cond.Wait()
+	}
+	if n.stopped {
+		lost := make(chan struct{})
+		close(lost)
+		return lost
+	}
+	return n.leadership
+}
+
+// Returns the id of the leader this replica last heard from, empty if it
+// doesn't know of one
+func (n *Node) Leader() string {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.leaderId
+}
+
+// Stops the replica, it no longer takes part in the group
+func (n *Node) Stop() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.stopped {
+		return
+	}
+	n.stopped = true
+	close(n.stopCh)
+	if n.leadership != nil {
+		close(n.leadership)
+		n.leadership = nil
+	}
+	n.role = follower
+	n.cond.Broadcast()
+}
+
+// Handles a candidate asking for this replica's vote
+func (n *Node) HandleRequestVote(req *RequestVoteRequest) (*RequestVoteResponse, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.stopped {
+		return nil, ErrStopped
+	}
+
+	if req.Term > n.term {
+		n.stepDown(req.Term)
+	}
+	resp := &RequestVoteResponse{Term: n.term}
+	if req.Term < n.term {
+		return resp, nil
+	}
+
+	// only vote for candidates whose log has every entry that may have been
+	// committed, i.e. is at least as up to date as this one
+	lastIndex := n.lastIndex()
+	lastTerm := n.termAt(lastIndex)
+	upToDate := req.LastLogTerm > lastTerm || (req.LastLogTerm == lastTerm && req.LastLogIndex >= lastIndex)
+	if !upToDate || (n.votedFor != "" && n.votedFor != req.CandidateId) {
+		return resp, nil
+	}
+	if n.votedFor == "" {
+		if err := n.storage.saveState(n.term, req.CandidateId); err != nil {
+			return nil, err
+		}
+		n.votedFor = req.CandidateId
+	}
+	n.resetElectionDeadline()
+	resp.VoteGranted = true
+	return resp, nil
+}
+
+// Handles the leader replicating entries to this replica, or letting it know
+// it's still the leader when there are none
+func (n *Node) HandleAppendEntries(req *AppendEntriesRequest) (*AppendEntriesResponse, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.stopped {
+		return nil, ErrStopped
+	}
+
+	resp := &AppendEntriesResponse{Term: n.term}
+	if req.Term < n.term {
+		return resp, nil
+	}
+	if req.Term > n.term || n.role != follower {
+		n.stepDown(req.Term)
+	} else {
+		n.resetElectionDeadline()
+	}
+	resp.Term = n.term
+	n.leaderId = req.LeaderId
+
+	// the entries follow the entry at PrevLogIndex in the leader's log, if
+	// this log doesn't have it the leader retries with earlier entries
+	prev := req.PrevLogIndex
+	entries := req.Entries
+	lastNew := prev + int64(len(entries))
+	if prev < n.snapshotIndex {
+		// the entries dropped from this log were applied, so they match
+		skip := min64(n.snapshotIndex-prev, int64(len(entries)))
+		prev += skip
+		entries = entries[skip:]
+		if prev < n.snapshotIndex {
+			prev = n.snapshotIndex
+		}
+	}
+	lastIndex := n.lastIndex()
+	if prev > lastIndex {
+		resp.ConflictIndex = lastIndex + 1
+		return resp, nil
+	}
+	if prev == req.PrevLogIndex && n.termAt(prev) != req.PrevLogTerm {
+		// skip back over the whole conflicting term
+		conflictTerm := n.termAt(prev)
+		index := prev
+		for index > n.snapshotIndex+1 && n.termAt(index-1) == conflictTerm {
+			index--
+		}
+		resp.ConflictIndex = index
+		return resp, nil
+	}
+
+	// skip the entries already in the log, those after the first one that
+	// isn't replace the rest of the log
+	i := 0
+	for ; i < len(entries); i++ {
+		index := prev + 1 + int64(i)
+		if index > lastIndex || n.termAt(index) != entries[i].Term {
+			break
+		}
+	}
+	if i < len(entries) {
+		first := prev + 1 + int64(i)
+		if first <= n.commitIndex {
+			log.Printf("ERROR: raft %v refusing to replace committed entry %v", n.id, first)
+			return resp, nil
+		}
+		if err := n.storage.saveEntries(first, lastIndex, first, entries[i:]); err != nil {
+			return nil, err
+		}
+		n.log = append(n.log[:first-n.snapshotIndex], entries[i:]...)
+	}
+
+	if commit := min64(req.LeaderCommit, lastNew); commit > n.commitIndex {
+		n.commitIndex = commit
+		n.cond.Broadcast()
+	}
+	resp.Success = true
+	return resp, nil
+}
+
+// Handles the leader replacing this replica's state with a snapshot, as it's
+// missing entries the leader dropped from its log
+func (n *Node) HandleInstallSnapshot(req *InstallSnapshotRequest) (*InstallSnapshotResponse, error) {
+	n.applyMutex.Lock()
+	defer n.applyMutex.Unlock()
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.stopped {
+		return nil, ErrStopped
+	}
+
+	resp := &InstallSnapshotResponse{Term: n.term}
+	if req.Term < n.term {
+		return resp, nil
+	}
+	if req.Term > n.term || n.role != follower {
+		n.stepDown(req.Term)
+	} else {
+		n.resetElectionDeadline()
+	}
+	resp.Term = n.term
+	n.leaderId = req.LeaderId
+
+	index := req.LastIncludedIndex
+	if index <= n.lastApplied {
+		return resp, nil
+	}
+
+	// the entries after the snapshot are kept if the log has the entry it
+	// ends with, otherwise the snapshot replaces the whole log
+	lastIndex := n.lastIndex()
+	keep := index <= lastIndex && n.termAt(index) == req.LastIncludedTerm
+	truncateThrough := lastIndex
+	if keep {
+		truncateThrough = index
+	}
+	if err := n.storage.restore(n.sm, req.Data, index, req.LastIncludedTerm, n.snapshotIndex+1, truncateThrough); err != nil {
+		return nil, err
+	}
+	if keep {
+		n.log = append([]Entry{{Term: req.LastIncludedTerm}}, n.log[index-n.snapshotIndex+1:]...)
+	} else {
+		n.log = []Entry{{Term: req.LastIncludedTerm}}
+	}
+	n.snapshotIndex = index
+	n.lastApplied = index
+	n.commitIndex = max64(n.commitIndex, index)
+	log.Printf("INFO: raft %v installed a snapshot through entry %v", n.id, index)
+	n.cond.Broadcast()
+	return resp, nil
+}
+
+// Stands for election once the election timeout passes without hearing from
+// a leader, and steps down as leader without a majority
+func (n *Node) run() {
+	ticker := time.NewTicker(n.heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case now := <-ticker.C:
+			n.mutex.Lock()
+			if n.stopped {
+				n.mutex.Unlock()
+				return
+			}
+			if n.role == leader && !n.hasQuorum(now) {
+				log.Printf("INFO: raft %v lost contact with a majority of replicas in term %v", n.id, n.term)
+				n.stepDown(n.term)
+			} else if n.role != leader && now.After(n.electionDeadline) {
+				n.startElection()
+			}
+			n.mutex.Unlock()
+		}
+	}
+}
+
+// Applies committed entries in order
+func (n *Node) applyCommitted() {
+	for {
+		n.mutex.Lock()
+		for !n.stopped && n.lastApplied >= n.commitIndex {
+			n.cond.Wait()
+		}
+		stopped := n.stopped
+		n.mutex.Unlock()
+		if stopped {
+			return
+		}
+
+		if err := n.applyNext(); err != nil {
+			log.Printf("ERROR: raft %v can't apply committed entries, retrying: %v", n.id, err)
+			select {
+			case <-n.stopCh:
+				return
+			case <-time.After(n.heartbeatInterval):
+			}
+		}
+	}
+}
+
+// Applies the next committed entries that weren't applied, then drops the
+// oldest applied entries from the log once it holds enough of them
+func (n *Node) applyNext() error {
+	n.applyMutex.Lock()
+	defer n.applyMutex.Unlock()
+
+	// committed entries are never replaced, and only dropped from the log
+	// while holding applyMutex, so they can be read unlocked
+	n.mutex.Lock()
+	first := n.lastApplied + 1
+	last := min64(n.commitIndex, n.lastApplied+maxEntriesPerAppend)
+	entries := n.log[first-n.snapshotIndex : last-n.snapshotIndex+1]
+	n.mutex.Unlock()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := n.storage.apply(n.sm, first, entries); err != nil {
+		return err
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.lastApplied = last
+	n.cond.Broadcast()
+	if n.lastApplied-n.snapshotIndex >= 2*n.retainedEntries {
+		n.truncateLog(n.lastApplied - n.retainedEntries)
+	}
+	return nil
+}
+
+// Drops the entries through the specified index, which were applied, from
+// the log.  The caller holds applyMutex and the mutex.
+func (n *Node) truncateLog(through int64) {
+	term := n.termAt(through)
+	if err := n.storage.truncate(n.snapshotIndex+1, through, term); err != nil {
+		log.Printf("ERROR: raft %v can't drop applied entries from its log: %v", n.id, err)
+		return
+	}
+	// copied so the dropped entries can be freed
+	n.log = append([]Entry{{Term: term}}, n.log[through-n.snapshotIndex+1:]...)
+	n.snapshotIndex = through
+}
+
+// The caller holds the mutex
+func (n *Node) startElection() {
+	term := n.term + 1
+	if err := n.storage.saveState(term, n.id); err != nil {
+		log.Printf("ERROR: raft %v can't save its vote to stand for election: %v", n.id, err)
+		n.resetElectionDeadline()
+		return
+	}
+	n.role = candidate
+	n.term = term
+	n.votedFor = n.id
+	n.leaderId = ""
+	n.resetElectionDeadline()
+	n.cond.Broadcast()
+
+	votes := 1
+	if n.isMajority(votes) {
+		n.becomeLeader()
+		return
+	}
+
+	lastIndex := n.lastIndex()
+	req := &RequestVoteRequest{
+		Term:         term,
+		CandidateId:  n.id,
+		LastLogIndex: lastIndex,
+		LastLogTerm:  n.termAt(lastIndex),
+	}
+	for _, peer := range n.peers {
+		go func(peer string) {
+			resp, err := n.transport.RequestVote(peer, req)
+			if err != nil {
+				return
+			}
+			n.mutex.Lock()
+			defer n.mutex.Unlock()
+			if resp.Term > n.term {
+				n.stepDown(resp.Term)
+				return
+			}
+			if !resp.VoteGranted || n.role != candidate || n.term != term {
+				return
+			}
+			votes++
+			if n.isMajority(votes) {
+				n.becomeLeader()
+			}
+		}(peer)
+	}
+}
+
+// Appends a noop entry, committing it commits the entries of earlier terms,
+// and starts replicating to the other replicas.  The caller holds the mutex.
+func (n *Node) becomeLeader() {
+	n.role = leader
+	n.leaderId = n.id
+	n.leadership = make(chan struct{})
+
+	entry := Entry{Term: n.term, Noop: true}
+	index := n.lastIndex() + 1
+	if err := n.storage.saveEntries(index, index-1, index, []Entry{entry}); err != nil {
+		log.Printf("ERROR: raft %v can't append an entry as leader: %v", n.id, err)
+		n.stepDown(n.term)
+		return
+	}
+	n.log = append(n.log, entry)
+	n.termStart = index
+	log.Printf("INFO: raft %v elected leader for term %v", n.id, n.term)
+
+	now := time.Now()
+	n.nextIndex = make(map[string]int64)
+	n.matchIndex = make(map[string]int64)
+	n.lastAck = make(map[string]time.Time)
+	n.replicate = make(map[string]chan struct{})
+	for _, peer := range n.peers {
+		n.nextIndex[peer] = index
+		n.lastAck[peer] = now
+		n.replicate[peer] = make(chan struct{}, 1)
+		go n.replicateTo(peer, n.term, n.replicate[peer])
+	}
+	n.advanceCommit()
+	n.cond.Broadcast()
+}
+
+// Sends the entries the peer doesn't have yet, or a heartbeat, until this
+// replica is no longer the leader of the specified term
+func (n *Node) replicateTo(peer string, term int64, wake chan struct{}) {
+	for {
+		n.mutex.Lock()
+		if n.stopped || n.role != leader || n.term != term {
+			n.mutex.Unlock()
+			return
+		}
+		next := n.nextIndex[peer]
+		if next <= n.snapshotIndex {
+			n.mutex.Unlock()
+			if n.sendSnapshot(peer, term) {
+				continue
+			}
+			select {
+			case <-n.stopCh:
+				return
+			case <-time.After(n.heartbeatInterval):
+			}
+			continue
+		}
+		prev := next - 1
+		last := min64(n.lastIndex(), prev+maxEntriesPerAppend)
+		req := &AppendEntriesRequest{
+			Term:         term,
+			LeaderId:     n.id,
+			PrevLogIndex: prev,
+			PrevLogTerm:  n.termAt(prev),
+			Entries:      append([]Entry{}, n.log[next-n.snapshotIndex:last-n.snapshotIndex+1]...),
+			LeaderCommit: n.commitIndex,
+		}
+		n.mutex.Unlock()
+
+		resp, err := n.transport.AppendEntries(peer, req)
+
+		n.mutex.Lock()
+		behind := false
+		if err == nil && resp.Term > n.term {
+			n.stepDown(resp.Term)
+		} else if err == nil && n.role == leader && n.term == term {
+			n.lastAck[peer] = time.Now()
+			if resp.Success {
+				match := prev + int64(len(req.Entries))
+				if match > n.matchIndex[peer] {
+					n.matchIndex[peer] = match
+					n.advanceCommit()
+				}
+				n.nextIndex[peer] = match + 1
+			} else {
+				n.nextIndex[peer] = max64(1, min64(resp.ConflictIndex, prev))
+			}
+			behind = n.nextIndex[peer] <= n.lastIndex()
+		}
+		n.mutex.Unlock()
+
+		if behind {
+			continue
+		}
+		select {
+		case <-n.stopCh:
+			return
+		case <-wake:
+		case <-time.After(n.heartbeatInterval):
+		}
+	}
+}
+
+// Sends the peer a snapshot of this replica's state, as it's missing entries
+// dropped from the log.  Returns true if the peer installed it.
+func (n *Node) sendSnapshot(peer string, term int64) bool {
+	data, index, snapshotTerm, err := n.storage.snapshot(n.sm)
+	if err != nil {
+		log.Printf("ERROR: raft %v can't take a snapshot for %v: %v", n.id, peer, err)
+		return false
+	}
+	req := &InstallSnapshotRequest{
+		Term:              term,
+		LeaderId:          n.id,
+		LastIncludedIndex: index,
+		LastIncludedTerm:  snapshotTerm,
+		Data:              data,
+	}
+	resp, err := n.transport.InstallSnapshot(peer, req)
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if err != nil {
+		return false
+	}
+	if resp.Term > n.term {
+		n.stepDown(resp.Term)
+		return false
+	}
+	if n.role != leader || n.term != term {
+		return false
+	}
+	n.lastAck[peer] = time.Now()
+	if index > n.matchIndex[peer] {
+		n.matchIndex[peer] = index
+		n.advanceCommit()
+	}
+	n.nextIndex[peer] = index + 1
+	return true
+}
+
+// Commits the latest entry of the current term saved by a majority of the
+// replicas, and so every entry before it.  The caller holds the mutex.
+func (n *Node) advanceCommit() {
+	for index := n.lastIndex(); index > n.commitIndex && n.termAt(index) == n.term; index-- {
+		count := 1
+		for _, match := range n.matchIndex {
+			if match >= index {
+				count++
+			}
+		}
+		if n.isMajority(count) {
+			n.commitIndex = index
+			n.cond.Broadcast()
+			return
+		}
+	}
+}
+
+// Becomes a follower in the specified term.  The caller holds the mutex.
+func (n *Node) stepDown(term int64) {
+	if term > n.term {
+		if err := n.storage.saveState(term, ""); err != nil {
+			log.Printf("ERROR: raft %v can't save term %v: %v", n.id, term, err)
+		}
+		n.term = term
+		n.votedFor = ""
+		n.leaderId = ""
+	}
+	if n.role == leader {
+		log.Printf("INFO: raft %v stepping down as leader in term %v", n.id, n.term)
+		close(n.leadership)
+		n.leadership = nil
+		n.leaderId = ""
+	}
+	n.role = follower
+	n.resetElectionDeadline()
+	n.cond.Broadcast()
+}
+
+// Returns true if the leader has heard from a majority of the replicas
+// within an election timeout.  The caller holds the mutex.
+func (n *Node) hasQuorum(now time.Time) bool {
+	count := 1
+	for _, ack := range n.lastAck {
+		if now.Sub(ack) < n.electionTimeout {
+			count++
+		}
+	}
+	return n.isMajority(count)
+}
+
+func (n *Node) isMajority(count int) bool {
+	return count > (len(n.peers)+1)/2
+}
+
+func (n *Node) lastIndex() int64 {
+	return n.snapshotIndex + int64(len(n.log)-1)
+}
+
+// Returns the term of the entry at the specified index, which is in the log
+// or the last entry dropped from it
+func (n *Node) termAt(index int64) int64 {
+	return n.log[index-n.snapshotIndex].Term
+}
+
+func (n *Node) resetElectionDeadline() {
+	jitter := time.Duration(rand.Int63n(int64(n.electionTimeout)))
+	n.electionDeadline = time.Now().Add(n.electionTimeout + jitter)
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,55 @@
+# RPCs between the replicas of a raft group, see common/raft.
+
+# To Generate files run from this (github.com/scootdev/scoot/common/raft) directory
+#     1. thrift --gen go:package_prefix=github.com/scootdev/scoot/common/raft/gen-go/,package=raftthrift,thrift_import=github.com/apache/thrift/lib/go/thrift raft.thrift
+
+struct Entry {
+  1: required i64 term
+  2: required bool noop      # Appended by a new leader, carries no data.
+  3: optional binary data
+}
+
+struct RequestVoteRequest {
+  1: required i64 term
+  2: required string candidateId
+  3: required i64 lastLogIndex
+  4: required i64 lastLogTerm
+}
+
+struct RequestVoteResponse {
+  1: required i64 term
+  2: required bool voteGranted
+}
+
+struct AppendEntriesRequest {
+  1: required i64 term
+  2: required string leaderId
+  3: required i64 prevLogIndex
+  4: required i64 prevLogTerm
+  5: required list<Entry> entries
+  6: required i64 leaderCommit
+}
+
+struct AppendEntriesResponse {
+  1: required i64 term
+  2: required bool success
+  3: optional i64 conflictIndex    # Where the leader should retry from when not successful.
+}
+
+struct InstallSnapshotRequest {
+  1: required i64 term
+  2: required string leaderId
+  3: required i64 lastIncludedIndex   # The snapshot replaces the log through this entry.
+  4: required i64 lastIncludedTerm
+  5: required binary data
+}
+
+struct InstallSnapshotResponse {
+  1: required i64 term
+}
+
+service Raft {
+  RequestVoteResponse RequestVote(1: RequestVoteRequest req)
+  AppendEntriesResponse AppendEntries(1: AppendEntriesRequest req)
+  InstallSnapshotResponse InstallSnapshot(1: InstallSnapshotRequest req)
+}
@@ -0,0 +1,309 @@
+package raft
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/common/kvstore"
+)
+
+const testElectionTimeout = 50 * time.Millisecond
+
+// Keeps the data of the entries applied, in order, in the store
+type testStateMachine struct{}
+
+const appliedBucket = "applied"
+
+func (testStateMachine) Apply(tx *kvstore.Tx, data []byte) error {
+	return tx.Put(appliedBucket, fmt.Sprintf("%016x", len(tx.Keys(appliedBucket))), data)
+}
+
+func (testStateMachine) Snapshot(tx *kvstore.Tx) ([]byte, error) {
+	applied, err := readApplied(tx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(applied)
+}
+
+func (testStateMachine) Restore(tx *kvstore.Tx, snapshot []byte) error {
+	var applied []string
+	if err := json.Unmarshal(snapshot, &applied); err != nil {
+		return err
+	}
+	for _, key := range tx.Keys(appliedBucket) {
+		if err := tx.Delete(appliedBucket, key); err != nil {
+			return err
+		}
+	}
+	for i, data := range applied {
+		if err := tx.Put(appliedBucket, fmt.Sprintf("%016x", i), []byte(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readApplied(tx *kvstore.Tx) ([]string, error) {
+	var applied []string
+	for _, key := range tx.Keys(appliedBucket) {
+		data, err := tx.Get(appliedBucket, key)
+		if err != nil {
+			return nil, err
+		}
+		applied = append(applied, string(data))
+	}
+	return applied, nil
+}
+
+// A group of replicas on a LoopbackNetwork
+type testGroup struct {
+	t               *testing.T
+	dirName         string
+	network         *LoopbackNetwork
+	retainedEntries int
+	ids             []string
+	nodes           map[string]*Node
+	dbs             map[string]*kvstore.DB
+}
+
+func makeTestGroup(t *testing.T, size int) *testGroup {
+	return makeTestGroupRetaining(t, size, 0)
+}
+
+// Makes a group whose replicas keep the specified number of applied entries
+func makeTestGroupRetaining(t *testing.T, size int, retainedEntries int) *testGroup {
+	dirName, err := ioutil.TempDir("", "raft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &testGroup{
+		t:               t,
+		dirName:         dirName,
+		network:         NewLoopbackNetwork(),
+		retainedEntries: retainedEntries,
+		nodes:           make(map[string]*Node),
+		dbs:             make(map[string]*kvstore.DB),
+	}
+	for i := 0; i < size; i++ {
+		g.ids = append(g.ids, fmt.Sprintf("replica%d", i))
+	}
+	for _, id := range g.ids {
+		g.start(id)
+	}
+	return g
+}
+
+// Starts the replica with the specified id, from its saved state if it ran before
+func (g *testGroup) start(id string) {
+	db, err := kvstore.Open(path.Join(g.dirName, id))
+	if err != nil {
+		g.t.Fatalf("Unexpected Error opening store %v", err)
+	}
+	var peers []string
+	for _, peer := range g.ids {
+		if peer != id {
+			peers = append(peers, peer)
+		}
+	}
+
+	config := Config{Id: id, Peers: peers, ElectionTimeout: testElectionTimeout, RetainedEntries: g.retainedEntries}
+	node, err := NewNode(config, db, g.network.Transport(id), testStateMachine{})
+	if err != nil {
+		g.t.Fatalf("Unexpected Error creating replica %v", err)
+	}
+	g.nodes[id] = node
+	g.dbs[id] = db
+	g.network.Register(id, node)
+}
+
+func (g *testGroup) stop(id string) {
+	g.nodes[id].Stop()
+	g.dbs[id].Close()
+}
+
+func (g *testGroup) cleanup() {
+	for id := range g.nodes {
+		g.stop(id)
+	}
+	os.RemoveAll(g.dirName)
+}
+
+// Returns the leader among the connected replicas once one is elected
+func (g *testGroup) waitForLeader(exclude string) string {
+	var leaderId string
+	waitFor(g.t, "a leader to be elected", func() bool {
+		for id, node := range g.nodes {
+			node.mutex.Lock()
+			ready := node.role == leader && node.lastApplied >= node.termStart
+			node.mutex.Unlock()
+			if ready && id != exclude {
+				leaderId = id
+				return true
+			}
+		}
+		return false
+	})
+	return leaderId
+}
+
+func (g *testGroup) waitForApplied(id string, expected ...string) {
+	waitFor(g.t, fmt.Sprintf("%v to apply %v", id, expected), func() bool {
+		var applied []string
+		g.dbs[id].View(func(tx *kvstore.Tx) error {
+			var err error
+			applied, err = readApplied(tx)
+			return err
+		})
+		return reflect.DeepEqual(applied, expected)
+	})
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %v", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestReplicatesToEveryReplica(t *testing.T) {
+	g := makeTestGroup(t, 3)
+	defer g.cleanup()
+
+	leaderId := g.waitForLeader("")
+	for _, data := range []string{"a", "b"} {
+		if err := g.nodes[leaderId].Propose([]byte(data)); err != nil {
+			t.Fatalf("Unexpected Error proposing %v", err)
+		}
+	}
+	for _, id := range g.ids {
+		g.waitForApplied(id, "a", "b")
+		if id != leaderId {
+			if err := g.nodes[id].Propose([]byte("c")); err != ErrNotLeader {
+				t.Errorf("Expected ErrNotLeader proposing to a follower, got %v", err)
+			}
+			if leader := g.nodes[id].Leader(); leader != leaderId {
+				t.Errorf("Expected %v to know the leader is %v, got %v", id, leaderId, leader)
+			}
+		}
+	}
+}
+
+func TestSingleReplica(t *testing.T) {
+	g := makeTestGroup(t, 1)
+	defer g.cleanup()
+
+	g.nodes["replica0"].WaitForLeadership()
+	if err := g.nodes["replica0"].Propose([]byte("a")); err != nil {
+		t.Fatalf("Unexpected Error proposing %v", err)
+	}
+	g.waitForApplied("replica0", "a")
+}
+
+func TestLeaderPartitioned_NewLeaderTakesOver(t *testing.T) {
+	g := makeTestGroup(t, 3)
+	defer g.cleanup()
+
+	oldLeader := g.waitForLeader("")
+	lost := g.nodes[oldLeader].WaitForLeadership()
+	g.nodes[oldLeader].Propose([]byte("a"))
+
+	g.network.Disconnect(oldLeader)
+	if err := g.nodes[oldLeader].Propose([]byte("uncommitted")); err != ErrLeadershipLost {
+		t.Errorf("Expected ErrLeadershipLost proposing without a majority, got %v", err)
+	}
+	select {
+	case <-lost:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the partitioned leader to step down")
+	}
+
+	newLeader := g.waitForLeader(oldLeader)
+	if err := g.nodes[newLeader].Propose([]byte("b")); err != nil {
+		t.Fatalf("Unexpected Error proposing %v", err)
+	}
+
+	// the old leader's uncommitted entry is replaced once it's back
+	g.network.Reconnect(oldLeader)
+	for _, id := range g.ids {
+		g.waitForApplied(id, "a", "b")
+	}
+}
+
+func TestRestart_KeepsState(t *testing.T) {
+	g := makeTestGroup(t, 3)
+	defer g.cleanup()
+
+	leaderId := g.waitForLeader("")
+	g.nodes[leaderId].Propose([]byte("a"))
+	g.nodes[leaderId].Propose([]byte("b"))
+	for _, id := range g.ids {
+		g.waitForApplied(id, "a", "b")
+	}
+	// only stop once every replica has applied, a replica left on its own can't
+	// learn what's committed
+	for _, id := range g.ids {
+		g.stop(id)
+	}
+
+	for _, id := range g.ids {
+		g.start(id)
+	}
+	leaderId = g.waitForLeader("")
+	g.nodes[leaderId].Propose([]byte("c"))
+	for _, id := range g.ids {
+		g.waitForApplied(id, "a", "b", "c")
+	}
+}
+
+func TestTruncatesLog_SendsSnapshot(t *testing.T) {
+	g := makeTestGroupRetaining(t, 3, 2)
+	defer g.cleanup()
+
+	leaderId := g.waitForLeader("")
+	var behind string
+	for _, id := range g.ids {
+		if id != leaderId {
+			behind = id
+			break
+		}
+	}
+	g.network.Disconnect(behind)
+
+	var expected []string
+	for i := 0; i < 10; i++ {
+		expected = append(expected, fmt.Sprint(i))
+		if err := g.nodes[leaderId].Propose([]byte(expected[i])); err != nil {
+			t.Fatalf("Unexpected Error proposing %v", err)
+		}
+	}
+	g.waitForApplied(leaderId, expected...)
+	leader := g.nodes[leaderId]
+	leader.mutex.Lock()
+	snapshotIndex, logLength := leader.snapshotIndex, len(leader.log)
+	leader.mutex.Unlock()
+	if snapshotIndex == 0 || logLength > 5 {
+		t.Errorf("Expected the leader to drop applied entries, kept %v after %v", logLength-1, snapshotIndex)
+	}
+
+	// the replica that fell behind is sent a snapshot, then the entries after it
+	g.network.Reconnect(behind)
+	g.waitForApplied(behind, expected...)
+	g.nodes[leaderId].Propose([]byte("last"))
+	expected = append(expected, "last")
+	g.waitForApplied(behind, expected...)
+
+	// and carries on from its state after a restart
+	g.stop(behind)
+	g.start(behind)
+	g.waitForApplied(behind, expected...)
+}
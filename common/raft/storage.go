@@ -0,0 +1,222 @@
+package raft
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/scootdev/scoot/common/kvstore"
+)
+
+// A replica's term, vote and log are kept in a kvstore so they survive
+// restarts, each write is durable before the replica acts on it.  The state
+// machine is kept in the same store, so the index of the last entry applied
+// is saved in the transaction that applies it.
+const (
+	stateBucket = "state" // "term", "vote", "applied" and "snapshot"
+	logBucket   = "log"   // entries by their index, in hex so they sort in order
+)
+
+type storage struct {
+	db *kvstore.DB
+}
+
+// What's saved in the store
+type savedState struct {
+	term     int64
+	votedFor string
+
+	// the last entry applied to the state machine
+	appliedIndex int64
+	appliedTerm  int64
+
+	// the last entry dropped from the log, log[0] is a sentinel for it and
+	// the entries after it follow
+	snapshotIndex int64
+	snapshotTerm  int64
+	log           []Entry
+}
+
+// Reads the state saved in the store
+func (s *storage) load() (saved savedState, err error) {
+	err = s.db.View(func(tx *kvstore.Tx) error {
+		value, err := tx.Get(stateBucket, "term")
+		if err != nil {
+			return err
+		}
+		if value != nil {
+			saved.term = int64(binary.BigEndian.Uint64(value))
+		}
+		value, err = tx.Get(stateBucket, "vote")
+		if err != nil {
+			return err
+		}
+		saved.votedFor = string(value)
+		if saved.appliedIndex, saved.appliedTerm, err = getPosition(tx, "applied"); err != nil {
+			return err
+		}
+		if saved.snapshotIndex, saved.snapshotTerm, err = getPosition(tx, "snapshot"); err != nil {
+			return err
+		}
+
+		saved.log = []Entry{{Term: saved.snapshotTerm}}
+		for _, key := range tx.Keys(logBucket) {
+			expected := saved.snapshotIndex + int64(len(saved.log))
+			index, err := strconv.ParseInt(key, 16, 64)
+			if err != nil || index != expected {
+				return fmt.Errorf("raft: log entry %q out of sequence, expected index %v", key, expected)
+			}
+			value, err := tx.Get(logBucket, key)
+			if err != nil {
+				return err
+			}
+			entry, err := decodeEntry(value)
+			if err != nil {
+				return fmt.Errorf("raft: log entry %v: %v", index, err)
+			}
+			saved.log = append(saved.log, entry)
+		}
+		return nil
+	})
+	return saved, err
+}
+
+func (s *storage) saveState(term int64, votedFor string) error {
+	return s.db.Update(func(tx *kvstore.Tx) error {
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(term))
+		if err := tx.Put(stateBucket, "term", value); err != nil {
+			return err
+		}
+		return tx.Put(stateBucket, "vote", []byte(votedFor))
+	})
+}
+
+// Deletes the entries from index truncateFrom through lastIndex, then saves
+// the specified entries starting at index first
+func (s *storage) saveEntries(truncateFrom, lastIndex int64, first int64, entries []Entry) error {
+	return s.db.Update(func(tx *kvstore.Tx) error {
+		if err := deleteEntries(tx, truncateFrom, lastIndex); err != nil {
+			return err
+		}
+		for i, entry := range entries {
+			if err := tx.Put(logBucket, entryKey(first+int64(i)), encodeEntry(entry)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Applies the entries, which follow the last entry applied, to the state
+// machine and saves that the last of them was applied
+func (s *storage) apply(sm StateMachine, first int64, entries []Entry) error {
+	return s.db.Update(func(tx *kvstore.Tx) error {
+		for _, entry := range entries {
+			if entry.Noop {
+				continue
+			}
+			if err := sm.Apply(tx, entry.Data); err != nil {
+				return err
+			}
+		}
+		last := first + int64(len(entries)) - 1
+		return putPosition(tx, "applied", last, entries[len(entries)-1].Term)
+	})
+}
+
+// Drops the entries from index first through index last, the entry at last
+// has the specified term, from the log
+func (s *storage) truncate(first, last int64, term int64) error {
+	return s.db.Update(func(tx *kvstore.Tx) error {
+		if err := deleteEntries(tx, first, last); err != nil {
+			return err
+		}
+		return putPosition(tx, "snapshot", last, term)
+	})
+}
+
+// Replaces the state machine with a snapshot of the state once the entry at
+// index was applied, and deletes the entries from truncateFrom through
+// lastIndex, which the snapshot replaces
+func (s *storage) restore(sm StateMachine, snapshot []byte, index, term int64, truncateFrom, lastIndex int64) error {
+	return s.db.Update(func(tx *kvstore.Tx) error {
+		if err := sm.Restore(tx, snapshot); err != nil {
+			return err
+		}
+		if err := deleteEntries(tx, truncateFrom, lastIndex); err != nil {
+			return err
+		}
+		if err := putPosition(tx, "applied", index, term); err != nil {
+			return err
+		}
+		return putPosition(tx, "snapshot", index, term)
+	})
+}
+
+// Returns a snapshot of the state machine, and the index and term of the
+// last entry applied to it
+func (s *storage) snapshot(sm StateMachine) (snapshot []byte, index, term int64, err error) {
+	err = s.db.View(func(tx *kvstore.Tx) error {
+		if index, term, err = getPosition(tx, "applied"); err != nil {
+			return err
+		}
+		snapshot, err = sm.Snapshot(tx)
+		return err
+	})
+	return snapshot, index, term, err
+}
+
+func deleteEntries(tx *kvstore.Tx, first, last int64) error {
+	for index := first; index <= last; index++ {
+		if err := tx.Delete(logBucket, entryKey(index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A position in the log is the index of an entry and its term
+func getPosition(tx *kvstore.Tx, key string) (index, term int64, err error) {
+	value, err := tx.Get(stateBucket, key)
+	if err != nil || value == nil {
+		return 0, 0, err
+	}
+	if len(value) != 16 {
+		return 0, 0, fmt.Errorf("raft: %v is %v bytes, expected 16", key, len(value))
+	}
+	return int64(binary.BigEndian.Uint64(value)), int64(binary.BigEndian.Uint64(value[8:])), nil
+}
+
+func putPosition(tx *kvstore.Tx, key string, index, term int64) error {
+	value := make([]byte, 16)
+	binary.BigEndian.PutUint64(value, uint64(index))
+	binary.BigEndian.PutUint64(value[8:], uint64(term))
+	return tx.Put(stateBucket, key, value)
+}
+
+func entryKey(index int64) string {
+	return fmt.Sprintf("%016x", index)
+}
+
+// An entry is its term, a byte that's 1 for a noop, then its data
+func encodeEntry(entry Entry) []byte {
+	value := make([]byte, 9+len(entry.Data))
+	binary.BigEndian.PutUint64(value, uint64(entry.Term))
+	if entry.Noop {
+		value[8] = 1
+	}
+	copy(value[9:], entry.Data)
+	return value
+}
+
+func decodeEntry(value []byte) (Entry, error) {
+	if len(value) < 9 {
+		return Entry{}, fmt.Errorf("%v bytes is too short", len(value))
+	}
+	return Entry{
+		Term: int64(binary.BigEndian.Uint64(value)),
+		Noop: value[8] == 1,
+		Data: value[9:],
+	}, nil
+}
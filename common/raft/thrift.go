@@ -0,0 +1,205 @@
+package raft
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/scootdev/scoot/common/raft/gen-go/raftthrift"
+)
+
+// Creates a thrift server handling the RPCs sent to a replica
+func MakeServer(
+	handler Handler,
+	transport thrift.TServerTransport,
+	transportFactory thrift.TTransportFactory,
+	protocolFactory thrift.TProtocolFactory) thrift.TServer {
+	return thrift.NewTSimpleServer4(
+		raftthrift.NewRaftProcessor(&thriftHandler{handler: handler}),
+		transport,
+		transportFactory,
+		protocolFactory)
+}
+
+type thriftHandler struct {
+	handler Handler
+}
+
+func (h *thriftHandler) RequestVote(req *raftthrift.RequestVoteRequest) (*raftthrift.RequestVoteResponse, error) {
+	resp, err := h.handler.HandleRequestVote(&RequestVoteRequest{
+		Term:         req.Term,
+		CandidateId:  req.CandidateId,
+		LastLogIndex: req.LastLogIndex,
+		LastLogTerm:  req.LastLogTerm,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &raftthrift.RequestVoteResponse{Term: resp.Term, VoteGranted: resp.VoteGranted}, nil
+}
+
+func (h *thriftHandler) AppendEntries(req *raftthrift.AppendEntriesRequest) (*raftthrift.AppendEntriesResponse, error) {
+	entries := make([]Entry, len(req.Entries))
+	for i, entry := range req.Entries {
+		entries[i] = Entry{Term: entry.Term, Noop: entry.Noop, Data: entry.Data}
+	}
+	resp, err := h.handler.HandleAppendEntries(&AppendEntriesRequest{
+		Term:         req.Term,
+		LeaderId:     req.LeaderId,
+		PrevLogIndex: req.PrevLogIndex,
+		PrevLogTerm:  req.PrevLogTerm,
+		Entries:      entries,
+		LeaderCommit: req.LeaderCommit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &raftthrift.AppendEntriesResponse{
+		Term:          resp.Term,
+		Success:       resp.Success,
+		ConflictIndex: &resp.ConflictIndex,
+	}, nil
+}
+
+func (h *thriftHandler) InstallSnapshot(req *raftthrift.InstallSnapshotRequest) (*raftthrift.InstallSnapshotResponse, error) {
+	resp, err := h.handler.HandleInstallSnapshot(&InstallSnapshotRequest{
+		Term:              req.Term,
+		LeaderId:          req.LeaderId,
+		LastIncludedIndex: req.LastIncludedIndex,
+		LastIncludedTerm:  req.LastIncludedTerm,
+		Data:              req.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &raftthrift.InstallSnapshotResponse{Term: resp.Term}, nil
+}
+
+// Sends RPCs to the other replicas over thrift.  Each peer has one
+// connection, redialed after an error.
+type thriftTransport struct {
+	timeout          time.Duration
+	transportFactory thrift.TTransportFactory
+	protocolFactory  thrift.TProtocolFactory
+	peers            map[string]*thriftPeer
+}
+
+type thriftPeer struct {
+	addr   string
+	mutex  sync.Mutex // thrift clients aren't safe for concurrent use
+	client *raftthrift.RaftClient
+}
+
+// Creates a Transport sending RPCs to the replicas at the specified addresses,
+// by id.  RPCs time out after the specified timeout.
+func NewThriftTransport(
+	addrs map[string]string,
+	timeout time.Duration,
+	transportFactory thrift.TTransportFactory,
+	protocolFactory thrift.TProtocolFactory) Transport {
+	t := &thriftTransport{
+		timeout:          timeout,
+		transportFactory: transportFactory,
+		protocolFactory:  protocolFactory,
+		peers:            make(map[string]*thriftPeer),
+	}
+	for id, addr := range addrs {
+		t.peers[id] = &thriftPeer{addr: addr}
+	}
+	return t
+}
+
+func (t *thriftTransport) RequestVote(peerId string, req *RequestVoteRequest) (*RequestVoteResponse, error) {
+	var resp *raftthrift.RequestVoteResponse
+	err := t.call(peerId, func(client *raftthrift.RaftClient) error {
+		var err error
+		resp, err = client.RequestVote(&raftthrift.RequestVoteRequest{
+			Term:         req.Term,
+			CandidateId:  req.CandidateId,
+			LastLogIndex: req.LastLogIndex,
+			LastLogTerm:  req.LastLogTerm,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RequestVoteResponse{Term: resp.Term, VoteGranted: resp.VoteGranted}, nil
+}
+
+func (t *thriftTransport) AppendEntries(peerId string, req *AppendEntriesRequest) (*AppendEntriesResponse, error) {
+	entries := make([]*raftthrift.Entry, len(req.Entries))
+	for i, entry := range req.Entries {
+		entries[i] = &raftthrift.Entry{Term: entry.Term, Noop: entry.Noop, Data: entry.Data}
+	}
+	var resp *raftthrift.AppendEntriesResponse
+	err := t.call(peerId, func(client *raftthrift.RaftClient) error {
+		var err error
+		resp, err = client.AppendEntries(&raftthrift.AppendEntriesRequest{
+			Term:         req.Term,
+			LeaderId:     req.LeaderId,
+			PrevLogIndex: req.PrevLogIndex,
+			PrevLogTerm:  req.PrevLogTerm,
+			Entries:      entries,
+			LeaderCommit: req.LeaderCommit,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AppendEntriesResponse{
+		Term:          resp.Term,
+		Success:       resp.Success,
+		ConflictIndex: resp.GetConflictIndex(),
+	}, nil
+}
+
+func (t *thriftTransport) InstallSnapshot(peerId string, req *InstallSnapshotRequest) (*InstallSnapshotResponse, error) {
+	var resp *raftthrift.InstallSnapshotResponse
+	err := t.call(peerId, func(client *raftthrift.RaftClient) error {
+		var err error
+		resp, err = client.InstallSnapshot(&raftthrift.InstallSnapshotRequest{
+			Term:              req.Term,
+			LeaderId:          req.LeaderId,
+			LastIncludedIndex: req.LastIncludedIndex,
+			LastIncludedTerm:  req.LastIncludedTerm,
+			Data:              req.Data,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &InstallSnapshotResponse{Term: resp.Term}, nil
+}
+
+// Calls fn with the peer's client, dialing it if needed
+func (t *thriftTransport) call(peerId string, fn func(client *raftthrift.RaftClient) error) error {
+	peer, ok := t.peers[peerId]
+	if !ok {
+		return fmt.Errorf("raft: no address for replica %v", peerId)
+	}
+	peer.mutex.Lock()
+	defer peer.mutex.Unlock()
+
+	if peer.client == nil {
+		socket, err := thrift.NewTSocketTimeout(peer.addr, t.timeout)
+		if err != nil {
+			return err
+		}
+		transport := t.transportFactory.GetTransport(socket)
+		if err := transport.Open(); err != nil {
+			return err
+		}
+		peer.client = raftthrift.NewRaftClientFactory(transport, t.protocolFactory)
+	}
+
+	err := fn(peer.client)
+	if err != nil {
+		peer.client.Transport.Close()
+		peer.client = nil
+	}
+	return err
+}
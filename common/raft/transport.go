@@ -0,0 +1,171 @@
+package raft
+
+import (
+	"errors"
+	"sync"
+)
+
+// An entry in the replicated log
+type Entry struct {
+	Term int64
+	Noop bool // appended by a new leader to commit the entries of earlier terms
+	Data []byte
+}
+
+type RequestVoteRequest struct {
+	Term         int64
+	CandidateId  string
+	LastLogIndex int64
+	LastLogTerm  int64
+}
+
+type RequestVoteResponse struct {
+	Term        int64
+	VoteGranted bool
+}
+
+type AppendEntriesRequest struct {
+	Term         int64
+	LeaderId     string
+	PrevLogIndex int64
+	PrevLogTerm  int64
+	Entries      []Entry
+	LeaderCommit int64
+}
+
+type AppendEntriesResponse struct {
+	Term    int64
+	Success bool
+
+	// When not successful, the index the leader should send entries from next
+	ConflictIndex int64
+}
+
+// Sent by the leader in place of entries a replica is missing that were
+// dropped from its log.  The snapshot is of the state once every entry
+// through LastIncludedIndex was applied.
+type InstallSnapshotRequest struct {
+	Term              int64
+	LeaderId          string
+	LastIncludedIndex int64
+	LastIncludedTerm  int64
+	Data              []byte
+}
+
+type InstallSnapshotResponse struct {
+	Term int64
+}
+
+// Sends RPCs to the other replicas of a group, by their id
+type Transport interface {
+	RequestVote(peerId string, req *RequestVoteRequest) (*RequestVoteResponse, error)
+	AppendEntries(peerId string, req *AppendEntriesRequest) (*AppendEntriesResponse, error)
+	InstallSnapshot(peerId string, req *InstallSnapshotRequest) (*InstallSnapshotResponse, error)
+}
+
+// Handles the RPCs sent to a replica, implemented by Node
+type Handler interface {
+	HandleRequestVote(req *RequestVoteRequest) (*RequestVoteResponse, error)
+	HandleAppendEntries(req *AppendEntriesRequest) (*AppendEntriesResponse, error)
+	HandleInstallSnapshot(req *InstallSnapshotRequest) (*InstallSnapshotResponse, error)
+}
+
+var errUnreachable = errors.New("raft: replica is unreachable")
+
+// Connects replicas running in the same process, for tests.  Replicas can
+// be disconnected to simulate them crashing or being partitioned away.
+type LoopbackNetwork struct {
+	mutex        sync.RWMutex
+	handlers     map[string]Handler
+	disconnected map[string]bool
+}
+
+func NewLoopbackNetwork() *LoopbackNetwork {
+	return &LoopbackNetwork{
+		handlers:     make(map[string]Handler),
+		disconnected: make(map[string]bool),
+	}
+}
+
+// Delivers the RPCs sent to the replica with the specified id to handler
+func (n *LoopbackNetwork) Register(id string, handler Handler) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.handlers[id] = handler
+}
+
+// Drops the RPCs sent to or from the replica with the specified id
+func (n *LoopbackNetwork) Disconnect(id string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.disconnected[id] = true
+}
+
+// Delivers the RPCs sent to or from the replica with the specified id again
+func (n *LoopbackNetwork) Reconnect(id string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delete(n.disconnected, id)
+}
+
+// Returns the Transport the replica with the specified id sends RPCs with
+func (n *LoopbackNetwork) Transport(id string) Transport {
+	return &loopbackTransport{network: n, from: id}
+}
+
+func (n *LoopbackNetwork) handler(from, to string) (Handler, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	handler, ok := n.handlers[to]
+	if !ok || n.disconnected[from] || n.disconnected[to] {
+		return nil, errUnreachable
+	}
+	return handler, nil
+}
+
+type loopbackTransport struct {
+	network *LoopbackNetwork
+	from    string
+}
+
+func (t *loopbackTransport) RequestVote(peerId string, req *RequestVoteRequest) (*RequestVoteResponse, error) {
+	handler, err := t.network.handler(t.from, peerId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handler.HandleRequestVote(req)
+	// the response can be lost too
+	if _, reachable := t.network.handler(t.from, peerId); reachable != nil {
+		return nil, reachable
+	}
+	return resp, err
+}
+
+func (t *loopbackTransport) AppendEntries(peerId string, req *AppendEntriesRequest) (*AppendEntriesResponse, error) {
+	handler, err := t.network.handler(t.from, peerId)
+	if err != nil {
+		return nil, err
+	}
+	// copy the entries as a network would, the sender keeps using them
+	sent := *req
+	sent.Entries = append([]Entry{}, req.Entries...)
+	resp, err := handler.HandleAppendEntries(&sent)
+	if _, reachable := t.network.handler(t.from, peerId); reachable != nil {
+		return nil, reachable
+	}
+	return resp, err
+}
+
+func (t *loopbackTransport) InstallSnapshot(peerId string, req *InstallSnapshotRequest) (*InstallSnapshotResponse, error) {
+	handler, err := t.network.handler(t.from, peerId)
+	if err != nil {
+		return nil, err
+	}
+	sent := *req
+	sent.Data = append([]byte{}, req.Data...)
+	resp, err := handler.HandleInstallSnapshot(&sent)
+	if _, reachable := t.network.handler(t.from, peerId); reachable != nil {
+		return nil, reachable
+	}
+	return resp, err
+}
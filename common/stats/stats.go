@@ -107,6 +107,10 @@ type StatsReceiver interface {
 	// function.
 	Latency(name ...string) Latency
 
+	// Provides a histogram of sampled int64 values over time, like Latency but
+	// for values that aren't durations.
+	Histogram(name ...string) Histogram
+
 	// Add a gauge, which holds an int64 value that can be set arbitrarily.
 	Gauge(name ...string) Gauge
 
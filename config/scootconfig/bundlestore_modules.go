@@ -0,0 +1,72 @@
+package scootconfig
+
+import (
+	"os"
+
+	"github.com/scootdev/scoot/ice"
+	"github.com/scootdev/scoot/os/temp"
+	"github.com/scootdev/scoot/snapshot/bundlestore"
+)
+
+// BundlestoreFileConfig keeps bundles in a directory on local disk.
+// Directory - where bundles are kept. If empty, the directory in the
+// environment is used, or else a temp dir.
+type BundlestoreFileConfig struct {
+	Type      string
+	Directory string
+}
+
+// Adds the BundlestoreFileConfig Create function to the goice MagicBag
+func (c *BundlestoreFileConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+// Creates the FileStore bundles are kept in
+func (c *BundlestoreFileConfig) Create(tmp *temp.TempDir) (bundlestore.Backend, error) {
+	if c.Directory != "" {
+		return bundlestore.MakeFileStore(c.Directory)
+	}
+	return bundlestore.MakeFileStoreInEnvOrTemp(tmp)
+}
+
+// BundlestoreS3Config keeps bundles in a bucket of an S3-compatible object store.
+// Endpoint - e.g. https://s3.us-west-2.amazonaws.com, or a minio server
+// Region, Bucket - where bundles are kept
+// Prefix - prepended to bundle names to make their keys, e.g. "bundles/"
+// AccessKeyID, SecretAccessKey - credentials to sign requests with. If
+// empty, they're taken from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+// PartSizeMB - bundles bigger than this are uploaded in parts of this size,
+// 16MB if 0.
+type BundlestoreS3Config struct {
+	Type            string
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PartSizeMB      int
+}
+
+// Adds the BundlestoreS3Config Create function to the goice MagicBag
+func (c *BundlestoreS3Config) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+// Creates the S3 store bundles are kept in
+func (c *BundlestoreS3Config) Create() (bundlestore.Backend, error) {
+	cfg := &bundlestore.S3Config{
+		Endpoint:        c.Endpoint,
+		Region:          c.Region,
+		Bucket:          c.Bucket,
+		Prefix:          c.Prefix,
+		AccessKeyID:     c.AccessKeyID,
+		SecretAccessKey: c.SecretAccessKey,
+		PartSize:        int64(c.PartSizeMB) * 1024 * 1024,
+	}
+	if cfg.AccessKeyID == "" && cfg.SecretAccessKey == "" {
+		cfg.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		cfg.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	return bundlestore.MakeS3Store(cfg)
+}
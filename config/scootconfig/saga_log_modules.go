@@ -1,6 +1,13 @@
 package scootconfig
 
 import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/scootdev/scoot/common/kvstore"
+	"github.com/scootdev/scoot/common/raft"
 	"github.com/scootdev/scoot/ice"
 	"github.com/scootdev/scoot/saga"
 	"github.com/scootdev/scoot/saga/sagalogs"
@@ -26,9 +33,12 @@ func (c *InMemorySagaLogConfig) Create() saga.SagaLog {
 // instance of the SagaLog interface
 // Directory specifies the name of the directory to store
 // Sagalog files in.
+// RetentionHours - sagas that ended are deleted once they were started
+// this many hours ago, 0 keeps them forever.
 type FileSagaLogConfig struct {
-	Type      string
-	Directory string
+	Type           string
+	Directory      string
+	RetentionHours int
 }
 
 // Adds the FileSagaLogConfig Create function to the goice MagicBag
@@ -38,5 +48,97 @@ func (c *FileSagaLogConfig) Install(bag *ice.MagicBag) {
 
 // Creates an instance of the FileSagaLog
 func (c *FileSagaLogConfig) Create() (saga.SagaLog, error) {
-	return sagalogs.MakeFileSagaLog(c.Directory)
+	return sagalogs.MakeFileSagaLogWithRetention(c.Directory, time.Duration(c.RetentionHours)*time.Hour)
+}
+
+// KVSagaLogConfig struct is used by goice to create a KVSagaLog
+// instance of the SagaLog interface
+// File specifies the name of the file the store is kept in.
+// RetentionHours - sagas that ended are deleted once they were started
+// this many hours ago, 0 keeps them forever.
+type KVSagaLogConfig struct {
+	Type           string
+	File           string
+	RetentionHours int
+}
+
+// Adds the KVSagaLogConfig Create function to the goice MagicBag
+func (c *KVSagaLogConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+// Creates an instance of the KVSagaLog
+func (c *KVSagaLogConfig) Create() (saga.SagaLog, error) {
+	return sagalogs.MakeKVSagaLogWithRetention(c.File, time.Duration(c.RetentionHours)*time.Hour)
+}
+
+// ReplicatedSagaLogConfig struct is used by goice to create a SagaLog
+// replicated across a group of schedulers with raft.  The scheduler waits
+// to become the leader before scheduling.
+// Id - this scheduler's id, a key of Replicas
+// Replicas - address of the raft thrift server of each scheduler in the
+// group, by id, including this one
+// File - file this scheduler's replica of the raft log is kept in
+// ElectionTimeoutMs - how long a standby scheduler waits to hear from the
+// leader before standing for election, in ms.  1s if 0.
+// RetentionHours - sagas that ended are deleted once they were started
+// this many hours ago, kept forever if 0
+type ReplicatedSagaLogConfig struct {
+	Type              string
+	Id                string
+	Replicas          map[string]string
+	File              string
+	ElectionTimeoutMs int
+	RetentionHours    int
+}
+
+// Adds the ReplicatedSagaLogConfig Create function to the goice MagicBag
+func (c *ReplicatedSagaLogConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+// Creates an instance of the ReplicatedSagaLog and starts serving the raft
+// RPCs sent to it
+func (c *ReplicatedSagaLogConfig) Create(
+	tf thrift.TTransportFactory,
+	pf thrift.TProtocolFactory) (saga.SagaLog, error) {
+	addr, ok := c.Replicas[c.Id]
+	if !ok {
+		return nil, fmt.Errorf("no replica address for saga log replica %q", c.Id)
+	}
+	electionTimeout := time.Duration(c.ElectionTimeoutMs) * time.Millisecond
+	if electionTimeout == 0 {
+		electionTimeout = raft.DefaultElectionTimeout
+	}
+
+	peers := []string{}
+	peerAddrs := make(map[string]string)
+	for id, peerAddr := range c.Replicas {
+		if id != c.Id {
+			peers = append(peers, id)
+			peerAddrs[id] = peerAddr
+		}
+	}
+
+	db, err := kvstore.Open(c.File)
+	if err != nil {
+		return nil, err
+	}
+	transport := raft.NewThriftTransport(peerAddrs, electionTimeout, tf, pf)
+	config := raft.Config{Id: c.Id, Peers: peers, ElectionTimeout: electionTimeout}
+	retention := time.Duration(c.RetentionHours) * time.Hour
+	slog, err := sagalogs.MakeReplicatedSagaLogWithRetention(config, db, transport, retention)
+	if err != nil {
+		return nil, err
+	}
+
+	serverSocket, err := thrift.NewTServerSocket(addr)
+	if err != nil {
+		return nil, err
+	}
+	server := raft.MakeServer(slog.Handler(), serverSocket, tf, pf)
+	go func() {
+		log.Fatal("Error serving saga log replica: ", server.Serve())
+	}()
+	return slog, nil
 }
@@ -17,13 +17,26 @@ import (
 //             from the sagalog, and restarts them.
 // DefaultTaskTimeoutMs - default timeout for tasks, in ms
 // OverheadMs - default overhead to add (to account for network and downloading)
+// SnapshotAffinityWaitMs - how long a task waits for a node that recently checked
+//             out its snapshot before running on any free node, in ms
+// Policy - policy deciding which tasks are scheduled first, "fair_share" (the
+//             default) or "largest_first"
+// TenantWeights - share of the cluster each tenant gets under the fair_share
+//             policy, relative to other tenants.  Unlisted tenants have a weight of 1
+// StragglerMultiplier - a task running this many times longer than expected gets
+//             a backup copy on an idle node, 0 disables backup copies
 type StatefulSchedulerConfig struct {
-	Type                 string
-	MaxRetriesPerTask    int
-	DebugMode            bool
-	RecoverJobsOnStartup bool
-	DefaultTaskTimeoutMs int
-	RunnerOverheadMs     int
+	Type                   string
+	MaxRetriesPerTask      int
+	DebugMode              bool
+	RecoverJobsOnStartup   bool
+	DefaultTaskTimeoutMs   int
+	RunnerOverheadMs       int
+	SnapshotAffinityWaitMs int
+	Policy                 string
+	TenantWeights          map[string]int
+	StragglerMultiplier    float64
+	PinSnapshots           bool
 }
 
 func (c *StatefulSchedulerConfig) Install(bag *ice.MagicBag) {
@@ -37,5 +50,10 @@ func (c *StatefulSchedulerConfig) Create() scheduler.SchedulerConfig {
 		RecoverJobsOnStartup: c.RecoverJobsOnStartup,
 		DefaultTaskTimeout:   time.Duration(c.DefaultTaskTimeoutMs) * time.Millisecond,
 		RunnerOverhead:       time.Duration(c.RunnerOverheadMs) * time.Millisecond,
+		SnapshotAffinityWait: time.Duration(c.SnapshotAffinityWaitMs) * time.Millisecond,
+		Policy:               c.Policy,
+		TenantWeights:        c.TenantWeights,
+		StragglerMultiplier:  c.StragglerMultiplier,
+		PinSnapshots:         c.PinSnapshots,
 	}
 }
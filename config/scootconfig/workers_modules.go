@@ -10,6 +10,7 @@ import (
 	"github.com/scootdev/scoot/os/temp"
 	"github.com/scootdev/scoot/runner"
 	"github.com/scootdev/scoot/runner/runners"
+	"github.com/scootdev/scoot/sched/scheduler"
 	"github.com/scootdev/scoot/sched/worker/workers"
 	"github.com/scootdev/scoot/workerapi/client"
 )
@@ -50,8 +51,20 @@ func (c *WorkersThriftConfig) Create(
 	return rf, nil
 }
 
+// Creates the clients the scheduler reads workers' capacities with
+func (c *WorkersThriftConfig) CreateClientFactory(
+	tf thrift.TTransportFactory,
+	pf thrift.TProtocolFactory) scheduler.WorkerClientFactory {
+	return func(node cluster.Node) scheduler.WorkerClient {
+		di := dialer.NewSimpleDialer(tf, pf)
+		cl, _ := client.NewSimpleClient(di, string(node.Id()))
+		return cl
+	}
+}
+
 func (c *WorkersThriftConfig) Install(bag *ice.MagicBag) {
 	bag.Put(c.Create)
+	bag.Put(c.CreateClientFactory)
 }
 
 // Parameters for configuring locally started workers
@@ -64,6 +77,8 @@ func (c *WorkersLocalConfig) Install(bag *ice.MagicBag) {
 	bag.Put(func(tmp *temp.TempDir) func(cluster.Node) runner.Service {
 		return InmemoryWorkerFactory(tmp)
 	})
+	// local workers don't report their capacity
+	bag.Put(func() scheduler.WorkerClientFactory { return nil })
 }
 
 func InmemoryWorkerFactory(tmp *temp.TempDir) func(cluster.Node) runner.Service {
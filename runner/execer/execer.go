@@ -1,6 +1,9 @@
 package execer
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 // Execer lets you run one Unix command. It differs from Runner in that it does not
 // know about Snapshots or Scoot. It's just a way to run a Unix process (or fake it).
@@ -13,9 +16,15 @@ type Command struct {
 	Argv []string
 	Dir  string
 
+	// Environment variables set for the process, in addition to those
+	// it inherits.  They override inherited variables of the same name.
+	EnvVars map[string]string
+
+	// Whether the process may use the network, if the execer sandboxes processes.
+	Network bool
+
 	Stdout io.Writer
 	Stderr io.Writer
-	// TODO(dbentley): environment variables?
 }
 
 type ProcessState int
@@ -50,4 +59,24 @@ type ProcessStatus struct {
 	State    ProcessState
 	ExitCode int
 	Error    string
+
+	// Most memory the process tree used, zero if the execer didn't measure it.
+	PeakMemory Memory
+
+	// Resources the process used, zero if the execer didn't measure them.
+	Usage Usage
+}
+
+// Resources used by a process and the children it waited for.
+type Usage struct {
+	WallTime time.Duration
+	UserTime time.Duration
+	SysTime  time.Duration
+
+	// Largest resident set size of the process or one of its children.
+	MaxRSS Memory
+
+	// Number of times the filesystem had to read from or write to disk.
+	BlockInputOps  int64
+	BlockOutputOps int64
 }
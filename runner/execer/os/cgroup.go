@@ -0,0 +1,299 @@
+package os
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/scootdev/scoot/runner/execer"
+)
+
+// Where the cgroup hierarchies are mounted
+const cgroupRoot = "/sys/fs/cgroup"
+
+// argv[0] the shim starting commands in v2 cgroups is started with, see
+// cgroup_linux.go
+const cgroupShimArg = "scoot-cgroup-shim"
+
+// Controllers used to limit commands
+var cgroupControllers = []string{"memory", "cpu", "pids"}
+
+// Period over which a cgroup's CPU quota is enforced
+const cpuPeriodUs = 100000
+
+// Limits on the resources each command's process tree can use.  Zero means
+// no limit.
+// Memory - bytes of memory, the kernel OOM kills the tree's processes past it
+// CPUs - cores worth of CPU time, e.g. 1.5 for one and a half cores
+// Pids - number of processes and threads
+type CgroupLimits struct {
+	Memory execer.Memory
+	CPUs   float64
+	Pids   int
+}
+
+// Parent of the cgroups created for commands, in either the unified (v2)
+// hierarchy or one hierarchy per controller (v1).
+type cgroups struct {
+	root   string
+	parent string
+	v2     bool
+}
+
+// Numbers the cgroups created by this process
+var nextCgroupId uint64
+
+// The cgroup one command's process tree is placed in.  Its directory by
+// controller, which with v2 is the same one for every controller.
+type cgroup struct {
+	v2   bool
+	dirs map[string]string
+}
+
+// Returns the cgroups parent, a path relative to the root of each hierarchy,
+// creating it if needed.  With v2 the controllers are enabled from the root
+// down to parent, they must be available at the root.
+func newCgroups(root, parent string) (*cgroups, error) {
+	c := &cgroups{root: root, parent: path.Clean("/" + parent)}
+	if _, err := os.Stat(path.Join(root, "cgroup.controllers")); err == nil {
+		c.v2 = true
+		dir := root
+		for _, name := range strings.Split(c.parent, "/") {
+			dir = path.Join(dir, name)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return nil, err
+			}
+			if err := enableCgroupControllers(dir); err != nil {
+				return nil, err
+			}
+		}
+		return c, nil
+	}
+
+	for _, controller := range cgroupControllers {
+		if _, err := os.Stat(path.Join(root, controller)); err != nil {
+			return nil, fmt.Errorf("cgroup %v controller not mounted at %v", controller, root)
+		}
+		if err := os.MkdirAll(path.Join(root, controller, c.parent), 0755); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Lets the children of the v2 cgroup at dir be limited by the controllers
+func enableCgroupControllers(dir string) error {
+	enabled, err := ioutil.ReadFile(path.Join(dir, "cgroup.subtree_control"))
+	if err != nil {
+		return err
+	}
+	for _, controller := range cgroupControllers {
+		if strings.Contains(" "+string(enabled)+" ", " "+controller+" ") {
+			continue
+		}
+		if err := writeCgroupFile(path.Join(dir, "cgroup.subtree_control"), "+"+controller); err != nil {
+			return fmt.Errorf("Couldn't enable cgroup %v controller in %v: %v", controller, dir, err)
+		}
+	}
+	return nil
+}
+
+// Creates a cgroup with the specified limits
+func (c *cgroups) create(limits CgroupLimits) (g *cgroup, err error) {
+	name := fmt.Sprintf("run-%d-%d", os.Getpid(), atomic.AddUint64(&nextCgroupId, 1))
+	g = &cgroup{v2: c.v2, dirs: make(map[string]string)}
+	for _, controller := range cgroupControllers {
+		if c.v2 {
+			g.dirs[controller] = path.Join(c.root, c.parent, name)
+		} else {
+			g.dirs[controller] = path.Join(c.root, controller, c.parent, name)
+		}
+	}
+	for _, dir := range g.uniqueDirs() {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			g.destroy()
+			return nil, err
+		}
+	}
+	defer func() {
+		if err != nil {
+			g.destroy()
+		}
+	}()
+
+	cpuQuota := int64(limits.CPUs * cpuPeriodUs)
+	if c.v2 {
+		if limits.Memory > 0 {
+			if err := g.write("memory", "memory.max", strconv.FormatUint(uint64(limits.Memory), 10)); err != nil {
+				return nil, err
+			}
+			// without swap the cap is on memory and swap combined, as with v1
+			g.write("memory", "memory.swap.max", "0")
+		}
+		if cpuQuota > 0 {
+			if err := g.write("cpu", "cpu.max", fmt.Sprintf("%d %d", cpuQuota, cpuPeriodUs)); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if limits.Memory > 0 {
+			if err := g.write("memory", "memory.limit_in_bytes", strconv.FormatUint(uint64(limits.Memory), 10)); err != nil {
+				return nil, err
+			}
+			// only there if the kernel accounts for swap
+			g.write("memory", "memory.memsw.limit_in_bytes", strconv.FormatUint(uint64(limits.Memory), 10))
+		}
+		if cpuQuota > 0 {
+			if err := g.write("cpu", "cpu.cfs_period_us", strconv.Itoa(cpuPeriodUs)); err != nil {
+				return nil, err
+			}
+			if err := g.write("cpu", "cpu.cfs_quota_us", strconv.FormatInt(cpuQuota, 10)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if limits.Pids > 0 {
+		if err := g.write("pids", "pids.max", strconv.Itoa(limits.Pids)); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// Kills every process in the cgroup, and returns once they're gone
+func (g *cgroup) kill() error {
+	if g.v2 {
+		// cgroup.kill is there from Linux 5.14, and kills atomically
+		g.write("memory", "cgroup.kill", "1")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		pids, err := g.pids()
+		if err != nil {
+			return err
+		}
+		if len(pids) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Couldn't kill processes %v in cgroup %v", pids, g.dirs["memory"])
+		}
+		// processes may still be forking, so kill until there are none left
+		for _, pid := range pids {
+			syscall.Kill(pid, syscall.SIGKILL)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Returns the processes in the cgroup, other than this one, whose thread that
+// started the cmd may still be in it with v1
+func (g *cgroup) pids() ([]int, error) {
+	data, err := ioutil.ReadFile(path.Join(g.dirs["memory"], "cgroup.procs"))
+	if err != nil {
+		return nil, err
+	}
+	pids := []int{}
+	for _, line := range strings.Fields(string(data)) {
+		if pid, err := strconv.Atoi(line); err == nil && pid != os.Getpid() {
+			pids = append(pids, pid)
+		}
+	}
+	return pids, nil
+}
+
+// Returns the most memory the cgroup's processes have used, 0 if the kernel
+// doesn't track it (memory.peak is there from Linux 5.19)
+func (g *cgroup) peakMemory() execer.Memory {
+	file := "memory.max_usage_in_bytes"
+	if g.v2 {
+		file = "memory.peak"
+	}
+	data, err := ioutil.ReadFile(path.Join(g.dirs["memory"], file))
+	if err != nil {
+		return 0
+	}
+	peak, _ := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return execer.Memory(peak)
+}
+
+// Returns whether the kernel OOM killed one of the cgroup's processes for
+// exceeding its memory limit
+func (g *cgroup) oomKilled() bool {
+	file := "memory.oom_control"
+	if g.v2 {
+		file = "memory.events"
+	}
+	f, err := os.Open(path.Join(g.dirs["memory"], file))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "oom_kill" {
+			return fields[1] != "0"
+		}
+	}
+	return false
+}
+
+// Removes the cgroup, which must have no processes left
+func (g *cgroup) destroy() error {
+	var errs []string
+	for _, dir := range g.uniqueDirs() {
+		// the kernel can take a moment to notice the last process is gone
+		var err error
+		for i := 0; i < 100; i++ {
+			if err = syscall.Rmdir(dir); err != syscall.EBUSY {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Sprintf("%v: %v", dir, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New("Couldn't remove cgroup " + strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func (g *cgroup) write(controller, file, value string) error {
+	return writeCgroupFile(path.Join(g.dirs[controller], file), value)
+}
+
+func (g *cgroup) uniqueDirs() []string {
+	if g.v2 {
+		return []string{g.dirs["memory"]}
+	}
+	dirs := []string{}
+	for _, controller := range cgroupControllers {
+		dirs = append(dirs, g.dirs[controller])
+	}
+	return dirs
+}
+
+// Writes to a cgroup interface file.  Unlike ioutil.WriteFile it doesn't
+// create or truncate the file, which cgroupfs doesn't support.
+func writeCgroupFile(file, value string) error {
+	f, err := os.OpenFile(file, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(value)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
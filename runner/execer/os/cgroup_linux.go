@@ -0,0 +1,142 @@
+package os
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Starts cmd in the cgroup, so there's no window where it can fork children
+// that escape it.  With v1 its first process is created in the cgroup.
+func (g *cgroup) start(cmd *exec.Cmd) error {
+	if g.v2 {
+		// Moving a thread would move the whole process with v2, so cmd is
+		// started through a shim that waits to be moved before it execs the
+		// command.
+		startR, startW, err := os.Pipe()
+		if err != nil {
+			return err
+		}
+		defer startW.Close()
+		fd := 3 + len(cmd.ExtraFiles)
+		cmd.Args = append([]string{cgroupShimArg, strconv.Itoa(fd), cmd.Path}, cmd.Args...)
+		cmd.Path = "/proc/self/exe"
+		cmd.ExtraFiles = append(cmd.ExtraFiles, startR)
+		err = cmd.Start()
+		// the started process has its own copy of the read end
+		startR.Close()
+		if err != nil {
+			return err
+		}
+		pid := strconv.Itoa(cmd.Process.Pid)
+		if err := writeCgroupFile(path.Join(g.dirs["memory"], "cgroup.procs"), pid); err != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+			return fmt.Errorf("Couldn't move process into cgroup: %v", err)
+		}
+		if _, err := startW.Write([]byte{0}); err != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+			return fmt.Errorf("Couldn't start process in cgroup: %v", err)
+		}
+		return nil
+	}
+
+	// v1 can't clone into a cgroup, but a thread can be moved into one
+	// before it forks, then back.
+	errCh := make(chan error)
+	go func() {
+		runtime.LockOSThread()
+		tid := syscall.Gettid()
+		from, err := threadCgroups(tid)
+		if err != nil {
+			runtime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+		err = moveThread(tid, g.dirs)
+		if err == nil {
+			err = cmd.Start()
+		}
+		if moveErr := moveThread(tid, from); moveErr != nil {
+			// Left locked, so the thread exits with this goroutine rather than
+			// running others in the cgroup.
+			if err == nil {
+				cmd.Process.Kill()
+				cmd.Wait()
+			}
+			errCh <- fmt.Errorf("Couldn't move thread out of cgroup: %v", moveErr)
+			return
+		}
+		runtime.UnlockOSThread()
+		errCh <- err
+	}()
+	return <-errCh
+}
+
+// Moves the thread to the cgroup directories by controller
+func moveThread(tid int, dirs map[string]string) error {
+	for _, controller := range cgroupControllers {
+		if err := writeCgroupFile(path.Join(dirs[controller], "tasks"), strconv.Itoa(tid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the v1 cgroup directories of the thread, by controller
+func threadCgroups(tid int) (map[string]string, error) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/self/task/%d/cgroup", tid))
+	if err != nil {
+		return nil, err
+	}
+	dirs := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		// hierarchy-id:controller[,controller...]:path
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		for _, controller := range strings.Split(fields[1], ",") {
+			dirs[controller] = path.Join(cgroupRoot, controller, fields[2])
+		}
+	}
+	for _, controller := range cgroupControllers {
+		if _, ok := dirs[controller]; !ok {
+			return nil, fmt.Errorf("No cgroup %v controller for thread %d", controller, tid)
+		}
+	}
+	return dirs, nil
+}
+
+func init() {
+	if len(os.Args) > 2 && os.Args[0] == cgroupShimArg {
+		runCgroupShim()
+	}
+}
+
+// Waits until the execer has moved this process into its v2 cgroup, which it
+// says by writing to the pipe whose fd is os.Args[1], then execs the command
+// in os.Args[2:], the path followed by argv.  Never returns.
+func runCgroupShim() {
+	fd, err := strconv.Atoi(os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid cgroup shim fd %q\n", os.Args[1])
+		os.Exit(1)
+	}
+	start := os.NewFile(uintptr(fd), "start")
+	if n, _ := start.Read(make([]byte, 1)); n != 1 {
+		// the execer couldn't move us, and is killing us
+		os.Exit(1)
+	}
+	start.Close()
+	err = syscall.Exec(os.Args[2], os.Args[3:], os.Environ())
+	fmt.Fprintf(os.Stderr, "Couldn't exec %s: %v\n", os.Args[2], err)
+	os.Exit(127)
+}
@@ -0,0 +1,12 @@
+// +build !linux
+
+package os
+
+import (
+	"errors"
+	"os/exec"
+)
+
+func (g *cgroup) start(cmd *exec.Cmd) error {
+	return errors.New("cgroups are only supported on Linux")
+}
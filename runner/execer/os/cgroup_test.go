@@ -0,0 +1,141 @@
+package os
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/common/stats"
+	"github.com/scootdev/scoot/runner/execer"
+)
+
+// Returns a cgroup execer, skipping the test where cgroups aren't writable
+func newTestCgroupExecer(t *testing.T, limits CgroupLimits) execer.Execer {
+	ex, err := NewCgroupExecer("scoot-test", limits, stats.NilStatsReceiver())
+	if err != nil {
+		t.Skipf("cgroups not available: %v", err)
+	}
+	return ex
+}
+
+func TestCgroupExecer_Runs(t *testing.T) {
+	ex := newTestCgroupExecer(t, CgroupLimits{Memory: 64 * 1024 * 1024, CPUs: 1, Pids: 32})
+
+	var stdout bytes.Buffer
+	p, err := ex.Exec(execer.Command{Argv: []string{"sh", "-c", "echo -n hello; exit 3"}, Stdout: &stdout})
+	if err != nil {
+		t.Fatalf("Couldn't run sh %v", err)
+	}
+	status := p.Wait()
+	if status.State != execer.COMPLETE || status.ExitCode != 3 || stdout.String() != "hello" {
+		t.Fatalf("Got unexpected status running sh %v, output %q", status, stdout.String())
+	}
+	if status.PeakMemory == 0 {
+		t.Logf("Kernel doesn't report peak memory usage")
+	}
+}
+
+func TestCgroupExecer_MemoryCap(t *testing.T) {
+	ex := newTestCgroupExecer(t, CgroupLimits{Memory: 16 * 1024 * 1024})
+
+	// holds 64MB in a shell variable
+	cmd := execer.Command{Argv: []string{"sh", "-c", "x=$(head -c 67108864 /dev/zero | tr '\\0' a); echo ${#x}"}}
+	p, err := ex.Exec(cmd)
+	if err != nil {
+		t.Fatalf("Couldn't run sh %v", err)
+	}
+	status := p.Wait()
+	if status.State != execer.FAILED || !strings.Contains(status.Error, "MemoryCap") {
+		t.Fatalf("Expected FAILED exceeding the MemoryCap, got %v", status)
+	}
+}
+
+func TestCgroupExecer_AbortKillsTree(t *testing.T) {
+	ex := newTestCgroupExecer(t, CgroupLimits{})
+
+	// the child leaves the process group, but not the cgroup
+	stdout := &lockedBuffer{}
+	cmd := execer.Command{Argv: []string{"sh", "-c", "setsid sleep 100 & echo $!; wait"}, Stdout: stdout}
+	p, err := ex.Exec(cmd)
+	if err != nil {
+		t.Fatalf("Couldn't run sh %v", err)
+	}
+	var pid int
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if pid, err = strconv.Atoi(strings.TrimSpace(stdout.String())); err == nil {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the child's pid")
+		}
+	}
+
+	status := p.Abort()
+	if status.State != execer.FAILED {
+		t.Fatalf("Expected FAILED aborting, got %v", status)
+	}
+	// reparented processes may not be reaped in a container, so a zombie is dead enough
+	if stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+		if fields := strings.Fields(string(stat)); len(fields) > 2 && fields[2] != "Z" {
+			t.Fatalf("Expected the aborted cmd's child %d to be killed, state %v", pid, fields[2])
+		}
+	}
+}
+
+// Buffer safe to read while the cmd writes to it
+type lockedBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.String()
+}
+
+func TestCgroup_V2StartsInCgroup(t *testing.T) {
+	// the unified hierarchy, whether or not it has the controllers
+	var root string
+	for _, dir := range []string{cgroupRoot, path.Join(cgroupRoot, "unified")} {
+		if _, err := os.Stat(path.Join(dir, "cgroup.controllers")); err == nil {
+			root = dir
+		}
+	}
+	name := fmt.Sprintf("scoot-test-%d", os.Getpid())
+	g := &cgroup{v2: true, dirs: make(map[string]string)}
+	for _, controller := range cgroupControllers {
+		g.dirs[controller] = path.Join(root, name)
+	}
+	if root == "" || os.Mkdir(g.dirs["memory"], 0755) != nil {
+		t.Skipf("cgroup v2 not available")
+	}
+	defer g.destroy()
+
+	// the command's first instruction runs in the cgroup, so nothing it forks escapes
+	var stdout bytes.Buffer
+	cmd := exec.Command("cat", "/proc/self/cgroup")
+	cmd.Stdout = &stdout
+	if err := g.start(cmd); err != nil {
+		t.Fatalf("Couldn't start cat %v", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("Error running cat %v", err)
+	}
+	if expected := "0::/" + name + "\n"; !strings.Contains(stdout.String(), expected) {
+		t.Fatalf("Expected the command to start in cgroup %q, got %q", expected, stdout.String())
+	}
+}
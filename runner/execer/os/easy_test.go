@@ -2,6 +2,7 @@ package os
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 	"time"
 
@@ -60,6 +61,53 @@ func TestOutput(t *testing.T) {
 	}
 }
 
+func TestEnvVars(t *testing.T) {
+	exer := NewExecer()
+
+	var stdout bytes.Buffer
+	cmd := execer.Command{
+		Argv:    []string{"sh", "-c", "echo -n $SCOOT_TEST_VAR:$HOME"},
+		EnvVars: map[string]string{"SCOOT_TEST_VAR": "value", "HOME": "/scoot/home"},
+		Stdout:  &stdout,
+	}
+	p, err := exer.Exec(cmd)
+	if err != nil {
+		t.Fatalf("Couldn't run sh %v", err)
+	}
+	status := p.Wait()
+	if status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status running sh %v", status)
+	}
+	if expected := "value:/scoot/home"; stdout.String() != expected {
+		t.Fatalf("Incorrect output, got %q; expected %q", stdout.String(), expected)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	// busy for a CPU second, in a child that's waited for
+	cmd := execer.Command{Argv: []string{"sh", "-c", "timeout 1 sh -c 'while :; do :; done'; true"}}
+	p, err := NewExecer().Exec(cmd)
+	if err != nil {
+		t.Fatalf("Couldn't run sh %v", err)
+	}
+	status := p.Wait()
+	if status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status running sh %v", status)
+	}
+	usage := status.Usage
+	if usage.WallTime < time.Second || usage.UserTime+usage.SysTime < 500*time.Millisecond || usage.MaxRSS == 0 {
+		t.Fatalf("Expected a second of wall time, half a second of CPU time and some memory, got %+v", usage)
+	}
+}
+
+func TestProcessEnv(t *testing.T) {
+	env := processEnv([]string{"A=1", "B=2", "C=x=y"}, map[string]string{"B": "3", "D": "4"})
+	expected := []string{"A=1", "C=x=y", "B=3", "D=4"}
+	if strings.Join(env, " ") != strings.Join(expected, " ") {
+		t.Fatalf("Incorrect env, got %v; expected %v", env, expected)
+	}
+}
+
 func TestMemUsage(t *testing.T) {
 	// Command to increase memory by 10MB every .1s until we hit 50MB after .5s.
 	// Creates a bash process and under that a python process. They should both contribute to MemUsage.
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,7 +19,7 @@ import (
 )
 
 func NewExecer() execer.Execer {
-	return &osExecer{}
+	return &osExecer{stat: stats.NilStatsReceiver()}
 }
 
 // For now memory can be capped on a per-execer basis rather than a per-command basis.
@@ -25,10 +28,29 @@ func NewBoundedExecer(memCap execer.Memory, stat stats.StatsReceiver) execer.Exe
 	return &osExecer{memCap: memCap, stat: stat.Scope("osexecer")}
 }
 
+// Runs each command's process tree in its own cgroup under parent, a path
+// relative to the root of the cgroup hierarchies, with the specified limits.
+// The kernel enforces the limits, and aborting a command kills its whole tree.
+// Errors if cgroups aren't available or writable, which is Linux only.
+func NewCgroupExecer(parent string, limits CgroupLimits, stat stats.StatsReceiver) (execer.Execer, error) {
+	cgroups, err := newCgroups(cgroupRoot, parent)
+	if err != nil {
+		return nil, err
+	}
+	return &osExecer{memCap: limits.Memory, stat: stat.Scope("osexecer"), cgroups: cgroups, limits: limits}, nil
+}
+
 type osExecer struct {
 	// Best effort monitoring of command to kill it if resident memory usage exceeds this cap. Ignored if zero.
 	memCap execer.Memory
 	stat   stats.StatsReceiver
+
+	// If set, each command runs in a new cgroup with these limits, instead of being monitored
+	cgroups *cgroups
+	limits  CgroupLimits
+
+	// Whether each command runs in a sandbox, see NewSandboxExecer
+	sandbox bool
 }
 
 type WriterDelegater interface {
@@ -40,6 +62,28 @@ type WriterDelegater interface {
 	WriterDelegate() io.Writer
 }
 
+// Returns the environment of a process inheriting inherited, a list of
+// "key=value" strings, with the variables in envVars set.
+func processEnv(inherited []string, envVars map[string]string) []string {
+	env := make([]string, 0, len(inherited)+len(envVars))
+	for _, kv := range inherited {
+		name := strings.SplitN(kv, "=", 2)[0]
+		if _, ok := envVars[name]; !ok {
+			env = append(env, kv)
+		}
+	}
+
+	names := make([]string, 0, len(envVars))
+	for name := range envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, name+"="+envVars[name])
+	}
+	return env
+}
+
 func (e *osExecer) Exec(command execer.Command) (result execer.Process, err error) {
 	if len(command.Argv) == 0 {
 		return nil, errors.New("No command specified.")
@@ -48,6 +92,9 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 	cmd := exec.Command(command.Argv[0], command.Argv[1:]...)
 
 	cmd.Stdout, cmd.Stderr, cmd.Dir = command.Stdout, command.Stderr, command.Dir
+	if len(command.EnvVars) > 0 {
+		cmd.Env = processEnv(os.Environ(), command.EnvVars)
+	}
 	// Make sure to get the best possible Writer, so if possible os/exec can connect
 	// the command's stdout/stderr directly to a file, instead of having to go through
 	// our delegation
@@ -60,22 +107,61 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-	err = cmd.Start()
+	var sandbox *sandbox
+	if e.sandbox {
+		if sandbox, err = newSandbox(cmd, command); err != nil {
+			return nil, err
+		}
+	}
+
+	var cgroup *cgroup
+	if e.cgroups != nil {
+		if cgroup, err = e.cgroups.create(e.limits); err != nil {
+			if sandbox != nil {
+				sandbox.release()
+			}
+			return nil, err
+		}
+	}
+
+	if cgroup != nil {
+		err = cgroup.start(cmd)
+	} else {
+		err = cmd.Start()
+	}
 	if err != nil {
+		if cgroup != nil {
+			cgroup.destroy()
+		}
+		if sandbox != nil {
+			sandbox.release()
+		}
 		return nil, err
 	}
 
-	proc := &osProcess{cmd: cmd}
-	if e.memCap > 0 {
+	proc := &osProcess{cmd: cmd, started: time.Now(), cgroup: cgroup, sandbox: sandbox, memCap: e.memCap, stat: e.stat}
+	if sandbox != nil {
+		if err := sandbox.waitForExec(); err != nil {
+			proc.Wait()
+			return nil, err
+		}
+	}
+	if cgroup == nil && e.memCap > 0 {
 		go proc.monitorMem(e.memCap, e.stat)
 	}
 	return proc, nil
 }
 
 type osProcess struct {
-	cmd    *exec.Cmd
-	result *execer.ProcessStatus
-	mutex  sync.Mutex
+	cmd     *exec.Cmd
+	started time.Time
+	result  *execer.ProcessStatus
+	mutex   sync.Mutex
+
+	cgroup  *cgroup
+	sandbox *sandbox
+	memCap  execer.Memory
+	stat    stats.StatsReceiver
 }
 
 // Periodically check to make sure memory constraints are respected.
@@ -116,6 +202,16 @@ func (p *osProcess) Wait() (result execer.ProcessStatus) {
 	} else {
 		p.result = &result
 	}
+	// runs once result is set below
+	defer func() {
+		if p.cgroup != nil && p.cgroup.oomKilled() {
+			result.State = execer.FAILED
+			result.Error = fmt.Sprintf("Cmd exceeded MemoryCap: %d", p.memCap)
+		}
+		result.Usage = processUsage(p.cmd.ProcessState, time.Since(p.started))
+		p.recordUsage(result.Usage)
+		p.release(&result)
+	}()
 	if err == nil {
 		result.State = execer.COMPLETE
 		result.ExitCode = 0
@@ -151,27 +247,85 @@ func (p *osProcess) Abort() (result execer.ProcessStatus) {
 	result.State = execer.FAILED
 	result.ExitCode = -1
 	result.Error = "Aborted."
-	pgid, err := syscall.Getpgid(p.cmd.Process.Pid)
-	if err == nil {
-		err = syscall.Kill(-pgid, 15)
-	}
-	if err != nil {
-		result.Error = "Aborted. Parent only, couldn't kill by pgid."
-	}
-	err = p.cmd.Process.Kill()
-	if err != nil {
-		result.Error = "Aborted. Couldn't kill pgid or parent."
+	if p.cgroup != nil {
+		// the whole tree, including what left the process group
+		if err := p.cgroup.kill(); err != nil {
+			log.Printf("Error killing aborted cmd's cgroup: %v", err)
+			result.Error = "Aborted. Couldn't kill cgroup."
+		}
+	} else {
+		pgid, err := syscall.Getpgid(p.cmd.Process.Pid)
+		if err == nil {
+			err = syscall.Kill(-pgid, 15)
+		}
+		if err != nil {
+			result.Error = "Aborted. Parent only, couldn't kill by pgid."
+		}
+		err = p.cmd.Process.Kill()
+		if err != nil {
+			result.Error = "Aborted. Couldn't kill pgid or parent."
+		}
 	}
 
-	_, err = p.cmd.Process.Wait()
+	state, err := p.cmd.Process.Wait()
 	if err, ok := err.(*exec.ExitError); ok {
 		if status, ok := err.Sys().(syscall.WaitStatus); ok {
 			result.ExitCode = status.ExitStatus()
 		}
 	}
+	result.Usage = processUsage(state, time.Since(p.started))
+	p.recordUsage(result.Usage)
+	p.release(&result)
 	return result
 }
 
+// Returns the resources used by the exited process, whose state is nil if it
+// couldn't be waited for
+func processUsage(state *os.ProcessState, wallTime time.Duration) execer.Usage {
+	usage := execer.Usage{WallTime: wallTime}
+	if state == nil {
+		return usage
+	}
+	usage.UserTime = state.UserTime()
+	usage.SysTime = state.SystemTime()
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		usage.MaxRSS = execer.Memory(int64(rusage.Maxrss) * maxRSSUnit)
+		usage.BlockInputOps = int64(rusage.Inblock)
+		usage.BlockOutputOps = int64(rusage.Oublock)
+	}
+	return usage
+}
+
+func (p *osProcess) recordUsage(usage execer.Usage) {
+	p.stat.Histogram("runWallTimeHistogram_ms").Update(int64(usage.WallTime / time.Millisecond))
+	p.stat.Histogram("runUserTimeHistogram_ms").Update(int64(usage.UserTime / time.Millisecond))
+	p.stat.Histogram("runSysTimeHistogram_ms").Update(int64(usage.SysTime / time.Millisecond))
+	p.stat.Histogram("runMaxRssHistogram_bytes").Update(int64(usage.MaxRSS))
+	p.stat.Histogram("runBlockInputOpsHistogram").Update(usage.BlockInputOps)
+	p.stat.Histogram("runBlockOutputOpsHistogram").Update(usage.BlockOutputOps)
+}
+
+// Kills what's left of the process tree, and removes its cgroup once the peak
+// memory usage is recorded in result, and its sandbox.
+func (p *osProcess) release(result *execer.ProcessStatus) {
+	if p.sandbox != nil {
+		p.sandbox.release()
+		p.sandbox = nil
+	}
+	if p.cgroup == nil {
+		return
+	}
+	if err := p.cgroup.kill(); err != nil {
+		log.Printf("Error killing cmd's remaining processes: %v", err)
+	}
+	result.PeakMemory = p.cgroup.peakMemory()
+	p.stat.Gauge("memory").Update(int64(result.PeakMemory))
+	if err := p.cgroup.destroy(); err != nil {
+		log.Printf("Error removing cmd's cgroup: %v", err)
+	}
+	p.cgroup = nil
+}
+
 func memUsage(pgid int) (execer.Memory, error) {
 	// Pass children of pgid from 'pgrep', and pgid itself, into 'ps' to get rss memory usages in KB, then sum them.
 	// Note: there may be better ways to do this if we choose to handle osx/linux separately.
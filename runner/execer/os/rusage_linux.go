@@ -0,0 +1,4 @@
+package os
+
+// ru_maxrss is in kilobytes on Linux
+const maxRSSUnit = 1024
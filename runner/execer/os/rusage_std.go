@@ -0,0 +1,6 @@
+// +build !linux
+
+package os
+
+// and in bytes on macOS
+const maxRSSUnit = 1
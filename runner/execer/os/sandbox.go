@@ -0,0 +1,43 @@
+package os
+
+import (
+	"errors"
+
+	"github.com/scootdev/scoot/runner/execer"
+)
+
+// argv[0] the sandbox's init is started with, see sandbox_linux.go
+const sandboxInitArg = "scoot-sandbox-init"
+
+// Env var the sandbox's init reads its config from
+const sandboxConfigEnvVar = "SCOOT_SANDBOX_CONFIG"
+
+// What the sandbox's init sets up before it starts the command
+type sandboxConfig struct {
+	// Empty dir the new root is mounted on
+	Root string
+
+	// Checkout, the only dir the command can write to besides /tmp and /dev
+	Dir string
+
+	Network bool
+}
+
+// Returns an Execer running each command like ex, one returned by NewExecer,
+// NewBoundedExecer or NewCgroupExecer, but in a sandbox: fresh Linux mount,
+// PID, network and user namespaces.  In the sandbox the command runs as root,
+// which is the worker's user outside of it, with a read-only view of the
+// filesystem except for its checkout, an empty /tmp and /dev.  It can only
+// use the network if the execer.Command asks for it.
+//
+// The sandbox's init is the worker binary itself, which sets the sandbox up
+// when started by it, before main() runs.
+func NewSandboxExecer(ex execer.Execer) (execer.Execer, error) {
+	e, ok := ex.(*osExecer)
+	if !ok {
+		return nil, errors.New("Only an os execer can be sandboxed")
+	}
+	sandboxed := *e
+	sandboxed.sandbox = true
+	return &sandboxed, nil
+}
@@ -0,0 +1,316 @@
+package os
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/scootdev/scoot/runner/execer"
+)
+
+// A command's sandbox, from the outside
+type sandbox struct {
+	root string
+
+	// Closed by the sandbox's init once it's started the command, after it
+	// writes why to if it can't
+	execR, execW *os.File
+}
+
+// Makes cmd start the sandbox's init in new namespaces, which sets the
+// sandbox up then starts command
+func newSandbox(cmd *exec.Cmd, command execer.Command) (*sandbox, error) {
+	root, err := ioutil.TempDir("", "sandbox")
+	if err != nil {
+		return nil, err
+	}
+	execR, execW, err := os.Pipe()
+	if err != nil {
+		os.Remove(root)
+		return nil, err
+	}
+	config, _ := json.Marshal(sandboxConfig{Root: root, Dir: command.Dir, Network: command.Network})
+
+	cmd.Path = "/proc/self/exe"
+	cmd.Args = append([]string{sandboxInitArg}, cmd.Args...)
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, sandboxConfigEnvVar+"="+string(config))
+	cmd.ExtraFiles = []*os.File{execW}
+
+	cmd.SysProcAttr.Cloneflags = syscall.CLONE_NEWNS | syscall.CLONE_NEWPID | syscall.CLONE_NEWUSER
+	if !command.Network {
+		cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+	cmd.SysProcAttr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}}
+	cmd.SysProcAttr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}}
+	cmd.SysProcAttr.GidMappingsEnableSetgroups = false
+	return &sandbox{root: root, execR: execR, execW: execW}, nil
+}
+
+// Returns once the sandbox's init has started the command, or why it couldn't
+func (s *sandbox) waitForExec() error {
+	// the started process has its own copy of the write end
+	s.execW.Close()
+	msg, _ := ioutil.ReadAll(s.execR)
+	if len(msg) > 0 {
+		return fmt.Errorf("Couldn't set up sandbox: %s", msg)
+	}
+	return nil
+}
+
+func (s *sandbox) release() {
+	s.execR.Close()
+	s.execW.Close()
+	// only mounted on in the sandbox's mount namespace
+	os.Remove(s.root)
+}
+
+func init() {
+	if len(os.Args) > 1 && os.Args[0] == sandboxInitArg {
+		runSandboxInit()
+	}
+}
+
+// Signals forwarded to the command by the sandbox's init.  As the PID
+// namespace's init it'd ignore them otherwise, as it would any signal it
+// doesn't handle.
+var sandboxForwardedSignals = []os.Signal{
+	syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP, syscall.SIGQUIT, syscall.SIGUSR1, syscall.SIGUSR2,
+}
+
+// Sets up the sandbox from within its namespaces, and runs the command in
+// os.Args[1:] as its child: it forwards signals to the command, and reaps
+// the processes orphaned in the sandbox, until the command exits.  It then
+// exits with the command's exit status, or 128+n if signal n killed it, and
+// the kernel kills what's left in the sandbox.  Never returns.
+func runSandboxInit() {
+	execW := os.NewFile(3, "exec")
+	fail := func(err error) {
+		fmt.Fprint(execW, err.Error())
+		os.Exit(1)
+	}
+
+	var config sandboxConfig
+	if err := json.Unmarshal([]byte(os.Getenv(sandboxConfigEnvVar)), &config); err != nil {
+		fail(err)
+	}
+	os.Unsetenv(sandboxConfigEnvVar)
+	if err := setupSandbox(config); err != nil {
+		fail(err)
+	}
+
+	argv0, err := exec.LookPath(os.Args[1])
+	if err != nil {
+		fail(err)
+	}
+	// the command mustn't keep the pipe open
+	syscall.CloseOnExec(3)
+	// before the command starts, so none are missed
+	signals := make(chan os.Signal, len(sandboxForwardedSignals))
+	signal.Notify(signals, sandboxForwardedSignals...)
+	pid, err := syscall.ForkExec(argv0, os.Args[1:], &syscall.ProcAttr{
+		Env:   os.Environ(),
+		Files: []uintptr{0, 1, 2},
+	})
+	if err != nil {
+		fail(err)
+	}
+	execW.Close()
+
+	go func() {
+		for sig := range signals {
+			syscall.Kill(pid, sig.(syscall.Signal))
+		}
+	}()
+	os.Exit(reapUntil(pid))
+}
+
+// Reaps the sandbox's processes until the command, pid, exits, returning the
+// status to exit with
+func reapUntil(pid int) int {
+	for {
+		var status syscall.WaitStatus
+		wpid, err := syscall.Wait4(-1, &status, 0, nil)
+		if err == syscall.EINTR {
+			continue
+		} else if err != nil {
+			// only if the command's gone without being waited for
+			return 1
+		}
+		if wpid != pid {
+			continue
+		}
+		if status.Signaled() {
+			return 128 + int(status.Signal())
+		}
+		return status.ExitStatus()
+	}
+}
+
+// Mounts the sandbox's filesystem on config.Root and makes it the root
+func setupSandbox(config sandboxConfig) error {
+	// keep the mounts below from propagating back to the host
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("making mounts private: %v", err)
+	}
+	root := config.Root
+	if err := syscall.Mount("/", root, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("binding root: %v", err)
+	}
+	if err := remountReadOnly(root, []string{"/dev", "/proc"}); err != nil {
+		return err
+	}
+
+	// /tmp first, since the checkout may be in it
+	if err := syscall.Mount("tmpfs", path.Join(root, "tmp"), "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "mode=1777"); err != nil {
+		return fmt.Errorf("mounting /tmp: %v", err)
+	}
+	if _, err := os.Stat("/dev/shm"); err == nil {
+		if err := syscall.Mount("tmpfs", path.Join(root, "dev/shm"), "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "mode=1777"); err != nil {
+			return fmt.Errorf("mounting /dev/shm: %v", err)
+		}
+	}
+	if err := syscall.Mount("proc", path.Join(root, "proc"), "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("mounting /proc: %v", err)
+	}
+	if config.Dir != "" {
+		dir := path.Join(root, config.Dir)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if err := syscall.Mount(config.Dir, dir, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+			return fmt.Errorf("binding checkout: %v", err)
+		}
+	}
+
+	// root becomes / and the old / is unmounted from under it
+	if err := syscall.Chdir(root); err != nil {
+		return err
+	}
+	if err := syscall.PivotRoot(".", "."); err != nil {
+		return fmt.Errorf("pivoting root: %v", err)
+	}
+	if err := syscall.Unmount(".", syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("unmounting old root: %v", err)
+	}
+	if err := syscall.Chdir(path.Join("/", config.Dir)); err != nil {
+		return err
+	}
+
+	if !config.Network {
+		// the new network namespace only has a loopback interface, and it's down
+		if err := loopbackUp(); err != nil {
+			return fmt.Errorf("bringing up loopback: %v", err)
+		}
+	}
+	return nil
+}
+
+// Makes the mounts under root read-only, except those under the excluded
+// paths relative to root
+func remountReadOnly(root string, excluded []string) error {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// id parent major:minor root mountpoint options ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		mountpoint := unescapeMountinfo(fields[4])
+		if mountpoint != root && !strings.HasPrefix(mountpoint, root+"/") {
+			continue
+		}
+		skip := false
+		for _, dir := range excluded {
+			if p := path.Join(root, dir); mountpoint == p || strings.HasPrefix(mountpoint, p+"/") {
+				skip = true
+			}
+		}
+		if skip {
+			continue
+		}
+
+		// flags locked by the outer user namespace have to be kept
+		flags := uintptr(syscall.MS_REMOUNT | syscall.MS_BIND | syscall.MS_RDONLY)
+		for _, opt := range strings.Split(fields[5], ",") {
+			switch opt {
+			case "nosuid":
+				flags |= syscall.MS_NOSUID
+			case "nodev":
+				flags |= syscall.MS_NODEV
+			case "noexec":
+				flags |= syscall.MS_NOEXEC
+			case "noatime":
+				flags |= syscall.MS_NOATIME
+			case "nodiratime":
+				flags |= syscall.MS_NODIRATIME
+			case "relatime":
+				flags |= syscall.MS_RELATIME
+			}
+		}
+		if err := syscall.Mount("", mountpoint, "", flags, ""); err != nil {
+			return fmt.Errorf("making %v read-only: %v", mountpoint, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Mountinfo escapes space, tab, newline and backslash in paths as \ooo
+func unescapeMountinfo(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b = append(b, byte(c))
+				i += 3
+				continue
+			}
+		}
+		b = append(b, s[i])
+	}
+	return string(b)
+}
+
+func loopbackUp() error {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+
+	// struct ifreq, with the ifr_flags member of its union
+	var ifr struct {
+		name  [syscall.IFNAMSIZ]byte
+		flags uint16
+		_     [22]byte
+	}
+	copy(ifr.name[:], "lo")
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCGIFFLAGS, uintptr(unsafe.Pointer(&ifr))); errno != 0 {
+		return errno
+	}
+	ifr.flags |= syscall.IFF_UP | syscall.IFF_RUNNING
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCSIFFLAGS, uintptr(unsafe.Pointer(&ifr))); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+// +build !linux
+
+package os
+
+import (
+	"errors"
+	"os/exec"
+
+	"github.com/scootdev/scoot/runner/execer"
+)
+
+type sandbox struct{}
+
+func newSandbox(cmd *exec.Cmd, command execer.Command) (*sandbox, error) {
+	return nil, errors.New("sandboxes are only supported on Linux")
+}
+
+func (s *sandbox) waitForExec() error {
+	return nil
+}
+
+func (s *sandbox) release() {}
@@ -0,0 +1,133 @@
+package os
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/runner/execer"
+)
+
+// Runs the shell script in a sandbox, skipping the test where namespaces
+// can't be created
+func runInSandbox(t *testing.T, command execer.Command, script string) (execer.ProcessStatus, string) {
+	ex, err := NewSandboxExecer(NewExecer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	command.Argv = []string{"sh", "-c", script}
+	command.Stdout, command.Stderr = &stdout, &stderr
+	p, err := ex.Exec(command)
+	if err != nil {
+		t.Skipf("sandbox not available: %v", err)
+	}
+	status := p.Wait()
+	if stderr.Len() > 0 {
+		t.Logf("stderr: %s", stderr.String())
+	}
+	return status, stdout.String()
+}
+
+func TestSandbox_Filesystem(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tmpFile := path.Base(dir) + "-tmp"
+	defer os.Remove(path.Join(os.TempDir(), tmpFile))
+
+	script := `
+echo out > out &&
+touch /tmp/` + tmpFile + ` &&
+! touch /etc/scoot-sandbox-test 2>/dev/null &&
+echo -n $(pwd) $(ls /tmp | wc -l)`
+	status, stdout := runInSandbox(t, execer.Command{Dir: dir}, script)
+	if status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status running sh %v", status)
+	}
+	// the checkout and a /tmp with only it and the file written to it
+	if expected := dir + " 2"; stdout != expected {
+		t.Errorf("Expected %q, got %q", expected, stdout)
+	}
+	if data, err := ioutil.ReadFile(path.Join(dir, "out")); err != nil || string(data) != "out\n" {
+		t.Errorf("Expected the checkout to be written to, got %q %v", data, err)
+	}
+	if _, err := os.Stat(path.Join(os.TempDir(), tmpFile)); !os.IsNotExist(err) {
+		t.Errorf("Expected the sandbox's /tmp not to be the host's, got %v", err)
+	}
+}
+
+func TestSandbox_Namespaces(t *testing.T) {
+	// the sandbox's init is pid 1, and there's no network but the loopback
+	script := `echo -n $(head -c 18 /proc/1/cmdline) $(cat /proc/net/dev | grep -c :) $(grep -c "lo:" /proc/net/dev)`
+	status, stdout := runInSandbox(t, execer.Command{}, script)
+	if status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status running sh %v", status)
+	}
+	if expected := sandboxInitArg + " 1 1"; stdout != expected {
+		t.Errorf("Expected %q, got %q", expected, stdout)
+	}
+
+	status, stdout = runInSandbox(t, execer.Command{Network: true}, "cat /proc/net/dev")
+	hostNet, _ := ioutil.ReadFile("/proc/net/dev")
+	if status.State != execer.COMPLETE || strings.Count(stdout, ":") != strings.Count(string(hostNet), ":") {
+		t.Errorf("Expected the host's network, got %v %q", status, stdout)
+	}
+}
+
+func TestSandbox_CommandNotFound(t *testing.T) {
+	ex, _ := NewSandboxExecer(NewExecer())
+	_, err := ex.Exec(execer.Command{Argv: []string{"/does/not/exist"}})
+	if err == nil || !strings.Contains(err.Error(), "sandbox") {
+		t.Errorf("Expected an error setting up the sandbox to exec a command that doesn't exist, got %v", err)
+	}
+}
+
+func TestSandbox_Init(t *testing.T) {
+	// orphans are reaped instead of left as zombies
+	script := `sh -c 'true &'; sleep 0.2; grep -l "^State:.Z" /proc/[0-9]*/status | wc -l`
+	status, stdout := runInSandbox(t, execer.Command{}, script)
+	if status.State != execer.COMPLETE || status.ExitCode != 0 || strings.TrimSpace(stdout) != "0" {
+		t.Errorf("Expected no zombies, got %v %q", status, stdout)
+	}
+
+	// the command exits with its status, or 128+n if killed by signal n
+	if status, _ := runInSandbox(t, execer.Command{}, "exit 7"); status.ExitCode != 7 {
+		t.Errorf("Expected the command's exit status, got %v", status)
+	}
+	if status, _ := runInSandbox(t, execer.Command{}, "kill -9 $$"); status.ExitCode != 128+9 {
+		t.Errorf("Expected the exit status of a command killed by SIGKILL, got %v", status)
+	}
+
+	// signals sent to the sandbox are forwarded to the command
+	dir, err := ioutil.TempDir("", "checkout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ex, _ := NewSandboxExecer(NewExecer())
+	p, err := ex.Exec(execer.Command{
+		Argv: []string{"sh", "-c", "trap 'exit 3' TERM; touch ready; while true; do sleep 0.05; done"},
+		Dir:  dir,
+	})
+	if err != nil {
+		t.Skipf("sandbox not available: %v", err)
+	}
+	for i := 0; i < 500; i++ {
+		if _, err := os.Stat(path.Join(dir, "ready")); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	p.(*osProcess).cmd.Process.Signal(syscall.SIGTERM)
+	if status := p.Wait(); status.State != execer.COMPLETE || status.ExitCode != 3 {
+		t.Errorf("Expected the command to handle SIGTERM, got %v", status)
+	}
+}
@@ -0,0 +1,33 @@
+package runner
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ValidateOutputs returns an error if a Command's Outputs aren't relative paths
+// staying within the checkout and result snapshot, or aren't valid patterns
+func ValidateOutputs(outputs map[string]string) error {
+	for src, dest := range outputs {
+		if !isLocalPath(src) || src == "." {
+			return fmt.Errorf("output %q must be a path within the checkout", src)
+		}
+		if _, err := filepath.Match(src, ""); err != nil {
+			return fmt.Errorf("output %q: %v", src, err)
+		}
+		if dest != "" && !isLocalPath(dest) {
+			return fmt.Errorf("output %q destination %q must be a dir within the snapshot", src, dest)
+		}
+	}
+	return nil
+}
+
+// Whether p is a non-empty relative path that doesn't go above its parent
+func isLocalPath(p string) bool {
+	if p == "" || filepath.IsAbs(p) {
+		return false
+	}
+	p = filepath.Clean(p)
+	return p != ".." && !strings.HasPrefix(p, "../")
+}
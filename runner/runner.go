@@ -30,13 +30,17 @@ type Command struct {
 	// Runner can optionally use this to run against a particular snapshot. Empty value is ignored.
 	SnapshotID string
 
-	// TODO(jschiller): get consensus on design and either implement or delete.
-	// Runner can optionally use this to specify content if creating a new snapshot.
-	// Keys: relative src file & dir paths in SnapshotId checkout. May contain '*' wildcard.
-	// Values: relative dest path=dir/base in new snapshot (if src has a wildcard, then dest path is treated as a parent dir).
-	//
-	// Note: nil and empty maps are different!, nil means don't filter, empty means filter everything.
-	// SnapshotPlan map[string]string
+	// Give the command network access, if the runner runs commands in a sandbox.
+	Network bool
+
+	// Commands with a higher priority are run first if the runner queues them.
+	Priority int
+
+	// Files and dirs to copy from the checkout into the result snapshot, alongside STDOUT and STDERR,
+	// if the command completes.
+	// Keys: relative src file & dir paths in the checkout. May contain filepath.Match wildcards.
+	// Values: relative dest dir in the result snapshot each match is copied into, "" for its root.
+	Outputs map[string]string
 }
 
 func (c Command) String() string {
@@ -45,6 +49,12 @@ func (c Command) String() string {
 		c.SnapshotID,
 		c.Argv,
 		c.Timeout)
+	if c.Network {
+		fmt.Fprintf(&b, "\tNetwork:\ttrue\n")
+	}
+	if c.Priority != 0 {
+		fmt.Fprintf(&b, "\tPriority:\t%d\n", c.Priority)
+	}
 
 	if len(c.EnvVars) > 0 {
 		fmt.Fprintf(&b, "\tEnv:\n")
@@ -53,6 +63,13 @@ func (c Command) String() string {
 		}
 	}
 
+	if len(c.Outputs) > 0 {
+		fmt.Fprintf(&b, "\tOutputs:\n")
+		for src, dest := range c.Outputs {
+			fmt.Fprintf(&b, "\t\t%s -> %s/\n", src, dest)
+		}
+	}
+
 	return b.String()
 }
 
@@ -33,6 +33,9 @@ type Invoker struct {
 	filer  snapshot.Filer
 	output runner.OutputCreator
 	tmp    *temp.TempDir
+
+	// if set, told the outputs created for each run, from their ids to their paths
+	outputsCreated func(id runner.RunID, outputs map[string]string)
 }
 
 // Run runs cmd
@@ -63,6 +66,11 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 		close(updateCh)
 	}()
 
+	// before checking out, which is wasted on a command that can't run
+	if err := runner.ValidateOutputs(cmd.Outputs); err != nil {
+		return runner.BadRequestStatus(id, err)
+	}
+
 	checkoutCh := make(chan checkoutAndError)
 	var checkout snapshot.Checkout
 	var err error
@@ -100,23 +108,29 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 
 	log.Printf("runner/runners/invoke.go: checkout done. id %v cmd: %+v checkout: %v", id, cmd, checkout.Path())
 
-	stdout, err := inv.output.Create(fmt.Sprintf("%s-stdout", id))
+	stdoutID, stderrID := fmt.Sprintf("%s-stdout", id), fmt.Sprintf("%s-stderr", id)
+	stdout, err := inv.output.Create(stdoutID)
 	if err != nil {
 		return runner.ErrorStatus(id, fmt.Errorf("could not create stdout: %v", err))
 	}
 	defer stdout.Close()
-	stderr, err := inv.output.Create(fmt.Sprintf("%s-stderr", id))
+	stderr, err := inv.output.Create(stderrID)
 	if err != nil {
 		return runner.ErrorStatus(id, fmt.Errorf("could not create stderr: %v", err))
 	}
 	defer stderr.Close()
 	log.Printf("RunID=%s, stdout=%s, stderr=%s\n", id, stdout.AsFile(), stderr.AsFile())
+	if inv.outputsCreated != nil {
+		inv.outputsCreated(id, map[string]string{stdoutID: stdout.AsFile(), stderrID: stderr.AsFile()})
+	}
 
 	p, err := inv.exec.Exec(execer.Command{
-		Argv:   cmd.Argv,
-		Dir:    checkout.Path(),
-		Stdout: stdout,
-		Stderr: stderr,
+		Argv:    cmd.Argv,
+		EnvVars: cmd.EnvVars,
+		Network: cmd.Network,
+		Dir:     checkout.Path(),
+		Stdout:  stdout,
+		Stderr:  stderr,
 	})
 	if err != nil {
 		return runner.ErrorStatus(id, fmt.Errorf("could not exec: %v", err))
@@ -138,11 +152,13 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 	// Wait for process to complete (or cancel if we're told to)
 	select {
 	case <-abortCh:
-		p.Abort()
-		return runner.AbortStatus(id)
+		status := runner.AbortStatus(id)
+		status.Usage = p.Abort().Usage
+		return status
 	case <-timeoutCh:
-		p.Abort()
-		return runner.TimeoutStatus(id)
+		status := runner.TimeoutStatus(id)
+		status.Usage = p.Abort().Usage
+		return status
 	case st = <-processCh:
 	}
 
@@ -155,6 +171,12 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 			return runner.ErrorStatus(id, fmt.Errorf("error staging ingestion dir: %v", err))
 		}
 		defer os.RemoveAll(tmp.Dir)
+		// first, so STDOUT and STDERR take precedence at the snapshot's root
+		if len(cmd.Outputs) > 0 {
+			if err := copyOutputs(checkout.Path(), cmd.Outputs, tmp.Dir); err != nil {
+				return runner.ErrorStatus(id, fmt.Errorf("error staging ingestion for outputs: %v", err))
+			}
+		}
 		outPath := stdout.AsFile()
 		errPath := stderr.AsFile()
 		stdoutName := "STDOUT"
@@ -180,13 +202,16 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 		//TODO: stdout/stderr should configurably point to a bundlestore server addr.
 		//Note: only modifying stdout/stderr refs when we're actively working with snapshotID.
 		status := runner.CompleteStatus(id, snapshotID, st.ExitCode)
+		status.Usage = st.Usage
 		if cmd.SnapshotID != "" {
 			status.StdoutRef = snapshotID + "/" + stdoutName
 			status.StderrRef = snapshotID + "/" + stderrName
 		}
 		return status
 	case execer.FAILED:
-		return runner.ErrorStatus(id, fmt.Errorf("error execing: %v", st.Error))
+		status := runner.ErrorStatus(id, fmt.Errorf("error execing: %v", st.Error))
+		status.Usage = st.Usage
+		return status
 	default:
 		return runner.ErrorStatus(id, fmt.Errorf("unexpected exec state: %v", st.State))
 	}
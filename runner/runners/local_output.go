@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -17,12 +18,23 @@ import (
 
 // local_output.go: output that's stored locally
 
+// Header set to the offset in the output a response's content ends at
+const OutputOffsetHeader = "X-Scoot-Output-Offset"
+
 type HttpOutputCreator interface {
 	http.Handler
 	runner.OutputCreator
 	HttpPath() string
 }
 
+// OutputRestorer is implemented by OutputCreators that can serve outputs
+// created before the worker restarted, e.g. those of runs loaded from a run journal.
+type OutputRestorer interface {
+	// Restore serves the output created with id, whose content is at path, as
+	// it was before the restart
+	Restore(id string, path string)
+}
+
 type localOutputCreator struct {
 	tmp      *temp.TempDir
 	hostname string
@@ -68,23 +80,35 @@ func (s *localOutputCreator) Create(id string) (runner.Output, error) {
 	uri := fmt.Sprintf("file://%s%s", s.hostname, absPath)
 	if s.httpUri != "" {
 		uri = fmt.Sprintf("%s/%s?file=%s", s.httpUri, id, uri)
+		s.mutex.Lock()
 		s.pathMap[strings.Trim(id, "/")] = absPath
 		s.pathMap[filepath.Base(absPath)] = absPath
+		s.mutex.Unlock()
 	}
 	return &localOutput{f: f, absPath: absPath, uri: uri}, nil
 }
 
+// Restore serves the output created with id again, once the worker restarted.
+func (s *localOutputCreator) Restore(id string, path string) {
+	if s.httpUri == "" {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pathMap[strings.Trim(id, "/")] = path
+	s.pathMap[filepath.Base(path)] = path
+}
+
 // Serves a minimal page that does ajax log tailing of the specified path
 // When '?content=true' is specified, this serves the content directly without ajax.
+// Adding '&offset=N' serves only the content written after the first N bytes,
+// for clients following the output as it's written.
 // Does not check the request path, either it finds the local file or 404s.
 func (s *localOutputCreator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
 	clientHtml :=
 		`<html>
 <script type="text/javascript">
-  var prevLength = 0
+  var offset = 0
   checkAtBottom = function() {
     //scrolling: http://stackoverflow.com/a/22394544
     var scrollTop = (document.documentElement && document.documentElement.scrollTop) || document.body.scrollTop;
@@ -102,14 +126,13 @@ func (s *localOutputCreator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
       var DONE=4, OK=200;
       if (xhr.readyState === DONE && xhr.status == OK) {
         var wasAtBottom = checkAtBottom()
-        document.body.innerText += xhr.responseText.substring(prevLength);
+        document.body.innerText += xhr.responseText;
         if (wasAtBottom)
           gotoBottom()
-        prevLength = xhr.responseText.length
+        offset = parseInt(xhr.getResponseHeader("` + OutputOffsetHeader + `"))
       }
     }
-    //TODO: request range to get delta.
-    xhr.open("GET", location.href + (location.search=="" ? "?" : "&") + "content=true");
+    xhr.open("GET", location.href + (location.search=="" ? "?" : "&") + "content=true&offset=" + offset);
     xhr.send();
   };
   sendRequest()
@@ -122,7 +145,9 @@ func (s *localOutputCreator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	path := strings.TrimPrefix(r.URL.Path, s.HttpPath())
+	s.mutex.Lock()
 	filepath, ok := s.pathMap[path]
+	s.mutex.Unlock()
 	if !ok {
 		http.Error(w, "Unrecognized path", http.StatusNotFound)
 	} else if resource, err := os.Open(filepath); err != nil {
@@ -130,15 +155,39 @@ func (s *localOutputCreator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	} else if info, err := resource.Stat(); err != nil {
 		http.Error(w, "", http.StatusInternalServerError)
 	} else {
+		defer resource.Close()
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		if r.URL.Query().Get("content") == "true" {
+		w.Header().Set("Access-Control-Expose-Headers", OutputOffsetHeader)
+		if r.URL.Query().Get("content") != "true" {
+			fmt.Fprintf(w, clientHtml)
+		} else if offsetParam := r.URL.Query().Get("offset"); offsetParam == "" {
+			w.Header().Set(OutputOffsetHeader, strconv.FormatInt(info.Size(), 10))
 			http.ServeContent(w, r, "", info.ModTime(), resource)
+		} else if offset, err := strconv.ParseInt(offsetParam, 10, 64); err != nil || offset < 0 {
+			http.Error(w, "Invalid offset", http.StatusBadRequest)
 		} else {
-			fmt.Fprintf(w, clientHtml)
+			serveFrom(w, resource, offset, info.Size())
 		}
 	}
 }
 
+// Serves the content of the output being written from offset up to size, its
+// size when the request came in, and the offset it ended at to ask for more
+// from in OutputOffsetHeader.
+func serveFrom(w http.ResponseWriter, resource *os.File, offset int64, size int64) {
+	if offset > size {
+		offset = size
+	}
+	if _, err := resource.Seek(offset, io.SeekStart); err != nil {
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.FormatInt(size-offset, 10))
+	w.Header().Set(OutputOffsetHeader, strconv.FormatInt(size, 10))
+	io.CopyN(w, resource, size-offset)
+}
+
 func (s *localOutputCreator) HttpPath() string {
 	return s.httpPath
 }
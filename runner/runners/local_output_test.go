@@ -0,0 +1,53 @@
+package runners
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/scootdev/scoot/os/temp"
+)
+
+func TestLocalOutputTailing(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp.Dir)
+	oc, err := NewHttpOutputCreator(tmp, "http://localhost:9091/output/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := oc.Create("1-stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	get := func(query string, expectedCode int, expectedBody string, expectedOffset string) {
+		w := httptest.NewRecorder()
+		oc.ServeHTTP(w, httptest.NewRequest("GET", "/output/1-stdout?content=true"+query, nil))
+		if w.Code != expectedCode {
+			t.Fatalf("%v: expected %v, got %v", query, expectedCode, w.Code)
+		}
+		if expectedCode != http.StatusOK {
+			return
+		}
+		if body := w.Body.String(); body != expectedBody {
+			t.Errorf("%v: expected %q, got %q", query, expectedBody, body)
+		}
+		if offset := w.Header().Get(OutputOffsetHeader); offset != expectedOffset {
+			t.Errorf("%v: expected offset %v, got %v", query, expectedOffset, offset)
+		}
+	}
+
+	out.Write([]byte("hello "))
+	get("&offset=0", http.StatusOK, "hello ", "6")
+	out.Write([]byte("world"))
+	get("&offset=6", http.StatusOK, "world", "11")
+	get("&offset=11", http.StatusOK, "", "11")
+	get("&offset=20", http.StatusOK, "", "11")
+	get("", http.StatusOK, "hello world", "11")
+	get("&offset=-1", http.StatusBadRequest, "", "")
+}
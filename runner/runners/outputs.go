@@ -0,0 +1,117 @@
+package runners
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// outputs.go: copying a command's declared outputs into its result snapshot.
+
+// Copies the files and dirs in the checkout at dir matching each of outputs'
+// keys into the dir under tmp named by its value.  Matches that resolve to
+// somewhere outside the checkout are refused, symlinks are copied as links
+// and never followed on either side.  Patterns that match nothing are
+// skipped, the command may legitimately not have produced a report, say.
+func copyOutputs(dir string, outputs map[string]string, tmp string) error {
+	checkout, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if tmp, err = filepath.EvalSymlinks(tmp); err != nil {
+		return err
+	}
+	for src, dest := range outputs {
+		matches, err := filepath.Glob(filepath.Join(checkout, src))
+		if err != nil {
+			return fmt.Errorf("output %q: %v", src, err)
+		}
+		if len(matches) == 0 {
+			log.Printf("runner/runners/outputs.go: output %q matched nothing in %v", src, dir)
+			continue
+		}
+		// a link copied by another output may be in the way
+		destDir := filepath.Join(tmp, dest)
+		if err := os.MkdirAll(destDir, 0755); err != nil {
+			return err
+		}
+		if resolved, err := filepath.EvalSymlinks(destDir); err != nil || !isWithin(tmp, resolved) {
+			return fmt.Errorf("output %q destination %q isn't a dir in the snapshot", src, dest)
+		}
+		for _, match := range matches {
+			// the match itself may be a link, but the dirs it's in must be in the checkout
+			parent, err := filepath.EvalSymlinks(filepath.Dir(match))
+			if err != nil {
+				return err
+			}
+			if !isWithin(checkout, parent) {
+				return fmt.Errorf("output %q matched %v outside of the checkout", src, match)
+			}
+			if err := copyTree(match, filepath.Join(destDir, filepath.Base(match))); err != nil {
+				return fmt.Errorf("output %q: %v", src, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Copies the file, dir or symlink at src to dest, replacing files already
+// copied there by another output that matched the same name
+func copyTree(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		mode := info.Mode()
+		if existing, err := os.Lstat(target); err == nil && (!mode.IsDir() || !existing.IsDir()) {
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+		}
+		switch {
+		case mode.IsDir():
+			return os.MkdirAll(target, mode.Perm()|0700)
+		case mode&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case mode.IsRegular():
+			return copyFile(path, target, mode.Perm())
+		default:
+			// sockets, fifos and devices have no content to snapshot
+			return nil
+		}
+	})
+}
+
+// Whether p is root or under it, both without symlinks
+func isWithin(root, p string) bool {
+	return p == root || strings.HasPrefix(p, root+string(filepath.Separator))
+}
+
+func copyFile(src, dest string, perm os.FileMode) error {
+	reader, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	writer, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(writer, reader); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
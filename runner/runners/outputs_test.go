@@ -0,0 +1,147 @@
+package runners
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scootdev/scoot/os/temp"
+	"github.com/scootdev/scoot/runner"
+	"github.com/scootdev/scoot/runner/execer/execers"
+	"github.com/scootdev/scoot/snapshot"
+	"github.com/scootdev/scoot/snapshot/snapshots"
+)
+
+// Makes a checkout with the files, keyed by path, and returns it and an empty
+// dir to copy outputs to
+func setupOutputs(t *testing.T, files map[string]string) (checkout, snapshot string) {
+	checkout, err := ioutil.TempDir("", "checkout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		p := filepath.Join(checkout, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snapshot, err = ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return checkout, snapshot
+}
+
+func assertFile(t *testing.T, p, expected string) {
+	if data, err := ioutil.ReadFile(p); err != nil || string(data) != expected {
+		t.Errorf("Expected %v to contain %q, got %q %v", p, expected, data, err)
+	}
+}
+
+func TestCopyOutputs(t *testing.T) {
+	checkout, snapshot := setupOutputs(t, map[string]string{
+		"reports/a.xml":         "a",
+		"reports/b.xml":         "b",
+		"reports/b.txt":         "not a report",
+		"coverage.out":          "coverage",
+		"bin/tool/tool":         "binary",
+		"bin/tool/lib/libx.so":  "lib",
+		"src/not-an-output.txt": "src",
+	})
+	defer os.RemoveAll(checkout)
+	defer os.RemoveAll(snapshot)
+	os.Symlink("tool", filepath.Join(checkout, "bin", "latest"))
+
+	outputs := map[string]string{
+		"reports/*.xml":  "test-reports",
+		"coverage.out":   "",
+		"bin/*":          "artifacts",
+		"never-produced": "",
+	}
+	if err := copyOutputs(checkout, outputs, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	assertFile(t, filepath.Join(snapshot, "test-reports", "a.xml"), "a")
+	assertFile(t, filepath.Join(snapshot, "test-reports", "b.xml"), "b")
+	assertFile(t, filepath.Join(snapshot, "coverage.out"), "coverage")
+	assertFile(t, filepath.Join(snapshot, "artifacts", "tool", "tool"), "binary")
+	assertFile(t, filepath.Join(snapshot, "artifacts", "tool", "lib", "libx.so"), "lib")
+	if link, err := os.Readlink(filepath.Join(snapshot, "artifacts", "latest")); err != nil || link != "tool" {
+		t.Errorf("Expected the link to be copied as a link, got %q %v", link, err)
+	}
+	for _, p := range []string{"test-reports/b.txt", "src", "never-produced"} {
+		if _, err := os.Lstat(filepath.Join(snapshot, p)); !os.IsNotExist(err) {
+			t.Errorf("Expected %v not to be copied, got %v", p, err)
+		}
+	}
+}
+
+func TestCopyOutputs_OutsideCheckout(t *testing.T) {
+	checkout, snapshot := setupOutputs(t, map[string]string{"out": "out"})
+	defer os.RemoveAll(checkout)
+	defer os.RemoveAll(snapshot)
+	outside, err := ioutil.TempDir("", "outside")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+	ioutil.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0644)
+	os.Symlink(outside, filepath.Join(checkout, "escape"))
+
+	// a link out of the checkout is copied as a link, but not gone through
+	if err := copyOutputs(checkout, map[string]string{"escape": ""}, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	err = copyOutputs(checkout, map[string]string{"escape/*": ""}, snapshot)
+	if err == nil || !strings.Contains(err.Error(), "outside of the checkout") {
+		t.Errorf("Expected an error copying from outside of the checkout, got %v", err)
+	}
+
+	// nor is the copied link gone through when copying into the snapshot
+	err = copyOutputs(checkout, map[string]string{"out": "escape"}, snapshot)
+	if err == nil {
+		t.Errorf("Expected an error copying through a link out of the snapshot")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "out")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be copied outside of the snapshot, got %v", err)
+	}
+}
+
+type countingCheckouter struct {
+	snapshot.Checkouter
+	checkouts int
+}
+
+func (c *countingCheckouter) Checkout(id string) (snapshot.Checkout, error) {
+	c.checkouts++
+	return c.Checkouter.Checkout(id)
+}
+
+func TestInvoker_InvalidOutputs(t *testing.T) {
+	tmp, err := temp.NewTempDir("", "invoker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp.Dir)
+	checkouter := &countingCheckouter{Checkouter: snapshots.MakeInvalidCheckouter()}
+	filer := snapshots.MakeFilerFacade(checkouter, snapshots.MakeNoopIngester())
+	inv := NewInvoker(execers.NewSimExecer(), filer, NewNullOutputCreator(), tmp)
+
+	cmd := &runner.Command{Argv: []string{"complete 0"}, SnapshotID: "snapshot", Outputs: map[string]string{"../escape": ""}}
+	_, updateCh := inv.Run(cmd, runner.RunID("1"))
+	var st runner.RunStatus
+	for st = range updateCh {
+	}
+	if st.State != runner.BADREQUEST || !strings.Contains(st.Error, "../escape") {
+		t.Errorf("Expected the command to be rejected, got %+v", st)
+	}
+	if checkouter.checkouts != 0 {
+		t.Errorf("Expected the command to be rejected before checking out, checked out %d times", checkouter.checkouts)
+	}
+}
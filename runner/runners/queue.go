@@ -2,6 +2,7 @@ package runners
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/scootdev/scoot/os/temp"
@@ -21,44 +22,82 @@ type cmdAndID struct {
 func NewQueueRunner(
 	exec execer.Execer, filer snapshot.Filer, output runner.OutputCreator, tmp *temp.TempDir, capacity int,
 ) runner.Service {
-	statusManager := NewStatusManager()
-	inv := NewInvoker(exec, filer, output, tmp)
-	controller := &QueueController{statusManager: statusManager, inv: inv, capacity: capacity}
-	return &Service{controller, statusManager, statusManager}
+	return NewSlotsRunner(exec, filer, output, tmp, 1, capacity)
 }
 
 func NewSingleRunner(exec execer.Execer, filer snapshot.Filer, output runner.OutputCreator, tmp *temp.TempDir) runner.Service {
 	return NewQueueRunner(exec, filer, output, tmp, 0)
 }
 
-// QueueController maintains a queue of commands to run (up to capacity).
+// NewSlotsRunner creates a new Service that runs up to slots commands at once,
+// each in its own checkout, and queues up to capacity more by priority.
+func NewSlotsRunner(
+	exec execer.Execer, filer snapshot.Filer, output runner.OutputCreator, tmp *temp.TempDir, slots int, capacity int,
+) runner.Service {
+	return newQueueService(NewStatusManager(), NewInvoker(exec, filer, output, tmp), slots, capacity)
+}
+
+// NewDurableSlotsRunner creates a Service like NewSlotsRunner, which keeps its
+// runs in a journal file so they're still there if the process restarts.
+// Cf. NewDurableStatusManager.
+func NewDurableSlotsRunner(
+	exec execer.Execer, filer snapshot.Filer, output runner.OutputCreator, tmp *temp.TempDir, slots int, capacity int,
+	journal string,
+) (runner.Service, error) {
+	statusManager, err := NewDurableStatusManager(journal, output)
+	if err != nil {
+		return nil, err
+	}
+	inv := NewInvoker(exec, filer, output, tmp)
+	inv.outputsCreated = statusManager.journalOutputs
+	return newQueueService(statusManager, inv, slots, capacity), nil
+}
+
+func newQueueService(statusManager *StatusManager, inv *Invoker, slots int, capacity int) runner.Service {
+	if slots < 1 {
+		slots = 1
+	}
+	controller := &QueueController{
+		statusManager: statusManager,
+		inv:           inv,
+		slots:         slots,
+		capacity:      capacity,
+		running:       make(map[runner.RunID]chan<- struct{}),
+	}
+	return &Service{controller, statusManager, statusManager}
+}
+
+// QueueController runs commands in slots, and maintains a queue of commands
+// waiting for one (up to capacity).  Commands with a higher Priority are run
+// first, in the order they were queued.
 type QueueController struct {
 	inv           *Invoker
 	statusManager *StatusManager
+	slots         int
 	capacity      int
 
-	mu           sync.Mutex
-	queue        []cmdAndID
-	runningID    runner.RunID
-	runningAbort chan<- struct{}
+	mu    sync.Mutex
+	queue []cmdAndID
+	// abort channels of the running commands, nil once they've been aborted
+	running map[runner.RunID]chan<- struct{}
 }
 
-// Run enqueues the command or rejects it, returning its status or an error.
+// Run starts or enqueues the command or rejects it, returning its status or an error.
 func (c *QueueController) Run(cmd *runner.Command) (runner.RunStatus, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	st, err := c.statusManager.NewRun()
+	if len(c.running) >= c.slots && len(c.queue) >= c.capacity {
+		return runner.RunStatus{}, fmt.Errorf(QueueFullMsg)
+	}
+	st, err := c.statusManager.NewRunFor(cmd)
 	if err != nil {
 		return st, err
 	}
-	if c.runningID == runner.RunID("") {
+	if len(c.running) < c.slots {
 		c.start(cmd, st.RunID)
 	} else {
-		if len(c.queue) >= c.capacity {
-			return runner.RunStatus{}, fmt.Errorf(QueueFullMsg)
-		}
-		c.queue = append(c.queue, cmdAndID{cmd, st.RunID})
+		c.enqueue(cmdAndID{cmd, st.RunID})
 	}
 	return st, nil
 }
@@ -67,16 +106,17 @@ func (c *QueueController) Run(cmd *runner.Command) (runner.RunStatus, error) {
 func (c *QueueController) Abort(run runner.RunID) (runner.RunStatus, error) {
 	c.mu.Lock()
 
-	if run == c.runningID {
-		if c.runningAbort != nil {
-			close(c.runningAbort)
-			c.runningAbort = nil
+	if abortCh, ok := c.running[run]; ok {
+		if abortCh != nil {
+			close(abortCh)
+			c.running[run] = nil
 		}
 	} else {
 		for i, cmdAndID := range c.queue {
 			if run == cmdAndID.id {
 				c.queue = append(c.queue[:i], c.queue[i+1:]...)
 				c.statusManager.Update(runner.AbortStatus(run))
+				break
 			}
 		}
 	}
@@ -86,21 +126,29 @@ func (c *QueueController) Abort(run runner.RunID) (runner.RunStatus, error) {
 	return runner.FinalStatus(c.statusManager, run)
 }
 
-// start starts a command, returning the current status
+// Inserts a command into the queue after those with the same or higher priority
+func (c *QueueController) enqueue(queued cmdAndID) {
+	i := sort.Search(len(c.queue), func(i int) bool {
+		return c.queue[i].cmd.Priority < queued.cmd.Priority
+	})
+	c.queue = append(c.queue, cmdAndID{})
+	copy(c.queue[i+1:], c.queue[i:])
+	c.queue[i] = queued
+}
+
+// start starts a command in a free slot
 func (c *QueueController) start(cmd *runner.Command, id runner.RunID) {
-	c.runningID = id
 	abortCh, updateCh := c.inv.Run(cmd, id)
-	c.runningAbort = abortCh
-	go c.watch(updateCh)
+	c.running[id] = abortCh
+	go c.watch(id, updateCh)
 }
 
-func (c *QueueController) watch(updateCh <-chan runner.RunStatus) {
+func (c *QueueController) watch(id runner.RunID, updateCh <-chan runner.RunStatus) {
 	for st := range updateCh {
 		if st.State.IsDone() {
 			c.mu.Lock()
 			defer c.mu.Unlock()
-			c.runningID = ""
-			c.runningAbort = nil
+			delete(c.running, id)
 			if len(c.queue) > 0 {
 				cmdAndID := c.queue[0]
 				c.queue = c.queue[1:]
@@ -149,7 +149,56 @@ func TestStatus(t *testing.T) {
 	assertWait(t, env.r, run8, complete(0))
 }
 
+// Run commands in two slots, with two more queued by priority
+func TestSlots(t *testing.T) {
+	env := setupSlots(2, 2, t)
+	defer env.teardown()
+
+	run1 := assertRun(t, env.r, running(), "pause", "complete 1")
+	run2 := assertRun(t, env.r, running(), "pause", "complete 2")
+	run3 := runWithPriority(t, env.r, 0, "pause", "complete 3")
+	run4 := runWithPriority(t, env.r, 1, "pause", "complete 4")
+	assertWait(t, env.r, run3, pending())
+	assertWait(t, env.r, run4, pending())
+	if _, err := env.r.Run(&runner.Command{Argv: []string{"complete 5"}}); err == nil || err.Error() != QueueFullMsg {
+		t.Fatal("Should not be able to schedule: ", err)
+	}
+
+	// the higher priority run4 takes the first slot freed, though it was queued last
+	env.sim.Resume()
+	assertWait(t, env.r, run4, running())
+	if st, _ := env.r.Status(run3); st.State != runner.PENDING {
+		t.Fatalf("Expected run3 to still be queued, was %v", st)
+	}
+
+	// aborting a running command frees its slot too
+	if _, err := env.r.Abort(run4); err != nil {
+		t.Fatal(err)
+	}
+	assertWait(t, env.r, run3, running())
+
+	env.sim.Resume()
+	env.sim.Resume()
+	for _, id := range []runner.RunID{run1, run2, run3} {
+		if st, err := runner.WaitForState(env.r, id, runner.COMPLETE); err != nil || st.State != runner.COMPLETE {
+			t.Fatalf("Expected %v to complete, got %v %v", id, st, err)
+		}
+	}
+}
+
+func runWithPriority(t *testing.T, r runner.Service, priority int, args ...string) runner.RunID {
+	st, err := r.Run(&runner.Command{Argv: args, Priority: priority})
+	if err != nil {
+		t.Fatalf("Couldn't run: %v %v", args, err)
+	}
+	return st.RunID
+}
+
 func setup(capacity int, t *testing.T) *env {
+	return setupSlots(1, capacity, t)
+}
+
+func setupSlots(slots int, capacity int, t *testing.T) *env {
 	sim := execers.NewSimExecer()
 	tmpDir, err := temp.TempDirDefault()
 	if err != nil {
@@ -160,7 +209,7 @@ func setup(capacity int, t *testing.T) *env {
 	if err != nil {
 		t.Fatalf("Test setup() failed getting output creator:%s", err.Error())
 	}
-	r := NewQueueRunner(sim, snapshots.MakeInvalidFiler(), outputCreator, tmpDir, capacity)
+	r := NewSlotsRunner(sim, snapshots.MakeInvalidFiler(), outputCreator, tmpDir, slots, capacity)
 
 	return &env{sim: sim, r: r}
 }
@@ -0,0 +1,164 @@
+package runners
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/scootdev/scoot/common/kvstore"
+	"github.com/scootdev/scoot/runner"
+)
+
+// run_journal.go: keeping a StatusManager's runs in a local file, so they
+// survive the worker restarting.
+
+// Error of the runs that were in progress when the worker stopped
+const WorkerRestartedMsg = "worker restarted while the run was in progress"
+
+// The journal's buckets:
+// - runs: a journaledRun for each run, keyed by its id
+// - meta: the id of the next run under nextRunIDKey, so ids aren't reused
+const (
+	journalRunsBucket = "runs"
+	journalMetaBucket = "meta"
+	nextRunIDKey      = "nextRunID"
+)
+
+type journaledRun struct {
+	Command *runner.Command  `json:"command,omitempty"`
+	Status  runner.RunStatus `json:"status"`
+	// the run's outputs, from their ids to their paths, so they can still be
+	// served once the worker restarts
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// NewDurableStatusManager creates a StatusManager that keeps its runs in the
+// journal file, creating it if it doesn't exist.  The runs journaled by an
+// earlier StatusManager are loaded, those that weren't done are FAILED as
+// they were lost when it stopped.  They're all kept until Erase'd.  Their
+// outputs are restored to output, if it's an OutputRestorer, so their OutUri
+// and ErrUri can still be served.
+func NewDurableStatusManager(fileName string, output runner.OutputCreator) (*StatusManager, error) {
+	db, err := kvstore.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	s := NewStatusManager()
+	s.journal = db
+
+	err = db.Update(func(tx *kvstore.Tx) error {
+		if data, err := tx.Get(journalMetaBucket, nextRunIDKey); err != nil {
+			return err
+		} else if data != nil {
+			if s.nextRunID, err = strconv.ParseInt(string(data), 10, 64); err != nil {
+				return fmt.Errorf("Corrupt next run id %q in run journal: %v", data, err)
+			}
+		}
+		for _, key := range tx.Keys(journalRunsBucket) {
+			data, err := tx.Get(journalRunsBucket, key)
+			if err != nil {
+				return err
+			}
+			var run journaledRun
+			if err := json.Unmarshal(data, &run); err != nil {
+				return fmt.Errorf("Corrupt run %v in run journal: %v", key, err)
+			}
+			if !run.Status.State.IsDone() {
+				run.Status.State = runner.FAILED
+				run.Status.Error = WorkerRestartedMsg
+				if err := putJournaledRun(tx, run); err != nil {
+					return err
+				}
+			}
+			s.runs[run.Status.RunID] = run.Status
+			if restorer, ok := output.(OutputRestorer); ok {
+				for id, path := range run.Outputs {
+					restorer.Restore(id, path)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	log.Printf("runner/runners/run_journal.go: loaded %d runs from %v", len(s.runs), fileName)
+	return s, nil
+}
+
+func putJournaledRun(tx *kvstore.Tx, run journaledRun) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	return tx.Put(journalRunsBucket, string(run.Status.RunID), data)
+}
+
+// Journals a new run and the id of the one after it
+func (s *StatusManager) journalNewRun(cmd *runner.Command, st runner.RunStatus) error {
+	return s.journal.Update(func(tx *kvstore.Tx) error {
+		if err := tx.Put(journalMetaBucket, nextRunIDKey, []byte(strconv.FormatInt(s.nextRunID, 10))); err != nil {
+			return err
+		}
+		return putJournaledRun(tx, journaledRun{Command: cmd, Status: st})
+	})
+}
+
+// Journals the outputs created for a run, from their ids to their paths
+func (s *StatusManager) journalOutputs(id runner.RunID, outputs map[string]string) {
+	err := s.journal.Update(func(tx *kvstore.Tx) error {
+		run := journaledRun{}
+		if data, err := tx.Get(journalRunsBucket, string(id)); err != nil {
+			return err
+		} else if data == nil {
+			// erased already
+			return nil
+		} else if err := json.Unmarshal(data, &run); err != nil {
+			return err
+		}
+		if run.Outputs == nil {
+			run.Outputs = make(map[string]string)
+		}
+		for outputID, path := range outputs {
+			run.Outputs[outputID] = path
+		}
+		return putJournaledRun(tx, run)
+	})
+	if err != nil {
+		// the outputs can't be served once the worker restarts, but the run goes on
+		log.Printf("runner/runners/run_journal.go: couldn't journal outputs %v of run %v: %v", outputs, id, err)
+	}
+}
+
+// Journals a run's new status, keeping its command and outputs
+func (s *StatusManager) journalUpdate(st runner.RunStatus) error {
+	return s.journal.Update(func(tx *kvstore.Tx) error {
+		run := journaledRun{}
+		if data, err := tx.Get(journalRunsBucket, string(st.RunID)); err != nil {
+			return err
+		} else if data != nil {
+			if err := json.Unmarshal(data, &run); err != nil {
+				return err
+			}
+		}
+		run.Status = st
+		return putJournaledRun(tx, run)
+	})
+}
+
+func (s *StatusManager) journalErase(run runner.RunID) error {
+	return s.journal.Update(func(tx *kvstore.Tx) error {
+		return tx.Delete(journalRunsBucket, string(run))
+	})
+}
+
+// Close closes the run journal, if there is one.  The StatusManager can't be
+// written to after.
+func (s *StatusManager) Close() error {
+	if s.journal == nil {
+		return nil
+	}
+	return s.journal.Close()
+}
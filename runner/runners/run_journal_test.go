@@ -0,0 +1,130 @@
+package runners
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scootdev/scoot/os/temp"
+	"github.com/scootdev/scoot/runner"
+	"github.com/scootdev/scoot/runner/execer/execers"
+	"github.com/scootdev/scoot/snapshot/snapshots"
+)
+
+func TestRunJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "run-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := filepath.Join(dir, "runs")
+
+	s, err := NewDurableStatusManager(journal, NewNullOutputCreator())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := &runner.Command{Argv: []string{"sleep", "100"}}
+	running, _ := s.NewRunFor(cmd)
+	s.Update(runner.RunningStatus(running.RunID, "out", "err"))
+	completed, _ := s.NewRunFor(&runner.Command{Argv: []string{"true"}})
+	s.Update(runner.RunningStatus(completed.RunID, "out", "err"))
+	s.Update(runner.CompleteStatus(completed.RunID, "snapshot", 0))
+	failed, _ := s.NewRun()
+	s.Update(runner.ErrorStatus(failed.RunID, errors.New("couldn't check out")))
+	erased, _ := s.NewRun()
+	s.Update(runner.CompleteStatus(erased.RunID, "", 1))
+	s.Erase(erased.RunID)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// as if the worker restarted
+	s, err = NewDurableStatusManager(journal, NewNullOutputCreator())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if st, err := s.Status(running.RunID); err != nil || st.State != runner.FAILED ||
+		st.Error != WorkerRestartedMsg || st.StdoutRef != "out" {
+		t.Errorf("Expected the run in progress to have failed, got %+v %v", st, err)
+	}
+	expected := runner.CompleteStatus(completed.RunID, "snapshot", 0)
+	expected.StdoutRef, expected.StderrRef = "out", "err"
+	if st, err := s.Status(completed.RunID); err != nil || st != expected {
+		t.Errorf("Expected %+v, got %+v %v", expected, st, err)
+	}
+	if st, err := s.Status(failed.RunID); err != nil || st.State != runner.FAILED || st.Error != "couldn't check out" {
+		t.Errorf("Expected the failed run to be kept, got %+v %v", st, err)
+	}
+	if st, err := s.Status(erased.RunID); err == nil {
+		t.Errorf("Expected the erased run to be gone, got %+v", st)
+	}
+	if all, _ := s.StatusAll(); len(all) != 3 {
+		t.Errorf("Expected 3 runs, got %v", all)
+	}
+
+	// run ids aren't reused, even those erased
+	if st, _ := s.NewRun(); st.RunID != "4" {
+		t.Errorf("Expected the next run to be 4, got %v", st.RunID)
+	}
+	// the failed run is journaled, as is erasing it
+	s.Erase(running.RunID)
+	s.Close()
+	s, err = NewDurableStatusManager(journal, NewNullOutputCreator())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if _, err := s.Status(running.RunID); err == nil {
+		t.Errorf("Expected the run erased after restarting to be gone")
+	}
+	if st, _ := s.Status("4"); st.State != runner.FAILED || st.Error != WorkerRestartedMsg {
+		t.Errorf("Expected the pending run to have failed, got %+v", st)
+	}
+}
+
+func TestDurableRunner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "run-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tmp, err := temp.NewTempDir(dir, "tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	journal := filepath.Join(dir, "runs")
+	output, err := NewHttpOutputCreator(tmp, "http://localhost:9091/output/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewDurableSlotsRunner(execers.NewSimExecer(), snapshots.MakeInvalidFiler(), output, tmp, 1, 0, journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := assertRun(t, r, complete(3), "complete 3")
+	r.(*Service).StatusReader.(*StatusManager).Close()
+
+	// as if the worker restarted, its outputs are still served
+	output, _ = NewHttpOutputCreator(tmp, "http://localhost:9091/output/")
+	s, err := NewDurableStatusManager(journal, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if st, err := s.Status(id); err != nil || st.State != runner.COMPLETE || st.ExitCode != 3 {
+		t.Errorf("Expected the completed run to be journaled, got %+v %v", st, err)
+	}
+	for _, name := range []string{"stdout", "stderr"} {
+		w := httptest.NewRecorder()
+		output.ServeHTTP(w, httptest.NewRequest("GET", "/output/"+string(id)+"-"+name+"?content=true", nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected the run's %v to be served after restarting, got %v", name, w.Code)
+		}
+	}
+}
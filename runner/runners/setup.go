@@ -3,12 +3,17 @@ package runners
 import (
 	"github.com/scootdev/scoot/common/stats"
 	"github.com/scootdev/scoot/ice"
+	"github.com/scootdev/scoot/os/temp"
+	"github.com/scootdev/scoot/runner"
 	"github.com/scootdev/scoot/runner/execer"
 	"github.com/scootdev/scoot/runner/execer/execers"
 	osexec "github.com/scootdev/scoot/runner/execer/os"
 	"github.com/scootdev/scoot/snapshot"
 )
 
+// Number of commands a runner runs at once
+type Slots int
+
 // Module returns a module that creates a new Runner.
 func Module() ice.Module {
 	return module{}
@@ -25,6 +30,15 @@ func (m module) Install(b *ice.MagicBag) {
 		func(db snapshot.DB) snapshot.Filer {
 			return snapshot.NewDBAdapter(db)
 		},
-		NewSingleRunner,
+		func() Slots {
+			return 1
+		},
+		// Doesn't queue commands, once every slot is busy more are refused
+		// with QueueFull as a single runner does
+		func(
+			ex execer.Execer, filer snapshot.Filer, output runner.OutputCreator, tmp *temp.TempDir, slots Slots,
+		) runner.Service {
+			return NewSlotsRunner(ex, filer, output, tmp, int(slots), 0)
+		},
 	)
 }
@@ -2,10 +2,12 @@ package runners
 
 import (
 	"fmt"
+	"log"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/scootdev/scoot/common/kvstore"
 	"github.com/scootdev/scoot/runner"
 )
 
@@ -23,6 +25,9 @@ type StatusManager struct {
 	runs      map[runner.RunID]runner.RunStatus
 	nextRunID int64
 	listeners []queryAndCh
+
+	// Where runs are journaled, nil if they're only kept in memory
+	journal *kvstore.DB
 }
 
 type queryAndCh struct {
@@ -34,6 +39,11 @@ type queryAndCh struct {
 
 // NewRun creates a new RunID in state Preparing
 func (s *StatusManager) NewRun() (runner.RunStatus, error) {
+	return s.NewRunFor(nil)
+}
+
+// NewRunFor creates a new RunID in state Preparing, to run cmd
+func (s *StatusManager) NewRunFor(cmd *runner.Command) (runner.RunStatus, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -44,6 +54,12 @@ func (s *StatusManager) NewRun() (runner.RunStatus, error) {
 		RunID: id,
 		State: runner.PENDING,
 	}
+	if s.journal != nil {
+		if err := s.journalNewRun(cmd, st); err != nil {
+			s.nextRunID--
+			return runner.RunStatus{}, fmt.Errorf("Couldn't journal new run: %v", err)
+		}
+	}
 	s.runs[id] = st
 	return st, nil
 }
@@ -68,6 +84,12 @@ func (s *StatusManager) Update(newStatus runner.RunStatus) error {
 	}
 
 	s.runs[newStatus.RunID] = newStatus
+	if s.journal != nil {
+		// the run goes on even if it's lost should the worker restart
+		if err := s.journalUpdate(newStatus); err != nil {
+			log.Printf("runner/runners/status_manager.go: couldn't journal status %+v: %v", newStatus, err)
+		}
+	}
 
 	listeners := make([]queryAndCh, 0, len(s.listeners))
 	for _, listener := range s.listeners {
@@ -128,6 +150,9 @@ func (s *StatusManager) Erase(run runner.RunID) error {
 	st := s.runs[run]
 	if st.State.IsDone() {
 		delete(s.runs, run)
+		if s.journal != nil {
+			return s.journalErase(run)
+		}
 	}
 	return nil
 }
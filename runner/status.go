@@ -3,6 +3,8 @@ package runner
 import (
 	"bytes"
 	"fmt"
+
+	"github.com/scootdev/scoot/runner/execer"
 )
 
 type RunID string
@@ -78,6 +80,9 @@ type RunStatus struct {
 
 	// Only valid if State == (FAILED || BADREQUEST)
 	Error string
+
+	// Resources used by the run's process, zero if it didn't get to run or wasn't measured
+	Usage execer.Usage
 }
 
 func (p RunStatus) String() string {
@@ -92,6 +97,10 @@ func (p RunStatus) String() string {
 	}
 
 	fmt.Fprintf(&b, "\tStdout:\t\t%s\n\tStderr:\t\t%s\n", p.StdoutRef, p.StderrRef)
+	if p.Usage != (execer.Usage{}) {
+		fmt.Fprintf(&b, "\tUsage:\t\twall %v, user %v, sys %v, max rss %d, block in %d, block out %d\n",
+			p.Usage.WallTime, p.Usage.UserTime, p.Usage.SysTime, p.Usage.MaxRSS, p.Usage.BlockInputOps, p.Usage.BlockOutputOps)
+	}
 
 	return b.String()
 }
@@ -15,31 +15,36 @@ import (
 type Saga struct {
 	id       string
 	log      SagaLog
+	notifier *sagaNotifier // notified of each message logged
 	state    *SagaState
+	version  int // the number of messages logged for the saga
 	updateCh chan sagaUpdate
 	mutex    sync.RWMutex // mutex controls access to Saga.state
 }
 
 // Start a New Saga.  Logs a Start Saga Message to the SagaLog
 // returns a Saga, or an error if one occurs
-func newSaga(sagaId string, job []byte, log SagaLog) (*Saga, error) {
+func newSaga(sagaId string, job []byte, labels map[string]string, log SagaLog, notifier *sagaNotifier) (*Saga, error) {
 
 	state, err := makeSagaState(sagaId, job)
 	if err != nil {
 		return nil, err
 	}
 
-	err = log.StartSaga(sagaId, job)
+	err = log.StartSaga(sagaId, job, labels)
 	if err != nil {
 		return nil, err
 	}
+	notifier.notify(sagaId, 1)
 
 	updateCh := make(chan sagaUpdate, 0)
 
 	s := &Saga{
 		id:       sagaId,
 		log:      log,
+		notifier: notifier,
 		state:    state,
+		version:  1,
 		updateCh: updateCh,
 		mutex:    sync.RWMutex{},
 	}
@@ -49,14 +54,16 @@ func newSaga(sagaId string, job []byte, log SagaLog) (*Saga, error) {
 	return s, nil
 }
 
-// Rehydrate a saga from a specified SagaState, does not write
-// to SagaLog assumes that this is a recovered saga.
-func rehydrateSaga(sagaId string, state *SagaState, log SagaLog) *Saga {
+// Rehydrate a saga from a specified SagaState, recovered from version
+// messages, does not write to SagaLog assumes that this is a recovered saga.
+func rehydrateSaga(sagaId string, state *SagaState, version int, log SagaLog, notifier *sagaNotifier) *Saga {
 	updateCh := make(chan sagaUpdate, 0)
 	s := &Saga{
 		id:       sagaId,
 		log:      log,
+		notifier: notifier,
 		state:    state,
+		version:  version,
 		updateCh: updateCh,
 		mutex:    sync.RWMutex{},
 	}
@@ -185,6 +192,10 @@ func (s *Saga) updateSaga(update sagaUpdate) {
 	defer s.mutex.Unlock()
 	var err error
 	s.state, err = logMessage(s.state, update.msg, s.log)
+	if err == nil {
+		s.version++
+		s.notifier.notify(s.id, s.version)
+	}
 	update.resultCh <- err
 }
 
@@ -1,12 +1,17 @@
 package saga
 
+import (
+	"time"
+)
+
 //
 // Saga Object which provides all Saga Functionality
 // Implementations of SagaLog should provide a factory method
 // which returns a saga based on its implementation.
 //
 type SagaCoordinator struct {
-	log SagaLog
+	log      SagaLog
+	notifier *sagaNotifier
 }
 
 //
@@ -14,14 +19,21 @@ type SagaCoordinator struct {
 //
 func MakeSagaCoordinator(log SagaLog) SagaCoordinator {
 	return SagaCoordinator{
-		log: log,
+		log:      log,
+		notifier: newSagaNotifier(),
 	}
 }
 
 // Make a Saga add it to the SagaCoordinator, if a Saga Already exists
 // with the same id, it will overwrite the already existing one.
 func (s SagaCoordinator) MakeSaga(sagaId string, job []byte) (*Saga, error) {
-	return newSaga(sagaId, job, s.log)
+	return newSaga(sagaId, job, nil, s.log, s.notifier)
+}
+
+// Makes a Saga like MakeSaga, described by the specified labels, which
+// ListSagas returns and can filter on.
+func (s SagaCoordinator) MakeSagaWithLabels(sagaId string, job []byte, labels map[string]string) (*Saga, error) {
+	return newSaga(sagaId, job, labels, s.log, s.notifier)
 }
 
 // Read the Current SagaState from the Log, intended for status queries does not check for recovery.
@@ -30,6 +42,93 @@ func (s SagaCoordinator) GetSagaState(sagaId string) (*SagaState, error) {
 	return recoverState(sagaId, s)
 }
 
+// The result of watching a saga.  Version is the number of messages logged for
+// the saga, which only increases as the saga makes progress.
+type SagaWatchResult struct {
+	// State of the saga at the version being watched, nil if no messages
+	// had been logged by then
+	PrevState *SagaState
+
+	// Current state of the saga, nil if no messages have been logged
+	State   *SagaState
+	Version int
+}
+
+// Blocks until the specified saga has changed since version, i.e. more than
+// version messages have been logged for it, or until the timeout elapses.
+// Only changes made through Sagas from this SagaCoordinator wake up watchers
+// before the timeout.  A version greater than the saga's current version is
+// treated as 0.
+func (s SagaCoordinator) WatchSaga(sagaId string, version int, timeout time.Duration) (*SagaWatchResult, error) {
+	// start watching before reading the log so no change is missed
+	w := s.notifier.watch(sagaId)
+	defer w.cancel()
+
+	msgs, err := s.log.GetMessages(sagaId)
+	if err != nil {
+		return nil, err
+	}
+	if version > len(msgs) {
+		version = 0
+	}
+	prevState, err := stateFromMessages(sagaId, msgs[:version])
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for len(msgs) == version {
+		select {
+		case <-w.changed:
+			// the log was already read at the notified version
+			if w.latest() == len(msgs) {
+				continue
+			}
+			if msgs, err = s.log.GetMessages(sagaId); err != nil {
+				return nil, err
+			}
+			if len(msgs) < version {
+				// started over, see MakeSaga
+				version = 0
+				prevState = nil
+			}
+		case <-timer.C:
+			return makeSagaWatchResult(sagaId, msgs, version, prevState)
+		}
+	}
+	return makeSagaWatchResult(sagaId, msgs, version, prevState)
+}
+
+// Returns the result of watching a saga that had prevState once version
+// messages were logged, applying only the messages logged since
+func makeSagaWatchResult(sagaId string, msgs []SagaMessage, version int, prevState *SagaState) (*SagaWatchResult, error) {
+	var state *SagaState
+	if prevState == nil {
+		var err error
+		if state, err = stateFromMessages(sagaId, msgs); err != nil {
+			return nil, err
+		}
+	} else {
+		state = copySagaState(prevState)
+		for _, msg := range msgs[version:] {
+			// a saga started over is read from the start
+			if msg.MsgType == StartSaga {
+				return makeSagaWatchResult(sagaId, msgs, 0, nil)
+			}
+			if err := updateSagaState(state, msg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &SagaWatchResult{
+		PrevState: prevState,
+		State:     state,
+		Version:   len(msgs),
+	}, nil
+}
+
 //
 // Should be called at Saga Creation time.
 // Returns a Slice of In Progress SagaIds
@@ -44,6 +143,14 @@ func (s SagaCoordinator) Startup() ([]string, error) {
 	return ids, nil
 }
 
+//
+// Returns a summary of the sagas in the log matching the filter,
+// ordered by start time, oldest first
+//
+func (s SagaCoordinator) ListSagas(filter SagaFilter) ([]SagaInfo, error) {
+	return s.log.ListSagas(filter)
+}
+
 //
 // Recovers SagaState by reading all logged messages from the log.
 // Utilizes the specified recoveryType to determine if Saga needs to be
@@ -52,7 +159,7 @@ func (s SagaCoordinator) Startup() ([]string, error) {
 // Returns the current SagaState.  If no Saga exists for the requested id, nil is returned
 //
 func (sc SagaCoordinator) RecoverSagaState(sagaId string, recoveryType SagaRecoveryType) (*Saga, error) {
-	state, err := recoverState(sagaId, sc)
+	state, version, err := recoverStateAndVersion(sagaId, sc)
 
 	if err != nil {
 		return nil, err
@@ -63,7 +170,7 @@ func (sc SagaCoordinator) RecoverSagaState(sagaId string, recoveryType SagaRecov
 	}
 
 	// now that we've recovered the saga initialize its update path
-	saga := rehydrateSaga(sagaId, state, sc.log)
+	saga := rehydrateSaga(sagaId, state, version, sc.log, sc.notifier)
 
 	// Check if we can safely proceed forward based on recovery method
 	// RollbackRecovery must check if in a SafeState,
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/golang/mock/gomock"
 	"testing"
+	"time"
 )
 
 func TestMakeSaga(t *testing.T) {
@@ -15,7 +16,7 @@ func TestMakeSaga(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga(id, job)
+	sagaLogMock.EXPECT().StartSaga(id, job, nil)
 
 	sc := MakeSagaCoordinator(sagaLogMock)
 	saga, err := sc.MakeSaga(id, job)
@@ -36,7 +37,7 @@ func TestMakeSagaLogError(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga(id, job).Return(errors.New("Failed to Log StartSaga"))
+	sagaLogMock.EXPECT().StartSaga(id, job, nil).Return(errors.New("Failed to Log StartSaga"))
 
 	sc := MakeSagaCoordinator(sagaLogMock)
 	saga, err := sc.MakeSaga(id, job)
@@ -170,3 +171,93 @@ func TestRecoverSagaState_ReturnsError(t *testing.T) {
 		t.Error("expected returned state to be nil when error occurs")
 	}
 }
+
+func TestWatchSaga_WakesOnChange(t *testing.T) {
+	id := "testSaga"
+	var job []byte
+	startMsg := MakeStartSagaMessage(id, job)
+	startTaskMsg := MakeStartTaskMessage(id, "task1", nil)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga(id, job, nil)
+	sagaLogMock.EXPECT().LogMessage(startTaskMsg)
+	sagaLogMock.EXPECT().GetMessages(id).Return([]SagaMessage{startMsg}, nil)
+	sagaLogMock.EXPECT().GetMessages(id).Return([]SagaMessage{startMsg, startTaskMsg}, nil)
+
+	sc := MakeSagaCoordinator(sagaLogMock)
+	saga, _ := sc.MakeSaga(id, job)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		saga.StartTask("task1", nil)
+	}()
+
+	result, err := sc.WatchSaga(id, 1, time.Minute)
+	if err != nil {
+		t.Fatalf("Unexpected error watching saga: %v", err)
+	}
+	if result.Version != 2 {
+		t.Errorf("Expected version 2, got %v", result.Version)
+	}
+	if result.PrevState.IsTaskStarted("task1") || !result.State.IsTaskStarted("task1") {
+		t.Errorf("Expected task1 to be started after version 1")
+	}
+}
+
+// A notification the log was already read at doesn't read it again
+func TestWatchSaga_SkipsReadWhenUnchanged(t *testing.T) {
+	id := "testSaga"
+	startMsg := MakeStartSagaMessage(id, nil)
+	startTaskMsg := MakeStartTaskMessage(id, "task1", nil)
+	endTaskMsg := MakeEndTaskMessage(id, "task1", nil)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().GetMessages(id).Return([]SagaMessage{startMsg, startTaskMsg}, nil)
+
+	sc := MakeSagaCoordinator(sagaLogMock)
+	go func() {
+		// the task's start was logged before the watch read the log
+		time.Sleep(10 * time.Millisecond)
+		sc.notifier.notify(id, 2)
+		time.Sleep(10 * time.Millisecond)
+		sagaLogMock.EXPECT().GetMessages(id).Return([]SagaMessage{startMsg, startTaskMsg, endTaskMsg}, nil)
+		sc.notifier.notify(id, 3)
+	}()
+
+	result, err := sc.WatchSaga(id, 2, time.Minute)
+	if err != nil {
+		t.Fatalf("Unexpected error watching saga: %v", err)
+	}
+	if result.Version != 3 {
+		t.Errorf("Expected version 3, got %v", result.Version)
+	}
+	if result.PrevState.IsTaskCompleted("task1") || !result.State.IsTaskCompleted("task1") {
+		t.Errorf("Expected task1 to be completed after version 2")
+	}
+}
+
+func TestWatchSaga_Timeout(t *testing.T) {
+	id := "testSaga"
+	startMsg := MakeStartSagaMessage(id, nil)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().GetMessages(id).Return([]SagaMessage{startMsg}, nil)
+
+	sc := MakeSagaCoordinator(sagaLogMock)
+	result, err := sc.WatchSaga(id, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unexpected error watching saga: %v", err)
+	}
+	if result.Version != 1 || result.State == nil {
+		t.Errorf("Expected unchanged saga at version 1, got %+v", result)
+	}
+}
@@ -0,0 +1,93 @@
+package saga
+
+import (
+	"sync"
+)
+
+// Fans out notifications that messages were logged for a saga to the
+// goroutines watching it.  Shared by a SagaCoordinator and the Sagas it
+// makes.  A nil sagaNotifier drops notifications.
+type sagaNotifier struct {
+	mutex    sync.Mutex
+	watchers map[string]map[*sagaWatch]bool // sagaId to the watches of it
+}
+
+func newSagaNotifier() *sagaNotifier {
+	return &sagaNotifier{
+		watchers: make(map[string]map[*sagaWatch]bool),
+	}
+}
+
+// A watch of a saga, signaled each time messages are logged for it until
+// it's cancelled
+type sagaWatch struct {
+	notifier *sagaNotifier
+	sagaId   string
+
+	// receives once messages were logged since it was last received from
+	changed chan struct{}
+
+	// the saga's version, i.e. the number of messages logged for it, as of
+	// the last notification, -1 if it hasn't been notified.  Guarded by the
+	// notifier's mutex
+	version int
+}
+
+// Starts watching the specified saga, the watch must be cancelled once the
+// caller stops waiting.
+func (n *sagaNotifier) watch(sagaId string) *sagaWatch {
+	w := &sagaWatch{notifier: n, sagaId: sagaId, changed: make(chan struct{}, 1), version: -1}
+	if n == nil {
+		return w
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.watchers[sagaId] == nil {
+		n.watchers[sagaId] = make(map[*sagaWatch]bool)
+	}
+	n.watchers[sagaId][w] = true
+	return w
+}
+
+// Returns the version of the saga as of the last notification, -1 if there
+// wasn't one
+func (w *sagaWatch) latest() int {
+	if w.notifier == nil {
+		return -1
+	}
+	w.notifier.mutex.Lock()
+	defer w.notifier.mutex.Unlock()
+	return w.version
+}
+
+func (w *sagaWatch) cancel() {
+	n := w.notifier
+	if n == nil {
+		return
+	}
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delete(n.watchers[w.sagaId], w)
+	if len(n.watchers[w.sagaId]) == 0 {
+		delete(n.watchers, w.sagaId)
+	}
+}
+
+// Wakes up everything watching the specified saga, which has had version
+// messages logged for it
+func (n *sagaNotifier) notify(sagaId string, version int) {
+	if n == nil {
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for w := range n.watchers[sagaId] {
+		w.version = version
+		select {
+		case w.changed <- struct{}{}:
+		default:
+		}
+	}
+}
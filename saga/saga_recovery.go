@@ -25,13 +25,31 @@ const (
 // Recovers SagaState from SagaLog messages
 //
 func recoverState(sagaId string, saga SagaCoordinator) (*SagaState, error) {
+	state, _, err := recoverStateAndVersion(sagaId, saga)
+	return state, err
+}
+
+//
+// Recovers SagaState from SagaLog messages, along with its version, the
+// number of messages logged
+//
+func recoverStateAndVersion(sagaId string, saga SagaCoordinator) (*SagaState, int, error) {
 
 	// Get Logged Messages For this Saga from the Log.
 	msgs, err := saga.log.GetMessages(sagaId)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
+	state, err := stateFromMessages(sagaId, msgs)
+	return state, len(msgs), err
+}
+
+//
+// Reconstructs SagaState from the messages logged for a saga.  Returns
+// nil if no messages were logged.
+//
+func stateFromMessages(sagaId string, msgs []SagaMessage) (*SagaState, error) {
 	if msgs == nil || len(msgs) == 0 {
 		return nil, nil
 	}
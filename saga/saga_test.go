@@ -13,10 +13,10 @@ func TestEndSaga(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.EndSaga()
 	if err != nil {
 		t.Error("Expected EndSaga to not return an error", err)
@@ -34,10 +34,10 @@ func TestEndSagaLogError(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log EndSaga Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.EndSaga()
 
 	if err == nil {
@@ -56,10 +56,10 @@ func TestAbortSaga(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.AbortSaga()
 
 	if err != nil {
@@ -78,10 +78,10 @@ func TestAbortSagaLogError(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log AbortSaga Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.AbortSaga()
 
 	if err == nil {
@@ -100,10 +100,10 @@ func TestStartTask(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.StartTask("task1", nil)
 
 	if err != nil {
@@ -122,10 +122,10 @@ func TestStartTaskLogError(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log StartTask Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.StartTask("task1", nil)
 
 	if err == nil {
@@ -144,11 +144,11 @@ func TestEndTask(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.StartTask("task1", nil)
 	err = s.EndTask("task1", nil)
 
@@ -168,11 +168,11 @@ func TestEndTaskLogError(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log EndTask Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.StartTask("task1", nil)
 	err = s.EndTask("task1", nil)
 
@@ -192,12 +192,12 @@ func TestStartCompTask(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(MakeAbortSagaMessage("testSaga"))
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.StartTask("task1", nil)
 	err = s.AbortSaga()
 	err = s.StartCompensatingTask("task1", nil)
@@ -218,12 +218,12 @@ func TestStartCompTaskLogError(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(MakeAbortSagaMessage("testSaga"))
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log StartCompTask Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.StartTask("task1", nil)
 	err = s.AbortSaga()
 	err = s.StartCompensatingTask("task1", nil)
@@ -244,13 +244,13 @@ func TestEndCompTask(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(MakeAbortSagaMessage("testSaga"))
 	sagaLogMock.EXPECT().LogMessage(MakeStartCompTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.StartTask("task1", nil)
 	err = s.AbortSaga()
 	err = s.StartCompensatingTask("task1", nil)
@@ -272,13 +272,13 @@ func TestEndCompTaskLogError(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(MakeAbortSagaMessage("testSaga"))
 	sagaLogMock.EXPECT().LogMessage(MakeStartCompTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log EndCompTask Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	err = s.StartTask("task1", nil)
 	err = s.AbortSaga()
 	err = s.StartCompensatingTask("task1", nil)
@@ -300,10 +300,10 @@ func TestMessageAfterEndSagaPanics(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	sagaLogMock := NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
+	sagaLogMock.EXPECT().StartSaga("testSaga", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, _ := newSaga("testSaga", nil, sagaLogMock)
+	s, _ := newSaga("testSaga", nil, nil, sagaLogMock, nil)
 	_ = s.EndSaga()
 
 	defer func() {
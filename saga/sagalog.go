@@ -4,6 +4,8 @@ package saga
 
 import (
 	"fmt"
+	"sort"
+	"time"
 )
 
 /*
@@ -12,10 +14,11 @@ import (
 type SagaLog interface {
 
 	/*
-	 * Log a Start Saga Message message to the log.
+	 * Log a Start Saga Message message to the log.  The labels describe
+	 * the saga, ListSagas returns them and can filter on them.
 	 * Returns an error if it fails.
 	 */
-	StartSaga(sagaId string, job []byte) error
+	StartSaga(sagaId string, job []byte, labels map[string]string) error
 
 	/*
 	 * Update the State of the Saga by Logging a message.
@@ -36,6 +39,95 @@ type SagaLog interface {
 	 * Returns an error if it fails.
 	 */
 	GetActiveSagas() ([]string, error)
+
+	/*
+	 * Returns a summary of every saga started matching the filter,
+	 * including completed sagas, ordered by start time, oldest first
+	 * unless the filter asks for the newest first.
+	 * Returns an error if it fails.
+	 */
+	ListSagas(filter SagaFilter) ([]SagaInfo, error)
+}
+
+// Implemented by SagaLogs replicated across a group of processes, only one
+// of which, the leader, can log messages at a time.  A scheduler using one
+// waits to become the leader before scheduling, then recovers the active
+// sagas, as another process may have been logging them until then.
+type ReplicatedSagaLog interface {
+	SagaLog
+
+	/*
+	 * Blocks until this process is the leader and the log is up to date.
+	 * Returns a channel that's closed once it's no longer the leader.
+	 */
+	WaitForLeadership() <-chan struct{}
+}
+
+// SagaInfo summarizes a saga in the log without its task messages
+type SagaInfo struct {
+	SagaId    string
+	StartTime time.Time // when the StartSaga message was logged
+	Job       []byte    // data of the StartSaga message
+	Aborted   bool      // an AbortSaga message was logged
+	Completed bool      // an EndSaga message was logged
+
+	// Given when the saga was started, nil for sagas logged before
+	// labels were kept, filters should allow for them.
+	Labels map[string]string
+}
+
+// SagaFilter restricts the sagas returned by ListSagas.  Zero values
+// don't restrict.
+type SagaFilter struct {
+	StartedAfter  time.Time // only sagas started at or after this time
+	StartedBefore time.Time // only sagas started before this time
+
+	// Only sagas Match returns true for.  It's called with SagaInfos
+	// without their Job, which is only read for the sagas returned.
+	Match func(info SagaInfo) bool
+
+	NewestFirst bool // order the sagas most recently started first
+	Limit       int  // return at most this many sagas, the first in order
+}
+
+// Returns true if the saga passes the filter
+func (f SagaFilter) Matches(info SagaInfo) bool {
+	if !f.StartedAfter.IsZero() && info.StartTime.Before(f.StartedAfter) {
+		return false
+	}
+	if !f.StartedBefore.IsZero() && !info.StartTime.Before(f.StartedBefore) {
+		return false
+	}
+	if f.Match != nil && !f.Match(info) {
+		return false
+	}
+	return true
+}
+
+// Orders the sagas that passed the filter as it asks, and returns at most
+// its Limit of them.  Sorts infos in place.
+func (f SagaFilter) Select(infos []SagaInfo) []SagaInfo {
+	if f.NewestFirst {
+		sort.Sort(sort.Reverse(SagaInfosByStartTime(infos)))
+	} else {
+		sort.Sort(SagaInfosByStartTime(infos))
+	}
+	if f.Limit > 0 && len(infos) > f.Limit {
+		infos = infos[:f.Limit]
+	}
+	return infos
+}
+
+// Sorts SagaInfos by start time, oldest first, and then by id
+type SagaInfosByStartTime []SagaInfo
+
+func (s SagaInfosByStartTime) Len() int      { return len(s) }
+func (s SagaInfosByStartTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s SagaInfosByStartTime) Less(i, j int) bool {
+	if !s[i].StartTime.Equal(s[j].StartTime) {
+		return s[i].StartTime.Before(s[j].StartTime)
+	}
+	return s[i].SagaId < s[j].SagaId
 }
 
 // CorruptedSagaLogError this is a critical error specifies
@@ -28,14 +28,14 @@ func (_m *MockSagaLog) EXPECT() *_MockSagaLogRecorder {
 	return _m.recorder
 }
 
-func (_m *MockSagaLog) StartSaga(sagaId string, job []byte) error {
-	ret := _m.ctrl.Call(_m, "StartSaga", sagaId, job)
+func (_m *MockSagaLog) StartSaga(sagaId string, job []byte, labels map[string]string) error {
+	ret := _m.ctrl.Call(_m, "StartSaga", sagaId, job, labels)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockSagaLogRecorder) StartSaga(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartSaga", arg0, arg1)
+func (_mr *_MockSagaLogRecorder) StartSaga(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartSaga", arg0, arg1, arg2)
 }
 
 func (_m *MockSagaLog) LogMessage(message SagaMessage) error {
@@ -69,3 +69,98 @@ func (_m *MockSagaLog) GetActiveSagas() ([]string, error) {
 func (_mr *_MockSagaLogRecorder) GetActiveSagas() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetActiveSagas")
 }
+
+func (_m *MockSagaLog) ListSagas(filter SagaFilter) ([]SagaInfo, error) {
+	ret := _m.ctrl.Call(_m, "ListSagas", filter)
+	ret0, _ := ret[0].([]SagaInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockSagaLogRecorder) ListSagas(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ListSagas", arg0)
+}
+
+// Mock of ReplicatedSagaLog interface
+type MockReplicatedSagaLog struct {
+	ctrl     *gomock.Controller
+	recorder *_MockReplicatedSagaLogRecorder
+}
+
+// Recorder for MockReplicatedSagaLog (not exported)
+type _MockReplicatedSagaLogRecorder struct {
+	mock *MockReplicatedSagaLog
+}
+
+func NewMockReplicatedSagaLog(ctrl *gomock.Controller) *MockReplicatedSagaLog {
+	mock := &MockReplicatedSagaLog{ctrl: ctrl}
+	mock.recorder = &_MockReplicatedSagaLogRecorder{mock}
+	return mock
+}
+
+func (_m *MockReplicatedSagaLog) EXPECT() *_MockReplicatedSagaLogRecorder {
+	return _m.recorder
+}
+
+func (_m *MockReplicatedSagaLog) StartSaga(sagaId string, job []byte, labels map[string]string) error {
+	ret := _m.ctrl.Call(_m, "StartSaga", sagaId, job, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockReplicatedSagaLogRecorder) StartSaga(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartSaga", arg0, arg1, arg2)
+}
+
+func (_m *MockReplicatedSagaLog) LogMessage(message SagaMessage) error {
+	ret := _m.ctrl.Call(_m, "LogMessage", message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockReplicatedSagaLogRecorder) LogMessage(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LogMessage", arg0)
+}
+
+func (_m *MockReplicatedSagaLog) GetMessages(sagaId string) ([]SagaMessage, error) {
+	ret := _m.ctrl.Call(_m, "GetMessages", sagaId)
+	ret0, _ := ret[0].([]SagaMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockReplicatedSagaLogRecorder) GetMessages(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetMessages", arg0)
+}
+
+func (_m *MockReplicatedSagaLog) GetActiveSagas() ([]string, error) {
+	ret := _m.ctrl.Call(_m, "GetActiveSagas")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockReplicatedSagaLogRecorder) GetActiveSagas() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetActiveSagas")
+}
+
+func (_m *MockReplicatedSagaLog) ListSagas(filter SagaFilter) ([]SagaInfo, error) {
+	ret := _m.ctrl.Call(_m, "ListSagas", filter)
+	ret0, _ := ret[0].([]SagaInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockReplicatedSagaLogRecorder) ListSagas(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ListSagas", arg0)
+}
+
+func (_m *MockReplicatedSagaLog) WaitForLeadership() <-chan struct{} {
+	ret := _m.ctrl.Call(_m, "WaitForLeadership")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+func (_mr *_MockReplicatedSagaLogRecorder) WaitForLeadership() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "WaitForLeadership")
+}
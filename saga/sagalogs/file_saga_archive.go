@@ -0,0 +1,162 @@
+package sagalogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/scootdev/scoot/saga"
+)
+
+// Sagas that ended are moved out of their directories into archive
+// segments, files holding the sagas of many jobs back to back, so finished
+// sagas don't cost a directory of files each.  An archived saga's record is
+// its job data followed by its messages as JSON, the index has its segment,
+// offset and lengths.
+const archiveDirName = ".archive"
+
+// Size past which a new segment is started
+const maxSegmentSize = 64 << 20
+
+const segmentPrefix = "segment_"
+
+type archivedMessage struct {
+	MsgType saga.SagaMessageType `json:"type"`
+	TaskId  string               `json:"task,omitempty"`
+	Data    []byte               `json:"data,omitempty"`
+}
+
+// Appends sagas to segments.  Not safe for concurrent use, the fileSagaLog
+// archives one saga at a time.  Reading archived sagas is.
+type sagaArchive struct {
+	dirName string
+
+	// segment being appended to, a new one is started the first time a
+	// saga is archived after startup
+	segment     *os.File
+	segmentName string
+	size        int64
+}
+
+func openSagaArchive(dirName string) (*sagaArchive, error) {
+	archiveDir := path.Join(dirName, archiveDirName)
+	if err := os.MkdirAll(archiveDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return &sagaArchive{dirName: archiveDir}, nil
+}
+
+// Appends a saga's record to the current segment and syncs it.  Fills in
+// where the record is in the specified index entry.
+func (a *sagaArchive) write(job []byte, msgs []saga.SagaMessage, entry *fileSagaIndexEntry) error {
+	archived := make([]archivedMessage, len(msgs))
+	for i, msg := range msgs {
+		archived[i] = archivedMessage{MsgType: msg.MsgType, TaskId: msg.TaskId, Data: msg.Data}
+	}
+	msgsAsBytes, err := json.Marshal(archived)
+	if err != nil {
+		return err
+	}
+
+	if a.segment == nil || a.size >= maxSegmentSize {
+		if err := a.startSegment(); err != nil {
+			return err
+		}
+	}
+
+	record := append(job[:len(job):len(job)], msgsAsBytes...)
+	_, err = a.segment.Write(record)
+	if err == nil {
+		err = a.segment.Sync()
+	}
+	if err != nil {
+		// the segment may end with part of the record, start a new one
+		a.segment.Close()
+		a.segment = nil
+		return err
+	}
+
+	entry.segment = a.segmentName
+	entry.offset = a.size
+	entry.length = int64(len(record))
+	entry.jobLength = int64(len(job))
+	a.size += int64(len(record))
+	return nil
+}
+
+func (a *sagaArchive) startSegment() error {
+	name := fmt.Sprintf("%v%020d", segmentPrefix, time.Now().UnixNano())
+	segment, err := os.OpenFile(path.Join(a.dirName, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	if a.segment != nil {
+		a.segment.Close()
+	}
+	a.segment = segment
+	a.segmentName = name
+	a.size = 0
+	return nil
+}
+
+// Reads the job data of an archived saga
+func (a *sagaArchive) readJob(entry *fileSagaIndexEntry) ([]byte, error) {
+	return a.read(entry.segment, entry.offset, entry.jobLength)
+}
+
+// Reads the messages of an archived saga
+func (a *sagaArchive) readMessages(sagaId string, entry *fileSagaIndexEntry) ([]saga.SagaMessage, error) {
+	msgsAsBytes, err := a.read(entry.segment, entry.offset+entry.jobLength, entry.length-entry.jobLength)
+	if err != nil {
+		return nil, saga.NewCorruptedSagaLogError(sagaId,
+			fmt.Sprintf("Error Reading Archived Saga from %v, Error: %v", entry.segment, err))
+	}
+
+	var archived []archivedMessage
+	if err := json.Unmarshal(msgsAsBytes, &archived); err != nil {
+		return nil, saga.NewCorruptedSagaLogError(sagaId,
+			fmt.Sprintf("Error Parsing Archived Saga from %v, Error: %v", entry.segment, err))
+	}
+	msgs := make([]saga.SagaMessage, len(archived))
+	for i, msg := range archived {
+		msgs[i] = saga.SagaMessage{SagaId: sagaId, MsgType: msg.MsgType, TaskId: msg.TaskId, Data: msg.Data}
+	}
+	return msgs, nil
+}
+
+func (a *sagaArchive) read(segmentName string, offset int64, length int64) ([]byte, error) {
+	segment, err := os.Open(path.Join(a.dirName, segmentName))
+	if err != nil {
+		return nil, err
+	}
+	defer segment.Close()
+
+	data := make([]byte, length)
+	if _, err := segment.ReadAt(data, offset); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Deletes the segments that aren't in the specified set of segments still
+// holding sagas, other than the one being appended to
+func (a *sagaArchive) removeSegments(live map[string]bool) error {
+	files, err := ioutil.ReadDir(a.dirName)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		name := file.Name()
+		if !strings.HasPrefix(name, segmentPrefix) || live[name] || name == a.segmentName {
+			continue
+		}
+		if err := os.Remove(path.Join(a.dirName, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package sagalogs
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path"
+	"time"
+)
+
+// The index of a fileSagaLog is journaled to a file in its directory, so
+// startup reads one file rather than every saga's log.  Each line is a JSON
+// record with the latest state of a saga, or that it was deleted, replaying
+// the lines in order gives the index.  The journal is rewritten with a
+// single record per saga on startup and once it's grown to several times
+// the size of the index.
+const indexFileName = ".index"
+
+// Number of records the journal may hold beyond twice the number of sagas
+// before it's rewritten
+const indexSlack = 1000
+
+type sagaIndexRecord struct {
+	SagaId    string `json:"id"`
+	Deleted   bool   `json:"deleted,omitempty"`
+	StartTime int64  `json:"start,omitempty"` // unix nanos
+	JobFile   string `json:"job,omitempty"`
+	Aborted   bool   `json:"aborted,omitempty"`
+	Completed bool   `json:"completed,omitempty"`
+	Segment   string `json:"segment,omitempty"`
+	Offset    int64  `json:"offset,omitempty"`
+	Length    int64  `json:"length,omitempty"`
+	JobLength int64  `json:"jobLength,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// The journal file being appended to.  Not safe for concurrent use, the
+// fileSagaLog only appends while holding its mutex.
+type sagaIndexJournal struct {
+	file    *os.File
+	records int // number of records in the file
+}
+
+// Replays the journal in the specified directory.  Returns a nil index if
+// there's no journal, a torn last record from a crash is skipped.
+func readSagaIndexJournal(dirName string) (map[string]*fileSagaIndexEntry, error) {
+	file, err := os.Open(path.Join(dirName, indexFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	index := make(map[string]*fileSagaIndexEntry)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var record sagaIndexRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			log.Printf("Skipping unreadable saga index record %q: %v", scanner.Text(), err)
+			continue
+		}
+		if record.Deleted {
+			delete(index, record.SagaId)
+			continue
+		}
+		index[record.SagaId] = &fileSagaIndexEntry{
+			startTime:   time.Unix(0, record.StartTime),
+			jobFileName: record.JobFile,
+			aborted:     record.Aborted,
+			completed:   record.Completed,
+			segment:     record.Segment,
+			offset:      record.Offset,
+			length:      record.Length,
+			jobLength:   record.JobLength,
+			labels:      record.Labels,
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// Writes a journal with one record per saga in the index, replacing the
+// journal in the specified directory once it's durable.  Returns the new
+// journal, open for appending.
+func writeSagaIndexJournal(dirName string, index map[string]*fileSagaIndexEntry) (*sagaIndexJournal, error) {
+	tmpFileName := path.Join(dirName, indexFileName+".tmp")
+	file, err := os.Create(tmpFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &sagaIndexJournal{file: file}
+	w := bufio.NewWriter(file)
+	for sagaId, entry := range index {
+		if err := writeSagaIndexRecord(w, makeSagaIndexRecord(sagaId, entry)); err != nil {
+			file.Close()
+			return nil, err
+		}
+		j.records++
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmpFileName, path.Join(dirName, indexFileName)); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// Appends the latest state of a saga to the journal, the caller syncs it
+func (j *sagaIndexJournal) put(sagaId string, entry *fileSagaIndexEntry) error {
+	j.records++
+	return writeSagaIndexRecord(j.file, makeSagaIndexRecord(sagaId, entry))
+}
+
+// Appends that a saga was deleted to the journal, the caller syncs it
+func (j *sagaIndexJournal) delete(sagaId string) error {
+	j.records++
+	return writeSagaIndexRecord(j.file, sagaIndexRecord{SagaId: sagaId, Deleted: true})
+}
+
+// Returns true if the journal should be rewritten for an index of the
+// specified size
+func (j *sagaIndexJournal) needsRewrite(numSagas int) bool {
+	return j.records > 2*numSagas+indexSlack
+}
+
+func makeSagaIndexRecord(sagaId string, entry *fileSagaIndexEntry) sagaIndexRecord {
+	return sagaIndexRecord{
+		SagaId:    sagaId,
+		StartTime: entry.startTime.UnixNano(),
+		JobFile:   entry.jobFileName,
+		Aborted:   entry.aborted,
+		Completed: entry.completed,
+		Segment:   entry.segment,
+		Offset:    entry.offset,
+		Length:    entry.length,
+		JobLength: entry.jobLength,
+		Labels:    entry.labels,
+	}
+}
+
+func writeSagaIndexRecord(w io.Writer, record sagaIndexRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return err
+}
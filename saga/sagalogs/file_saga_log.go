@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"os"
 	"path"
+	"sync"
 	"time"
 
 	"github.com/scootdev/scoot/saga"
@@ -44,21 +46,241 @@ import (
 
 // EndSaga Message
 // EndSaga
+//
+// An index of the sagas is kept in memory to answer GetActiveSagas and
+// ListSagas without reading every log, and journaled to disk so startup
+// doesn't read them either.  Once a saga ends its directory is compacted
+// into an archive segment, and archived sagas started longer than the
+// retention ago are deleted.  Writers logging concurrently share fsyncs.
 type fileSagaLog struct {
-	dirName string
+	dirName   string
+	retention time.Duration
+
+	// guards the index, its journal & the open log files and directories
+	mutex    sync.RWMutex
+	index    map[string]*fileSagaIndexEntry
+	journal  *sagaIndexJournal
+	logFiles map[string]*os.File // log files of sagas that haven't ended, open for appending
+	sagaDirs map[string]*os.File // directories of sagas that haven't ended, synced with their data files
+	dir      *os.File            // dirName, synced with the saga directories created in it
+
+	// Files are synced after the mutex is released, so a file closed while
+	// callers are syncing it is only closed once they're done.  syncing counts
+	// the callers syncing each file, closing holds the files waiting to close.
+	committer groupCommitter
+	syncing   map[*os.File]int
+	closing   map[*os.File]bool
+
+	// serializes archiving sagas and applying the retention
+	archiveMutex  sync.Mutex
+	archive       *sagaArchive
+	lastRetention time.Time
+}
+
+// What the index knows about a saga.  The job data is read from
+// jobFileName, or the archive, when needed rather than held in memory.
+type fileSagaIndexEntry struct {
+	startTime   time.Time
+	jobFileName string
+	aborted     bool
+	completed   bool
+	labels      map[string]string // nil if the index was rebuilt from the logs
+
+	// where the saga's record is once it's archived, segment is empty before
+	segment   string
+	offset    int64
+	length    int64
+	jobLength int64
+}
+
+func (e *fileSagaIndexEntry) archived() bool {
+	return e.segment != ""
 }
 
+// How often archived sagas are checked against the retention
+const retentionCheckInterval = time.Minute
+
 // Creates a FileSagaLog with files stored at the specified directory
-// If the directory does not exist it will create it.
+// If the directory does not exist it will create it.  Sagas are kept forever.
 func MakeFileSagaLog(dirName string) (*fileSagaLog, error) {
+	return MakeFileSagaLogWithRetention(dirName, 0)
+}
+
+// Creates a FileSagaLog with files stored at the specified directory, which
+// deletes sagas that ended once they were started more than retention ago.
+// A retention of 0 keeps sagas forever.
+func MakeFileSagaLogWithRetention(dirName string, retention time.Duration) (*fileSagaLog, error) {
 
 	if err := os.MkdirAll(dirName, os.ModePerm); err != nil {
 		return nil, err
 	}
 
-	return &fileSagaLog{
-		dirName: dirName,
-	}, nil
+	archive, err := openSagaArchive(dirName)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := loadSagaIndex(dirName)
+	if err != nil {
+		return nil, err
+	}
+
+	journal, err := writeSagaIndexJournal(dirName, index)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.Open(dirName)
+	if err != nil {
+		return nil, err
+	}
+
+	slog := &fileSagaLog{
+		dirName:   dirName,
+		retention: retention,
+		index:     index,
+		journal:   journal,
+		logFiles:  make(map[string]*os.File),
+		sagaDirs:  make(map[string]*os.File),
+		dir:       dir,
+		syncing:   make(map[*os.File]int),
+		closing:   make(map[*os.File]bool),
+		archive:   archive,
+	}
+
+	// finish compacting sagas that ended before the last shutdown
+	slog.archiveEndedSagas()
+	if err := slog.compact(time.Now()); err != nil {
+		log.Printf("Error compacting saga log %v: %v", dirName, err)
+	}
+	return slog, nil
+}
+
+// Loads the index from its journal, checked against the saga directories.
+// Sagas the journal doesn't know about, from an older log or a crash, are
+// indexed from their logs, as are sagas that haven't been archived in case
+// their last messages weren't journaled.  Sagas whose logs can't be parsed
+// are left out of the index.  Directories of sagas archived just before a
+// crash are removed.
+func loadSagaIndex(dirName string) (map[string]*fileSagaIndexEntry, error) {
+	index, err := readSagaIndexJournal(dirName)
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		index = make(map[string]*fileSagaIndexEntry)
+	}
+
+	files, err := ioutil.ReadDir(dirName)
+	if err != nil {
+		return nil, err
+	}
+
+	onDisk := make(map[string]bool)
+	for _, file := range files {
+		if !file.IsDir() || file.Name() == archiveDirName {
+			continue
+		}
+		sagaId := file.Name()
+		sagaDir := path.Join(dirName, sagaId)
+		journaled, ok := index[sagaId]
+		if ok && journaled.archived() {
+			if err := os.RemoveAll(sagaDir); err != nil {
+				log.Printf("Error removing directory of archived saga %v: %v", sagaId, err)
+			}
+			continue
+		}
+
+		onDisk[sagaId] = true
+		entry, err := readSagaIndexEntry(path.Join(sagaDir, "log"), sagaId)
+		if err != nil {
+			log.Printf("Not indexing saga %v: %v", sagaId, err)
+			delete(index, sagaId)
+			continue
+		}
+		if entry == nil {
+			delete(index, sagaId)
+			continue
+		}
+		if ok {
+			entry.startTime = journaled.startTime
+			entry.labels = journaled.labels
+		}
+		index[sagaId] = entry
+	}
+
+	for sagaId, entry := range index {
+		if !entry.archived() && !onDisk[sagaId] {
+			log.Printf("Not indexing saga %v: its directory is missing", sagaId)
+			delete(index, sagaId)
+		}
+	}
+	return index, nil
+}
+
+// Reads the index entry for a saga from its log file.  Only the structure
+// of the log is parsed, task data files aren't read.  Returns nil if the
+// saga was never started.
+func readSagaIndexEntry(logFileName string, sagaId string) (*fileSagaIndexEntry, error) {
+	logFile, err := os.Open(logFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer logFile.Close()
+
+	var entry *fileSagaIndexEntry
+	scanner := bufio.NewScanner(logFile)
+	for scanner.Scan() {
+		// number of lines following the message type
+		args := 0
+		isStart := false
+		switch msgType := scanner.Text(); msgType {
+		case saga.StartSaga.String():
+			args = 1
+			isStart = true
+		case saga.StartTask.String(), saga.EndTask.String(),
+			saga.StartCompTask.String(), saga.EndCompTask.String():
+			args = 2
+		case saga.AbortSaga.String(), saga.EndSaga.String():
+			if entry == nil {
+				return nil, saga.NewCorruptedSagaLogError(sagaId,
+					fmt.Sprintf("Error Parsing SagaLog %v logged before StartSaga", msgType))
+			}
+			entry.aborted = entry.aborted || msgType == saga.AbortSaga.String()
+			entry.completed = entry.completed || msgType == saga.EndSaga.String()
+		default:
+			return nil, saga.NewCorruptedSagaLogError(sagaId,
+				fmt.Sprintf("Error Parsing SagaLog unrecognized message type, %v", msgType))
+		}
+
+		for i := 0; i < args; i++ {
+			if ok := scanner.Scan(); !ok {
+				return nil, saga.NewCorruptedSagaLogError(sagaId,
+					fmt.Sprintf("Error Parsing SagaLog, Error: %v", createUnexpectedScanEndMsg(scanner)))
+			}
+		}
+
+		if isStart {
+			jobFileName := scanner.Text()
+			info, err := os.Stat(jobFileName)
+			if err != nil {
+				return nil, saga.NewCorruptedSagaLogError(sagaId,
+					fmt.Sprintf("Error Reading DataFile %v, Error: %v", jobFileName, err))
+			}
+			if entry == nil {
+				entry = &fileSagaIndexEntry{}
+			}
+			entry.startTime = info.ModTime()
+			entry.jobFileName = jobFileName
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entry, nil
 }
 
 // all files for a saga log are stored in a directory named
@@ -102,77 +324,59 @@ func (log *fileSagaLog) createJobDataFileName(sagaId string) string {
 
 // Log a Start Saga Message message to the log.
 // Returns an error if it fails.
-func (log *fileSagaLog) StartSaga(sagaId string, job []byte) error {
+func (log *fileSagaLog) StartSaga(sagaId string, job []byte, labels map[string]string) error {
 
 	// Create directory for this saga if it doesn't exist
-	dirName := log.getSagaDirectory(sagaId)
-	if _, err := os.Stat(dirName); err != nil {
-		if os.IsNotExist(err) {
-			err = os.Mkdir(dirName, os.ModePerm)
-			if err != nil {
-				return err
-			}
-		} else {
-			return err
-		}
+	err := os.Mkdir(log.getSagaDirectory(sagaId), os.ModePerm)
+	created := err == nil
+	if err != nil && !os.IsExist(err) {
+		return err
 	}
 
 	// Write Data File
 	dataFileName := log.createJobDataFileName(sagaId)
-	err := ioutil.WriteFile(dataFileName, job, os.ModePerm)
-	if err != nil {
+	if err := log.writeDataFile(sagaId, dataFileName, job, created); err != nil {
 		return err
 	}
 
-	// Write Message to Log File
-	var logFile *os.File
-	defer logFile.Close()
-	logFileName := log.getSagaLogFileName(sagaId)
+	// write log message
+	msg := []byte(fmt.Sprintf("%v\n%v\n",
+		saga.StartSaga.String(),
+		dataFileName))
 
+	log.mutex.Lock()
 	// Append StartSaga message to the log
 	// Get File Handle for Saga Create it if it doesn't exist
-	if _, err = os.Stat(logFileName); err != nil {
-		if os.IsNotExist(err) {
-			logFile, err = os.Create(logFileName)
-			if err != nil {
-				return err
-			}
-		} else {
-			return err
-		}
-	} else {
-		logFile, err = os.OpenFile(logFileName, os.O_APPEND|os.O_RDWR, os.ModePerm)
-		if err != nil {
-			return err
+	logFile, err := log.openLogFile(sagaId, true)
+	if err == nil {
+		_, err = logFile.Write(msg)
+	}
+	if err == nil {
+		entry, ok := log.index[sagaId]
+		if !ok || entry.archived() {
+			entry = &fileSagaIndexEntry{}
+			log.index[sagaId] = entry
 		}
+		entry.startTime = time.Now()
+		entry.jobFileName = dataFileName
+		entry.labels = labels
+		err = log.journal.put(sagaId, entry)
 	}
+	journalFile := log.journal.file
+	if err == nil {
+		log.holdForSync(logFile, journalFile)
+	}
+	log.mutex.Unlock()
 
-	// write log message
-	msg := []byte(fmt.Sprintf("%v\n%v\n",
-		saga.StartSaga.String(),
-		dataFileName))
-
-	_, err = logFile.Write(msg)
 	if err != nil {
 		return err
 	}
-
-	logFile.Sync()
-	return nil
+	return log.syncHeld(logFile, journalFile)
 }
 
 // Update the State of the Saga by Logging a message.
 // Returns an error if it fails.
 func (log *fileSagaLog) LogMessage(message saga.SagaMessage) error {
-	fileName := log.getSagaLogFileName(message.SagaId)
-
-	// Get file handle for Saga if it doesn't exist return error,
-	// Saga wasn't started.  OpenFile so we can append to it
-	logFile, err := os.OpenFile(fileName, os.O_APPEND|os.O_RDWR, os.ModePerm)
-	defer logFile.Close()
-	if err != nil {
-		return err
-	}
 
 	// Write MessageType
 	msg := []byte(fmt.Sprintf("%v\n", message.MsgType.String()))
@@ -186,8 +390,7 @@ func (log *fileSagaLog) LogMessage(message saga.SagaMessage) error {
 		// write task data to file
 		dataFileName := log.createTaskDataFileName(
 			message.SagaId, message.TaskId, message.MsgType)
-		err = ioutil.WriteFile(dataFileName, message.Data, os.ModePerm)
-		if err != nil {
+		if err := log.writeDataFile(message.SagaId, dataFileName, message.Data, false); err != nil {
 			return err
 		}
 
@@ -198,18 +401,168 @@ func (log *fileSagaLog) LogMessage(message saga.SagaMessage) error {
 				dataFileName))...)
 	}
 
-	_, err = logFile.Write(msg)
+	log.mutex.Lock()
+	// Get file handle for Saga if it doesn't exist return error,
+	// Saga wasn't started.
+	logFile, err := log.openLogFile(message.SagaId, false)
 	if err != nil {
+		log.mutex.Unlock()
 		return err
 	}
+	toSync := []*os.File{logFile}
+	_, err = logFile.Write(msg)
+
+	ended := err == nil && message.MsgType == saga.EndSaga
+	if err == nil && (message.MsgType == saga.AbortSaga || ended) {
+		if entry, ok := log.index[message.SagaId]; ok {
+			entry.aborted = entry.aborted || message.MsgType == saga.AbortSaga
+			entry.completed = entry.completed || ended
+			err = log.journal.put(message.SagaId, entry)
+			toSync = append(toSync, log.journal.file)
+		}
+	}
+	if err == nil {
+		log.holdForSync(toSync...)
+	}
+	if ended {
+		// nothing more is logged for the saga, its log is closed once synced
+		delete(log.logFiles, message.SagaId)
+		log.closeFile(logFile)
+		if dir, ok := log.sagaDirs[message.SagaId]; ok {
+			delete(log.sagaDirs, message.SagaId)
+			log.closeFile(dir)
+		}
+	}
+	log.mutex.Unlock()
 
-	logFile.Sync()
+	if err == nil {
+		err = log.syncHeld(toSync...)
+	}
+	if !ended || err != nil {
+		return err
+	}
+	if err := log.archiveSaga(message.SagaId); err != nil {
+		logCompactionError(log.dirName, err)
+	}
+	if err := log.compact(time.Now()); err != nil {
+		logCompactionError(log.dirName, err)
+	}
 	return nil
 }
 
+// Returns the open log file of the specified saga, opening it if needed.
+// If create is false the log file must exist, i.e. the saga was started.
+// The caller holds the mutex.
+func (log *fileSagaLog) openLogFile(sagaId string, create bool) (*os.File, error) {
+	if logFile, ok := log.logFiles[sagaId]; ok {
+		return logFile, nil
+	}
+
+	flags := os.O_APPEND | os.O_WRONLY
+	if create {
+		flags |= os.O_CREATE
+	}
+	logFile, err := os.OpenFile(log.getSagaLogFileName(sagaId), flags, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+	log.logFiles[sagaId] = logFile
+	return logFile, nil
+}
+
+// Writes a message's data to a new file in the saga's directory, and returns
+// once the file and its directory entry are durable, so the log never refers
+// to data a crash could lose.  If the saga's directory was just created, its
+// entry in dirName is synced too.
+func (log *fileSagaLog) writeDataFile(sagaId string, fileName string, data []byte, createdDir bool) error {
+	dataFile, err := os.OpenFile(fileName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer dataFile.Close()
+	if _, err := dataFile.Write(data); err != nil {
+		return err
+	}
+
+	log.mutex.Lock()
+	sagaDir, err := log.openSagaDir(sagaId)
+	if err != nil {
+		log.mutex.Unlock()
+		return err
+	}
+	toSync := []*os.File{dataFile, sagaDir}
+	if createdDir {
+		toSync = append(toSync, log.dir)
+	}
+	log.holdForSync(toSync...)
+	log.mutex.Unlock()
+
+	return log.syncHeld(toSync...)
+}
+
+// Returns the open directory of the specified saga, opening it if needed.
+// The caller holds the mutex.
+func (log *fileSagaLog) openSagaDir(sagaId string) (*os.File, error) {
+	if sagaDir, ok := log.sagaDirs[sagaId]; ok {
+		return sagaDir, nil
+	}
+	sagaDir, err := os.Open(log.getSagaDirectory(sagaId))
+	if err != nil {
+		return nil, err
+	}
+	log.sagaDirs[sagaId] = sagaDir
+	return sagaDir, nil
+}
+
+// Marks the files as being synced, so they stay open until syncHeld is
+// done with them.  The caller holds the mutex.
+func (log *fileSagaLog) holdForSync(files ...*os.File) {
+	for _, f := range files {
+		log.syncing[f]++
+	}
+}
+
+// Syncs files held by holdForSync, then closes any of them closed meanwhile
+// that no one else is syncing.
+func (log *fileSagaLog) syncHeld(files ...*os.File) error {
+	err := log.committer.sync(files...)
+
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	for _, f := range files {
+		log.syncing[f]--
+		if log.syncing[f] > 0 {
+			continue
+		}
+		delete(log.syncing, f)
+		if log.closing[f] {
+			delete(log.closing, f)
+			f.Close()
+		}
+	}
+	return err
+}
+
+// Closes the file, or once it's synced if it's being synced.  The caller
+// holds the mutex.
+func (log *fileSagaLog) closeFile(f *os.File) {
+	if log.syncing[f] > 0 {
+		log.closing[f] = true
+		return
+	}
+	f.Close()
+}
+
 // Returns all of the messages logged so far for the
 // specified saga.
 func (log *fileSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	log.mutex.RLock()
+	defer log.mutex.RUnlock()
+
+	if entry, ok := log.index[sagaId]; ok && entry.archived() {
+		return log.archive.readMessages(sagaId, entry)
+	}
+
 	fileName := log.getSagaLogFileName(sagaId)
 
 	// check if this saga actually exists
@@ -365,25 +718,191 @@ func createUnexpectedScanEndMsg(scanner *bufio.Scanner) string {
 	return errMsg
 }
 
-//
-// Returns a list of all in progress sagaIds.
-// This MUST include all not completed sagaIds.
-// It may also included completed sagas
+// Returns the ids of the sagas in the index that haven't ended.
 // Returns an error if it fails.
-//
-// This is a very dumb implementation currently just returns
-// a list of all sagas.  This can be improved with an index
-//
 func (log *fileSagaLog) GetActiveSagas() ([]string, error) {
-	files, err := ioutil.ReadDir(log.dirName)
+	log.mutex.RLock()
+	defer log.mutex.RUnlock()
+
+	sagaIds := make([]string, 0)
+	for sagaId, entry := range log.index {
+		if !entry.completed {
+			sagaIds = append(sagaIds, sagaId)
+		}
+	}
+	return sagaIds, nil
+}
+
+// Returns the sagas in the index matching the filter, in the order it asks.
+// Only the job data of the sagas returned is read, sagas whose job data
+// can no longer be read are left out.
+func (log *fileSagaLog) ListSagas(filter saga.SagaFilter) ([]saga.SagaInfo, error) {
+	log.mutex.RLock()
+	infos := make([]saga.SagaInfo, 0, len(log.index))
+	entries := make(map[string]fileSagaIndexEntry)
+	for sagaId, entry := range log.index {
+		info := saga.SagaInfo{
+			SagaId:    sagaId,
+			StartTime: entry.startTime,
+			Aborted:   entry.aborted,
+			Completed: entry.completed,
+			Labels:    entry.labels,
+		}
+		if filter.Matches(info) {
+			infos = append(infos, info)
+			entries[sagaId] = *entry
+		}
+	}
+	log.mutex.RUnlock()
+
+	return readSagaJobs(filter.Select(infos), entries, log.archive), nil
+}
+
+// Fills in the job data of each saga from its job data file, or the archive
+func readSagaJobs(infos []saga.SagaInfo, entries map[string]fileSagaIndexEntry, archive *sagaArchive) []saga.SagaInfo {
+	read := infos[:0]
+	for _, info := range infos {
+		entry := entries[info.SagaId]
+		var job []byte
+		var err error
+		if entry.archived() {
+			job, err = archive.readJob(&entry)
+		} else {
+			job, err = ioutil.ReadFile(entry.jobFileName)
+		}
+		if err != nil {
+			log.Printf("Not listing saga %v, error reading job data: %v", info.SagaId, err)
+			continue
+		}
+		info.Job = job
+		read = append(read, info)
+	}
+	return read
+}
+
+// Moves an ended saga out of its directory into the archive
+func (log *fileSagaLog) archiveSaga(sagaId string) error {
+	log.archiveMutex.Lock()
+	defer log.archiveMutex.Unlock()
+
+	log.mutex.RLock()
+	entry, ok := log.index[sagaId]
+	var archived fileSagaIndexEntry
+	if ok {
+		archived = *entry
+	}
+	log.mutex.RUnlock()
+	if !ok || !archived.completed || archived.archived() {
+		return nil
+	}
+
+	msgs, err := log.GetMessages(sagaId)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	job, err := ioutil.ReadFile(archived.jobFileName)
+	if err != nil {
+		return err
+	}
+	archived.jobFileName = ""
+	if err := log.archive.write(job, msgs, &archived); err != nil {
+		return err
+	}
+
+	// the index points readers at the archive before the directory goes
+	log.mutex.Lock()
+	if log.index[sagaId] != entry {
+		// the saga was started again while it was being archived
+		log.mutex.Unlock()
+		return nil
+	}
+	*entry = archived
+	err = log.journal.put(sagaId, entry)
+	journalFile := log.journal.file
+	if err == nil {
+		log.holdForSync(journalFile)
+	}
+	log.mutex.Unlock()
+	if err == nil {
+		err = log.syncHeld(journalFile)
+	}
+	if err != nil {
+		return err
 	}
+	return os.RemoveAll(log.getSagaDirectory(sagaId))
+}
 
-	sagaIds := make([]string, len(files))
-	for i, file := range files {
-		sagaIds[i] = file.Name()
+// Archives every saga that ended but isn't archived yet
+func (log *fileSagaLog) archiveEndedSagas() {
+	var ended []string
+	log.mutex.RLock()
+	for sagaId, entry := range log.index {
+		if entry.completed && !entry.archived() {
+			ended = append(ended, sagaId)
+		}
 	}
+	log.mutex.RUnlock()
 
-	return sagaIds, nil
+	for _, sagaId := range ended {
+		if err := log.archiveSaga(sagaId); err != nil {
+			logCompactionError(log.dirName, err)
+		}
+	}
+}
+
+// Deletes archived sagas started longer than the retention ago, and the
+// segments no longer holding any sagas, at most once every
+// retentionCheckInterval.  Rewrites the index journal once it's mostly
+// superseded records.
+func (log *fileSagaLog) compact(now time.Time) error {
+	log.archiveMutex.Lock()
+	defer log.archiveMutex.Unlock()
+
+	applyRetention := log.retention > 0 && now.Sub(log.lastRetention) >= retentionCheckInterval
+	live := make(map[string]bool)
+
+	log.mutex.Lock()
+	var err error
+	if applyRetention {
+		log.lastRetention = now
+		cutoff := now.Add(-log.retention)
+		for sagaId, entry := range log.index {
+			if entry.archived() && entry.startTime.Before(cutoff) {
+				delete(log.index, sagaId)
+				if err == nil {
+					err = log.journal.delete(sagaId)
+				}
+			} else if entry.archived() {
+				live[entry.segment] = true
+			}
+		}
+	}
+	if err == nil && log.journal.needsRewrite(len(log.index)) {
+		var journal *sagaIndexJournal
+		journal, err = writeSagaIndexJournal(log.dirName, log.index)
+		if err == nil {
+			log.closeFile(log.journal.file)
+			log.journal = journal
+		}
+	}
+	journalFile := log.journal.file
+	if err == nil && applyRetention {
+		log.holdForSync(journalFile)
+	}
+	log.mutex.Unlock()
+
+	if err != nil || !applyRetention {
+		return err
+	}
+	// segments are only removed once the deletions are durable
+	if err := log.syncHeld(journalFile); err != nil {
+		return err
+	}
+	return log.archive.removeSegments(live)
+}
+
+// Logs an error compacting the saga log, which doesn't fail the message
+// being logged.  It's retried on startup.
+func logCompactionError(dirName string, err error) {
+	log.Printf("Error compacting saga log %v: %v", dirName, err)
 }
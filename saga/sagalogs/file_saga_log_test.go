@@ -1,11 +1,12 @@
 package sagalogs
 
 import (
-	"bytes"
+	"io/ioutil"
 	"os"
 	"path"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/scootdev/scoot/saga"
 )
@@ -37,137 +38,161 @@ func isSagaInActiveList(sagaId string, slog saga.SagaLog) bool {
 	return false
 }
 
+func openFileSagaLog() (saga.SagaLog, error) {
+	return MakeFileSagaLog(getDirName())
+}
+
 func TestStartSaga(t *testing.T) {
 	defer testCleanup(t)
+	testStartSaga(t, openFileSagaLog)
+}
 
-	dirName := getDirName()
-	sagaId := "saga1"
-
-	slog, err := MakeFileSagaLog(dirName)
-	if err != nil {
-		t.Errorf("Unexpected Error Returned %v", err)
-	}
-
-	data := []byte{0, 1, 2, 3, 4, 5}
-	err = slog.StartSaga(sagaId, data)
-	if err != nil {
-		t.Errorf("Unexpected Error starting Saga")
-	}
-
-	msgs, err := slog.GetMessages(sagaId)
-	if err != nil {
-		t.Errorf("Unexpected Error Getting Messages %v", err)
-	}
+func TestStartSaga_SagaFileAlreadyExists(t *testing.T) {
+	defer testCleanup(t)
+	testStartSaga_Twice(t, openFileSagaLog)
+}
 
-	if len(msgs) != 1 {
-		t.Errorf("Expected 1 message for saga, not %v, Messages: %+v", len(msgs), msgs)
-	}
+func TestFullSaga(t *testing.T) {
+	defer testCleanup(t)
+	testFullSaga(t, openFileSagaLog)
+}
 
-	if msgs[0].MsgType != saga.StartSaga {
-		t.Errorf("Unexpected Message Type.  Expected: StartSaga, Actual: %v", msgs[0].MsgType)
-	}
+func TestGetMessages_SagaDoesNotExist(t *testing.T) {
+	defer testCleanup(t)
+	testGetMessages_SagaDoesNotExist(t, openFileSagaLog)
+}
 
-	if !bytes.Equal(data, msgs[0].Data) {
-		t.Errorf("Expected Start Saga Message Data to be: %v, Actual: %v", data, msgs[0].Data)
-	}
+func TestListSagas(t *testing.T) {
+	defer testCleanup(t)
+	dirName := getDirName()
+	testListSagas(t, openFileSagaLog, func() {
+		// a corrupt saga is left out of the index when it's rebuilt
+		os.Mkdir(path.Join(dirName, "corrupt"), os.ModePerm)
+		ioutil.WriteFile(path.Join(dirName, "corrupt", "log"), []byte("NotAMessage\n"), os.ModePerm)
+	})
+}
 
-	if !isSagaInActiveList(sagaId, slog) {
-		t.Errorf("Expected Saga to be in active list")
-	}
+func TestListSagas_Filter(t *testing.T) {
+	defer testCleanup(t)
+	testListSagas_Filter(t, openFileSagaLog)
 }
 
-func TestStartSaga_SagaFileAlreadyExists(t *testing.T) {
+func TestListSagas_Select(t *testing.T) {
 	defer testCleanup(t)
+	testListSagas_Select(t, openFileSagaLog)
+}
 
+func TestEndSaga_ArchivesSaga(t *testing.T) {
+	defer testCleanup(t)
 	dirName := getDirName()
-	sagaId := "start_saga_called_twice"
-	data1 := []byte{0, 1, 2, 3, 4, 5}
-	data2 := []byte{6, 7, 8, 9}
-
 	slog, _ := MakeFileSagaLog(dirName)
-	slog.StartSaga(sagaId, data1)
-	err := slog.StartSaga(sagaId, data2)
-	if err != nil {
-		t.Errorf("Unexpected Error Calling Start Saga Twice %v", err)
-	}
 
-	msgs, err := slog.GetMessages(sagaId)
-	if err != nil {
-		t.Errorf("Unexpected Error Getting Messages %v", err)
+	loggedMsgs := []saga.SagaMessage{
+		saga.MakeStartSagaMessage("ended", []byte("job")),
+		saga.MakeStartTaskMessage("ended", "task1", []byte("started")),
+		saga.MakeEndTaskMessage("ended", "task1", []byte("done")),
+		saga.MakeEndSagaMessage("ended"),
+	}
+	slog.StartSaga("ended", []byte("job"), nil)
+	for _, msg := range loggedMsgs[1:] {
+		if err := slog.LogMessage(msg); err != nil {
+			t.Fatalf("Unexpected Error Logging Msg: %+v, Error: %v", msg, err)
+		}
 	}
+	slog.StartSaga("active", []byte("job2"), nil)
 
-	if len(msgs) != 2 {
-		t.Errorf("Expected 1 message for saga, not %v, Messages: %+v", len(msgs), msgs)
+	if _, err := os.Stat(path.Join(dirName, "ended")); !os.IsNotExist(err) {
+		t.Errorf("Expected the ended saga's directory to be removed once it's archived, got %v", err)
+	}
+	if _, ok := slog.sagaDirs["ended"]; ok || slog.logFiles["ended"] != nil {
+		t.Errorf("Expected the ended saga's log and directory to be closed")
+	}
+	if isSagaInActiveList("ended", slog) || !isSagaInActiveList("active", slog) {
+		t.Errorf("Expected only the saga that hasn't ended to be active")
 	}
 
-	if !isSagaInActiveList(sagaId, slog) {
-		t.Errorf("Expected Saga to be in Active List")
+	restarted, err := MakeFileSagaLog(dirName)
+	if err != nil {
+		t.Fatalf("Unexpected Error recreating FileSagaLog %v", err)
+	}
+	for _, l := range []saga.SagaLog{slog, restarted} {
+		msgs, err := l.GetMessages("ended")
+		if err != nil {
+			t.Fatalf("Unexpected Error Getting Messages %v", err)
+		}
+		if !reflect.DeepEqual(loggedMsgs, msgs) {
+			t.Errorf("Expected archived messages %+v, got %+v", loggedMsgs, msgs)
+		}
+		infos, _ := l.ListSagas(saga.SagaFilter{})
+		if len(infos) != 2 || string(infos[0].Job) != "job" || !infos[0].Completed {
+			t.Errorf("Expected the archived saga to be listed with its job, got %+v", infos)
+		}
+	}
+	if isSagaInActiveList("ended", restarted) || !isSagaInActiveList("active", restarted) {
+		t.Errorf("Expected only the saga that hasn't ended to be active after a restart")
 	}
 }
 
-func TestFullSaga(t *testing.T) {
+func TestRestart_ChecksJournalAgainstLogs(t *testing.T) {
 	defer testCleanup(t)
-
 	dirName := getDirName()
-	sagaId := "fullsaga"
-
 	slog, _ := MakeFileSagaLog(dirName)
-	jobData := []byte{0, 1, 2, 3, 4, 5}
-	slog.StartSaga(sagaId, jobData)
-
-	loggedMsgs := []saga.SagaMessage{
-		saga.MakeStartSagaMessage(sagaId, jobData),
-		saga.MakeStartTaskMessage(sagaId, "task1", []byte("run task 1")),
-		saga.MakeEndTaskMessage(sagaId, "task1", []byte("success")),
-		saga.MakeAbortSagaMessage(sagaId),
-		saga.MakeStartCompTaskMessage(sagaId, "task1", []byte("rollingback task1")),
-		saga.MakeEndCompTaskMessage(sagaId, "task1", []byte("finished rollingback task1")),
-		saga.MakeEndSagaMessage(sagaId),
-	}
-
-	for i, msg := range loggedMsgs {
-		// skip start saga message we already did it
-		if i == 0 {
-			continue
-		}
+	slog.StartSaga("unjournaled", []byte("job1"), nil)
+	slog.StartSaga("ended", []byte("job2"), nil)
 
-		err := slog.LogMessage(msg)
-		if err != nil {
-			t.Fatalf("Unexpected Error Logging Msg: %+v, Error: %v", msg, err)
-		}
-
-	}
+	// a saga logged before the index was journaled, and the end of a saga
+	// that was logged but not journaled before a crash
+	os.Remove(path.Join(dirName, indexFileName))
+	f, _ := os.OpenFile(path.Join(dirName, "ended", "log"), os.O_APPEND|os.O_WRONLY, os.ModePerm)
+	f.WriteString(saga.EndSaga.String() + "\n")
+	f.Close()
 
-	rtnMsgs, err := slog.GetMessages(sagaId)
+	restarted, err := MakeFileSagaLog(dirName)
 	if err != nil {
-		t.Fatalf("Unexpected Error returned from GetMessages. %v", err)
+		t.Fatalf("Unexpected Error recreating FileSagaLog %v", err)
 	}
-
-	if len(rtnMsgs) != len(loggedMsgs) {
-		t.Fatalf("Expected GetMessages to return %v messages.  Actual %v.  Msgs %+v",
-			len(loggedMsgs), len(rtnMsgs), rtnMsgs)
+	if !isSagaInActiveList("unjournaled", restarted) {
+		t.Errorf("Expected the saga missing from the journal to be active")
 	}
-
-	// we expect the messages to be returned in the same order they are logged
-	for j, rtnMsg := range rtnMsgs {
-		if !reflect.DeepEqual(loggedMsgs[j], rtnMsg) {
-			t.Errorf("Expected Logged Message and Returned Message to be Equal.  Expected %v, Actual %v",
-				loggedMsgs[j], rtnMsg)
-		}
+	if isSagaInActiveList("ended", restarted) {
+		t.Errorf("Expected the saga whose log ended to not be active")
+	}
+	if _, err := os.Stat(path.Join(dirName, "ended")); !os.IsNotExist(err) {
+		t.Errorf("Expected the ended saga to be archived on startup, got %v", err)
 	}
 }
 
-func TestGetMessages_SagaDoesNotExist(t *testing.T) {
+func TestRetention_DeletesOldArchivedSagas(t *testing.T) {
 	defer testCleanup(t)
 	dirName := getDirName()
-	slog, _ := MakeFileSagaLog(dirName)
-	msgs, err := slog.GetMessages("does_not_exist")
+	slog, _ := MakeFileSagaLogWithRetention(dirName, time.Hour)
 
-	if err != nil {
-		t.Errorf("Unexpected Error Getting Messages %v", err)
+	slog.StartSaga("ended", []byte("job1"), nil)
+	slog.LogMessage(saga.MakeEndSagaMessage("ended"))
+	slog.StartSaga("active", []byte("job2"), nil)
+
+	if err := slog.compact(time.Now().Add(2 * time.Hour)); err != nil {
+		t.Fatalf("Unexpected Error compacting %v", err)
+	}
+	if msgs, _ := slog.GetMessages("ended"); msgs != nil {
+		t.Errorf("Expected the old saga to be deleted, got %+v", msgs)
+	}
+	if msgs, _ := slog.GetMessages("active"); len(msgs) != 1 {
+		t.Errorf("Expected the saga that hasn't ended to be kept, got %+v", msgs)
 	}
 
-	if msgs != nil {
-		t.Errorf("Expeceted no messages to be returned %+v", msgs)
+	// the segment that held the deleted saga is removed once it's no
+	// longer being appended to
+	restarted, err := MakeFileSagaLogWithRetention(dirName, time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected Error recreating FileSagaLog %v", err)
+	}
+	infos, _ := restarted.ListSagas(saga.SagaFilter{})
+	if len(infos) != 1 || infos[0].SagaId != "active" {
+		t.Errorf("Expected only the active saga to be listed, got %+v", infos)
+	}
+	segments, _ := ioutil.ReadDir(path.Join(dirName, archiveDirName))
+	if len(segments) != 0 {
+		t.Errorf("Expected segments without sagas to be removed, got %v", len(segments))
 	}
 }
@@ -0,0 +1,83 @@
+package sagalogs
+
+import (
+	"os"
+	"sync"
+)
+
+// Batches the fsyncs of files written by concurrent callers, so under load
+// many messages share one fsync per file.  A caller whose writes must be
+// durable calls sync with the files it wrote.  If no sync is in progress it
+// syncs every file requested so far, otherwise its files are synced in the
+// next batch, once the current one is done.
+type groupCommitter struct {
+	mutex   sync.Mutex
+	syncing bool
+	next    *commitBatch // batch collecting files for the next sync
+}
+
+type commitBatch struct {
+	files map[*os.File]bool
+	done  chan struct{} // closed once the batch is synced
+	err   error
+}
+
+// Returns once the specified files are synced to disk
+func (c *groupCommitter) sync(files ...*os.File) error {
+	c.mutex.Lock()
+	if c.next == nil {
+		c.next = &commitBatch{
+			files: make(map[*os.File]bool),
+			done:  make(chan struct{}),
+		}
+	}
+	b := c.next
+	for _, f := range files {
+		b.files[f] = true
+	}
+	lead := !c.syncing
+	c.syncing = true
+	c.mutex.Unlock()
+
+	if lead {
+		c.flush(b)
+	}
+	<-b.done
+	return b.err
+}
+
+// Syncs batches until there are none left, or until the specified batch is
+// synced.  Later batches are then synced by another go routine so the
+// caller isn't held up by the callers that came after it.
+func (c *groupCommitter) flush(until *commitBatch) {
+	for {
+		c.mutex.Lock()
+		b := c.next
+		if b == nil {
+			c.syncing = false
+			c.mutex.Unlock()
+			return
+		}
+		c.next = nil
+		c.mutex.Unlock()
+
+		// callers keep their files open until their syncs return
+		for f := range b.files {
+			if err := f.Sync(); err != nil && b.err == nil {
+				b.err = err
+			}
+		}
+		close(b.done)
+
+		if b == until {
+			c.mutex.Lock()
+			if c.next == nil {
+				c.syncing = false
+			} else {
+				go c.flush(nil)
+			}
+			c.mutex.Unlock()
+			return
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package sagalogs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"testing"
+)
+
+func TestGroupCommit_ConcurrentSyncs(t *testing.T) {
+	defer testCleanup(t)
+	dirName := getDirName()
+	os.MkdirAll(dirName, os.ModePerm)
+
+	var files []*os.File
+	for i := 0; i < 4; i++ {
+		f, err := os.Create(path.Join(dirName, fmt.Sprintf("file%d", i)))
+		if err != nil {
+			t.Fatalf("Unexpected Error creating file %v", err)
+		}
+		defer f.Close()
+		files = append(files, f)
+	}
+
+	var c groupCommitter
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(f *os.File) {
+			defer wg.Done()
+			if _, err := f.WriteString("line\n"); err != nil {
+				errs <- err
+				return
+			}
+			errs <- c.sync(f)
+		}(files[i%len(files)])
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Unexpected Error syncing %v", err)
+		}
+	}
+
+	for _, f := range files {
+		data, _ := ioutil.ReadFile(f.Name())
+		if len(data) != 25*len("line\n") {
+			t.Errorf("Expected every write to %v, got %v bytes", f.Name(), len(data))
+		}
+	}
+}
+
+func TestFileSagaLog_CloseWhileSyncing(t *testing.T) {
+	defer testCleanup(t)
+	slog, err := MakeFileSagaLog(getDirName())
+	if err != nil {
+		t.Fatalf("Unexpected Error creating saga log %v", err)
+	}
+	f, err := os.Create(path.Join(getDirName(), "file"))
+	if err != nil {
+		t.Fatalf("Unexpected Error creating file %v", err)
+	}
+
+	slog.mutex.Lock()
+	slog.holdForSync(f)
+	slog.closeFile(f)
+	slog.mutex.Unlock()
+	if _, err := f.WriteString("line\n"); err != nil {
+		t.Fatalf("Expected a file being synced to stay open, got %v", err)
+	}
+
+	if err := slog.syncHeld(f); err != nil {
+		t.Fatalf("Unexpected Error syncing %v", err)
+	}
+	if _, err := f.WriteString("line\n"); err == nil {
+		t.Fatalf("Expected the file to be closed once it was synced")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/scootdev/scoot/saga"
 	"sync"
+	"time"
 )
 
 /*
@@ -15,6 +16,7 @@ import (
  */
 type inMemorySagaLog struct {
 	sagas map[string][]saga.SagaMessage
+	infos map[string]*saga.SagaInfo
 	mutex sync.RWMutex
 }
 
@@ -28,6 +30,7 @@ func MakeInMemorySagaCoordinator() saga.SagaCoordinator {
 func MakeInMemorySagaLog() saga.SagaLog {
 	return &inMemorySagaLog{
 		sagas: make(map[string][]saga.SagaMessage),
+		infos: make(map[string]*saga.SagaInfo),
 		mutex: sync.RWMutex{},
 	}
 }
@@ -45,16 +48,28 @@ func (log *inMemorySagaLog) LogMessage(msg saga.SagaMessage) error {
 	}
 
 	log.sagas[sagaId] = append(msgs, msg)
+	switch msg.MsgType {
+	case saga.AbortSaga:
+		log.infos[sagaId].Aborted = true
+	case saga.EndSaga:
+		log.infos[sagaId].Completed = true
+	}
 	return nil
 }
 
-func (log *inMemorySagaLog) StartSaga(sagaId string, job []byte) error {
+func (log *inMemorySagaLog) StartSaga(sagaId string, job []byte, labels map[string]string) error {
 
 	log.mutex.Lock()
 	defer log.mutex.Unlock()
 
 	startMsg := saga.MakeStartSagaMessage(sagaId, job)
 	log.sagas[sagaId] = []saga.SagaMessage{startMsg}
+	log.infos[sagaId] = &saga.SagaInfo{
+		SagaId:    sagaId,
+		StartTime: time.Now(),
+		Job:       job,
+		Labels:    labels,
+	}
 
 	return nil
 }
@@ -88,3 +103,23 @@ func (log *inMemorySagaLog) GetActiveSagas() ([]string, error) {
 
 	return keys, nil
 }
+
+/*
+ * Returns the Sagas Started since this InMemory Saga was created
+ * that match the filter
+ */
+func (log *inMemorySagaLog) ListSagas(filter saga.SagaFilter) ([]saga.SagaInfo, error) {
+	log.mutex.RLock()
+	defer log.mutex.RUnlock()
+
+	infos := make([]saga.SagaInfo, 0, len(log.infos))
+	for _, info := range log.infos {
+		// matched without the job, like the durable logs
+		summary := *info
+		summary.Job = nil
+		if filter.Matches(summary) {
+			infos = append(infos, *info)
+		}
+	}
+	return filter.Select(infos), nil
+}
@@ -0,0 +1,40 @@
+package sagalogs
+
+import (
+	"testing"
+
+	"github.com/scootdev/scoot/saga"
+)
+
+func TestInMemoryListSagas(t *testing.T) {
+	slog := MakeInMemorySagaLog()
+
+	slog.StartSaga("saga1", []byte("job1"), nil)
+	slog.StartSaga("saga2", []byte("job2"), nil)
+	slog.LogMessage(saga.MakeAbortSagaMessage("saga2"))
+	slog.LogMessage(saga.MakeEndSagaMessage("saga2"))
+
+	infos, err := slog.ListSagas(saga.SagaFilter{})
+	if err != nil {
+		t.Fatalf("Unexpected Error Listing Sagas %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 sagas, got %+v", infos)
+	}
+	if infos[0].SagaId != "saga1" || infos[0].Aborted || infos[0].Completed || string(infos[0].Job) != "job1" {
+		t.Errorf("Expected saga1 to be in progress, got %+v", infos[0])
+	}
+	if infos[1].SagaId != "saga2" || !infos[1].Aborted || !infos[1].Completed {
+		t.Errorf("Expected saga2 to be aborted and completed, got %+v", infos[1])
+	}
+
+	infos, _ = slog.ListSagas(saga.SagaFilter{StartedAfter: infos[1].StartTime.Add(1)})
+	if len(infos) != 0 {
+		t.Errorf("Expected no sagas started after saga2, got %+v", infos)
+	}
+}
+
+func TestInMemoryListSagas_Select(t *testing.T) {
+	slog := MakeInMemorySagaLog()
+	testListSagas_Select(t, func() (saga.SagaLog, error) { return slog, nil })
+}
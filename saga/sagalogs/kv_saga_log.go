@@ -0,0 +1,436 @@
+package sagalogs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scootdev/scoot/common/kvstore"
+	"github.com/scootdev/scoot/saga"
+)
+
+// Writes Saga Log to a single-file key-value store.  Each message is logged
+// in one transaction with the saga state it changes, so a crash never leaves
+// a saga's state out of step with its messages.
+//
+// The store's buckets, keyed by saga id unless noted:
+// - sagas: a kvSagaRecord with the state of each saga
+// - jobs: the job data of the saga's latest StartSaga
+// - active: nothing, an index of the sagas that haven't ended
+// - messages/<saga id>: the messages of a saga that hasn't ended, keyed by
+// their sequence number in hex so the keys sort in the order they were logged
+// - ended: the messages of a saga that ended, all in one value, keyed by
+// kvEndedKey so the keys sort by start time
+//
+// Once a saga ends its messages are moved into one value, so the store's
+// index of keys, which is kept in memory, holds a few keys per ended saga.
+// Ended sagas started longer than the retention ago are deleted.
+type kvSagaLog struct {
+	fileName  string
+	db        *kvstore.DB
+	retention time.Duration
+
+	// serializes applying the retention
+	retentionMutex sync.Mutex
+	lastRetention  time.Time
+}
+
+const (
+	kvSagasBucket          = "sagas"
+	kvJobsBucket           = "jobs"
+	kvActiveBucket         = "active"
+	kvMessagesBucketPrefix = "messages/"
+	kvEndedBucket          = "ended"
+)
+
+type kvSagaRecord struct {
+	StartTime int64 `json:"start"` // unix nanos
+	Aborted   bool  `json:"aborted,omitempty"`
+	Completed bool  `json:"completed,omitempty"`
+	Messages  int   `json:"messages"` // number of messages logged
+
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Creates a KVSagaLog stored in the specified file, creating the file if it
+// does not exist.  Sagas are kept forever.
+func MakeKVSagaLog(fileName string) (*kvSagaLog, error) {
+	return MakeKVSagaLogWithRetention(fileName, 0)
+}
+
+// Creates a KVSagaLog stored in the specified file, which deletes sagas that
+// ended once they were started more than retention ago.  A retention of 0
+// keeps sagas forever.
+func MakeKVSagaLogWithRetention(fileName string, retention time.Duration) (*kvSagaLog, error) {
+	db, err := kvstore.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	slog, err := newKVSagaLog(fileName, db, retention)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := slog.applyRetention(time.Now()); err != nil {
+		logCompactionError(fileName, err)
+	}
+	return slog, nil
+}
+
+// Creates a kvSagaLog of the sagas in an open store
+func newKVSagaLog(fileName string, db *kvstore.DB, retention time.Duration) (*kvSagaLog, error) {
+	// compact sagas that ended before their messages were moved on ending
+	if err := db.Update(compactKVEndedSagas); err != nil {
+		return nil, err
+	}
+	return &kvSagaLog{fileName: fileName, db: db, retention: retention}, nil
+}
+
+// Log a Start Saga Message message to the log.  Starting a saga that ended
+// starts it over, dropping its old messages.
+// Returns an error if it fails.
+func (log *kvSagaLog) StartSaga(sagaId string, job []byte, labels map[string]string) error {
+	return log.db.Update(func(tx *kvstore.Tx) error {
+		return startKVSaga(tx, sagaId, job, labels, time.Now())
+	})
+}
+
+// Update the State of the Saga by Logging a message.
+// Returns an error if it fails.
+func (log *kvSagaLog) LogMessage(message saga.SagaMessage) error {
+	err := log.db.Update(func(tx *kvstore.Tx) error {
+		started, err := logKVMessage(tx, message)
+		if err == nil && !started {
+			return errors.New(fmt.Sprintf("Saga: %s is not Started yet.", message.SagaId))
+		}
+		return err
+	})
+	if err != nil || message.MsgType != saga.EndSaga {
+		return err
+	}
+	if err := log.applyRetention(time.Now()); err != nil {
+		logCompactionError(log.fileName, err)
+	}
+	return nil
+}
+
+// Returns all of the messages logged so far for the
+// specified saga.
+func (log *kvSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	var msgs []saga.SagaMessage
+	err := log.db.View(func(tx *kvstore.Tx) error {
+		record, err := getKVSagaRecord(tx, sagaId)
+		if err != nil || record == nil {
+			return err
+		}
+		archived, err := getKVMessages(tx, sagaId, record)
+		if err != nil {
+			return err
+		}
+		for _, msg := range archived {
+			msgs = append(msgs, saga.SagaMessage{
+				SagaId:  sagaId,
+				MsgType: msg.MsgType,
+				TaskId:  msg.TaskId,
+				Data:    msg.Data,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// Returns the ids of the sagas that haven't ended.
+// Returns an error if it fails.
+func (log *kvSagaLog) GetActiveSagas() ([]string, error) {
+	var sagaIds []string
+	err := log.db.View(func(tx *kvstore.Tx) error {
+		sagaIds = tx.Keys(kvActiveBucket)
+		return nil
+	})
+	return sagaIds, err
+}
+
+// Returns the sagas matching the filter, in the order it asks.  Only the
+// job data of the sagas returned is read.
+func (log *kvSagaLog) ListSagas(filter saga.SagaFilter) ([]saga.SagaInfo, error) {
+	var infos []saga.SagaInfo
+	err := log.db.View(func(tx *kvstore.Tx) error {
+		for _, sagaId := range tx.Keys(kvSagasBucket) {
+			record, err := getKVSagaRecord(tx, sagaId)
+			if err != nil {
+				return err
+			}
+			info := saga.SagaInfo{
+				SagaId:    sagaId,
+				StartTime: time.Unix(0, record.StartTime),
+				Aborted:   record.Aborted,
+				Completed: record.Completed,
+				Labels:    record.Labels,
+			}
+			if filter.Matches(info) {
+				infos = append(infos, info)
+			}
+		}
+
+		infos = filter.Select(infos)
+		for i := range infos {
+			job, err := tx.Get(kvJobsBucket, infos[i].SagaId)
+			if err != nil {
+				return err
+			}
+			infos[i].Job = job
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if infos == nil {
+		infos = []saga.SagaInfo{}
+	}
+	return infos, nil
+}
+
+// Deletes ended sagas started longer than the retention ago, at most once
+// every retentionCheckInterval
+func (log *kvSagaLog) applyRetention(now time.Time) error {
+	cutoff, ok := log.retentionCutoff(now)
+	if !ok {
+		return nil
+	}
+	return log.db.Update(func(tx *kvstore.Tx) error {
+		return deleteKVSagasEndedBefore(tx, cutoff)
+	})
+}
+
+// Returns the start time, in unix nanos, before which ended sagas are
+// deleted, and false if the retention isn't due to be applied
+func (log *kvSagaLog) retentionCutoff(now time.Time) (int64, bool) {
+	log.retentionMutex.Lock()
+	defer log.retentionMutex.Unlock()
+	if log.retention <= 0 || now.Sub(log.lastRetention) < retentionCheckInterval {
+		return 0, false
+	}
+	log.lastRetention = now
+	return now.Add(-log.retention).UnixNano(), true
+}
+
+// Starts the saga, or starts it over, dropping its old messages if it ended
+func startKVSaga(tx *kvstore.Tx, sagaId string, job []byte, labels map[string]string, startTime time.Time) error {
+	record, err := getKVSagaRecord(tx, sagaId)
+	if err != nil {
+		return err
+	}
+	if record == nil || record.Completed {
+		if record != nil {
+			if err := tx.Delete(kvEndedBucket, kvEndedKey(sagaId, record)); err != nil {
+				return err
+			}
+		}
+		record = &kvSagaRecord{}
+	}
+	record.StartTime = startTime.UnixNano()
+	record.Labels = labels
+
+	if err := tx.Put(kvJobsBucket, sagaId, job); err != nil {
+		return err
+	}
+	if err := tx.Put(kvActiveBucket, sagaId, nil); err != nil {
+		return err
+	}
+	return putKVMessage(tx, record, saga.MakeStartSagaMessage(sagaId, job))
+}
+
+// Logs a message of a started saga, compacting its messages if it ends.
+// Returns false if the saga was never started.
+func logKVMessage(tx *kvstore.Tx, message saga.SagaMessage) (bool, error) {
+	record, err := getKVSagaRecord(tx, message.SagaId)
+	if err != nil || record == nil {
+		return false, err
+	}
+
+	switch message.MsgType {
+	case saga.AbortSaga:
+		record.Aborted = true
+	case saga.EndSaga:
+		record.Completed = true
+		if err := tx.Delete(kvActiveBucket, message.SagaId); err != nil {
+			return true, err
+		}
+	}
+	if err := putKVMessage(tx, record, message); err != nil {
+		return true, err
+	}
+	if record.Completed {
+		return true, compactKVSaga(tx, message.SagaId, record)
+	}
+	return true, nil
+}
+
+// Deletes the ended sagas started before the cutoff, in unix nanos
+func deleteKVSagasEndedBefore(tx *kvstore.Tx, cutoff int64) error {
+	// the ended sagas are keyed in order of start time
+	for _, key := range tx.Keys(kvEndedBucket) {
+		startTime, sagaId, ok := parseKVEndedKey(key)
+		if ok && startTime >= cutoff {
+			break
+		}
+		if err := tx.Delete(kvEndedBucket, key); err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := tx.Delete(kvSagasBucket, sagaId); err != nil {
+			return err
+		}
+		if err := tx.Delete(kvJobsBucket, sagaId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func kvMessagesBucket(sagaId string) string {
+	return kvMessagesBucketPrefix + sagaId
+}
+
+// The key of an ended saga's messages, "<start time in hex>/<saga id>"
+func kvEndedKey(sagaId string, record *kvSagaRecord) string {
+	return fmt.Sprintf("%016x/%s", record.StartTime, sagaId)
+}
+
+func parseKVEndedKey(key string) (startTime int64, sagaId string, ok bool) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return 0, "", false
+	}
+	start, err := strconv.ParseUint(parts[0], 16, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return int64(start), parts[1], true
+}
+
+// Returns the saga's messages, those moved on ending followed by any still
+// kept one per key
+func getKVMessages(tx *kvstore.Tx, sagaId string, record *kvSagaRecord) ([]archivedMessage, error) {
+	var msgs []archivedMessage
+	if record.Completed {
+		value, err := tx.Get(kvEndedBucket, kvEndedKey(sagaId, record))
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			if err := json.Unmarshal(value, &msgs); err != nil {
+				return nil, saga.NewCorruptedSagaLogError(sagaId,
+					fmt.Sprintf("Error Parsing Messages, Error: %v", err))
+			}
+		}
+	}
+
+	bucket := kvMessagesBucket(sagaId)
+	for _, key := range tx.Keys(bucket) {
+		value, err := tx.Get(bucket, key)
+		if err != nil {
+			return nil, err
+		}
+		var msg archivedMessage
+		if err := json.Unmarshal(value, &msg); err != nil {
+			return nil, saga.NewCorruptedSagaLogError(sagaId,
+				fmt.Sprintf("Error Parsing Message %v, Error: %v", key, err))
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// Moves the messages of a saga that ended into one value
+func compactKVSaga(tx *kvstore.Tx, sagaId string, record *kvSagaRecord) error {
+	msgs, err := getKVMessages(tx, sagaId, record)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(msgs)
+	if err != nil {
+		return err
+	}
+	if err := tx.Put(kvEndedBucket, kvEndedKey(sagaId, record), value); err != nil {
+		return err
+	}
+	return deleteKVMessages(tx, sagaId)
+}
+
+// Deletes the messages of a saga kept one per key
+func deleteKVMessages(tx *kvstore.Tx, sagaId string) error {
+	bucket := kvMessagesBucket(sagaId)
+	for _, key := range tx.Keys(bucket) {
+		if err := tx.Delete(bucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compacts the ended sagas whose messages are still kept one per key, from
+// a store written before they were compacted on ending
+func compactKVEndedSagas(tx *kvstore.Tx) error {
+	active := make(map[string]bool)
+	for _, sagaId := range tx.Keys(kvActiveBucket) {
+		active[sagaId] = true
+	}
+	for _, sagaId := range tx.Keys(kvSagasBucket) {
+		if active[sagaId] || len(tx.Keys(kvMessagesBucket(sagaId))) == 0 {
+			continue
+		}
+		record, err := getKVSagaRecord(tx, sagaId)
+		if err != nil {
+			return err
+		}
+		if err := compactKVSaga(tx, sagaId, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the saga's record, nil if it was never started
+func getKVSagaRecord(tx *kvstore.Tx, sagaId string) (*kvSagaRecord, error) {
+	value, err := tx.Get(kvSagasBucket, sagaId)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	var record kvSagaRecord
+	if err := json.Unmarshal(value, &record); err != nil {
+		return nil, saga.NewCorruptedSagaLogError(sagaId,
+			fmt.Sprintf("Error Parsing Saga Record, Error: %v", err))
+	}
+	return &record, nil
+}
+
+// Adds the message after the saga's other messages and writes its record
+func putKVMessage(tx *kvstore.Tx, record *kvSagaRecord, msg saga.SagaMessage) error {
+	msgAsBytes, err := json.Marshal(archivedMessage{MsgType: msg.MsgType, TaskId: msg.TaskId, Data: msg.Data})
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%016x", record.Messages)
+	if err := tx.Put(kvMessagesBucket(msg.SagaId), key, msgAsBytes); err != nil {
+		return err
+	}
+	record.Messages++
+
+	recordAsBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return tx.Put(kvSagasBucket, msg.SagaId, recordAsBytes)
+}
@@ -0,0 +1,136 @@
+package sagalogs
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/common/kvstore"
+	"github.com/scootdev/scoot/saga"
+)
+
+func openKVSagaLog() (saga.SagaLog, error) {
+	if err := os.MkdirAll(getDirName(), os.ModePerm); err != nil {
+		return nil, err
+	}
+	return MakeKVSagaLog(path.Join(getDirName(), "sagas.db"))
+}
+
+func TestKVSagaLog_StartSaga(t *testing.T) {
+	defer testCleanup(t)
+	testStartSaga(t, openKVSagaLog)
+}
+
+func TestKVSagaLog_StartSagaTwice(t *testing.T) {
+	defer testCleanup(t)
+	testStartSaga_Twice(t, openKVSagaLog)
+}
+
+func TestKVSagaLog_FullSaga(t *testing.T) {
+	defer testCleanup(t)
+	testFullSaga(t, openKVSagaLog)
+}
+
+func TestKVSagaLog_GetMessages_SagaDoesNotExist(t *testing.T) {
+	defer testCleanup(t)
+	testGetMessages_SagaDoesNotExist(t, openKVSagaLog)
+}
+
+func TestKVSagaLog_ListSagas(t *testing.T) {
+	defer testCleanup(t)
+	testListSagas(t, openKVSagaLog, nil)
+}
+
+func TestKVSagaLog_ListSagas_Filter(t *testing.T) {
+	defer testCleanup(t)
+	testListSagas_Filter(t, openKVSagaLog)
+}
+
+func TestKVSagaLog_ListSagas_Select(t *testing.T) {
+	defer testCleanup(t)
+	testListSagas_Select(t, openKVSagaLog)
+}
+
+func TestKVSagaLog_ActiveSagas(t *testing.T) {
+	defer testCleanup(t)
+	slog, _ := openKVSagaLog()
+
+	slog.StartSaga("ended", []byte("job1"), nil)
+	slog.LogMessage(saga.MakeEndSagaMessage("ended"))
+	slog.StartSaga("active", []byte("job2"), nil)
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("not_started")); err == nil {
+		t.Errorf("Expected an error logging to a saga that wasn't started")
+	}
+
+	restarted, err := openKVSagaLog()
+	if err != nil {
+		t.Fatalf("Unexpected Error reopening SagaLog %v", err)
+	}
+	for _, l := range []saga.SagaLog{slog, restarted} {
+		active, err := l.GetActiveSagas()
+		if err != nil {
+			t.Fatalf("Unexpected Error Getting Active Sagas %v", err)
+		}
+		if !reflect.DeepEqual(active, []string{"active"}) {
+			t.Errorf("Expected only the saga that hasn't ended to be active, got %v", active)
+		}
+	}
+
+	// starting the ended saga over drops its old messages
+	restarted.StartSaga("ended", []byte("job3"), nil)
+	msgs, _ := restarted.GetMessages("ended")
+	expected := []saga.SagaMessage{saga.MakeStartSagaMessage("ended", []byte("job3"))}
+	if !reflect.DeepEqual(msgs, expected) {
+		t.Errorf("Expected messages %+v, got %+v", expected, msgs)
+	}
+	if !isSagaInActiveList("ended", restarted) {
+		t.Errorf("Expected the restarted saga to be active")
+	}
+}
+
+func TestKVSagaLog_EndedSagasCompacted(t *testing.T) {
+	defer testCleanup(t)
+	os.MkdirAll(getDirName(), os.ModePerm)
+	slog, err := MakeKVSagaLogWithRetention(path.Join(getDirName(), "sagas.db"), time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected Error creating KVSagaLog %v", err)
+	}
+
+	slog.StartSaga("ended", []byte("job1"), nil)
+	slog.LogMessage(saga.MakeStartTaskMessage("ended", "task1", []byte("data")))
+	slog.LogMessage(saga.MakeEndSagaMessage("ended"))
+	slog.StartSaga("active", []byte("job2"), nil)
+
+	var messageKeys, endedKeys int
+	slog.db.View(func(tx *kvstore.Tx) error {
+		messageKeys = len(tx.Keys(kvMessagesBucket("ended")))
+		endedKeys = len(tx.Keys(kvEndedBucket))
+		return nil
+	})
+	if messageKeys != 0 || endedKeys != 1 {
+		t.Errorf("Expected the ended saga's messages in one key, got %v message keys, %v ended", messageKeys, endedKeys)
+	}
+	expected := []saga.SagaMessage{
+		saga.MakeStartSagaMessage("ended", []byte("job1")),
+		saga.MakeStartTaskMessage("ended", "task1", []byte("data")),
+		saga.MakeEndSagaMessage("ended"),
+	}
+	if msgs, _ := slog.GetMessages("ended"); !reflect.DeepEqual(msgs, expected) {
+		t.Errorf("Expected messages %+v, got %+v", expected, msgs)
+	}
+
+	// old ended sagas are deleted, those that haven't ended are kept
+	slog.lastRetention = time.Time{}
+	if err := slog.applyRetention(time.Now().Add(2 * time.Hour)); err != nil {
+		t.Fatalf("Unexpected Error applying retention %v", err)
+	}
+	if msgs, _ := slog.GetMessages("ended"); msgs != nil {
+		t.Errorf("Expected the old saga to be deleted, got %+v", msgs)
+	}
+	infos, _ := slog.ListSagas(saga.SagaFilter{})
+	if len(infos) != 1 || infos[0].SagaId != "active" {
+		t.Errorf("Expected only the active saga to be listed, got %+v", infos)
+	}
+}
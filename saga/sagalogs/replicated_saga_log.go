@@ -0,0 +1,279 @@
+package sagalogs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/scootdev/scoot/common/kvstore"
+	"github.com/scootdev/scoot/common/raft"
+	"github.com/scootdev/scoot/saga"
+)
+
+// Saga Log replicated across a group of schedulers with raft, see
+// common/raft.  Each message is an entry in the raft log, and every replica
+// applies the committed entries to its copy of the sagas, kept in the same
+// store as its raft log with the same layout as a KVSagaLog.  Once applied
+// the entries are dropped from the raft log, and sagas that ended are
+// compacted and deleted after the retention, so neither the log nor the
+// sagas are held in memory.
+//
+// Only the leader logs messages, the other replicas return a retryable
+// InternalLogError.  Reads are served from this replica's copy, which on the
+// leader has every message logged so far, and on the others may be behind.
+type replicatedSagaLog struct {
+	node *raft.Node
+
+	// reads this replica's copy of the sagas, and decides when the
+	// retention is due
+	sagas *kvSagaLog
+}
+
+// An entry in the raft log, logging a message or, if DeleteEndedBefore is
+// set, deleting the ended sagas started before it so every replica deletes
+// the same sagas
+type replicatedSagaEntry struct {
+	SagaId    string               `json:"id"`
+	MsgType   saga.SagaMessageType `json:"type"`
+	TaskId    string               `json:"task,omitempty"`
+	Data      []byte               `json:"data,omitempty"`
+	StartTime int64                `json:"start,omitempty"` // unix nanos, of a StartSaga
+	Labels    map[string]string    `json:"labels,omitempty"`
+
+	DeleteEndedBefore int64 `json:"deleteEndedBefore,omitempty"` // unix nanos
+}
+
+// A saga in a snapshot of the sagas
+type replicatedSagaSnapshot struct {
+	SagaId   string            `json:"id"`
+	Record   kvSagaRecord      `json:"record"`
+	Job      []byte            `json:"job,omitempty"`
+	Messages []archivedMessage `json:"messages"`
+}
+
+// Creates a ReplicatedSagaLog whose replica of the raft log and the sagas is
+// kept in the specified store, sending RPCs to the other replicas over
+// transport.  The RPCs sent to this replica must be passed to its Handler.
+// Sagas are kept forever.
+func MakeReplicatedSagaLog(config raft.Config, db *kvstore.DB, transport raft.Transport) (*replicatedSagaLog, error) {
+	return MakeReplicatedSagaLogWithRetention(config, db, transport, 0)
+}
+
+// Creates a ReplicatedSagaLog like MakeReplicatedSagaLog, which deletes
+// sagas that ended once they were started more than retention ago.  A
+// retention of 0 keeps sagas forever.
+func MakeReplicatedSagaLogWithRetention(
+	config raft.Config,
+	db *kvstore.DB,
+	transport raft.Transport,
+	retention time.Duration) (*replicatedSagaLog, error) {
+	sagas, err := newKVSagaLog(fmt.Sprintf("replica %v", config.Id), db, retention)
+	if err != nil {
+		return nil, err
+	}
+	slog := &replicatedSagaLog{sagas: sagas}
+	node, err := raft.NewNode(config, db, transport, slog)
+	if err != nil {
+		return nil, err
+	}
+	slog.node = node
+	return slog, nil
+}
+
+// Returns the handler of the raft RPCs sent to this replica
+func (log *replicatedSagaLog) Handler() raft.Handler {
+	return log.node
+}
+
+// Stops this replica, it no longer takes part in the group
+func (log *replicatedSagaLog) Stop() {
+	log.node.Stop()
+}
+
+// Blocks until this replica is the leader and has applied the messages
+// logged by the previous leaders.  Returns a channel that's closed once it's
+// no longer the leader.
+func (log *replicatedSagaLog) WaitForLeadership() <-chan struct{} {
+	return log.node.WaitForLeadership()
+}
+
+// Log a Start Saga Message message to the log.  Starting a saga that ended
+// starts it over, dropping its old messages.
+// Returns an error if it fails.
+func (log *replicatedSagaLog) StartSaga(sagaId string, job []byte, labels map[string]string) error {
+	return log.propose(replicatedSagaEntry{
+		SagaId:    sagaId,
+		MsgType:   saga.StartSaga,
+		Data:      job,
+		StartTime: time.Now().UnixNano(),
+		Labels:    labels,
+	})
+}
+
+// Update the State of the Saga by Logging a message.
+// Returns an error if it fails.
+func (log *replicatedSagaLog) LogMessage(message saga.SagaMessage) error {
+	var record *kvSagaRecord
+	err := log.sagas.db.View(func(tx *kvstore.Tx) error {
+		var err error
+		record, err = getKVSagaRecord(tx, message.SagaId)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return errors.New(fmt.Sprintf("Saga: %s is not Started yet.", message.SagaId))
+	}
+
+	err = log.propose(replicatedSagaEntry{
+		SagaId:  message.SagaId,
+		MsgType: message.MsgType,
+		TaskId:  message.TaskId,
+		Data:    message.Data,
+	})
+	if err != nil || message.MsgType != saga.EndSaga {
+		return err
+	}
+	if cutoff, ok := log.sagas.retentionCutoff(time.Now()); ok {
+		if err := log.propose(replicatedSagaEntry{DeleteEndedBefore: cutoff}); err != nil {
+			logCompactionError(log.sagas.fileName, err)
+		}
+	}
+	return nil
+}
+
+// Appends the entry to the raft log, returns once it's committed and applied
+func (log *replicatedSagaLog) propose(entry replicatedSagaEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := log.node.Propose(data); err != nil {
+		return saga.NewInternalLogError(
+			fmt.Sprintf("Error replicating message for saga %v, leader %q, Error: %v", entry.SagaId, log.node.Leader(), err))
+	}
+	return nil
+}
+
+// Applies a committed entry to this replica's copy of the sagas
+func (log *replicatedSagaLog) Apply(tx *kvstore.Tx, data []byte) error {
+	entry, ok := readReplicatedSagaEntry(data)
+	if !ok {
+		return nil
+	}
+	if entry.DeleteEndedBefore != 0 {
+		return deleteKVSagasEndedBefore(tx, entry.DeleteEndedBefore)
+	}
+	if entry.MsgType == saga.StartSaga {
+		return startKVSaga(tx, entry.SagaId, entry.Data, entry.Labels, time.Unix(0, entry.StartTime))
+	}
+
+	// checked before proposing, only a saga started over or deleted since
+	// can be missing
+	_, err := logKVMessage(tx, saga.SagaMessage{
+		SagaId:  entry.SagaId,
+		MsgType: entry.MsgType,
+		TaskId:  entry.TaskId,
+		Data:    entry.Data,
+	})
+	return err
+}
+
+// Returns every saga in this replica's copy, for a replica that's missing
+// entries dropped from the raft log
+func (log *replicatedSagaLog) Snapshot(tx *kvstore.Tx) ([]byte, error) {
+	sagas := []replicatedSagaSnapshot{}
+	for _, sagaId := range tx.Keys(kvSagasBucket) {
+		record, err := getKVSagaRecord(tx, sagaId)
+		if err != nil {
+			return nil, err
+		}
+		job, err := tx.Get(kvJobsBucket, sagaId)
+		if err != nil {
+			return nil, err
+		}
+		msgs, err := getKVMessages(tx, sagaId, record)
+		if err != nil {
+			return nil, err
+		}
+		sagas = append(sagas, replicatedSagaSnapshot{SagaId: sagaId, Record: *record, Job: job, Messages: msgs})
+	}
+	return json.Marshal(sagas)
+}
+
+// Replaces this replica's copy of the sagas with a snapshot
+func (log *replicatedSagaLog) Restore(tx *kvstore.Tx, snapshot []byte) error {
+	var sagas []replicatedSagaSnapshot
+	if err := json.Unmarshal(snapshot, &sagas); err != nil {
+		return err
+	}
+
+	for _, sagaId := range tx.Keys(kvSagasBucket) {
+		if err := deleteKVMessages(tx, sagaId); err != nil {
+			return err
+		}
+	}
+	for _, bucket := range []string{kvSagasBucket, kvJobsBucket, kvActiveBucket, kvEndedBucket} {
+		for _, key := range tx.Keys(bucket) {
+			if err := tx.Delete(bucket, key); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, s := range sagas {
+		record := s.Record
+		record.Messages = 0
+		for _, msg := range s.Messages {
+			err := putKVMessage(tx, &record, saga.SagaMessage{
+				SagaId:  s.SagaId,
+				MsgType: msg.MsgType,
+				TaskId:  msg.TaskId,
+				Data:    msg.Data,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := tx.Put(kvJobsBucket, s.SagaId, s.Job); err != nil {
+			return err
+		}
+		if record.Completed {
+			if err := compactKVSaga(tx, s.SagaId, &record); err != nil {
+				return err
+			}
+		} else if err := tx.Put(kvActiveBucket, s.SagaId, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readReplicatedSagaEntry(data []byte) (replicatedSagaEntry, bool) {
+	var entry replicatedSagaEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		log.Printf("ERROR: skipping unreadable saga log entry %q: %v", data, err)
+		return entry, false
+	}
+	return entry, true
+}
+
+// Returns all of the messages logged so far for the
+// specified saga.
+func (log *replicatedSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	return log.sagas.GetMessages(sagaId)
+}
+
+// Returns the ids of the sagas that haven't ended.
+// Returns an error if it fails.
+func (log *replicatedSagaLog) GetActiveSagas() ([]string, error) {
+	return log.sagas.GetActiveSagas()
+}
+
+// Returns the sagas matching the filter, in the order it asks.
+func (log *replicatedSagaLog) ListSagas(filter saga.SagaFilter) ([]saga.SagaInfo, error) {
+	return log.sagas.ListSagas(filter)
+}
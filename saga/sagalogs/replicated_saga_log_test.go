@@ -0,0 +1,248 @@
+package sagalogs
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/common/kvstore"
+	"github.com/scootdev/scoot/common/raft"
+	"github.com/scootdev/scoot/saga"
+)
+
+const testElectionTimeout = 50 * time.Millisecond
+
+// Starts a replica of a group on the network, with its raft log in the
+// sagas directory
+func startReplica(t *testing.T, network *raft.LoopbackNetwork, id string, peers []string) (*replicatedSagaLog, *kvstore.DB) {
+	config := raft.Config{Id: id, Peers: peers, ElectionTimeout: testElectionTimeout}
+	return startReplicaWithConfig(t, network, config, 0)
+}
+
+func startReplicaWithConfig(
+	t *testing.T,
+	network *raft.LoopbackNetwork,
+	config raft.Config,
+	retention time.Duration) (*replicatedSagaLog, *kvstore.DB) {
+	if err := os.MkdirAll(getDirName(), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	db, err := kvstore.Open(path.Join(getDirName(), config.Id))
+	if err != nil {
+		t.Fatalf("Unexpected Error opening store %v", err)
+	}
+	id := config.Id
+	slog, err := MakeReplicatedSagaLogWithRetention(config, db, network.Transport(id), retention)
+	if err != nil {
+		t.Fatalf("Unexpected Error creating ReplicatedSagaLog %v", err)
+	}
+	network.Register(id, slog.Handler())
+	return slog, db
+}
+
+// Returns an opener of a group of one replica, which is restarted each
+// time it's reopened
+func makeSingleReplicaOpener(t *testing.T) (sagaLogOpener, func()) {
+	network := raft.NewLoopbackNetwork()
+	var slog *replicatedSagaLog
+	var db *kvstore.DB
+	stop := func() {
+		if slog != nil {
+			slog.Stop()
+			db.Close()
+		}
+	}
+	open := func() (saga.SagaLog, error) {
+		stop()
+		slog, db = startReplica(t, network, "replica0", nil)
+		slog.WaitForLeadership()
+		return slog, nil
+	}
+	return open, stop
+}
+
+func TestReplicatedSagaLog_Suite(t *testing.T) {
+	tests := map[string]func(t *testing.T, open sagaLogOpener){
+		"StartSaga":                testStartSaga,
+		"StartSagaTwice":           testStartSaga_Twice,
+		"FullSaga":                 testFullSaga,
+		"GetMessages_DoesNotExist": testGetMessages_SagaDoesNotExist,
+		"ListSagas_Filter":         testListSagas_Filter,
+		"ListSagas_Select":         testListSagas_Select,
+		"ListSagas": func(t *testing.T, open sagaLogOpener) {
+			testListSagas(t, open, nil)
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer testCleanup(t)
+			open, stop := makeSingleReplicaOpener(t)
+			defer stop()
+			test(t, open)
+		})
+	}
+}
+
+func TestReplicatedSagaLog_Failover(t *testing.T) {
+	defer testCleanup(t)
+	network := raft.NewLoopbackNetwork()
+	ids := []string{"replica0", "replica1", "replica2"}
+	logs := make(map[string]*replicatedSagaLog)
+	for _, id := range ids {
+		var peers []string
+		for _, peer := range ids {
+			if peer != id {
+				peers = append(peers, peer)
+			}
+		}
+		slog, db := startReplica(t, network, id, peers)
+		defer db.Close()
+		defer slog.Stop()
+		logs[id] = slog
+	}
+
+	leaderId, lost := waitForLeader(t, logs, "")
+	leader := logs[leaderId]
+	leader.StartSaga("saga1", []byte("job1"), nil)
+	leader.LogMessage(saga.MakeStartTaskMessage("saga1", "task1", []byte("started")))
+	leader.StartSaga("saga2", []byte("job2"), nil)
+	leader.LogMessage(saga.MakeEndSagaMessage("saga2"))
+
+	for id, slog := range logs {
+		if id == leaderId {
+			continue
+		}
+		err := slog.StartSaga("saga3", nil, nil)
+		if _, ok := err.(saga.InternalLogError); !ok {
+			t.Errorf("Expected an InternalLogError logging to a follower, got %v", err)
+		}
+	}
+
+	// the leader crashes or is cut off, another replica takes over with the
+	// messages it logged
+	network.Disconnect(leaderId)
+	select {
+	case <-lost:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the disconnected leader to lose its leadership")
+	}
+	newLeaderId, _ := waitForLeader(t, logs, leaderId)
+	newLeader := logs[newLeaderId]
+
+	active, _ := newLeader.GetActiveSagas()
+	if !reflect.DeepEqual(active, []string{"saga1"}) {
+		t.Errorf("Expected saga1 to be active on the new leader, got %v", active)
+	}
+	expected := []saga.SagaMessage{
+		saga.MakeStartSagaMessage("saga1", []byte("job1")),
+		saga.MakeStartTaskMessage("saga1", "task1", []byte("started")),
+		saga.MakeEndTaskMessage("saga1", "task1", []byte("done")),
+	}
+	if err := newLeader.LogMessage(expected[2]); err != nil {
+		t.Fatalf("Unexpected Error logging to the new leader %v", err)
+	}
+	msgs, _ := newLeader.GetMessages("saga1")
+	if !reflect.DeepEqual(msgs, expected) {
+		t.Errorf("Expected messages %+v, got %+v", expected, msgs)
+	}
+}
+
+// A replica that falls behind is sent a snapshot of the sagas once the
+// leader has dropped the entries it's missing, and ended sagas are deleted
+// on every replica after the retention
+func TestReplicatedSagaLog_Snapshot(t *testing.T) {
+	defer testCleanup(t)
+	network := raft.NewLoopbackNetwork()
+	ids := []string{"replica0", "replica1", "replica2"}
+	logs := make(map[string]*replicatedSagaLog)
+	for _, id := range ids {
+		var peers []string
+		for _, peer := range ids {
+			if peer != id {
+				peers = append(peers, peer)
+			}
+		}
+		config := raft.Config{Id: id, Peers: peers, ElectionTimeout: testElectionTimeout, RetainedEntries: 2}
+		slog, db := startReplicaWithConfig(t, network, config, time.Hour)
+		defer db.Close()
+		defer slog.Stop()
+		logs[id] = slog
+	}
+
+	leaderId, _ := waitForLeader(t, logs, "")
+	leader := logs[leaderId]
+	var behindId string
+	for id := range logs {
+		if id != leaderId {
+			behindId = id
+			break
+		}
+	}
+	network.Disconnect(behindId)
+
+	// a saga started long ago, deleted once it ends as it is past the retention
+	leader.propose(replicatedSagaEntry{SagaId: "old", MsgType: saga.StartSaga, StartTime: 1})
+	leader.LogMessage(saga.MakeEndSagaMessage("old"))
+	for _, sagaId := range []string{"saga1", "saga2", "saga3"} {
+		leader.StartSaga(sagaId, []byte(sagaId), map[string]string{"tenant": "a"})
+		leader.LogMessage(saga.MakeStartTaskMessage(sagaId, "task1", []byte("started")))
+	}
+	leader.LogMessage(saga.MakeEndSagaMessage("saga1"))
+
+	network.Reconnect(behindId)
+	expected, _ := leader.ListSagas(saga.SagaFilter{})
+	if len(expected) != 3 {
+		t.Fatalf("Expected the old saga to be deleted, got %+v", expected)
+	}
+	waitForCondition(t, "the replica that fell behind to catch up", func() bool {
+		infos, _ := logs[behindId].ListSagas(saga.SagaFilter{})
+		return reflect.DeepEqual(infos, expected)
+	})
+	for _, sagaId := range []string{"saga1", "saga2"} {
+		expectedMsgs, _ := leader.GetMessages(sagaId)
+		msgs, _ := logs[behindId].GetMessages(sagaId)
+		if !reflect.DeepEqual(msgs, expectedMsgs) {
+			t.Errorf("Expected messages %+v, got %+v", expectedMsgs, msgs)
+		}
+	}
+	active, _ := logs[behindId].GetActiveSagas()
+	if !reflect.DeepEqual(active, []string{"saga2", "saga3"}) {
+		t.Errorf("Expected saga2 and saga3 to be active, got %v", active)
+	}
+}
+
+func waitForCondition(t *testing.T, what string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %v", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// Returns the replica that's leader once one other than exclude is, and the
+// channel closed when it loses its leadership
+func waitForLeader(t *testing.T, logs map[string]*replicatedSagaLog, exclude string) (string, <-chan struct{}) {
+	type leadership struct {
+		id   string
+		lost <-chan struct{}
+	}
+	ch := make(chan leadership, len(logs))
+	for id, slog := range logs {
+		if id != exclude {
+			go func(id string, slog *replicatedSagaLog) {
+				ch <- leadership{id, slog.WaitForLeadership()}
+			}(id, slog)
+		}
+	}
+	select {
+	case l := <-ch:
+		return l.id, l.lost
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for a leader other than %q", exclude)
+	}
+	return "", nil
+}
@@ -0,0 +1,236 @@
+package sagalogs
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/saga"
+)
+
+// Tests every SagaLog that's stored durably passes.  Each takes a function
+// that opens the log being tested, calling it again reopens the same log as
+// after a restart.
+type sagaLogOpener func() (saga.SagaLog, error)
+
+func testStartSaga(t *testing.T, open sagaLogOpener) {
+	sagaId := "saga1"
+
+	slog, err := open()
+	if err != nil {
+		t.Errorf("Unexpected Error Returned %v", err)
+	}
+
+	data := []byte{0, 1, 2, 3, 4, 5}
+	err = slog.StartSaga(sagaId, data, nil)
+	if err != nil {
+		t.Errorf("Unexpected Error starting Saga")
+	}
+
+	msgs, err := slog.GetMessages(sagaId)
+	if err != nil {
+		t.Errorf("Unexpected Error Getting Messages %v", err)
+	}
+
+	if len(msgs) != 1 {
+		t.Errorf("Expected 1 message for saga, not %v, Messages: %+v", len(msgs), msgs)
+	}
+
+	if msgs[0].MsgType != saga.StartSaga {
+		t.Errorf("Unexpected Message Type.  Expected: StartSaga, Actual: %v", msgs[0].MsgType)
+	}
+
+	if !bytes.Equal(data, msgs[0].Data) {
+		t.Errorf("Expected Start Saga Message Data to be: %v, Actual: %v", data, msgs[0].Data)
+	}
+
+	if !isSagaInActiveList(sagaId, slog) {
+		t.Errorf("Expected Saga to be in active list")
+	}
+}
+
+func testStartSaga_Twice(t *testing.T, open sagaLogOpener) {
+	sagaId := "start_saga_called_twice"
+	data1 := []byte{0, 1, 2, 3, 4, 5}
+	data2 := []byte{6, 7, 8, 9}
+
+	slog, _ := open()
+	slog.StartSaga(sagaId, data1, nil)
+	err := slog.StartSaga(sagaId, data2, nil)
+	if err != nil {
+		t.Errorf("Unexpected Error Calling Start Saga Twice %v", err)
+	}
+
+	msgs, err := slog.GetMessages(sagaId)
+	if err != nil {
+		t.Errorf("Unexpected Error Getting Messages %v", err)
+	}
+
+	if len(msgs) != 2 {
+		t.Errorf("Expected 1 message for saga, not %v, Messages: %+v", len(msgs), msgs)
+	}
+
+	if !isSagaInActiveList(sagaId, slog) {
+		t.Errorf("Expected Saga to be in Active List")
+	}
+}
+
+func testFullSaga(t *testing.T, open sagaLogOpener) {
+	sagaId := "fullsaga"
+
+	slog, _ := open()
+	jobData := []byte{0, 1, 2, 3, 4, 5}
+	slog.StartSaga(sagaId, jobData, nil)
+
+	loggedMsgs := []saga.SagaMessage{
+		saga.MakeStartSagaMessage(sagaId, jobData),
+		saga.MakeStartTaskMessage(sagaId, "task1", []byte("run task 1")),
+		saga.MakeEndTaskMessage(sagaId, "task1", []byte("success")),
+		saga.MakeAbortSagaMessage(sagaId),
+		saga.MakeStartCompTaskMessage(sagaId, "task1", []byte("rollingback task1")),
+		saga.MakeEndCompTaskMessage(sagaId, "task1", []byte("finished rollingback task1")),
+		saga.MakeEndSagaMessage(sagaId),
+	}
+
+	for i, msg := range loggedMsgs {
+		// skip start saga message we already did it
+		if i == 0 {
+			continue
+		}
+
+		err := slog.LogMessage(msg)
+		if err != nil {
+			t.Fatalf("Unexpected Error Logging Msg: %+v, Error: %v", msg, err)
+		}
+
+	}
+
+	rtnMsgs, err := slog.GetMessages(sagaId)
+	if err != nil {
+		t.Fatalf("Unexpected Error returned from GetMessages. %v", err)
+	}
+
+	if len(rtnMsgs) != len(loggedMsgs) {
+		t.Fatalf("Expected GetMessages to return %v messages.  Actual %v.  Msgs %+v",
+			len(loggedMsgs), len(rtnMsgs), rtnMsgs)
+	}
+
+	// we expect the messages to be returned in the same order they are logged
+	for j, rtnMsg := range rtnMsgs {
+		if !reflect.DeepEqual(loggedMsgs[j], rtnMsg) {
+			t.Errorf("Expected Logged Message and Returned Message to be Equal.  Expected %v, Actual %v",
+				loggedMsgs[j], rtnMsg)
+		}
+	}
+}
+
+func testGetMessages_SagaDoesNotExist(t *testing.T, open sagaLogOpener) {
+	slog, _ := open()
+	msgs, err := slog.GetMessages("does_not_exist")
+
+	if err != nil {
+		t.Errorf("Unexpected Error Getting Messages %v", err)
+	}
+
+	if msgs != nil {
+		t.Errorf("Expeceted no messages to be returned %+v", msgs)
+	}
+}
+
+func testListSagas(t *testing.T, open sagaLogOpener, beforeRestart func()) {
+	slog, _ := open()
+
+	slog.StartSaga("saga1", []byte("job1"), nil)
+	slog.LogMessage(saga.MakeEndSagaMessage("saga1"))
+	slog.StartSaga("saga2", []byte("job2"), map[string]string{"tenant": "tenant2"})
+	slog.LogMessage(saga.MakeStartTaskMessage("saga2", "task1", []byte{1}))
+	slog.LogMessage(saga.MakeAbortSagaMessage("saga2"))
+	slog.StartSaga("saga3", []byte("job3"), nil)
+
+	expected := []saga.SagaInfo{
+		{SagaId: "saga1", Job: []byte("job1"), Completed: true},
+		{SagaId: "saga2", Job: []byte("job2"), Aborted: true, Labels: map[string]string{"tenant": "tenant2"}},
+		{SagaId: "saga3", Job: []byte("job3")},
+	}
+	check := func(l saga.SagaLog) {
+		infos, err := l.ListSagas(saga.SagaFilter{})
+		if err != nil {
+			t.Fatalf("Unexpected Error Listing Sagas %v", err)
+		}
+		if len(infos) != len(expected) {
+			t.Fatalf("Expected %v sagas, got %+v", len(expected), infos)
+		}
+		for i, info := range infos {
+			if info.StartTime.IsZero() {
+				t.Errorf("Expected saga %v to have a start time", info.SagaId)
+			}
+			info.StartTime = time.Time{}
+			if !reflect.DeepEqual(expected[i], info) {
+				t.Errorf("Expected saga %+v, got %+v", expected[i], info)
+			}
+		}
+	}
+
+	// checked before reopening, which may close the log
+	check(slog)
+	if beforeRestart != nil {
+		beforeRestart()
+	}
+	restarted, err := open()
+	if err != nil {
+		t.Fatalf("Unexpected Error reopening SagaLog %v", err)
+	}
+	check(restarted)
+}
+
+func testListSagas_Filter(t *testing.T, open sagaLogOpener) {
+	slog, _ := open()
+
+	slog.StartSaga("before", nil, nil)
+	time.Sleep(10 * time.Millisecond)
+	start := time.Now()
+	slog.StartSaga("during", nil, nil)
+	end := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	slog.StartSaga("after", nil, nil)
+
+	infos, err := slog.ListSagas(saga.SagaFilter{StartedAfter: start, StartedBefore: end})
+	if err != nil {
+		t.Fatalf("Unexpected Error Listing Sagas %v", err)
+	}
+	if len(infos) != 1 || infos[0].SagaId != "during" {
+		t.Errorf("Expected only saga during to be listed, got %+v", infos)
+	}
+}
+
+func testListSagas_Select(t *testing.T, open sagaLogOpener) {
+	slog, _ := open()
+
+	for i, tenant := range []string{"a", "b", "a", "a"} {
+		slog.StartSaga(fmt.Sprintf("saga%d", i), []byte{byte(i)}, map[string]string{"tenant": tenant})
+		time.Sleep(time.Millisecond)
+	}
+
+	filter := saga.SagaFilter{
+		Match: func(info saga.SagaInfo) bool {
+			if info.Job != nil {
+				t.Errorf("Expected saga %v to be matched without its job", info.SagaId)
+			}
+			return info.Labels["tenant"] == "a"
+		},
+		NewestFirst: true,
+		Limit:       2,
+	}
+	infos, err := slog.ListSagas(filter)
+	if err != nil {
+		t.Fatalf("Unexpected Error Listing Sagas %v", err)
+	}
+	if len(infos) != 2 || infos[0].SagaId != "saga3" || infos[1].SagaId != "saga2" {
+		t.Fatalf("Expected the 2 newest sagas of tenant a, got %+v", infos)
+	}
+	if !bytes.Equal(infos[0].Job, []byte{3}) || !bytes.Equal(infos[1].Job, []byte{2}) {
+		t.Errorf("Expected the listed sagas' jobs, got %+v", infos)
+	}
+}
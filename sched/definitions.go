@@ -15,6 +15,21 @@ type Job struct {
 	Def JobDefinition
 }
 
+// Labels of the saga of each job, so jobs can be listed without reading
+// their definitions
+const (
+	TenantLabel  = "tenant"
+	JobTypeLabel = "jobType"
+)
+
+// Returns the labels the saga of the job is started with
+func (j *Job) Labels() map[string]string {
+	return map[string]string{
+		TenantLabel:  j.Def.Tenant,
+		JobTypeLabel: j.Def.JobType,
+	}
+}
+
 // Serialize Job to binary slice, and error is
 // returned if the object cannot be Serialized
 func (j *Job) Serialize() ([]byte, error) {
@@ -43,11 +58,31 @@ func DeserializeJob(input []byte) (*Job, error) {
 type JobDefinition struct {
 	JobType string
 	Tasks   map[string]TaskDefinition
+
+	// Jobs with a higher priority have their tasks scheduled first
+	Priority int
+
+	// Tenant that submitted the job, the cluster is shared fairly between tenants
+	Tenant string
 }
 
 // Task is one task to run
 type TaskDefinition struct {
 	runner.Command
+
+	// Resources the task needs on the node it runs on
+	Resources Resources
+
+	// Ids of the tasks in the same job that must succeed before this task
+	// is run.  If one of them fails this task is skipped.
+	Dependencies []string
+
+	// Whether & when the task is run again if its run doesn't succeed
+	Retry RetryPolicy
+
+	// How long the task usually runs, 0 if unknown.  The scheduler starts a
+	// backup copy of a task that runs much longer than this.
+	ExpectedDuration time.Duration
 }
 
 // Status for Job & Tasks
@@ -71,6 +106,9 @@ const (
 	// Job/Task finished unsuccessfully all compensating actions
 	// have been applied.
 	RolledBack
+
+	// Task was not run because a task it depends on failed
+	Skipped
 )
 
 // transforms a thrift Job into a scheduler Job
@@ -92,18 +130,31 @@ func makeDomainJobFromThriftJob(thriftJob *schedthrift.Job) *Job {
 				EnvVars:    cmd.GetEnvVars(),
 				Timeout:    time.Duration(cmd.GetTimeout()),
 				SnapshotID: cmd.GetSnapshotId(),
+				Network:    cmd.GetNetwork(),
+				Outputs:    cmd.GetOutputs(),
+			}
+			resources := Resources{
+				CPUSlots:    int(task.GetCpuSlots()),
+				MemoryBytes: task.GetMemoryBytes(),
+			}
+			domainTasks[taskName] = TaskDefinition{
+				Command:      command,
+				Resources:    resources,
+				Dependencies: task.GetDependencies(),
+				Retry:        makeDomainRetryPolicyFromThrift(task.GetRetryPolicy()),
+
+				ExpectedDuration: time.Duration(task.GetExpectedDuration()),
 			}
-			domainTasks[taskName] = TaskDefinition{command}
 		}
 	}
 
-	jobType := ""
-	if thriftJobDef != nil {
-		jobType = thriftJobDef.GetJobType()
-	}
 	domainJobDef := JobDefinition{
-		JobType: jobType,
-		Tasks:   domainTasks,
+		Tasks: domainTasks,
+	}
+	if thriftJobDef != nil {
+		domainJobDef.JobType = thriftJobDef.GetJobType()
+		domainJobDef.Priority = int(thriftJobDef.GetPriority())
+		domainJobDef.Tenant = thriftJobDef.GetTenant()
 	}
 
 	return &Job{
@@ -126,8 +177,29 @@ func makeThriftJobFromDomainJob(domainJob *Job) (*schedthrift.Job, error) {
 			EnvVars:    domainTask.EnvVars,
 			Timeout:    &to,
 			SnapshotId: domainTask.SnapshotID,
+			Outputs:    domainTask.Outputs,
+		}
+		if domainTask.Network {
+			network := true
+			cmd.Network = &network
+		}
+		thriftTask := schedthrift.TaskDefinition{
+			Command:      &cmd,
+			Dependencies: domainTask.Dependencies,
+		}
+		if domainTask.Resources.CPUSlots != 0 {
+			cpuSlots := int32(domainTask.Resources.CPUSlots)
+			thriftTask.CpuSlots = &cpuSlots
+		}
+		if domainTask.Resources.MemoryBytes != 0 {
+			memoryBytes := domainTask.Resources.MemoryBytes
+			thriftTask.MemoryBytes = &memoryBytes
+		}
+		thriftTask.RetryPolicy = makeThriftRetryPolicyFromDomain(domainTask.Retry)
+		if domainTask.ExpectedDuration != 0 {
+			expected := int64(domainTask.ExpectedDuration)
+			thriftTask.ExpectedDuration = &expected
 		}
-		thriftTask := schedthrift.TaskDefinition{Command: &cmd}
 		thriftTasks[taskName] = &thriftTask
 	}
 
@@ -135,6 +207,14 @@ func makeThriftJobFromDomainJob(domainJob *Job) (*schedthrift.Job, error) {
 		JobType: &(*domainJob).Def.JobType,
 		Tasks:   thriftTasks,
 	}
+	if domainJob.Def.Priority != 0 {
+		priority := int32(domainJob.Def.Priority)
+		thriftJobDefinition.Priority = &priority
+	}
+	if domainJob.Def.Tenant != "" {
+		tenant := domainJob.Def.Tenant
+		thriftJobDefinition.Tenant = &tenant
+	}
 
 	thriftJob := schedthrift.Job{
 		ID:            domainJob.Id,
@@ -144,3 +224,52 @@ func makeThriftJobFromDomainJob(domainJob *Job) (*schedthrift.Job, error) {
 	return &thriftJob, nil
 
 }
+
+// transforms a thrift RetryPolicy into a scheduler RetryPolicy
+func makeDomainRetryPolicyFromThrift(thriftPolicy *schedthrift.RetryPolicy) RetryPolicy {
+	var policy RetryPolicy
+	if thriftPolicy == nil {
+		return policy
+	}
+
+	policy.MaxAttempts = int(thriftPolicy.GetMaxAttempts())
+	policy.InitialBackoff = time.Duration(thriftPolicy.GetInitialBackoff())
+	policy.MaxBackoff = time.Duration(thriftPolicy.GetMaxBackoff())
+	for _, state := range thriftPolicy.GetRetryableStates() {
+		policy.RetryableStates = append(policy.RetryableStates, runner.RunState(state))
+	}
+	for _, code := range thriftPolicy.GetRetryableExitCodes() {
+		policy.RetryableExitCodes = append(policy.RetryableExitCodes, int(code))
+	}
+	return policy
+}
+
+// converts a scheduler RetryPolicy into a thrift RetryPolicy, nil if it's
+// the zero value
+func makeThriftRetryPolicyFromDomain(policy RetryPolicy) *schedthrift.RetryPolicy {
+	if policy.MaxAttempts == 0 && policy.InitialBackoff == 0 && policy.MaxBackoff == 0 &&
+		len(policy.RetryableStates) == 0 && len(policy.RetryableExitCodes) == 0 {
+		return nil
+	}
+
+	thriftPolicy := schedthrift.NewRetryPolicy()
+	if policy.MaxAttempts != 0 {
+		maxAttempts := int32(policy.MaxAttempts)
+		thriftPolicy.MaxAttempts = &maxAttempts
+	}
+	if policy.InitialBackoff != 0 {
+		initialBackoff := int64(policy.InitialBackoff)
+		thriftPolicy.InitialBackoff = &initialBackoff
+	}
+	if policy.MaxBackoff != 0 {
+		maxBackoff := int64(policy.MaxBackoff)
+		thriftPolicy.MaxBackoff = &maxBackoff
+	}
+	for _, state := range policy.RetryableStates {
+		thriftPolicy.RetryableStates = append(thriftPolicy.RetryableStates, int32(state))
+	}
+	for _, code := range policy.RetryableExitCodes {
+		thriftPolicy.RetryableExitCodes = append(thriftPolicy.RetryableExitCodes, int32(code))
+	}
+	return thriftPolicy
+}
@@ -0,0 +1,101 @@
+package sched
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/scootdev/scoot/runner"
+)
+
+// Returns true if a task's run completed and its command exited successfully.
+// Tasks depending on a task whose run didn't succeed are skipped.
+func RunSucceeded(st runner.RunStatus) bool {
+	return st.State == runner.COMPLETE && st.ExitCode == 0
+}
+
+// Checks that the dependencies of the job's tasks form a DAG: every
+// dependency names another task in the job, and no task depends on
+// itself, directly or transitively.
+func (d JobDefinition) ValidateDependencies() error {
+	for taskId, task := range d.Tasks {
+		for _, dep := range task.Dependencies {
+			if dep == taskId {
+				return fmt.Errorf("task %v depends on itself", taskId)
+			}
+			if _, ok := d.Tasks[dep]; !ok {
+				return fmt.Errorf("task %v depends on unknown task %v", taskId, dep)
+			}
+		}
+	}
+
+	// depth first search for a back edge, visiting tasks in a fixed order
+	// so the reported cycle is deterministic
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var visit func(taskId string) error
+	visit = func(taskId string) error {
+		switch state[taskId] {
+		case visiting:
+			return fmt.Errorf("task %v is part of a dependency cycle", taskId)
+		case visited:
+			return nil
+		}
+		state[taskId] = visiting
+		for _, dep := range d.Tasks[taskId].Dependencies {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[taskId] = visited
+		return nil
+	}
+
+	var taskIds []string
+	for taskId := range d.Tasks {
+		taskIds = append(taskIds, taskId)
+	}
+	sort.Strings(taskIds)
+	for _, taskId := range taskIds {
+		if err := visit(taskId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the ids of the tasks that can't run because a task they depend on,
+// directly or transitively, is one of the specified failed tasks.
+func (d JobDefinition) SkippedTasks(failed map[string]bool) map[string]bool {
+	skipped := make(map[string]bool)
+	if len(failed) == 0 {
+		return skipped
+	}
+
+	// task id to the ids of the tasks depending on it
+	dependents := make(map[string][]string)
+	for taskId, task := range d.Tasks {
+		for _, dep := range task.Dependencies {
+			dependents[dep] = append(dependents[dep], taskId)
+		}
+	}
+
+	var toVisit []string
+	for taskId := range failed {
+		toVisit = append(toVisit, taskId)
+	}
+	for len(toVisit) > 0 {
+		taskId := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		for _, dependent := range dependents[taskId] {
+			if !skipped[dependent] {
+				skipped[dependent] = true
+				toVisit = append(toVisit, dependent)
+			}
+		}
+	}
+	return skipped
+}
@@ -0,0 +1,73 @@
+package sched
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/scootdev/scoot/runner"
+)
+
+// makes a JobDefinition from a map of task id to the ids it depends on
+func makeDAGJobDef(deps map[string][]string) JobDefinition {
+	def := JobDefinition{Tasks: make(map[string]TaskDefinition)}
+	for taskId, taskDeps := range deps {
+		def.Tasks[taskId] = TaskDefinition{Dependencies: taskDeps}
+	}
+	return def
+}
+
+func Test_ValidateDependencies(t *testing.T) {
+	tests := []struct {
+		deps  map[string][]string
+		valid bool
+	}{
+		{map[string][]string{"a": nil, "b": nil}, true},
+		{map[string][]string{"a": nil, "b": {"a"}, "c": {"a", "b"}}, true},
+		{map[string][]string{"a": {"missing"}}, false},
+		{map[string][]string{"a": {"a"}}, false},
+		{map[string][]string{"a": {"c"}, "b": {"a"}, "c": {"b"}}, false},
+	}
+
+	for i, test := range tests {
+		err := makeDAGJobDef(test.deps).ValidateDependencies()
+		if test.valid && err != nil {
+			t.Errorf("%d: expected %v to be valid, got %v", i, test.deps, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%d: expected %v to be invalid", i, test.deps)
+		}
+	}
+}
+
+func Test_SkippedTasks(t *testing.T) {
+	def := makeDAGJobDef(map[string][]string{
+		"a": nil,
+		"b": {"a"},
+		"c": {"b"},
+		"d": nil,
+		"e": {"c", "d"},
+	})
+
+	skipped := def.SkippedTasks(map[string]bool{"b": true})
+	expected := map[string]bool{"c": true, "e": true}
+	if !reflect.DeepEqual(skipped, expected) {
+		t.Errorf("Expected %v to be skipped, got %v", expected, skipped)
+	}
+
+	if skipped := def.SkippedTasks(nil); len(skipped) != 0 {
+		t.Errorf("Expected no tasks to be skipped, got %v", skipped)
+	}
+}
+
+func Test_RunSucceeded(t *testing.T) {
+	if !RunSucceeded(runner.CompleteStatus("run1", "", 0)) {
+		t.Errorf("Expected a complete run with exit code 0 to succeed")
+	}
+	if RunSucceeded(runner.CompleteStatus("run1", "", 1)) {
+		t.Errorf("Expected a complete run with exit code 1 to fail")
+	}
+	if RunSucceeded(runner.ErrorStatus("run1", errors.New("boom"))) {
+		t.Errorf("Expected a failed run to fail")
+	}
+}
@@ -21,11 +21,15 @@ var GoUnusedProtection__ int
 //  - EnvVars
 //  - Timeout
 //  - SnapshotId
+//  - Network
+//  - Outputs
 type Command struct {
 	Argv       []string          `thrift:"argv,1,required" json:"argv"`
 	EnvVars    map[string]string `thrift:"envVars,2" json:"envVars,omitempty"`
 	Timeout    *int64            `thrift:"timeout,3" json:"timeout,omitempty"`
 	SnapshotId string            `thrift:"snapshotId,4,required" json:"snapshotId"`
+	Network    *bool             `thrift:"network,5" json:"network,omitempty"`
+	Outputs    map[string]string `thrift:"outputs,6" json:"outputs,omitempty"`
 }
 
 func NewCommand() *Command {
@@ -54,6 +58,21 @@ func (p *Command) GetTimeout() int64 {
 func (p *Command) GetSnapshotId() string {
 	return p.SnapshotId
 }
+
+var Command_Network_DEFAULT bool
+
+func (p *Command) GetNetwork() bool {
+	if !p.IsSetNetwork() {
+		return Command_Network_DEFAULT
+	}
+	return *p.Network
+}
+
+var Command_Outputs_DEFAULT map[string]string
+
+func (p *Command) GetOutputs() map[string]string {
+	return p.Outputs
+}
 func (p *Command) IsSetEnvVars() bool {
 	return p.EnvVars != nil
 }
@@ -62,6 +81,14 @@ func (p *Command) IsSetTimeout() bool {
 	return p.Timeout != nil
 }
 
+func (p *Command) IsSetNetwork() bool {
+	return p.Network != nil
+}
+
+func (p *Command) IsSetOutputs() bool {
+	return p.Outputs != nil
+}
+
 func (p *Command) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -97,6 +124,14 @@ func (p *Command) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetSnapshotId = true
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -186,6 +221,43 @@ func (p *Command) readField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *Command) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Network = &v
+	}
+	return nil
+}
+
+func (p *Command) readField6(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Outputs = tMap
+	for i := 0; i < size; i++ {
+		var _key3 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key3 = v
+		}
+		var _val4 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val4 = v
+		}
+		p.Outputs[_key3] = _val4
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
 func (p *Command) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("Command"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -202,6 +274,12 @@ func (p *Command) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField4(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -286,6 +364,47 @@ func (p *Command) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *Command) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNetwork() {
+		if err := oprot.WriteFieldBegin("network", thrift.BOOL, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:network: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.Network)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.network (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:network: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOutputs() {
+		if err := oprot.WriteFieldBegin("outputs", thrift.MAP, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:outputs: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Outputs)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Outputs {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:outputs: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *Command) String() string {
 	if p == nil {
 		return "<nil>"
@@ -293,10 +412,342 @@ func (p *Command) String() string {
 	return fmt.Sprintf("Command(%+v)", *p)
 }
 
+// Attributes:
+//  - MaxAttempts
+//  - InitialBackoff
+//  - MaxBackoff
+//  - RetryableStates
+//  - RetryableExitCodes
+type RetryPolicy struct {
+	MaxAttempts        *int32  `thrift:"maxAttempts,1" json:"maxAttempts,omitempty"`
+	InitialBackoff     *int64  `thrift:"initialBackoff,2" json:"initialBackoff,omitempty"`
+	MaxBackoff         *int64  `thrift:"maxBackoff,3" json:"maxBackoff,omitempty"`
+	RetryableStates    []int32 `thrift:"retryableStates,4" json:"retryableStates,omitempty"`
+	RetryableExitCodes []int32 `thrift:"retryableExitCodes,5" json:"retryableExitCodes,omitempty"`
+}
+
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{}
+}
+
+var RetryPolicy_MaxAttempts_DEFAULT int32
+
+func (p *RetryPolicy) GetMaxAttempts() int32 {
+	if !p.IsSetMaxAttempts() {
+		return RetryPolicy_MaxAttempts_DEFAULT
+	}
+	return *p.MaxAttempts
+}
+
+var RetryPolicy_InitialBackoff_DEFAULT int64
+
+func (p *RetryPolicy) GetInitialBackoff() int64 {
+	if !p.IsSetInitialBackoff() {
+		return RetryPolicy_InitialBackoff_DEFAULT
+	}
+	return *p.InitialBackoff
+}
+
+var RetryPolicy_MaxBackoff_DEFAULT int64
+
+func (p *RetryPolicy) GetMaxBackoff() int64 {
+	if !p.IsSetMaxBackoff() {
+		return RetryPolicy_MaxBackoff_DEFAULT
+	}
+	return *p.MaxBackoff
+}
+
+var RetryPolicy_RetryableStates_DEFAULT []int32
+
+func (p *RetryPolicy) GetRetryableStates() []int32 {
+	return p.RetryableStates
+}
+
+var RetryPolicy_RetryableExitCodes_DEFAULT []int32
+
+func (p *RetryPolicy) GetRetryableExitCodes() []int32 {
+	return p.RetryableExitCodes
+}
+func (p *RetryPolicy) IsSetMaxAttempts() bool {
+	return p.MaxAttempts != nil
+}
+
+func (p *RetryPolicy) IsSetInitialBackoff() bool {
+	return p.InitialBackoff != nil
+}
+
+func (p *RetryPolicy) IsSetMaxBackoff() bool {
+	return p.MaxBackoff != nil
+}
+
+func (p *RetryPolicy) IsSetRetryableStates() bool {
+	return p.RetryableStates != nil
+}
+
+func (p *RetryPolicy) IsSetRetryableExitCodes() bool {
+	return p.RetryableExitCodes != nil
+}
+
+func (p *RetryPolicy) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.MaxAttempts = &v
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.InitialBackoff = &v
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.MaxBackoff = &v
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField4(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]int32, 0, size)
+	p.RetryableStates = tSlice
+	for i := 0; i < size; i++ {
+		var _elem5 int32
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem5 = v
+		}
+		p.RetryableStates = append(p.RetryableStates, _elem5)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]int32, 0, size)
+	p.RetryableExitCodes = tSlice
+	for i := 0; i < size; i++ {
+		var _elem6 int32
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem6 = v
+		}
+		p.RetryableExitCodes = append(p.RetryableExitCodes, _elem6)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *RetryPolicy) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RetryPolicy"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RetryPolicy) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxAttempts() {
+		if err := oprot.WriteFieldBegin("maxAttempts", thrift.I32, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:maxAttempts: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.MaxAttempts)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxAttempts (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:maxAttempts: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetInitialBackoff() {
+		if err := oprot.WriteFieldBegin("initialBackoff", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:initialBackoff: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.InitialBackoff)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.initialBackoff (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:initialBackoff: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxBackoff() {
+		if err := oprot.WriteFieldBegin("maxBackoff", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:maxBackoff: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MaxBackoff)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxBackoff (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:maxBackoff: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryableStates() {
+		if err := oprot.WriteFieldBegin("retryableStates", thrift.LIST, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:retryableStates: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.I32, len(p.RetryableStates)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.RetryableStates {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:retryableStates: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryableExitCodes() {
+		if err := oprot.WriteFieldBegin("retryableExitCodes", thrift.LIST, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:retryableExitCodes: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.I32, len(p.RetryableExitCodes)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.RetryableExitCodes {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:retryableExitCodes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RetryPolicy(%+v)", *p)
+}
+
 // Attributes:
 //  - Command
+//  - CpuSlots
+//  - MemoryBytes
+//  - Dependencies
+//  - RetryPolicy
+//  - ExpectedDuration
 type TaskDefinition struct {
-	Command *Command `thrift:"command,1,required" json:"command"`
+	Command          *Command     `thrift:"command,1,required" json:"command"`
+	CpuSlots         *int32       `thrift:"cpuSlots,2" json:"cpuSlots,omitempty"`
+	MemoryBytes      *int64       `thrift:"memoryBytes,3" json:"memoryBytes,omitempty"`
+	Dependencies     []string     `thrift:"dependencies,4" json:"dependencies,omitempty"`
+	RetryPolicy      *RetryPolicy `thrift:"retryPolicy,5" json:"retryPolicy,omitempty"`
+	ExpectedDuration *int64       `thrift:"expectedDuration,6" json:"expectedDuration,omitempty"`
 }
 
 func NewTaskDefinition() *TaskDefinition {
@@ -311,10 +762,72 @@ func (p *TaskDefinition) GetCommand() *Command {
 	}
 	return p.Command
 }
+
+var TaskDefinition_CpuSlots_DEFAULT int32
+
+func (p *TaskDefinition) GetCpuSlots() int32 {
+	if !p.IsSetCpuSlots() {
+		return TaskDefinition_CpuSlots_DEFAULT
+	}
+	return *p.CpuSlots
+}
+
+var TaskDefinition_MemoryBytes_DEFAULT int64
+
+func (p *TaskDefinition) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return TaskDefinition_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+
+var TaskDefinition_Dependencies_DEFAULT []string
+
+func (p *TaskDefinition) GetDependencies() []string {
+	return p.Dependencies
+}
+
+var TaskDefinition_RetryPolicy_DEFAULT *RetryPolicy
+
+func (p *TaskDefinition) GetRetryPolicy() *RetryPolicy {
+	if !p.IsSetRetryPolicy() {
+		return TaskDefinition_RetryPolicy_DEFAULT
+	}
+	return p.RetryPolicy
+}
+
+var TaskDefinition_ExpectedDuration_DEFAULT int64
+
+func (p *TaskDefinition) GetExpectedDuration() int64 {
+	if !p.IsSetExpectedDuration() {
+		return TaskDefinition_ExpectedDuration_DEFAULT
+	}
+	return *p.ExpectedDuration
+}
 func (p *TaskDefinition) IsSetCommand() bool {
 	return p.Command != nil
 }
 
+func (p *TaskDefinition) IsSetCpuSlots() bool {
+	return p.CpuSlots != nil
+}
+
+func (p *TaskDefinition) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *TaskDefinition) IsSetDependencies() bool {
+	return p.Dependencies != nil
+}
+
+func (p *TaskDefinition) IsSetRetryPolicy() bool {
+	return p.RetryPolicy != nil
+}
+
+func (p *TaskDefinition) IsSetExpectedDuration() bool {
+	return p.ExpectedDuration != nil
+}
+
 func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -336,6 +849,26 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetCommand = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -362,6 +895,63 @@ func (p *TaskDefinition) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *TaskDefinition) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.CpuSlots = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField4(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Dependencies = tSlice
+	for i := 0; i < size; i++ {
+		var _elem7 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem7 = v
+		}
+		p.Dependencies = append(p.Dependencies, _elem7)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField5(iprot thrift.TProtocol) error {
+	p.RetryPolicy = &RetryPolicy{}
+	if err := p.RetryPolicy.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.RetryPolicy), err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.ExpectedDuration = &v
+	}
+	return nil
+}
+
 func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -369,6 +959,21 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -391,6 +996,89 @@ func (p *TaskDefinition) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *TaskDefinition) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpuSlots() {
+		if err := oprot.WriteFieldBegin("cpuSlots", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:cpuSlots: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.CpuSlots)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpuSlots (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:cpuSlots: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDependencies() {
+		if err := oprot.WriteFieldBegin("dependencies", thrift.LIST, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:dependencies: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Dependencies)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Dependencies {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:dependencies: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryPolicy() {
+		if err := oprot.WriteFieldBegin("retryPolicy", thrift.STRUCT, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:retryPolicy: ", p), err)
+		}
+		if err := p.RetryPolicy.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.RetryPolicy), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:retryPolicy: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetExpectedDuration() {
+		if err := oprot.WriteFieldBegin("expectedDuration", thrift.I64, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:expectedDuration: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.ExpectedDuration)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.expectedDuration (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:expectedDuration: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *TaskDefinition) String() string {
 	if p == nil {
 		return "<nil>"
@@ -401,9 +1089,13 @@ func (p *TaskDefinition) String() string {
 // Attributes:
 //  - JobType
 //  - Tasks
+//  - Priority
+//  - Tenant
 type JobDefinition struct {
-	JobType *string                    `thrift:"jobType,1" json:"jobType,omitempty"`
-	Tasks   map[string]*TaskDefinition `thrift:"tasks,2" json:"tasks,omitempty"`
+	JobType  *string                    `thrift:"jobType,1" json:"jobType,omitempty"`
+	Tasks    map[string]*TaskDefinition `thrift:"tasks,2" json:"tasks,omitempty"`
+	Priority *int32                     `thrift:"priority,3" json:"priority,omitempty"`
+	Tenant   *string                    `thrift:"tenant,4" json:"tenant,omitempty"`
 }
 
 func NewJobDefinition() *JobDefinition {
@@ -424,6 +1116,24 @@ var JobDefinition_Tasks_DEFAULT map[string]*TaskDefinition
 func (p *JobDefinition) GetTasks() map[string]*TaskDefinition {
 	return p.Tasks
 }
+
+var JobDefinition_Priority_DEFAULT int32
+
+func (p *JobDefinition) GetPriority() int32 {
+	if !p.IsSetPriority() {
+		return JobDefinition_Priority_DEFAULT
+	}
+	return *p.Priority
+}
+
+var JobDefinition_Tenant_DEFAULT string
+
+func (p *JobDefinition) GetTenant() string {
+	if !p.IsSetTenant() {
+		return JobDefinition_Tenant_DEFAULT
+	}
+	return *p.Tenant
+}
 func (p *JobDefinition) IsSetJobType() bool {
 	return p.JobType != nil
 }
@@ -432,6 +1142,14 @@ func (p *JobDefinition) IsSetTasks() bool {
 	return p.Tasks != nil
 }
 
+func (p *JobDefinition) IsSetPriority() bool {
+	return p.Priority != nil
+}
+
+func (p *JobDefinition) IsSetTenant() bool {
+	return p.Tenant != nil
+}
+
 func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -454,6 +1172,14 @@ func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -486,17 +1212,17 @@ func (p *JobDefinition) readField2(iprot thrift.TProtocol) error {
 	tMap := make(map[string]*TaskDefinition, size)
 	p.Tasks = tMap
 	for i := 0; i < size; i++ {
-		var _key3 string
+		var _key8 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_key3 = v
+			_key8 = v
 		}
-		_val4 := &TaskDefinition{}
-		if err := _val4.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val4), err)
+		_val9 := &TaskDefinition{}
+		if err := _val9.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val9), err)
 		}
-		p.Tasks[_key3] = _val4
+		p.Tasks[_key8] = _val9
 	}
 	if err := iprot.ReadMapEnd(); err != nil {
 		return thrift.PrependError("error reading map end: ", err)
@@ -504,6 +1230,24 @@ func (p *JobDefinition) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *JobDefinition) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Priority = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Tenant = &v
+	}
+	return nil
+}
+
 func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("JobDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -514,6 +1258,12 @@ func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -564,6 +1314,36 @@ func (p *JobDefinition) writeField2(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *JobDefinition) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPriority() {
+		if err := oprot.WriteFieldBegin("priority", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:priority: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Priority)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.priority (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:priority: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTenant() {
+		if err := oprot.WriteFieldBegin("tenant", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:tenant: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tenant)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tenant (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:tenant: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *JobDefinition) String() string {
 	if p == nil {
 		return "<nil>"
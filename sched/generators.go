@@ -89,9 +89,10 @@ func GenRandomTask(rng *rand.Rand) TaskDefinition {
 		Argv:       args,
 		EnvVars:    envVarsMap,
 		Timeout:    timeout,
+		Network:    rng.Intn(2) == 0,
 	}
 
-	return TaskDefinition{cmd}
+	return TaskDefinition{Command: cmd}
 }
 
 // Randomly generates an Id that is valid for
@@ -17,15 +17,32 @@ struct Command {
   2: optional map<string, string> envVars,
   3: optional i64 timeout,
   4: required string snapshotId,
+  5: optional bool network,
+  6: optional map<string, string> outputs,
+}
+
+struct RetryPolicy {
+  1: optional i32 maxAttempts,
+  2: optional i64 initialBackoff,
+  3: optional i64 maxBackoff,
+  4: optional list<i32> retryableStates,
+  5: optional list<i32> retryableExitCodes,
 }
 
 struct TaskDefinition {
   1: required Command command,
+  2: optional i32 cpuSlots,
+  3: optional i64 memoryBytes,
+  4: optional list<string> dependencies,
+  5: optional RetryPolicy retryPolicy,
+  6: optional i64 expectedDuration,
 }
 
 struct JobDefinition {
   1: optional string jobType,
   2: optional map<string, TaskDefinition> tasks,
+  3: optional i32 priority,
+  4: optional string tenant,
 }
 
 struct Job {
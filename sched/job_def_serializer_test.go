@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/scootdev/scoot/common/thrifthelpers"
+	"github.com/scootdev/scoot/runner"
 	"github.com/scootdev/scoot/sched/gen-go/schedthrift"
 )
 
@@ -46,6 +48,8 @@ func makeFixedSampleJob() *Job {
 	jobDef := JobDefinition{}
 	jobDef.Tasks = make(map[string]TaskDefinition)
 	jobDef.JobType = "jobTypeVal"
+	jobDef.Priority = 2
+	jobDef.Tenant = "tenantVal"
 	taskDefinition := TaskDefinition{}
 	taskDefinition.SnapshotID = "snapshotIDVal"
 	taskDefinition.Timeout = 3
@@ -55,6 +59,14 @@ func makeFixedSampleJob() *Job {
 	envVars["envVar2"] = "var2Value"
 	args := []string{"arg1", "arg2"}
 	taskDefinition.Argv = args
+	taskDefinition.Outputs = map[string]string{"reports/*.xml": "reports", "coverage.out": ""}
+	taskDefinition.Retry = RetryPolicy{
+		MaxAttempts:        3,
+		InitialBackoff:     time.Second,
+		RetryableStates:    []runner.RunState{runner.FAILED, runner.TIMEDOUT},
+		RetryableExitCodes: []int{75},
+	}
+	taskDefinition.ExpectedDuration = time.Minute
 	jobDef.Tasks["task0"] = taskDefinition
 	//Print(jobDef)  -I enable this for debugging
 	job.Def = jobDef
@@ -0,0 +1,44 @@
+package sched
+
+// Resources describes the resources a task needs to run, or the
+// resources a node has available for running tasks.
+type Resources struct {
+	// Number of cpu slots.  A task that doesn't specify any
+	// needs one slot, see Normalized()
+	CPUSlots int
+
+	// Memory in bytes.  Zero means no requirement for a task,
+	// and unbounded memory for a node.
+	MemoryBytes int64
+}
+
+// Returns the Resources with defaults applied for unspecified values
+func (r Resources) Normalized() Resources {
+	if r.CPUSlots <= 0 {
+		r.CPUSlots = 1
+	}
+	if r.MemoryBytes < 0 {
+		r.MemoryBytes = 0
+	}
+	return r
+}
+
+// Returns true if needed fits within these Resources
+func (r Resources) Fits(needed Resources) bool {
+	if needed.CPUSlots > r.CPUSlots {
+		return false
+	}
+	// no memory limit fits any amount of memory
+	if r.MemoryBytes > 0 && needed.MemoryBytes > r.MemoryBytes {
+		return false
+	}
+	return true
+}
+
+// Returns the sum of these Resources and other
+func (r Resources) Add(other Resources) Resources {
+	return Resources{
+		CPUSlots:    r.CPUSlots + other.CPUSlots,
+		MemoryBytes: r.MemoryBytes + other.MemoryBytes,
+	}
+}
@@ -0,0 +1,36 @@
+package sched
+
+import (
+	"testing"
+)
+
+func Test_Resources_Normalized(t *testing.T) {
+	r := Resources{}.Normalized()
+	if r.CPUSlots != 1 || r.MemoryBytes != 0 {
+		t.Errorf("Expected unspecified resources to need 1 slot & no memory, not %+v", r)
+	}
+
+	r = Resources{CPUSlots: 4, MemoryBytes: 1024}.Normalized()
+	if r.CPUSlots != 4 || r.MemoryBytes != 1024 {
+		t.Errorf("Expected specified resources to be unchanged, not %+v", r)
+	}
+}
+
+func Test_Resources_Fits(t *testing.T) {
+	capacity := Resources{CPUSlots: 4, MemoryBytes: 1024}
+
+	if !capacity.Fits(Resources{CPUSlots: 4, MemoryBytes: 1024}) {
+		t.Errorf("Expected resources equal to capacity to fit")
+	}
+	if capacity.Fits(Resources{CPUSlots: 5}) {
+		t.Errorf("Expected more slots than capacity to not fit")
+	}
+	if capacity.Fits(Resources{CPUSlots: 1, MemoryBytes: 1025}) {
+		t.Errorf("Expected more memory than capacity to not fit")
+	}
+
+	unbounded := Resources{CPUSlots: 1}
+	if !unbounded.Fits(Resources{CPUSlots: 1, MemoryBytes: 1 << 40}) {
+		t.Errorf("Expected capacity with no memory limit to fit any memory")
+	}
+}
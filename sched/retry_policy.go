@@ -0,0 +1,101 @@
+package sched
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scootdev/scoot/runner"
+)
+
+// RetryPolicy decides whether a task whose run didn't succeed is run again,
+// and how long the scheduler waits before doing so.  The zero value retries
+// every run that doesn't complete, immediately, up to the scheduler's
+// default number of attempts.
+type RetryPolicy struct {
+	// Most times the task is run, including the first.  0 uses the
+	// scheduler's default.
+	MaxAttempts int
+
+	// Wait before the first retry, doubled for each retry after and capped
+	// at MaxBackoff if it's set.  0 retries immediately.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// States of a run that are retried.  If empty every state but COMPLETE
+	// is retried.  Runs that complete are only retried if their exit code
+	// is one of RetryableExitCodes.
+	RetryableStates    []runner.RunState
+	RetryableExitCodes []int
+}
+
+// Returns the number of times a task with this policy is run at most,
+// defaultMaxAttempts if the policy doesn't say.
+func (p RetryPolicy) Attempts(defaultMaxAttempts int) int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+	return defaultMaxAttempts
+}
+
+// Returns true if a run ending with the specified status should be retried,
+// provided the task has attempts left.
+func (p RetryPolicy) IsRetryable(st runner.RunStatus) bool {
+	if st.State == runner.COMPLETE {
+		for _, code := range p.RetryableExitCodes {
+			if code == st.ExitCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(p.RetryableStates) == 0 {
+		return true
+	}
+	for _, state := range p.RetryableStates {
+		if state == st.State {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns how long to wait before running a task again after its
+// attempt'th run, counting from 1, didn't succeed.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff > 0; i++ {
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// Returns an error if the policy can't be applied
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("maxAttempts must not be negative, got %v", p.MaxAttempts)
+	}
+	if p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+		return fmt.Errorf("backoff must not be negative")
+	}
+	for _, state := range p.RetryableStates {
+		if state == runner.COMPLETE {
+			return fmt.Errorf("COMPLETE runs are retried by exit code, not state")
+		}
+		if state == runner.PENDING || state == runner.PREPARING || state == runner.RUNNING {
+			return fmt.Errorf("%v is not a final state of a run", state)
+		}
+	}
+	for _, code := range p.RetryableExitCodes {
+		if code == 0 {
+			return fmt.Errorf("runs exiting with 0 succeeded and can't be retried")
+		}
+	}
+	return nil
+}
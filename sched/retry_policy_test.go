@@ -0,0 +1,86 @@
+package sched
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/runner"
+)
+
+func Test_RetryPolicy_DefaultRetriesRunsThatDontComplete(t *testing.T) {
+	var policy RetryPolicy
+
+	for _, state := range []runner.RunState{runner.FAILED, runner.TIMEDOUT, runner.ABORTED, runner.BADREQUEST} {
+		if !policy.IsRetryable(runner.RunStatus{State: state}) {
+			t.Errorf("Expected a %v run to be retried", state)
+		}
+	}
+	if policy.IsRetryable(runner.RunStatus{State: runner.COMPLETE, ExitCode: 1}) {
+		t.Errorf("Expected a completed run not to be retried")
+	}
+	if policy.Attempts(3) != 3 {
+		t.Errorf("Expected the default number of attempts, got %v", policy.Attempts(3))
+	}
+}
+
+func Test_RetryPolicy_RetryableStatesAndExitCodes(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:        5,
+		RetryableStates:    []runner.RunState{runner.FAILED, runner.TIMEDOUT},
+		RetryableExitCodes: []int{75},
+	}
+
+	cases := []struct {
+		st        runner.RunStatus
+		retryable bool
+	}{
+		{runner.RunStatus{State: runner.FAILED}, true},
+		{runner.RunStatus{State: runner.TIMEDOUT}, true},
+		{runner.RunStatus{State: runner.BADREQUEST}, false},
+		{runner.RunStatus{State: runner.COMPLETE, ExitCode: 75}, true},
+		{runner.RunStatus{State: runner.COMPLETE, ExitCode: 1}, false},
+	}
+	for _, c := range cases {
+		if policy.IsRetryable(c.st) != c.retryable {
+			t.Errorf("Expected retryable to be %v for %v", c.retryable, c.st)
+		}
+	}
+	if policy.Attempts(1) != 5 {
+		t.Errorf("Expected the policy's number of attempts, got %v", policy.Attempts(1))
+	}
+}
+
+func Test_RetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, backoff := range expected {
+		if actual := policy.Backoff(i + 1); actual != backoff {
+			t.Errorf("Expected backoff %v after attempt %v, got %v", backoff, i+1, actual)
+		}
+	}
+
+	if (RetryPolicy{}).Backoff(3) != 0 {
+		t.Errorf("Expected no backoff by default")
+	}
+}
+
+func Test_RetryPolicy_Validate(t *testing.T) {
+	invalid := []RetryPolicy{
+		{MaxAttempts: -1},
+		{InitialBackoff: -time.Second},
+		{RetryableStates: []runner.RunState{runner.COMPLETE}},
+		{RetryableStates: []runner.RunState{runner.RUNNING}},
+		{RetryableExitCodes: []int{0}},
+	}
+	for _, policy := range invalid {
+		if policy.Validate() == nil {
+			t.Errorf("Expected %+v to be invalid", policy)
+		}
+	}
+
+	valid := RetryPolicy{MaxAttempts: 3, RetryableStates: []runner.RunState{runner.FAILED}, RetryableExitCodes: []int{1}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Unexpected error validating %+v: %v", valid, err)
+	}
+}
@@ -1,30 +1,92 @@
 package scheduler
 
 import (
+	"time"
+
 	"github.com/scootdev/scoot/cloud/cluster"
+	"github.com/scootdev/scoot/sched"
 )
 
-const noTask = ""
+// Capacity assumed for nodes until (or unless) they report their own
+var defaultNodeCapacity = sched.Resources{CPUSlots: 1}
+
+// Number of recently checked out snapshots remembered per node
+const maxRecentSnapshots = 3
 
 // clusterState maintains a cluster of nodes
-// and information about what task is running on each node
+// and information about what tasks are running on each node
 type clusterState struct {
 	updateCh chan []cluster.NodeUpdate
 	nodes    map[cluster.NodeId]*nodeState
 }
 
+// Identifies a task across all jobs
+type taskKey struct {
+	jobId  string
+	taskId string
+}
+
 // The State of A Node in the Cluster
 type nodeState struct {
-	node        cluster.Node
-	runningTask string
+	node         cluster.Node
+	capacity     sched.Resources
+	runningTasks map[taskKey]sched.Resources // resources used by each running task
+	snapshots    []string                    // snapshot ids recently checked out, most recent first
+
+	// capacity has been read from the node, or reading it has been given up on
+	capacityKnown    bool
+	fetchingCapacity bool
+	capacityReadAt   time.Time
+
+	// reading the capacity failed, the node may offer more than its capacity
+	capacityAssumed bool
 }
 
 // Initializes a Node State for the specified Node
 func newNodeState(node cluster.Node) *nodeState {
 	return &nodeState{
-		node:        node,
-		runningTask: noTask,
+		node:         node,
+		capacity:     defaultNodeCapacity,
+		runningTasks: make(map[taskKey]sched.Resources),
+	}
+}
+
+// Returns the resources used by all the tasks running on this node
+func (n *nodeState) used() sched.Resources {
+	var used sched.Resources
+	for _, r := range n.runningTasks {
+		used = used.Add(r)
+	}
+	return used
+}
+
+// Returns true if the node recently checked out the specified snapshot
+func (n *nodeState) hasSnapshot(snapshotId string) bool {
+	for _, id := range n.snapshots {
+		if id == snapshotId {
+			return true
+		}
 	}
+	return false
+}
+
+// Records that the node checked out the specified snapshot
+func (n *nodeState) snapshotUsed(snapshotId string) {
+	if snapshotId == "" {
+		return
+	}
+	snapshots := []string{snapshotId}
+	for _, id := range n.snapshots {
+		if id != snapshotId && len(snapshots) < maxRecentSnapshots {
+			snapshots = append(snapshots, id)
+		}
+	}
+	n.snapshots = snapshots
+}
+
+// Returns the number of cpu slots not used by running tasks
+func (n *nodeState) freeSlots() int {
+	return n.capacity.CPUSlots - n.used().CPUSlots
 }
 
 // Creates a New State Distributor with the initial nodes, and which updates
@@ -44,20 +106,48 @@ func newClusterState(initial []cluster.Node, updateCh chan []cluster.NodeUpdate)
 	return cs
 }
 
-// Update ClusterState to reflect that a task has been scheduled on a
-// particular node
-func (c *clusterState) taskScheduled(nodeId cluster.NodeId, taskId string) {
+// Update ClusterState to reflect that a task has been scheduled on a particular
+// node, where it uses the task's resources and checks out the task's snapshot
+func (c *clusterState) taskScheduled(nodeId cluster.NodeId, jobId, taskId string, def sched.TaskDefinition) {
 	ns := c.nodes[nodeId]
-	ns.runningTask = taskId
+	ns.runningTasks[taskKey{jobId, taskId}] = def.Resources.Normalized()
+	ns.snapshotUsed(def.SnapshotID)
 }
 
 // Update ClusterState to reflect that a task has finished running on
 // a particular node, whether successfully or unsuccessfully
-func (c *clusterState) taskCompleted(nodeId cluster.NodeId, taskId string) {
+func (c *clusterState) taskCompleted(nodeId cluster.NodeId, jobId, taskId string) {
+	// this node may have been removed from the cluster in the last update
+	ns, ok := c.nodes[nodeId]
+	if ok {
+		delete(ns.runningTasks, taskKey{jobId, taskId})
+	}
+}
+
+// Returns true if some node in the cluster could run a task needing the
+// specified resources once it's idle.  Until every node's capacity has been
+// read, or while there are no nodes, the task is assumed to fit.
+func (c *clusterState) fitsSomeNode(needed sched.Resources) bool {
+	if len(c.nodes) == 0 {
+		return true
+	}
+	for _, ns := range c.nodes {
+		if !ns.capacityKnown || ns.capacityAssumed || ns.capacity.Fits(needed) {
+			return true
+		}
+	}
+	return false
+}
+
+// Update ClusterState with the capacity read from a particular node
+func (c *clusterState) capacityFetched(nodeId cluster.NodeId, capacity sched.Resources) {
 	// this node may have been removed from the cluster in the last update
 	ns, ok := c.nodes[nodeId]
 	if ok {
-		ns.runningTask = noTask
+		ns.capacity = capacity.Normalized()
+		ns.capacityKnown = true
+		ns.fetchingCapacity = false
+		ns.capacityReadAt = time.Now()
 	}
 }
 
@@ -84,9 +174,12 @@ func (c *clusterState) update(updates []cluster.NodeUpdate) {
 	for _, update := range updates {
 		switch update.UpdateType {
 		case cluster.NodeAdded:
-			// add the node if it doesn't already exist
-			if _, ok := c.nodes[update.Node.Id()]; !ok {
+			// add the node if it doesn't already exist.  A node added again
+			// may have restarted, so its capacity is read again.
+			if ns, ok := c.nodes[update.Node.Id()]; !ok {
 				c.nodes[update.Node.Id()] = newNodeState(update.Node)
+			} else {
+				ns.capacityKnown = false
 			}
 		case cluster.NodeRemoved:
 			delete(c.nodes, update.Id)
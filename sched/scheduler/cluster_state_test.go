@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"github.com/scootdev/scoot/cloud/cluster"
+	"github.com/scootdev/scoot/sched"
 	"testing"
 )
 
@@ -23,7 +24,7 @@ func Test_ClusterState_UpdateCluster(t *testing.T) {
 	}
 
 	ns, _ := cs.getNodeState(cluster.NodeId("node1"))
-	if ns.runningTask != noTask {
+	if len(ns.runningTasks) != 0 {
 		t.Errorf("expected newly added node to have no tasks")
 	}
 
@@ -53,7 +54,7 @@ func Test_ClusterState_DuplicateNodeAdd(t *testing.T) {
 	cl := makeTestCluster("node1")
 	cs := newClusterState(cl.nodes, cl.ch)
 
-	cs.taskScheduled("node1", "task1")
+	cs.taskScheduled("node1", "job1", "task1", sched.TaskDefinition{})
 
 	// readd node to cluster
 	cl.add("node1")
@@ -66,8 +67,8 @@ func Test_ClusterState_DuplicateNodeAdd(t *testing.T) {
 
 	ns, _ := cs.getNodeState("node1")
 	// verify that the state wasn't modified
-	if ns.runningTask != "task1" {
-		t.Errorf("Expected adding an already tracked node to not modify state %v", cs.nodes[cluster.NodeId("node1")].runningTask)
+	if _, ok := ns.runningTasks[taskKey{"job1", "task1"}]; !ok {
+		t.Errorf("Expected adding an already tracked node to not modify state %v", cs.nodes[cluster.NodeId("node1")].runningTasks)
 	}
 }
 
@@ -75,26 +76,96 @@ func Test_TaskStarted(t *testing.T) {
 	cl := makeTestCluster("node1")
 	cs := newClusterState(cl.nodes, cl.ch)
 
-	cs.taskScheduled("node1", "task1")
+	cs.taskScheduled("node1", "job1", "task1", sched.TaskDefinition{})
 	ns, _ := cs.getNodeState("node1")
 
-	if ns.runningTask != "task1" {
+	if _, ok := ns.runningTasks[taskKey{"job1", "task1"}]; !ok {
 		t.Errorf("Expected Node1 to be running task1")
 	}
+	if ns.freeSlots() != 0 {
+		t.Errorf("Expected task1 to use Node1's only slot, free slots: %v", ns.freeSlots())
+	}
 }
 
 func Test_TaskCompleted(t *testing.T) {
 	cl := makeTestCluster("node1")
 	cs := newClusterState(cl.nodes, cl.ch)
 
-	cs.taskScheduled("node1", "task1")
+	cs.taskScheduled("node1", "job1", "task1", sched.TaskDefinition{})
 	ns, _ := cs.getNodeState("node1")
 
-	cs.taskCompleted("node1", "task1")
-	if ns.runningTask != noTask {
-		t.Errorf("Expected Node1 to be running task1")
+	cs.taskCompleted("node1", "job1", "task1")
+	if len(ns.runningTasks) != 0 {
+		t.Errorf("Expected Node1 to not be running any tasks")
+	}
+
+}
+
+// ensures a node's capacity is read again once it's removed and added back,
+// or added again while it's tracked
+func Test_ClusterState_ReaddedNodeCapacityUnknown(t *testing.T) {
+	cl := makeTestCluster("node1")
+	cs := newClusterState(cl.nodes, cl.ch)
+
+	cs.capacityFetched("node1", sched.Resources{CPUSlots: 4})
+	cl.remove("node1")
+	cs.updateCluster()
+	cl.add("node1")
+	cs.updateCluster()
+	if ns, _ := cs.getNodeState("node1"); ns.capacityKnown || ns.capacity != defaultNodeCapacity {
+		t.Errorf("Expected the readded node's capacity to be unknown, got %+v", ns)
+	}
+
+	cs.capacityFetched("node1", sched.Resources{CPUSlots: 4})
+	cl.add("node1")
+	cs.updateCluster()
+	if ns, _ := cs.getNodeState("node1"); ns.capacityKnown {
+		t.Errorf("Expected the node added again to have its capacity read again")
+	}
+}
+
+func Test_CapacityFetched(t *testing.T) {
+	cl := makeTestCluster("node1")
+	cs := newClusterState(cl.nodes, cl.ch)
+
+	cs.capacityFetched("node1", sched.Resources{CPUSlots: 4, MemoryBytes: 1024})
+	cs.taskScheduled("node1", "job1", "task1", sched.TaskDefinition{Resources: sched.Resources{CPUSlots: 3, MemoryBytes: 512}})
+	ns, _ := cs.getNodeState("node1")
+
+	if !ns.capacityKnown || ns.capacity != (sched.Resources{CPUSlots: 4, MemoryBytes: 1024}) {
+		t.Errorf("Expected Node1 to have the fetched capacity, not %+v", ns.capacity)
+	}
+	if ns.freeSlots() != 1 {
+		t.Errorf("Expected Node1 to have 1 free slot, not %v", ns.freeSlots())
+	}
+	if ns.used().MemoryBytes != 512 {
+		t.Errorf("Expected Node1 to be using 512 bytes, not %v", ns.used().MemoryBytes)
+	}
+}
+
+func Test_FitsSomeNode(t *testing.T) {
+	cl := makeTestCluster("node1", "node2")
+	cs := newClusterState(cl.nodes, cl.ch)
+	large := sched.Resources{CPUSlots: 8}
+
+	cs.capacityFetched("node1", sched.Resources{CPUSlots: 4})
+	if !cs.fitsSomeNode(large) {
+		t.Errorf("Expected a task to fit while a node's capacity is unknown")
+	}
+
+	cs.capacityFetched("node2", sched.Resources{CPUSlots: 2})
+	if cs.fitsSomeNode(large) {
+		t.Errorf("Expected a task larger than every node not to fit")
+	}
+	if !cs.fitsSomeNode(sched.Resources{CPUSlots: 4}) {
+		t.Errorf("Expected a task to fit the largest node")
 	}
 
+	ns, _ := cs.getNodeState("node2")
+	ns.capacityAssumed = true
+	if !cs.fitsSomeNode(large) {
+		t.Errorf("Expected a task to fit while a node's capacity is assumed")
+	}
 }
 
 type testCluster struct {
@@ -123,3 +194,26 @@ func (h *testCluster) remove(node string) {
 	update := cluster.NewRemove(cluster.NodeId(node))
 	h.ch <- []cluster.NodeUpdate{update}
 }
+
+func Test_SnapshotsUsed(t *testing.T) {
+	cl := makeTestCluster("node1")
+	cs := newClusterState(cl.nodes, cl.ch)
+	ns, _ := cs.getNodeState("node1")
+
+	for _, id := range []string{"snap1", "snap2", "snap3", "snap1", "snap4"} {
+		def := sched.TaskDefinition{}
+		def.SnapshotID = id
+		cs.taskScheduled("node1", "job1", id, def)
+		cs.taskCompleted("node1", "job1", id)
+	}
+
+	// snap2 is least recently used and should have been evicted
+	for _, id := range []string{"snap4", "snap1", "snap3"} {
+		if !ns.hasSnapshot(id) {
+			t.Errorf("Expected Node1 to hold %v, holds: %v", id, ns.snapshots)
+		}
+	}
+	if ns.hasSnapshot("snap2") {
+		t.Errorf("Expected Node1 to no longer hold snap2, holds: %v", ns.snapshots)
+	}
+}
@@ -1,8 +1,12 @@
 package scheduler
 
 import (
+	"log"
+	"time"
+
 	"github.com/scootdev/scoot/saga"
 	"github.com/scootdev/scoot/sched"
+	"github.com/scootdev/scoot/workerapi"
 )
 
 // Contains all the information for a job in progress
@@ -11,6 +15,9 @@ type jobState struct {
 	Saga       *saga.Saga            // saga associated with this job
 	Tasks      map[string]*taskState //taskId to taskState
 	EndingSaga bool                  //denotes whether an EndSagaMsg is in progress or not
+	Killed     bool                  //denotes whether the job has been killed, no new tasks are scheduled
+	Aborted    bool                  //denotes whether an AbortSagaMsg has been logged
+	Submitted  time.Time             //when the job was submitted, before any restart
 }
 
 // Contains all the information for a specified task
@@ -20,17 +27,28 @@ type taskState struct {
 	Def           sched.TaskDefinition
 	Status        sched.Status
 	NumTimesTried int
+	Failed        bool          // set once the task is Completed if its run didn't succeed
+	Runner        *taskRunner   // runner of the current attempt, set while the task is InProgress
+	Backup        *taskRunner   // runner of a backup copy of the current attempt, if one was started
+	ReadySince    time.Time     // when the task last became, or next becomes, ready to be scheduled
+	Started       time.Time     // when the current attempt was started
+	Duration      time.Duration // how long the attempt that completed the task ran
+
+	// earlier runs of the task that were retried, oldest first
+	Attempts []workerapi.RunAttempt
 }
 
 // Creates a New Job State based on the specified Job and Saga
 // The jobState will reflect any previous progress made on this
 // job and logged to the Sagalog
 func newJobState(job *sched.Job, saga *saga.Saga) *jobState {
+	now := time.Now()
 	j := &jobState{
 		Job:        job,
 		Saga:       saga,
 		Tasks:      make(map[string]*taskState),
 		EndingSaga: false,
+		Submitted:  now,
 	}
 
 	for taskId, taskDef := range job.Def.Tasks {
@@ -40,6 +58,7 @@ func newJobState(job *sched.Job, saga *saga.Saga) *jobState {
 			Def:           taskDef,
 			Status:        sched.NotStarted,
 			NumTimesTried: 0,
+			ReadySince:    now,
 		}
 	}
 
@@ -47,22 +66,66 @@ func newJobState(job *sched.Job, saga *saga.Saga) *jobState {
 	// done or not done.  Scheduler currently doesn't support
 	// scheduling compensating tasks.  In Progress tasks
 	// are considered not done and will be rescheduled.
+	// Tasks whose logged run didn't succeed are failed, and tasks
+	// depending on them are skipped.
+	// Tasks that aren't done keep the history of their runs, so they're
+	// retried no more times than their policy allows.
+	failed := make(map[string]bool)
 	for _, taskId := range saga.GetState().GetTaskIds() {
 		if saga.GetState().IsTaskCompleted(taskId) {
 			j.Tasks[taskId].Status = sched.Completed
+			if !endTaskSucceeded(saga.GetState().GetEndTaskData(taskId)) {
+				j.Tasks[taskId].Failed = true
+				failed[taskId] = true
+			}
+		} else if data := saga.GetState().GetStartTaskData(taskId); data != nil {
+			if attempts, err := workerapi.DeserializeRunAttempts(data); err == nil {
+				j.Tasks[taskId].Attempts = attempts
+				j.Tasks[taskId].NumTimesTried = numPrimaryAttempts(attempts)
+			}
 		}
 	}
+	for taskId := range job.Def.SkippedTasks(failed) {
+		if j.Tasks[taskId].Status == sched.NotStarted {
+			j.Tasks[taskId].Status = sched.Skipped
+		}
+	}
+
+	// A job that was killed before it was recovered only needs to
+	// finish rolling back, none of its tasks should be run
+	if saga.GetState().IsSagaAborted() {
+		j.Killed = true
+		j.Aborted = true
+	}
 
 	return j
 }
 
+// Returns the number of times a task was tried, the runs of backup copies
+// are part of the attempt they copied, see taskStarted
+func numPrimaryAttempts(attempts []workerapi.RunAttempt) int {
+	n := 0
+	for _, a := range attempts {
+		if !a.Backup {
+			n++
+		}
+	}
+	return n
+}
+
 // Returns a list of taskIds that can be scheduled currently.
 func (j *jobState) getUnScheduledTasks() []*taskState {
 
 	var tasksToRun []*taskState
 
+	// Killed jobs don't schedule any more tasks
+	if j.Killed {
+		return tasksToRun
+	}
+
+	now := time.Now()
 	for _, state := range j.Tasks {
-		if state.Status == sched.NotStarted {
+		if state.Status == sched.NotStarted && !state.ReadySince.After(now) && j.dependenciesSucceeded(state) {
 			tasksToRun = append(tasksToRun, state)
 		}
 	}
@@ -70,29 +133,122 @@ func (j *jobState) getUnScheduledTasks() []*taskState {
 	return tasksToRun
 }
 
-// Update JobState to reflect that a Task has been started
-func (j *jobState) taskStarted(taskId string) {
+// Returns true if all the tasks the specified task depends on have
+// completed successfully
+func (j *jobState) dependenciesSucceeded(task *taskState) bool {
+	for _, dep := range task.Def.Dependencies {
+		depState := j.Tasks[dep]
+		if depState.Status != sched.Completed || depState.Failed {
+			return false
+		}
+	}
+	return true
+}
+
+// Update JobState to reflect that a Task has been started by the specified taskRunner
+func (j *jobState) taskStarted(taskId string, tr *taskRunner) {
 	taskState := j.Tasks[taskId]
 	taskState.Status = sched.InProgress
 	taskState.NumTimesTried++
+	taskState.Runner = tr
+	taskState.Backup = nil
+	taskState.Started = time.Now()
+}
+
+// Update JobState to reflect that a backup copy of a Task's current attempt
+// has been started by the specified taskRunner
+func (j *jobState) backupStarted(taskId string, tr *taskRunner) {
+	j.Tasks[taskId].Backup = tr
 }
 
-// Update JobState to reflect that a Task has been completed
-func (j *jobState) taskCompleted(taskId string) {
+// Update JobState to reflect that a Task has been completed.  If its run
+// failed the tasks depending on it are skipped, otherwise the tasks it was
+// holding back become ready.
+func (j *jobState) taskCompleted(taskId string, failed bool) {
 	taskState := j.Tasks[taskId]
 	taskState.Status = sched.Completed
+	taskState.Failed = failed
+	taskState.Runner = nil
+	taskState.Backup = nil
+	taskState.Duration = time.Since(taskState.Started)
+
+	if failed {
+		for skippedId := range j.Job.Def.SkippedTasks(map[string]bool{taskId: true}) {
+			if j.Tasks[skippedId].Status == sched.NotStarted {
+				j.Tasks[skippedId].Status = sched.Skipped
+			}
+		}
+		return
+	}
+
+	now := time.Now()
+	for _, t := range j.Tasks {
+		if t.Status == sched.NotStarted && dependsOn(t, taskId) && j.dependenciesSucceeded(t) {
+			t.ReadySince = now
+		}
+	}
+}
+
+func dependsOn(task *taskState, taskId string) bool {
+	for _, dep := range task.Def.Dependencies {
+		if dep == taskId {
+			return true
+		}
+	}
+	return false
 }
 
-// Update JobState to reflect that an error has occurred running this Task
-func (j *jobState) errorRunningTask(taskId string, err error) {
+// Returns true if the serialized RunStatus logged in an EndTask message
+// is for a successful run
+func endTaskSucceeded(data []byte) bool {
+	if data == nil {
+		// completed without a run status, nothing to say it failed
+		return true
+	}
+	st, err := workerapi.DeserializeProcessStatus(data)
+	if err != nil {
+		log.Printf("Error deserializing EndTask data, treating the task as successful: %v", err)
+		return true
+	}
+	return sched.RunSucceeded(st)
+}
+
+// Update JobState to reflect that an error has occurred running this Task.
+// If the task was run, runs are the runs of the attempt's copies, which are
+// added to the task's history, and the task isn't ready again until its
+// backoff has passed.
+func (j *jobState) errorRunningTask(taskId string, err error, runs []workerapi.RunAttempt) {
 	taskState := j.Tasks[taskId]
 	taskState.Status = sched.NotStarted
+	taskState.Runner = nil
+	taskState.Backup = nil
+	taskState.ReadySince = time.Now()
+	if len(runs) > 0 {
+		taskState.Attempts = append(taskState.Attempts, runs...)
+		taskState.ReadySince = taskState.ReadySince.Add(taskState.Def.Retry.Backoff(taskState.NumTimesTried))
+	}
+}
+
+// Returns the runners of all the tasks currently InProgress, including
+// their backup copies
+func (j *jobState) getRunningTasks() []*taskRunner {
+	var runners []*taskRunner
+	for _, tState := range j.Tasks {
+		if tState.Status == sched.InProgress && tState.Runner != nil {
+			runners = append(runners, tState.Runner)
+		}
+		if tState.Status == sched.InProgress && tState.Backup != nil {
+			runners = append(runners, tState.Backup)
+		}
+	}
+	return runners
 }
 
-// Returns the Current Job Status
+// Returns the Current Job Status, a job is Completed once each of its
+// tasks is Completed or Skipped
 func (j *jobState) getJobStatus() sched.Status {
 	for _, tState := range j.Tasks {
-		if tState.Status != sched.Completed {
+		if tState.Status != sched.Completed && tState.Status != sched.Skipped {
 			return sched.InProgress
 		}
 	}
@@ -1,9 +1,14 @@
 package scheduler
 
 import (
+	"errors"
+	"time"
+
+	"github.com/scootdev/scoot/runner"
 	"github.com/scootdev/scoot/saga/sagalogs"
 	"github.com/scootdev/scoot/sched"
 	"github.com/scootdev/scoot/tests/testhelpers"
+	"github.com/scootdev/scoot/workerapi"
 	"testing"
 )
 
@@ -55,3 +60,140 @@ func Test_NewJobState_PreviousProgress_CompletedTasks(t *testing.T) {
 		t.Errorf("Expected all Tasks to be completed")
 	}
 }
+
+// makes a job whose task b depends on a, and c depends on b
+func makeChainJob() sched.Job {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 0)
+	job.Def.Tasks = map[string]sched.TaskDefinition{
+		"a": sched.GenTask(),
+		"b": sched.GenTask(),
+		"c": sched.GenTask(),
+	}
+	b := job.Def.Tasks["b"]
+	b.Dependencies = []string{"a"}
+	job.Def.Tasks["b"] = b
+	c := job.Def.Tasks["c"]
+	c.Dependencies = []string{"b"}
+	job.Def.Tasks["c"] = c
+	return job
+}
+
+func unscheduledTaskIds(j *jobState) []string {
+	var ids []string
+	for _, task := range j.getUnScheduledTasks() {
+		ids = append(ids, task.TaskId)
+	}
+	return ids
+}
+
+func Test_GetUnscheduledTasks_WaitsForDependencies(t *testing.T) {
+	job := makeChainJob()
+	jobAsBytes, _ := job.Serialize()
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+	jobState := newJobState(&job, saga)
+
+	if ids := unscheduledTaskIds(jobState); len(ids) != 1 || ids[0] != "a" {
+		t.Fatalf("Expected only a to be schedulable, got %v", ids)
+	}
+
+	jobState.taskStarted("a", nil)
+	jobState.taskCompleted("a", false)
+	if ids := unscheduledTaskIds(jobState); len(ids) != 1 || ids[0] != "b" {
+		t.Fatalf("Expected only b to be schedulable once a succeeded, got %v", ids)
+	}
+}
+
+func Test_TaskCompleted_FailureSkipsDependents(t *testing.T) {
+	job := makeChainJob()
+	jobAsBytes, _ := job.Serialize()
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+	jobState := newJobState(&job, saga)
+
+	jobState.taskStarted("a", nil)
+	jobState.taskCompleted("a", true)
+
+	if ids := unscheduledTaskIds(jobState); len(ids) != 0 {
+		t.Errorf("Expected no tasks to be schedulable once a failed, got %v", ids)
+	}
+	if jobState.Tasks["b"].Status != sched.Skipped || jobState.Tasks["c"].Status != sched.Skipped {
+		t.Errorf("Expected b and c to be skipped, got %v and %v",
+			jobState.Tasks["b"].Status, jobState.Tasks["c"].Status)
+	}
+	if jobState.getJobStatus() != sched.Completed {
+		t.Errorf("Expected job with failed and skipped tasks to be completed")
+	}
+}
+
+func Test_NewJobState_PreviousProgress_FailedDependency(t *testing.T) {
+	job := makeChainJob()
+	jobAsBytes, _ := job.Serialize()
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+
+	// a succeeded and b failed before the scheduler restarted
+	succeeded, _ := workerapi.SerializeProcessStatus(runner.CompleteStatus("run1", "", 0))
+	saga.StartTask("a", nil)
+	saga.EndTask("a", succeeded)
+	failed, _ := workerapi.SerializeProcessStatus(runner.CompleteStatus("run2", "", 1))
+	saga.StartTask("b", nil)
+	saga.EndTask("b", failed)
+
+	jobState := newJobState(&job, saga)
+
+	if jobState.Tasks["a"].Failed || !jobState.Tasks["b"].Failed {
+		t.Errorf("Expected only b to be recovered as failed")
+	}
+	if jobState.Tasks["c"].Status != sched.Skipped {
+		t.Errorf("Expected c to be recovered as skipped, got %v", jobState.Tasks["c"].Status)
+	}
+	if jobState.getJobStatus() != sched.Completed {
+		t.Errorf("Expected recovered job to be completed")
+	}
+}
+
+func Test_ErrorRunningTask_BacksOffAndRecordsAttempt(t *testing.T) {
+	job := sched.Job{
+		Id: "job1",
+		Def: sched.JobDefinition{
+			Tasks: map[string]sched.TaskDefinition{
+				"task1": {Retry: sched.RetryPolicy{InitialBackoff: time.Hour}},
+			},
+		},
+	}
+	jobAsBytes, _ := job.Serialize()
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+	j := newJobState(&job, saga)
+
+	j.taskStarted("task1", nil)
+	attempt := workerapi.RunAttempt{WorkerId: "node1", Status: runner.RunStatus{State: runner.FAILED}}
+	j.errorRunningTask("task1", errors.New("failed"), []workerapi.RunAttempt{attempt})
+
+	if len(j.getUnScheduledTasks()) != 0 {
+		t.Errorf("Expected the task to wait for its backoff before it's retried")
+	}
+	if len(j.Tasks["task1"].Attempts) != 1 || j.Tasks["task1"].Attempts[0] != attempt {
+		t.Errorf("Expected the failed run to be recorded, got %+v", j.Tasks["task1"].Attempts)
+	}
+}
+
+func Test_NewJobState_PreviousProgress_Attempts(t *testing.T) {
+	job := sched.Job{
+		Id:  "job1",
+		Def: sched.JobDefinition{Tasks: map[string]sched.TaskDefinition{"task1": {}}},
+	}
+	jobAsBytes, _ := job.Serialize()
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+
+	attempts := []workerapi.RunAttempt{
+		{WorkerId: "node1", Status: runner.RunStatus{State: runner.FAILED}},
+		{WorkerId: "node2", Status: runner.RunStatus{State: runner.TIMEDOUT}},
+		{WorkerId: "node3", Status: runner.RunStatus{State: runner.ABORTED}, Backup: true},
+	}
+	data, _ := workerapi.SerializeTaskStatus(runner.RunStatus{State: runner.PENDING}, attempts)
+	saga.StartTask("task1", data)
+
+	// the backup copy's run was part of the second attempt
+	j := newJobState(&job, saga)
+	if j.Tasks["task1"].NumTimesTried != 2 || len(j.Tasks["task1"].Attempts) != 3 {
+		t.Errorf("Expected the task's 3 earlier runs in 2 attempts to be recovered, got %+v", j.Tasks["task1"])
+	}
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"log"
+	"sync"
+
+	"github.com/scootdev/scoot/cloud/cluster"
+	"github.com/scootdev/scoot/common/stats"
+	"github.com/scootdev/scoot/saga"
+	"github.com/scootdev/scoot/sched"
+)
+
+// Scheduler for one of a group of schedulers sharing a replicated saga log,
+// only the leader of the log's group schedules jobs.  Each time this one
+// becomes the leader it starts a StatefulScheduler that recovers the jobs
+// the previous leader was running, and stops it once it's no longer the
+// leader.  While it isn't the leader ScheduleJob & KillJob return a
+// NotLeaderError.
+type leaderScheduler struct {
+	mu      sync.Mutex
+	current *statefulScheduler // nil while this isn't the leader
+}
+
+// Creates a Scheduler that schedules the jobs while this process is the
+// leader of the saga log's group, see leaderScheduler.  Returns without
+// waiting for leadership.
+func NewLeaderSchedulerFromCluster(
+	cl *cluster.Cluster,
+	sl saga.ReplicatedSagaLog,
+	sc saga.SagaCoordinator,
+	rf RunnerFactory,
+	config SchedulerConfig,
+	stat stats.StatsReceiver,
+) Scheduler {
+	s := &leaderScheduler{}
+	config.RecoverJobsOnStartup = true
+	go s.lead(sl, func() (*statefulScheduler, func()) {
+		sub := cl.Subscribe()
+		return NewStatefulScheduler(sub.InitialMembers, sub.Updates, sc, rf, config, stat),
+			func() { sub.Closer.Close() }
+	})
+	return s
+}
+
+// Starts a scheduler each time this process becomes the leader, and stops
+// it once it's no longer the leader.  The next scheduler isn't started until
+// the stopped one's task runners have exited, so they can't log to the sagas
+// it recovers.  start returns the scheduler, and a func to release what it
+// was started with once it's stopped.
+func (s *leaderScheduler) lead(sl saga.ReplicatedSagaLog, start func() (*statefulScheduler, func())) {
+	for {
+		log.Println("Waiting to become the saga log leader")
+		lost := sl.WaitForLeadership()
+		log.Println("Became the saga log leader, recovering jobs")
+		current, release := start()
+		s.setCurrent(current)
+
+		<-lost
+		log.Println("Lost saga log leadership, stopping scheduling until this scheduler leads again")
+		s.setCurrent(nil)
+		current.stop()
+		release()
+	}
+}
+
+func (s *leaderScheduler) setCurrent(current *statefulScheduler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = current
+}
+
+func (s *leaderScheduler) getCurrent() *statefulScheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+func (s *leaderScheduler) ScheduleJob(jobDef sched.JobDefinition) (string, error) {
+	current := s.getCurrent()
+	if current == nil {
+		return "", &NotLeaderError{}
+	}
+	return current.ScheduleJob(jobDef)
+}
+
+func (s *leaderScheduler) KillJob(jobId string) error {
+	current := s.getCurrent()
+	if current == nil {
+		return &NotLeaderError{}
+	}
+	return current.KillJob(jobId)
+}
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/cloud/cluster"
+	"github.com/scootdev/scoot/os/temp"
+	"github.com/scootdev/scoot/runner"
+	"github.com/scootdev/scoot/saga"
+	"github.com/scootdev/scoot/sched"
+	"github.com/scootdev/scoot/sched/worker/workers"
+)
+
+// ReplicatedSagaLog whose leadership the test hands out, each channel sent
+// on leadership is closed to lose it
+type testLeadership struct {
+	saga.SagaLog
+	leadership chan chan struct{}
+}
+
+func (l *testLeadership) WaitForLeadership() <-chan struct{} {
+	return <-l.leadership
+}
+
+func waitForLeader(t *testing.T, s *leaderScheduler, leader bool) {
+	for i := 0; i < 500; i++ {
+		if (s.getCurrent() != nil) == leader {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected leader %v", leader)
+}
+
+func Test_LeaderScheduler_OnlySchedulesWhileLeader(t *testing.T) {
+	sl := &testLeadership{leadership: make(chan chan struct{})}
+	s := &leaderScheduler{}
+	started, released := 0, make(chan struct{}, 2)
+	go s.lead(sl, func() (*statefulScheduler, func()) {
+		started++
+		return makeDefaultStatefulScheduler(), func() { released <- struct{}{} }
+	})
+
+	jobDef := sched.GenJobDef(1)
+	if _, err := s.ScheduleJob(jobDef); err == nil {
+		t.Fatalf("expected scheduling to fail before becoming the leader")
+	} else if _, ok := err.(*NotLeaderError); !ok {
+		t.Fatalf("expected NotLeaderError, got %v", err)
+	}
+
+	lost := make(chan struct{})
+	sl.leadership <- lost
+	waitForLeader(t, s, true)
+	if _, err := s.ScheduleJob(jobDef); err != nil {
+		t.Fatalf("expected the leader to schedule, got %v", err)
+	}
+
+	current := s.getCurrent()
+	close(lost)
+	waitForLeader(t, s, false)
+	<-released
+	if err := s.KillJob("job1"); err == nil {
+		t.Fatalf("expected killing a job to fail after losing leadership")
+	} else if _, ok := err.(*NotLeaderError); !ok {
+		t.Fatalf("expected NotLeaderError, got %v", err)
+	}
+	if err := current.KillJob("job1"); err == nil {
+		t.Fatalf("expected the stopped scheduler not to kill jobs")
+	}
+
+	// leading again starts a new scheduler, which recovers the jobs
+	sl.leadership <- make(chan struct{})
+	waitForLeader(t, s, true)
+	if started != 2 || s.getCurrent() == current {
+		t.Fatalf("expected a new scheduler once leading again, started %v", started)
+	}
+}
+
+// Runner whose aborts are held until released
+type heldAbortRunner struct {
+	runner.Service
+	release chan struct{}
+}
+
+func (r heldAbortRunner) Abort(id runner.RunID) (runner.RunStatus, error) {
+	<-r.release
+	return r.Service.Abort(id)
+}
+
+// The next term's scheduler isn't started until the task runners of the
+// scheduler that lost leadership have exited
+func Test_LeaderScheduler_WaitsForStoppedRunners(t *testing.T) {
+	jobDef := sched.GenJobDef(1)
+	for taskId, task := range jobDef.Tasks {
+		task.Argv = []string{"pause"}
+		jobDef.Tasks[taskId] = task
+	}
+	deps := getDefaultSchedDeps()
+	tmp, _ := temp.TempDirDefault()
+	release := make(chan struct{})
+	deps.rf = func(cluster.Node) runner.Service {
+		return heldAbortRunner{workers.MakeSimWorker(tmp), release}
+	}
+	deps.config.DefaultTaskTimeout = time.Minute
+	first := makeStatefulSchedulerDeps(deps)
+	jobId, _ := first.ScheduleJob(jobDef)
+	for len(first.inProgressJobs) == 0 || len(first.inProgressJobs[jobId].getRunningTasks()) == 0 {
+		first.step()
+	}
+
+	sl := &testLeadership{leadership: make(chan chan struct{})}
+	s := &leaderScheduler{}
+	schedulers := []*statefulScheduler{first, makeDefaultStatefulScheduler()}
+	started := make(chan struct{}, 2)
+	go s.lead(sl, func() (*statefulScheduler, func()) {
+		next := schedulers[0]
+		schedulers = schedulers[1:]
+		started <- struct{}{}
+		return next, func() {}
+	})
+
+	lost := make(chan struct{})
+	sl.leadership <- lost
+	<-started
+	close(lost)
+	go func() { sl.leadership <- make(chan struct{}) }()
+	select {
+	case <-started:
+		t.Fatalf("expected the next scheduler not to start while the stopped one's task is being aborted")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the next scheduler to start once the stopped one's task runner exited")
+	}
+}
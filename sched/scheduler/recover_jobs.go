@@ -31,12 +31,15 @@ func recoverJobs(sc saga.SagaCoordinator, addJobCh chan jobAddedMsg) {
 	}
 
 	// if there are no activeSagas return
-	if activeSagas == nil {
+	if len(activeSagas) == 0 {
 		return
 	}
 
 	log.Printf("DEBUG: Recovering Active Sagas %+v", activeSagas)
 
+	// jobs are ordered by when they were submitted, which is when their sagas were started
+	startTimes := sagaStartTimes(sc, activeSagas)
+
 	var wg sync.WaitGroup
 	wg.Add(len(activeSagas))
 
@@ -60,8 +63,9 @@ func recoverJobs(sc saga.SagaCoordinator, addJobCh chan jobAddedMsg) {
 				log.Printf("INFO: Rescheduling Saga %v", sagaId)
 				// reschedule saga
 				addJobCh <- jobAddedMsg{
-					job:  job,
-					saga: activeSaga,
+					job:       job,
+					saga:      activeSaga,
+					submitted: startTimes[sagaId],
 				}
 			}
 
@@ -71,6 +75,27 @@ func recoverJobs(sc saga.SagaCoordinator, addJobCh chan jobAddedMsg) {
 	wg.Wait()
 }
 
+// Returns when each of the sagas was started.  If the log can't say, the
+// sagas are left out, and their jobs are treated as submitted on recovery.
+func sagaStartTimes(sc saga.SagaCoordinator, sagaIds []string) map[string]time.Time {
+	ids := make(map[string]bool)
+	for _, sagaId := range sagaIds {
+		ids[sagaId] = true
+	}
+	startTimes := make(map[string]time.Time)
+	infos, err := sc.ListSagas(saga.SagaFilter{
+		Match: func(info saga.SagaInfo) bool { return ids[info.SagaId] },
+	})
+	if err != nil {
+		log.Printf("ERROR: occurred reading when the Active Sagas were started %v", err)
+		return startTimes
+	}
+	for _, info := range infos {
+		startTimes[info.SagaId] = info.StartTime
+	}
+	return startTimes
+}
+
 // Attempts to recover the specified saga from the provided SagaCoordinator
 // If the specified Saga exists and is still Active (no End Saga Message Logged) then
 // then a Saga will be returned.  If it does not nil will be returned.
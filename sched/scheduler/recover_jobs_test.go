@@ -83,7 +83,12 @@ func Test_RecoverJobs_ActiveSagas(t *testing.T) {
 	job1Data, _ := (&job1).Serialize()
 	job2Data, _ := (&job2).Serialize()
 
+	started := time.Now().Add(-time.Hour)
 	slog.EXPECT().GetActiveSagas().Return([]string{"saga1", "saga2"}, nil)
+	slog.EXPECT().ListSagas(gomock.Any()).Return([]saga.SagaInfo{
+		{SagaId: "saga1", StartTime: started},
+		{SagaId: "saga2", StartTime: started.Add(time.Minute)},
+	}, nil)
 	slog.EXPECT().GetMessages("saga1").Return([]saga.SagaMessage{
 		saga.MakeStartSagaMessage("saga1", job1Data),
 	}, nil)
@@ -110,6 +115,11 @@ func Test_RecoverJobs_ActiveSagas(t *testing.T) {
 	if _, ok2 := recoveredJobs["saga2"]; !ok2 {
 		t.Errorf("expected saga2 to be rescheduled")
 	}
+
+	// recovered jobs keep the order they were submitted in
+	if !recoveredJobs["saga1"].submitted.Equal(started) || !recoveredJobs["saga2"].submitted.Equal(started.Add(time.Minute)) {
+		t.Errorf("Expected the jobs to be submitted when their sagas were started, got %v", recoveredJobs)
+	}
 }
 
 // verifies that if recovering saga returns nil, then we don't add it
@@ -123,6 +133,7 @@ func Test_RecoverJob_NilActiveSaga(t *testing.T) {
 	jobData, _ := (&job).Serialize()
 
 	slog.EXPECT().GetActiveSagas().Return([]string{"saga1", "saga2"}, nil)
+	slog.EXPECT().ListSagas(gomock.Any()).Return(nil, errors.New("Test Error!"))
 	slog.EXPECT().GetMessages("saga1").Return([]saga.SagaMessage{
 		saga.MakeStartSagaMessage("saga1", jobData),
 	}, nil)
@@ -158,6 +169,7 @@ func Test_RecoverJob_NonDeSerializableJob(t *testing.T) {
 
 	sc, slog := makeMockSagaCoord(mockCtrl)
 	slog.EXPECT().GetActiveSagas().Return([]string{"saga1"}, nil)
+	slog.EXPECT().ListSagas(gomock.Any()).Return(nil, nil)
 	slog.EXPECT().GetMessages("saga1").Return([]saga.SagaMessage{
 		saga.MakeStartSagaMessage("saga1", []byte{0, 1, 2, 3, 4}),
 	}, nil)
@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"log"
+	"sort"
+
+	"github.com/scootdev/scoot/sched"
+)
+
+// Names of the scheduling policies, used to select one in SchedulerConfig
+const (
+	// Strict priority tiers, with weighted fair share between tenants
+	// within a tier.  The default.
+	FairSharePolicy = "fair_share"
+
+	// Largest tasks first, ignoring priorities and tenants
+	LargestFirstPolicy = "largest_first"
+)
+
+// Decides the order in which ready tasks are offered to the cluster.
+// getTaskAssignments places tasks in the order they're returned, so tasks
+// earlier in the list get the first pick of nodes.
+type schedulingPolicy interface {
+	// Returns the unscheduled tasks of the specified jobs, in the order
+	// they should be scheduled
+	orderTasks(jobs []*jobState) []*taskState
+
+	// Returns the tier of the job's tasks.  A task that doesn't fit on any
+	// node keeps the tasks of lower tiers from being placed until it is.
+	tier(job *jobState) int
+
+	// Name of the policy, reported in stats
+	name() string
+}
+
+// Creates the policy named in config.  Falls back to FairSharePolicy if
+// the name is empty or unknown.
+func makeSchedulingPolicy(config SchedulerConfig) schedulingPolicy {
+	switch config.Policy {
+	case LargestFirstPolicy:
+		return &largestFirstPolicy{}
+	case FairSharePolicy, "":
+	default:
+		log.Printf("Unknown scheduling policy %q, using %v", config.Policy, FairSharePolicy)
+	}
+	return &fairSharePolicy{weights: config.TenantWeights}
+}
+
+// Schedules all tasks of a higher priority job before those of a lower
+// priority job.  Within a priority tier tasks are interleaved between
+// tenants, each time picking the tenant using the fewest cpu slots relative
+// to its weight.  A tenant's tasks are ordered by job submission, then by
+// size, largest first.
+type fairSharePolicy struct {
+	// tenant to weight, tenants that aren't listed have a weight of 1
+	weights map[string]int
+}
+
+func (p *fairSharePolicy) name() string {
+	return FairSharePolicy
+}
+
+func (p *fairSharePolicy) tier(job *jobState) int {
+	return job.Job.Def.Priority
+}
+
+func (p *fairSharePolicy) weight(tenant string) int64 {
+	if w, ok := p.weights[tenant]; ok && w > 0 {
+		return int64(w)
+	}
+	return 1
+}
+
+func (p *fairSharePolicy) orderTasks(jobs []*jobState) []*taskState {
+	// cpu slots used by each tenant, including the tasks ordered so far
+	usage := make(map[string]int64)
+
+	// ready tasks by priority and then tenant
+	tiers := make(map[int]map[string][]*taskState)
+	for _, j := range sortedJobs(jobs) {
+		tenant := j.Job.Def.Tenant
+		for _, t := range j.Tasks {
+			if t.Status == sched.InProgress {
+				usage[tenant] += int64(t.Def.Resources.Normalized().CPUSlots)
+			}
+		}
+
+		ready := j.getUnScheduledTasks()
+		if len(ready) == 0 {
+			continue
+		}
+		sort.Sort(tasksBySizeDesc(ready))
+
+		priority := j.Job.Def.Priority
+		if tiers[priority] == nil {
+			tiers[priority] = make(map[string][]*taskState)
+		}
+		tiers[priority][tenant] = append(tiers[priority][tenant], ready...)
+	}
+
+	var priorities []int
+	for priority := range tiers {
+		priorities = append(priorities, priority)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+
+	var ordered []*taskState
+	for _, priority := range priorities {
+		queues := tiers[priority]
+		var tenants []string
+		for tenant := range queues {
+			tenants = append(tenants, tenant)
+		}
+		sort.Strings(tenants)
+
+		for len(tenants) > 0 {
+			// pick the tenant furthest below its share, ties go to
+			// the first tenant by name
+			next := 0
+			for i, tenant := range tenants[1:] {
+				if usage[tenant]*p.weight(tenants[next]) < usage[tenants[next]]*p.weight(tenant) {
+					next = i + 1
+				}
+			}
+
+			tenant := tenants[next]
+			task := queues[tenant][0]
+			ordered = append(ordered, task)
+			usage[tenant] += int64(task.Def.Resources.Normalized().CPUSlots)
+
+			queues[tenant] = queues[tenant][1:]
+			if len(queues[tenant]) == 0 {
+				tenants = append(tenants[:next], tenants[next+1:]...)
+			}
+		}
+	}
+	return ordered
+}
+
+// Schedules the largest tasks first regardless of which job they belong to,
+// which packs nodes most tightly.
+type largestFirstPolicy struct{}
+
+func (p *largestFirstPolicy) name() string {
+	return LargestFirstPolicy
+}
+
+// Every task is in the same tier
+func (p *largestFirstPolicy) tier(job *jobState) int {
+	return 0
+}
+
+func (p *largestFirstPolicy) orderTasks(jobs []*jobState) []*taskState {
+	var ordered []*taskState
+	for _, j := range jobs {
+		ordered = append(ordered, j.getUnScheduledTasks()...)
+	}
+	sort.Sort(tasksBySizeDesc(ordered))
+	return ordered
+}
+
+// Returns the jobs in the order they were submitted
+func sortedJobs(jobs []*jobState) []*jobState {
+	sorted := make([]*jobState, len(jobs))
+	copy(sorted, jobs)
+	sort.Sort(jobsBySubmission(sorted))
+	return sorted
+}
+
+type jobsBySubmission []*jobState
+
+func (j jobsBySubmission) Len() int      { return len(j) }
+func (j jobsBySubmission) Swap(a, b int) { j[a], j[b] = j[b], j[a] }
+func (j jobsBySubmission) Less(a, b int) bool {
+	if !j[a].Submitted.Equal(j[b].Submitted) {
+		return j[a].Submitted.Before(j[b].Submitted)
+	}
+	return j[a].Job.Id < j[b].Job.Id
+}
@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/sched"
+)
+
+// makes a jobState with numTasks unscheduled single slot tasks
+func makePolicyJob(jobId string, tenant string, priority int, numTasks int, submitted time.Time) *jobState {
+	j := &jobState{
+		Job: &sched.Job{
+			Id: jobId,
+			Def: sched.JobDefinition{
+				Tasks:    make(map[string]sched.TaskDefinition),
+				Priority: priority,
+				Tenant:   tenant,
+			},
+		},
+		Tasks:     make(map[string]*taskState),
+		Submitted: submitted,
+	}
+	for i := 0; i < numTasks; i++ {
+		taskId := fmt.Sprintf("task%d", i)
+		j.Tasks[taskId] = &taskState{
+			JobId:  jobId,
+			TaskId: taskId,
+			Status: sched.NotStarted,
+		}
+	}
+	return j
+}
+
+func orderedJobIds(tasks []*taskState) []string {
+	var ids []string
+	for _, t := range tasks {
+		ids = append(ids, t.JobId)
+	}
+	return ids
+}
+
+// Verifies that all tasks of a higher priority job are ordered first
+func Test_FairSharePolicy_PriorityTiers(t *testing.T) {
+	now := time.Now()
+	low := makePolicyJob("low", "tenant1", 0, 3, now)
+	high := makePolicyJob("high", "tenant2", 2, 2, now.Add(time.Second))
+
+	policy := makeSchedulingPolicy(SchedulerConfig{})
+	ordered := orderedJobIds(policy.orderTasks([]*jobState{low, high}))
+
+	expected := []string{"high", "high", "low", "low", "low"}
+	if fmt.Sprint(ordered) != fmt.Sprint(expected) {
+		t.Errorf("Expected tasks ordered %v, got %v", expected, ordered)
+	}
+}
+
+// Verifies that tenants in the same tier are interleaved by weight, and that
+// a tenant's jobs are ordered by submission
+func Test_FairSharePolicy_WeightedTenants(t *testing.T) {
+	now := time.Now()
+	big := makePolicyJob("big", "tenant1", 0, 10, now)
+	heavyFirst := makePolicyJob("heavyFirst", "tenant2", 0, 2, now.Add(time.Second))
+	heavySecond := makePolicyJob("heavySecond", "tenant2", 0, 2, now.Add(2*time.Second))
+
+	policy := makeSchedulingPolicy(SchedulerConfig{
+		TenantWeights: map[string]int{"tenant2": 2},
+	})
+	ordered := orderedJobIds(policy.orderTasks([]*jobState{heavySecond, big, heavyFirst}))
+
+	expected := []string{
+		"big", "heavyFirst", "heavyFirst", "big", "heavySecond", "heavySecond", "big", "big",
+	}
+	if fmt.Sprint(ordered[:len(expected)]) != fmt.Sprint(expected) {
+		t.Errorf("Expected tasks ordered %v..., got %v", expected, ordered)
+	}
+}
+
+// Verifies that running tasks count towards a tenant's share
+func Test_FairSharePolicy_RunningTasksCount(t *testing.T) {
+	now := time.Now()
+	busy := makePolicyJob("busy", "tenant1", 0, 4, now)
+	busy.Tasks["task0"].Status = sched.InProgress
+	busy.Tasks["task1"].Status = sched.InProgress
+	idle := makePolicyJob("idle", "tenant2", 0, 2, now)
+
+	policy := makeSchedulingPolicy(SchedulerConfig{Policy: FairSharePolicy})
+	ordered := orderedJobIds(policy.orderTasks([]*jobState{busy, idle}))
+
+	expected := []string{"idle", "idle", "busy", "busy"}
+	if fmt.Sprint(ordered) != fmt.Sprint(expected) {
+		t.Errorf("Expected tasks ordered %v, got %v", expected, ordered)
+	}
+}
+
+// Verifies that the largest first policy ignores priorities
+func Test_LargestFirstPolicy(t *testing.T) {
+	now := time.Now()
+	small := makePolicyJob("small", "tenant1", 5, 1, now)
+	large := makePolicyJob("large", "tenant2", 0, 1, now)
+	large.Tasks["task0"].Def.Resources = sched.Resources{CPUSlots: 2}
+
+	policy := makeSchedulingPolicy(SchedulerConfig{Policy: LargestFirstPolicy})
+	if policy.name() != LargestFirstPolicy {
+		t.Fatalf("Expected %v policy, got %v", LargestFirstPolicy, policy.name())
+	}
+	ordered := orderedJobIds(policy.orderTasks([]*jobState{small, large}))
+
+	expected := []string{"large", "small"}
+	if fmt.Sprint(ordered) != fmt.Sprint(expected) {
+		t.Errorf("Expected tasks ordered %v, got %v", expected, ordered)
+	}
+}
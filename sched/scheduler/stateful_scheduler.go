@@ -1,8 +1,11 @@
 package scheduler
 
 import (
+	"fmt"
 	"log"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	uuid "github.com/nu7hatch/gouuid"
@@ -12,26 +15,74 @@ import (
 	"github.com/scootdev/scoot/runner"
 	"github.com/scootdev/scoot/saga"
 	"github.com/scootdev/scoot/sched"
+	"github.com/scootdev/scoot/workerapi"
 )
 
 // Scheduler Config variables read at initialization
 // MaxRetriesPerTask - the number of times to retry a failing task before
-//     marking it as completed.
+//     marking it as completed, unless the task's RetryPolicy says otherwise.
 // DebugMode - if true, starts the scheduler up but does not start
 //     the update loop.  Instead the loop must be advanced manually
 //     by calling step()
 // RecoverJobsOnStartup - if true, the scheduler recovers active sagas,
 //             from the sagalog, and restarts them.
+// SnapshotAffinityWait - how long a task waits for a node that recently
+//             checked out its snapshot before running on any free node.
+// Policy - name of the policy deciding which tasks are scheduled first,
+//             FairSharePolicy if empty.
+// TenantWeights - share of the cluster each tenant gets under FairSharePolicy,
+//             relative to other tenants.  Unlisted tenants have a weight of 1.
+// StragglerMultiplier - a task running this many times longer than its
+//             expected duration, or than the median of its job's finished
+//             tasks, gets a backup copy on an idle node.  0 disables backups.
+// PinSnapshots - whether the bundlestore collects bundles, so the snapshots
+//             of the jobs in progress need pinning.
+// SnapshotPinner - if set, pins the snapshots of the jobs in progress so
+//             they aren't collected while in use.
+// WorkerClientFactory - if set, makes clients to read the resources nodes'
+//             workers offer.  Otherwise every node is assumed to offer a
+//             single cpu slot and unbounded memory.
 type SchedulerConfig struct {
 	MaxRetriesPerTask    int
 	DebugMode            bool
 	RecoverJobsOnStartup bool
 	DefaultTaskTimeout   time.Duration
 	RunnerOverhead       time.Duration
+	SnapshotAffinityWait time.Duration
+	Policy               string
+	TenantWeights        map[string]int
+	StragglerMultiplier  float64
+	PinSnapshots         bool
+	SnapshotPinner       SnapshotPinner
+	WorkerClientFactory  WorkerClientFactory
 }
 
 type RunnerFactory func(node cluster.Node) runner.Service
 
+// Pins snapshots for a duration, so that the data they're made from isn't
+// collected while jobs use them.
+type SnapshotPinner interface {
+	PinSnapshots(snapshotIDs []string, d time.Duration) error
+}
+
+// The snapshots of jobs in progress are pinned this often, for
+// snapshotPinLease, so that their pins lapse a while after the jobs finish
+// (or after the scheduler that pinned them stops).
+const snapshotPinInterval = time.Minute
+const snapshotPinLease = 5 * time.Minute
+
+// Reports the status of a node's worker, including the resources it offers.
+type WorkerClient interface {
+	QueryWorker() (workerapi.WorkerStatus, error)
+	Close() error
+}
+
+type WorkerClientFactory func(node cluster.Node) WorkerClient
+
+// Nodes' capacities are read again this often, in case their workers
+// restarted offering something else.
+const nodeCapacityRefreshInterval = 5 * time.Minute
+
 // Scheduler that keeps track of the state of running tasks & the cluster
 // so that it can make smarter scheduling decisions
 //
@@ -43,19 +94,34 @@ type RunnerFactory func(node cluster.Node) runner.Service
 // The callbacks are executed as part of the scheduler loop.  They therefore can
 // safely read & modify the scheduler state.
 type statefulScheduler struct {
-	sagaCoord     saga.SagaCoordinator
-	runnerFactory RunnerFactory
+	sagaCoord           saga.SagaCoordinator
+	runnerFactory       RunnerFactory
+	workerClientFactory WorkerClientFactory
 	asyncRunner   async.Runner
 	addJobCh      chan jobAddedMsg
+	killJobCh     chan jobKillRequest
+	stopCh        chan struct{} // closed by stop()
+	stoppedCh     chan struct{} // closed once the stopped loop's work has exited
+	debugMode     bool
 
 	// Scheduler config
-	maxRetriesPerTask  int
-	defaultTaskTimeout time.Duration
-	runnerOverhead     time.Duration
+	maxRetriesPerTask    int
+	defaultTaskTimeout   time.Duration
+	runnerOverhead       time.Duration
+	snapshotAffinityWait time.Duration
+	stragglerMultiplier  float64
+	policy               schedulingPolicy
+	snapshotPinner       SnapshotPinner
 
 	// Scheduler State
 	clusterState   *clusterState
 	inProgressJobs map[string]*jobState // map of inprogress jobId to jobState
+	tenants        map[string]bool      // tenants that have submitted jobs, for stats
+
+	// Snapshot pinning state
+	lastSnapshotPin  time.Time
+	pinningSnapshots bool // whether a pin is in progress
+	jobsToPin        bool // whether jobs were added since the last pin
 
 	// stats
 	stat stats.StatsReceiver
@@ -101,22 +167,32 @@ func NewStatefulScheduler(
 ) *statefulScheduler {
 
 	sched := &statefulScheduler{
-		sagaCoord:     sc,
-		runnerFactory: rf,
+		sagaCoord:           sc,
+		runnerFactory:       rf,
+		workerClientFactory: config.WorkerClientFactory,
 		asyncRunner:   async.NewRunner(),
 		addJobCh:      make(chan jobAddedMsg, 1),
-
-		maxRetriesPerTask:  config.MaxRetriesPerTask,
-		defaultTaskTimeout: config.DefaultTaskTimeout,
-		runnerOverhead:     config.RunnerOverhead,
+		killJobCh:     make(chan jobKillRequest, 1),
+		stopCh:        make(chan struct{}),
+		stoppedCh:     make(chan struct{}),
+		debugMode:     config.DebugMode,
+
+		maxRetriesPerTask:    config.MaxRetriesPerTask,
+		defaultTaskTimeout:   config.DefaultTaskTimeout,
+		runnerOverhead:       config.RunnerOverhead,
+		snapshotAffinityWait: config.SnapshotAffinityWait,
+		stragglerMultiplier:  config.StragglerMultiplier,
+		policy:               makeSchedulingPolicy(config),
+		snapshotPinner:       config.SnapshotPinner,
 
 		clusterState:   newClusterState(initialCluster, clusterUpdates),
 		inProgressJobs: make(map[string]*jobState),
+		tenants:        make(map[string]bool),
 		stat:           stat,
 	}
 
-	log.Printf("INFO: Creating Scheduler, Debug Mode %v, Recover Active Sagas %v",
-		config.DebugMode, config.RecoverJobsOnStartup)
+	log.Printf("INFO: Creating Scheduler, Debug Mode %v, Recover Active Sagas %v, Policy %v",
+		config.DebugMode, config.RecoverJobsOnStartup, sched.policy.name())
 
 	if !config.DebugMode {
 		// start the scheduler loop
@@ -128,8 +204,18 @@ func NewStatefulScheduler(
 	// Recover Jobs in a separate go routine to allow the scheduler
 	// to accept new jobs while recovering old ones.
 	if config.RecoverJobsOnStartup {
+		recovered := make(chan jobAddedMsg)
 		go func() {
-			recoverJobs(sched.sagaCoord, sched.addJobCh)
+			recoverJobs(sched.sagaCoord, recovered)
+			close(recovered)
+		}()
+		go func() {
+			for msg := range recovered {
+				select {
+				case sched.addJobCh <- msg:
+				case <-sched.stopCh:
+				}
+			}
 		}()
 	}
 	return sched
@@ -138,6 +224,9 @@ func NewStatefulScheduler(
 type jobAddedMsg struct {
 	job  *sched.Job
 	saga *saga.Saga
+
+	// when the job was submitted, i.e. its saga was started, now if zero
+	submitted time.Time
 }
 
 func (s *statefulScheduler) ScheduleJob(jobDef sched.JobDefinition) (string, error) {
@@ -154,21 +243,85 @@ func (s *statefulScheduler) ScheduleJob(jobDef sched.JobDefinition) (string, err
 		return "", err
 	}
 
+	submitted := time.Now()
 	// Log StartSaga Message
-	sagaObj, err := s.sagaCoord.MakeSaga(job.Id, asBytes)
+	sagaObj, err := s.sagaCoord.MakeSagaWithLabels(job.Id, asBytes, job.Labels())
 	if err != nil {
 		return "", err
 	}
 
 	s.stat.Counter("schedJobsCounter").Inc(1)
-	s.addJobCh <- jobAddedMsg{
-		job:  job,
-		saga: sagaObj,
+	select {
+	case s.addJobCh <- jobAddedMsg{job: job, saga: sagaObj, submitted: submitted}:
+	case <-s.stopCh:
+		// the next leader recovers the job from its saga
 	}
 
 	return job.Id, nil
 }
 
+// Error returned by a Scheduler that isn't the leader of its saga log's
+// group, so it isn't scheduling jobs.  The request can be retried once
+// another scheduler, or this one, leads the group.
+type NotLeaderError struct{}
+
+func (e *NotLeaderError) Error() string {
+	return "not the saga log leader, retry once a leader is elected"
+}
+
+// Error returned by KillJob when the specified job is not in progress
+// on this scheduler, i.e. it doesn't exist or has already completed.
+type JobNotInProgressError struct {
+	JobId string
+}
+
+func (e *JobNotInProgressError) Error() string {
+	return fmt.Sprintf("job %v is not in progress", e.JobId)
+}
+
+type jobKillRequest struct {
+	jobId      string
+	responseCh chan error
+}
+
+// Kills the specified job.  No more of the job's tasks will be scheduled,
+// its running tasks are aborted and its saga is aborted & rolled back.
+// Returns once the AbortSaga message has been logged, the roll back happens
+// asynchronously.
+func (s *statefulScheduler) KillJob(jobId string) error {
+	defer s.stat.Latency("schedKillJobLatency_ms").Time().Stop()
+	s.stat.Counter("schedKillJobRequestsCounter").Inc(1)
+
+	responseCh := make(chan error, 1)
+	select {
+	case s.killJobCh <- jobKillRequest{jobId: jobId, responseCh: responseCh}:
+	case <-s.stopCh:
+		return &NotLeaderError{}
+	}
+
+	select {
+	case err := <-responseCh:
+		return err
+	case <-s.stopCh:
+		return &NotLeaderError{}
+	}
+}
+
+// Stops the scheduler loop once it finishes its current step, and aborts
+// the tasks it's running.  The jobs aren't ended, they're left for the next
+// leader to recover from the saga log.  Returns once the tasks' runners, and
+// the rest of the scheduler's async work, have exited so nothing more is
+// logged to the jobs' sagas.
+func (s *statefulScheduler) stop() {
+	close(s.stopCh)
+	if s.debugMode {
+		// there's no loop, the caller steps the scheduler
+		s.abortRunningTasks()
+		return
+	}
+	<-s.stoppedCh
+}
+
 // generates a jobId using a random uuid
 func generateJobId() string {
 
@@ -182,9 +335,16 @@ func generateJobId() string {
 	}
 }
 
-// run the scheduler loop indefinitely
+// run the scheduler loop until it's stopped
 func (s *statefulScheduler) loop() {
 	for {
+		select {
+		case <-s.stopCh:
+			s.abortRunningTasks()
+			close(s.stoppedCh)
+			return
+		default:
+		}
 		s.step()
 		numTasks := int64(0)
 		for _, job := range s.inProgressJobs {
@@ -193,16 +353,72 @@ func (s *statefulScheduler) loop() {
 		s.stat.Gauge("schedInProgressJobsGauge").Update(int64(len(s.inProgressJobs)))
 		s.stat.Gauge("schedInProgressTasksGauge").Update(numTasks)
 		s.stat.Gauge("schedNumRunningTasksGauge").Update(int64(s.asyncRunner.NumRunning()))
+		s.updatePolicyStats()
 	}
 }
 
+// Aborts the running tasks of a stopped scheduler, and waits for them and
+// the rest of the async work in progress to exit.  Their callbacks are
+// never processed.
+func (s *statefulScheduler) abortRunningTasks() {
+	var aborting sync.WaitGroup
+	for _, job := range s.inProgressJobs {
+		for _, tr := range job.getRunningTasks() {
+			aborting.Add(1)
+			go func(tr *taskRunner) {
+				defer aborting.Done()
+				if err := tr.abort(); err != nil {
+					log.Printf("Error aborting task %v of Job %v: %v", tr.taskId, tr.saga.GetState().SagaId(), err)
+				}
+			}(tr)
+		}
+	}
+	aborting.Wait()
+	s.asyncRunner.Wait()
+}
+
+// Reports the scheduling policy in use, and the tasks each tenant has
+// running & waiting to be scheduled
+func (s *statefulScheduler) updatePolicyStats() {
+	s.stat.Scope("policies", s.policy.name()).Gauge("schedActivePolicyGauge").Update(1)
+
+	running := make(map[string]int64)
+	ready := make(map[string]int64)
+	for _, job := range s.inProgressJobs {
+		tenant := tenantStatName(job.Job.Def.Tenant)
+		s.tenants[tenant] = true
+		for _, task := range job.Tasks {
+			if task.Status == sched.InProgress {
+				running[tenant]++
+			}
+		}
+		ready[tenant] += int64(len(job.getUnScheduledTasks()))
+	}
+
+	// tenants with no jobs left are reported as idle
+	for tenant := range s.tenants {
+		s.stat.Scope("tenants", tenant).Gauge("schedRunningTasksGauge").Update(running[tenant])
+		s.stat.Scope("tenants", tenant).Gauge("schedReadyTasksGauge").Update(ready[tenant])
+	}
+}
+
+// Name a tenant is reported under in stats
+func tenantStatName(tenant string) string {
+	if tenant == "" {
+		return "default"
+	}
+	return tenant
+}
+
 // run one loop iteration
 func (s *statefulScheduler) step() {
 	// update scheduler state with messages received since last loop
 	// nodes added or removed to cluster, new jobs scheduled,
 	// async functions completed & invoke callbacks
 	s.addJobs()
+	s.killJobs()
 	s.clusterState.updateCluster()
+	s.fetchNodeCapacities()
 	s.asyncRunner.ProcessMessages()
 
 	// TODO: make processUpdates on scheduler state wait until an update
@@ -211,7 +427,46 @@ func (s *statefulScheduler) step() {
 	// have occurred
 
 	s.checkForCompletedJobs()
+	s.pinSnapshots()
 	s.scheduleTasks()
+	s.startBackupTasks()
+}
+
+// Asynchronously pins the snapshots of the jobs in progress, when jobs were
+// added or the last pin is about to lapse
+func (s *statefulScheduler) pinSnapshots() {
+	if s.snapshotPinner == nil || s.pinningSnapshots || len(s.inProgressJobs) == 0 {
+		return
+	}
+	if !s.jobsToPin && time.Since(s.lastSnapshotPin) < snapshotPinInterval {
+		return
+	}
+
+	seen := make(map[string]bool)
+	ids := []string{}
+	for _, job := range s.inProgressJobs {
+		for _, task := range job.Tasks {
+			if id := task.Def.SnapshotID; id != "" && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	s.pinningSnapshots, s.jobsToPin = true, false
+	s.asyncRunner.RunAsync(
+		func() error {
+			return s.snapshotPinner.PinSnapshots(ids, snapshotPinLease)
+		},
+		func(err error) {
+			s.pinningSnapshots = false
+			if err != nil {
+				// retried next interval, before the previous pins lapse
+				log.Printf("Error pinning the snapshots of jobs in progress: %v", err)
+				s.stat.Counter("schedSnapshotPinErrorCounter").Inc(1)
+			}
+			s.lastSnapshotPin = time.Now()
+		})
 }
 
 // Checks if any new jobs have been scheduled since the last loop and adds
@@ -219,18 +474,148 @@ func (s *statefulScheduler) step() {
 func (s *statefulScheduler) addJobs() {
 	select {
 	case newJobMsg := <-s.addJobCh:
-		s.inProgressJobs[newJobMsg.job.Id] = newJobState(newJobMsg.job, newJobMsg.saga)
+		js := newJobState(newJobMsg.job, newJobMsg.saga)
+		if !newJobMsg.submitted.IsZero() {
+			js.Submitted = newJobMsg.submitted
+		}
+		s.inProgressJobs[newJobMsg.job.Id] = js
+		s.jobsToPin = true
+	default:
+	}
+}
+
+// Asynchronously reads the capacity of nodes which haven't reported it yet,
+// or last reported it over nodeCapacityRefreshInterval ago.
+func (s *statefulScheduler) fetchNodeCapacities() {
+	now := time.Now()
+	for _, ns := range s.clusterState.nodes {
+		if ns.fetchingCapacity || (ns.capacityKnown && now.Sub(ns.capacityReadAt) < nodeCapacityRefreshInterval) {
+			continue
+		}
+
+		nodeId := ns.node.Id()
+		if s.workerClientFactory == nil {
+			s.clusterState.capacityFetched(nodeId, defaultNodeCapacity)
+			continue
+		}
+
+		ns.fetchingCapacity = true
+		node := ns.node
+		fetching := ns
+		var capacity sched.Resources
+		s.asyncRunner.RunAsync(
+			func() error {
+				cl := s.workerClientFactory(node)
+				defer cl.Close()
+				st, err := cl.QueryWorker()
+				capacity = sched.Resources{
+					CPUSlots:    st.Capacity.CPUSlots,
+					MemoryBytes: st.Capacity.MemoryBytes,
+				}
+				return err
+			},
+			func(err error) {
+				// the node was removed, and maybe added back, while it was read
+				if cur, ok := s.clusterState.getNodeState(nodeId); !ok || cur != fetching {
+					return
+				}
+				if err != nil {
+					log.Printf("Error reading capacity of node %v, assuming %+v: %v", nodeId, defaultNodeCapacity, err)
+					s.stat.Counter("schedFailedCapacityFetchCounter").Inc(1)
+					capacity = defaultNodeCapacity
+				}
+				s.clusterState.capacityFetched(nodeId, capacity)
+				fetching.capacityAssumed = err != nil
+			})
+	}
+}
+
+// Checks if any jobs have been killed since the last loop.  A killed job
+// stops scheduling tasks & an AbortSaga message is logged asynchronously.
+// Once it's logged the job's running tasks are aborted.
+func (s *statefulScheduler) killJobs() {
+	select {
+	case req := <-s.killJobCh:
+		jobState, ok := s.inProgressJobs[req.jobId]
+		if !ok || jobState.EndingSaga {
+			req.responseCh <- &JobNotInProgressError{JobId: req.jobId}
+			return
+		}
+
+		// already killed, nothing more to do
+		if jobState.Killed {
+			req.responseCh <- nil
+			return
+		}
+
+		jobState.Killed = true
+		j := jobState
+
+		s.asyncRunner.RunAsync(
+			func() error {
+				return j.Saga.AbortSaga()
+			},
+			func(err error) {
+				if err != nil {
+					// allow the job to make progress again, the kill
+					// can be retried by the caller
+					j.Killed = false
+					s.stat.Counter("schedFailedAbortSagaCounter").Inc(1)
+					req.responseCh <- err
+					return
+				}
+
+				log.Printf("Job %v Killed \n", j.Job.Id)
+				j.Aborted = true
+				s.stat.Counter("schedKilledJobsCounter").Inc(1)
+				for _, tr := range j.getRunningTasks() {
+					s.asyncRunner.RunAsync(tr.abort, func(err error) {
+						if err != nil {
+							log.Printf("Error aborting task %v of killed Job %v: %v", tr.taskId, j.Job.Id, err)
+						}
+					})
+				}
+				req.responseCh <- nil
+			})
 	default:
 	}
 }
 
 // checks if any of the in progress jobs are completed.  If a job is
-// completed log an EndSaga Message to the SagaLog asynchronously
+// completed log an EndSaga Message to the SagaLog asynchronously.
+// Killed jobs are completed once none of their tasks are running, they
+// are rolled back before the EndSaga Message is logged.
 func (s *statefulScheduler) checkForCompletedJobs() {
 
+	// Check For Rolled Back Jobs & Log EndSaga Message
+	for _, jobState := range s.inProgressJobs {
+		if jobState.Aborted && !jobState.EndingSaga && len(jobState.getRunningTasks()) == 0 {
+
+			// mark job as being rolled back
+			jobState.EndingSaga = true
+
+			j := jobState
+
+			s.asyncRunner.RunAsync(
+				func() error {
+					return rollbackSaga(j.Saga)
+				},
+				func(err error) {
+					if err == nil {
+						log.Printf("Job %v Rolled Back \n", j.Job.Id)
+						delete(s.inProgressJobs, j.Job.Id)
+					} else {
+						// will retry rolling back on next scheduler loop
+						j.EndingSaga = false
+						s.stat.Counter("schedRetriedEndSagaCounter").Inc(1)
+					}
+				})
+		}
+	}
+
 	// Check For Completed Jobs & Log EndSaga Message
 	for _, jobState := range s.inProgressJobs {
-		if jobState.getJobStatus() == sched.Completed && !jobState.EndingSaga {
+		if jobState.getJobStatus() == sched.Completed && !jobState.EndingSaga && !jobState.Killed {
 
 			// mark job as being completed
 			jobState.EndingSaga = true
@@ -261,14 +646,19 @@ func (s *statefulScheduler) checkForCompletedJobs() {
 
 // figures out which tasks to schedule next and on which worker and then runs them
 func (s *statefulScheduler) scheduleTasks() {
-	// Get a list of all available tasks to be ran
-	var unscheduledTasks []*taskState
+	// Get a list of all available tasks to be ran, in the order the policy
+	// wants them scheduled
+	var jobs []*jobState
 	for _, jobState := range s.inProgressJobs {
-		unscheduledTasks = append(unscheduledTasks, jobState.getUnScheduledTasks()...)
+		jobs = append(jobs, jobState)
 	}
+	unscheduledTasks := s.failOversizedTasks(s.policy.orderTasks(jobs))
 
 	// Calculate a list of Tasks to Node Assignments & start running all those jobs
-	taskAssignments := getTaskAssignments(s.clusterState, unscheduledTasks)
+	tier := func(task *taskState) int {
+		return s.policy.tier(s.inProgressJobs[task.JobId])
+	}
+	taskAssignments := getTaskAssignments(s.clusterState, unscheduledTasks, tier, s.snapshotAffinityWait, time.Now())
 	for _, ta := range taskAssignments {
 
 		// Set up variables for async functions & callback
@@ -278,12 +668,11 @@ func (s *statefulScheduler) scheduleTasks() {
 		saga := s.inProgressJobs[jobId].Saga
 		jobState := s.inProgressJobs[jobId]
 		nodeId := ta.node.Id()
+		// workers running several tasks at once queue them by their job's priority
+		taskDef.Priority = jobState.Job.Def.Priority
 
-		preventRetries := bool(ta.task.NumTimesTried >= s.maxRetriesPerTask)
-
-		// Mark Task as Started
-		s.clusterState.taskScheduled(nodeId, taskId)
-		jobState.taskStarted(taskId)
+		maxAttempts := taskDef.Retry.Attempts(s.maxRetriesPerTask + 1)
+		preventRetries := bool(ta.task.NumTimesTried+1 >= maxAttempts)
 
 		runner := &taskRunner{
 			saga:   saga,
@@ -294,29 +683,156 @@ func (s *statefulScheduler) scheduleTasks() {
 			runnerOverhead:        s.runnerOverhead,
 			markCompleteOnFailure: preventRetries,
 
-			taskId: taskId,
-			task:   taskDef,
+			taskId:   taskId,
+			task:     taskDef,
+			nodeId:   string(nodeId),
+			attempts: ta.task.Attempts,
 		}
+		runner.copies = newTaskCopies(runner)
 
+		// Mark Task as Started
+		s.clusterState.taskScheduled(nodeId, jobId, taskId, taskDef)
+		jobState.taskStarted(taskId, runner)
+		s.stat.Scope("tenants", tenantStatName(jobState.Job.Def.Tenant)).Counter("schedScheduledTasksCounter").Inc(1)
+		s.stat.Scope("priorities", strconv.Itoa(jobState.Job.Def.Priority)).Counter("schedScheduledTasksCounter").Inc(1)
+
+		s.asyncRunner.RunAsync(runner.run, s.taskRunCallback(jobState, runner, nodeId))
+	}
+}
+
+// Fails the tasks that need more resources than any node in the cluster
+// offers, which would otherwise wait to be scheduled forever.  Returns the
+// other tasks, in the same order.
+func (s *statefulScheduler) failOversizedTasks(tasks []*taskState) []*taskState {
+	var fitting []*taskState
+	for _, task := range tasks {
+		needed := task.Def.Resources.Normalized()
+		if s.clusterState.fitsSomeNode(needed) {
+			fitting = append(fitting, task)
+			continue
+		}
+
+		jobState := s.inProgressJobs[task.JobId]
+		taskId := task.TaskId
+		st := runner.RunStatus{
+			State: runner.BADREQUEST,
+			Error: fmt.Sprintf("task needs %v cpu slots and %v bytes of memory, more than any node offers",
+				needed.CPUSlots, needed.MemoryBytes),
+		}
+		tr := &taskRunner{
+			saga:     jobState.Saga,
+			stat:     s.stat,
+			taskId:   taskId,
+			task:     task.Def,
+			attempts: task.Attempts,
+		}
+		log.Printf("Failing task %v of Job %v: %v", taskId, task.JobId, st.Error)
+		s.stat.Counter("schedOversizedTasksCounter").Inc(1)
+
+		jobState.taskStarted(taskId, nil)
 		s.asyncRunner.RunAsync(
-			runner.run,
+			func() error {
+				if err := tr.logTaskStatus(nil, saga.StartTask); err != nil {
+					return err
+				}
+				return tr.logTaskStatus(&st, saga.EndTask)
+			},
 			func(err error) {
-				// update the jobState
-				if err == nil {
-					log.Println("Ending task", taskId, " command:", strings.Join(taskDef.Argv, " "))
-
-					jobState.taskCompleted(taskId)
-				} else {
-					retry := "(will be retried)"
-					if preventRetries {
-						retry = "(will not be retried)"
-					}
-					log.Println("Error running task ", taskId, " command:", strings.Join(taskDef.Argv, " "), retry)
-					jobState.errorRunningTask(taskId, err)
+				if err != nil {
+					jobState.errorRunningTask(taskId, err, nil)
+					return
 				}
-
-				// update cluster state that this node is now free
-				s.clusterState.taskCompleted(nodeId, taskId)
+				jobState.taskCompleted(taskId, true)
 			})
 	}
+	return fitting
+}
+
+// Starts a backup copy of each straggling task on an idle node.  Whichever
+// copy finishes first decides the task's outcome and the other is aborted.
+// Backups only use spare capacity, none are started while tasks are waiting
+// to be scheduled.
+func (s *statefulScheduler) startBackupTasks() {
+	if s.stragglerMultiplier <= 0 {
+		return
+	}
+
+	var jobs []*jobState
+	for _, jobState := range s.inProgressJobs {
+		if len(jobState.getUnScheduledTasks()) > 0 {
+			return
+		}
+		jobs = append(jobs, jobState)
+	}
+	now := time.Now()
+	for _, task := range findStragglers(jobs, s.stragglerMultiplier, now) {
+		original := task.Runner
+		node, ok := getBackupNode(s.clusterState, task, cluster.NodeId(original.nodeId))
+		if !ok {
+			continue
+		}
+		backup := original.makeBackup(s.runnerFactory(node), string(node.Id()))
+		if backup == nil {
+			// the original just finished
+			continue
+		}
+
+		log.Printf("Task %v of job %v has run for %v on node %v, starting a backup copy on node %v",
+			task.TaskId, task.JobId, now.Sub(task.Started), original.nodeId, node.Id())
+		jobState := s.inProgressJobs[task.JobId]
+		s.clusterState.taskScheduled(node.Id(), task.JobId, task.TaskId, task.Def)
+		jobState.backupStarted(task.TaskId, backup)
+		s.stat.Counter("schedBackupTasksCounter").Inc(1)
+
+		s.asyncRunner.RunAsync(backup.run, s.taskRunCallback(jobState, backup, node.Id()))
+	}
+}
+
+// Returns the callback that updates the scheduler state once the specified
+// copy of a task, running on the specified node, finishes
+func (s *statefulScheduler) taskRunCallback(jobState *jobState, tr *taskRunner, nodeId cluster.NodeId) func(error) {
+	jobId := jobState.Job.Id
+	taskId := tr.taskId
+	return func(err error) {
+		// update the jobState
+		switch err {
+		case nil:
+			log.Println("Ending task", taskId, " command:", strings.Join(tr.task.Argv, " "))
+
+			jobState.taskCompleted(taskId, tr.failed)
+		case errTaskSuperseded:
+			// the copy that decided the task's outcome updates the jobState
+		default:
+			retry := "(will be retried)"
+			if tr.markCompleteOnFailure {
+				retry = "(will not be retried)"
+			}
+			log.Println("Error running task ", taskId, " command:", strings.Join(tr.task.Argv, " "), retry)
+			jobState.errorRunningTask(taskId, err, tr.runs)
+		}
+
+		// update cluster state that this node is now free
+		s.clusterState.taskCompleted(nodeId, jobId, taskId)
+	}
+}
+
+// Logs compensating task messages for every started task of an aborted saga
+// and then ends it.  Scoot tasks have no side effects that need to be undone,
+// so the compensating tasks are no-ops.
+func rollbackSaga(s *saga.Saga) error {
+	state := s.GetState()
+	for _, taskId := range state.GetTaskIds() {
+		if !state.IsTaskStarted(taskId) || state.IsCompTaskCompleted(taskId) {
+			continue
+		}
+		if !state.IsCompTaskStarted(taskId) {
+			if err := s.StartCompensatingTask(taskId, nil); err != nil {
+				return err
+			}
+		}
+		if err := s.EndCompensatingTask(taskId, nil); err != nil {
+			return err
+		}
+	}
+	return s.EndSaga()
 }
@@ -15,7 +15,7 @@ func Test_StatefulScheduler_TasksDistributedEvenly(t *testing.T) {
 	s := makeDefaultStatefulScheduler()
 
 	//initialize NodeMap to keep track of tasks per node
-	taskMap := make(map[string]cluster.NodeId)
+	taskMap := make(map[taskKey]cluster.NodeId)
 
 	/*jobId, _ :=*/ s.ScheduleJob(jobDef)
 	s.step()
@@ -24,8 +24,8 @@ func Test_StatefulScheduler_TasksDistributedEvenly(t *testing.T) {
 		s.step()
 
 		for nodeId, state := range s.clusterState.nodes {
-			if state.runningTask != noTask {
-				taskMap[state.runningTask] = nodeId
+			for key, _ := range state.runningTasks {
+				taskMap[key] = nodeId
 			}
 		}
 	}
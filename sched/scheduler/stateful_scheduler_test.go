@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/scootdev/scoot/sched"
 	"github.com/scootdev/scoot/sched/worker/workers"
 	"github.com/scootdev/scoot/snapshot/snapshots"
+	"github.com/scootdev/scoot/workerapi"
 )
 
 // objects needed to initialize a stateful scheduler
@@ -89,7 +91,7 @@ func Test_StatefulScheduler_ScheduleJobSuccess(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga(gomock.Any(), gomock.Any())
+	sagaLogMock.EXPECT().StartSaga(gomock.Any(), gomock.Any(), gomock.Any())
 
 	deps := getDefaultSchedDeps()
 	deps.sc = saga.MakeSagaCoordinator(sagaLogMock)
@@ -112,7 +114,7 @@ func Test_StatefulScheduler_ScheduleJobFailure(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga(gomock.Any(), gomock.Any()).Return(errors.New("test error"))
+	sagaLogMock.EXPECT().StartSaga(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("test error"))
 
 	deps := getDefaultSchedDeps()
 	deps.sc = saga.MakeSagaCoordinator(sagaLogMock)
@@ -253,7 +255,7 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga(gomock.Any(), gomock.Any())
+	sagaLogMock.EXPECT().StartSaga(gomock.Any(), gomock.Any(), gomock.Any())
 
 	deps.sc = saga.MakeSagaCoordinator(sagaLogMock)
 
@@ -276,7 +278,7 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 	}
 
 	// verify scheduler state updated appropriately
-	if s.clusterState.nodes["node1"].runningTask != taskId {
+	if _, ok := s.clusterState.nodes["node1"].runningTasks[taskKey{jobId, taskId}]; !ok {
 		t.Errorf("Expected %v to be scheduled on node1.  nodestate: %+v", taskId, s.clusterState.nodes["node1"])
 	}
 
@@ -286,7 +288,7 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 	}
 
 	// verify state changed appropriately
-	if s.clusterState.nodes["node1"].runningTask != noTask {
+	if len(s.clusterState.nodes["node1"].runningTasks) != 0 {
 		t.Errorf("Expected node1 to not have any running tasks")
 	}
 
@@ -304,3 +306,311 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 		s.step()
 	}
 }
+
+// A task needing more cpu slots than any node offers is failed instead of
+// waiting to be scheduled forever
+func Test_StatefulScheduler_FailsOversizedTask(t *testing.T) {
+	jobDef := sched.GenJobDef(1)
+	var taskId string
+	for id, task := range jobDef.Tasks {
+		task.Resources.CPUSlots = 2
+		jobDef.Tasks[id] = task
+		taskId = id
+	}
+
+	s := makeDefaultStatefulScheduler()
+	jobId, _ := s.ScheduleJob(jobDef)
+	s.step()
+	for s.inProgressJobs[jobId].Tasks[taskId].Status != sched.Completed {
+		s.step()
+	}
+	if !s.inProgressJobs[jobId].Tasks[taskId].Failed {
+		t.Errorf("Expected the oversized task to fail")
+	}
+
+	state := s.inProgressJobs[jobId].Saga.GetState()
+	st, _ := workerapi.DeserializeProcessStatus(state.GetEndTaskData(taskId))
+	if st.State != runner.BADREQUEST {
+		t.Errorf("Expected a BADREQUEST status to be logged, got %+v", st)
+	}
+	for _, ns := range s.clusterState.nodes {
+		if len(ns.runningTasks) != 0 {
+			t.Errorf("Expected the oversized task not to be scheduled, got %+v", ns)
+		}
+	}
+}
+
+// Ensure a killed job stops running, its running task is aborted and
+// its saga is aborted & rolled back
+func Test_StatefulScheduler_KillJob(t *testing.T) {
+	jobDef := sched.GenJobDef(2)
+	for taskId, task := range jobDef.Tasks {
+		task.Argv = []string{"pause"}
+		jobDef.Tasks[taskId] = task
+	}
+
+	deps := getDefaultSchedDeps()
+	// cluster with one node, so only one task can be running
+	cl := makeTestCluster("node1")
+	deps.initialCl = cl.nodes
+	deps.clUpdates = cl.ch
+	tmp, _ := temp.TempDirDefault()
+	deps.rf = func(cluster.Node) runner.Service {
+		return workers.MakeSimWorker(tmp)
+	}
+	deps.config.DefaultTaskTimeout = time.Minute
+	s := makeStatefulSchedulerDeps(deps)
+
+	jobId, _ := s.ScheduleJob(jobDef)
+
+	// advance scheduler until a task is running
+	for len(s.inProgressJobs) == 0 || len(s.inProgressJobs[jobId].getRunningTasks()) == 0 {
+		s.step()
+	}
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.KillJob(jobId)
+	}()
+
+	var err error
+	for killed := false; !killed; {
+		s.step()
+		select {
+		case err = <-errCh:
+			killed = true
+		default:
+		}
+	}
+	if err != nil {
+		t.Fatalf("Expected job to be killed successfully: %v", err)
+	}
+
+	state, _ := deps.sc.GetSagaState(jobId)
+	if !state.IsSagaAborted() {
+		t.Errorf("Expected killed job's saga to be aborted")
+	}
+
+	// advance scheduler until the aborted task returns & the job is rolled back
+	for len(s.inProgressJobs) > 0 {
+		if len(s.inProgressJobs[jobId].getUnScheduledTasks()) != 0 {
+			t.Fatalf("Expected killed job to not schedule any tasks")
+		}
+		s.step()
+	}
+
+	state, _ = deps.sc.GetSagaState(jobId)
+	if !state.IsSagaCompleted() {
+		t.Errorf("Expected killed job's saga to be completed")
+	}
+	for _, taskId := range state.GetTaskIds() {
+		if state.IsTaskCompleted(taskId) {
+			t.Errorf("Expected task %v of killed job to not be completed", taskId)
+		}
+		if !state.IsCompTaskCompleted(taskId) {
+			t.Errorf("Expected task %v of killed job to be rolled back", taskId)
+		}
+	}
+}
+
+func Test_StatefulScheduler_KillJobNotInProgress(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.KillJob("job1")
+	}()
+
+	var err error
+	for done := false; !done; {
+		s.step()
+		select {
+		case err = <-errCh:
+			done = true
+		default:
+		}
+	}
+
+	if _, ok := err.(*JobNotInProgressError); !ok {
+		t.Errorf("Expected JobNotInProgressError killing an unknown job, not %v", err)
+	}
+}
+
+// Ensure a straggling task gets a backup copy on an idle node, and that the
+// task completes once the backup finishes while the original is aborted
+func Test_StatefulScheduler_BackupOfStragglingTask(t *testing.T) {
+	jobDef := sched.GenJobDef(1)
+	var taskId string
+	for id, task := range jobDef.Tasks {
+		taskId = id
+		task.Argv = []string{"pause"}
+		task.ExpectedDuration = time.Millisecond
+		jobDef.Tasks[id] = task
+	}
+
+	deps := getDefaultSchedDeps()
+	cl := makeTestCluster("node1", "node2")
+	deps.initialCl = cl.nodes
+	deps.clUpdates = cl.ch
+	tmp, _ := temp.TempDirDefault()
+	// runs on node1 pause until they're aborted, runs on node2 complete
+	deps.rf = func(n cluster.Node) runner.Service {
+		if n.Id() == "node1" {
+			return workers.MakeSimWorker(tmp)
+		}
+		return workers.MakeDoneWorker(tmp)
+	}
+	deps.config.DefaultTaskTimeout = time.Minute
+	deps.config.StragglerMultiplier = 2
+	s := makeStatefulSchedulerDeps(deps)
+
+	jobId, _ := s.ScheduleJob(jobDef)
+	for len(s.inProgressJobs) == 0 || s.inProgressJobs[jobId].Tasks[taskId].Status == sched.NotStarted {
+		s.step()
+	}
+	task := s.inProgressJobs[jobId].Tasks[taskId]
+	if task.Runner.nodeId != "node1" {
+		t.Fatalf("Expected the task to be scheduled on node1, got %v", task.Runner.nodeId)
+	}
+
+	// pretend the task has been running long enough to be straggling
+	task.Started = time.Now().Add(-time.Minute)
+	s.step()
+	if task.Backup == nil || task.Backup.nodeId != "node2" {
+		t.Fatalf("Expected a backup copy of the task on node2, got %+v", task.Backup)
+	}
+	if _, ok := s.clusterState.nodes["node2"].runningTasks[taskKey{jobId, taskId}]; !ok {
+		t.Errorf("Expected the backup to be running on node2")
+	}
+
+	// advance scheduler until the job completes & both nodes are free
+	for len(s.inProgressJobs) > 0 || len(s.clusterState.nodes["node1"].runningTasks) > 0 ||
+		len(s.clusterState.nodes["node2"].runningTasks) > 0 {
+		s.step()
+	}
+
+	state, _ := deps.sc.GetSagaState(jobId)
+	if !state.IsTaskCompleted(taskId) {
+		t.Fatalf("Expected the task to be completed")
+	}
+	endStatus, _ := workerapi.DeserializeProcessStatus(state.GetEndTaskData(taskId))
+	if endStatus.State != runner.COMPLETE || endStatus.ExitCode != 0 {
+		t.Errorf("Expected the backup's successful run to be logged, got %+v", endStatus)
+	}
+	attempts, _ := workerapi.DeserializeRunAttempts(state.GetEndTaskData(taskId))
+	if len(attempts) != 1 || attempts[0].WorkerId != "node1" || attempts[0].Status.State != runner.ABORTED {
+		t.Errorf("Expected the original run to be logged as aborted, got %+v", attempts)
+	}
+}
+
+type testSnapshotPinner chan []string
+
+func (p testSnapshotPinner) PinSnapshots(ids []string, d time.Duration) error {
+	p <- ids
+	return nil
+}
+
+// Ensure the snapshots of jobs in progress are pinned once they're added,
+// and that the pins are renewed
+func Test_StatefulScheduler_PinsSnapshots(t *testing.T) {
+	pinner := make(testSnapshotPinner, 1)
+	// with no nodes the job stays in progress
+	deps := getDefaultSchedDeps()
+	deps.initialCl = nil
+	deps.config.SnapshotPinner = pinner
+	s := makeStatefulSchedulerDeps(deps)
+
+	jobDef := sched.GenJobDef(2)
+	expected := map[string]bool{}
+	for _, task := range jobDef.Tasks {
+		expected[task.SnapshotID] = true
+	}
+	s.ScheduleJob(jobDef)
+
+	awaitPin := func() {
+		for i := 0; i < 1000; i++ {
+			s.step()
+			select {
+			case ids := <-pinner:
+				pinned := map[string]bool{}
+				for _, id := range ids {
+					pinned[id] = true
+				}
+				if !reflect.DeepEqual(pinned, expected) {
+					t.Fatalf("Expected snapshots %v to be pinned, got %v", expected, ids)
+				}
+				for s.pinningSnapshots {
+					s.step()
+				}
+				return
+			case <-time.After(time.Millisecond):
+			}
+		}
+		t.Fatalf("Expected the job's snapshots to be pinned")
+	}
+	awaitPin()
+
+	s.step()
+	select {
+	case ids := <-pinner:
+		t.Fatalf("Expected the pin not to be renewed yet, got %v", ids)
+	default:
+	}
+
+	s.lastSnapshotPin = time.Now().Add(-snapshotPinInterval)
+	awaitPin()
+}
+
+type testWorkerClient struct {
+	capacity sched.Resources
+	closed   *bool
+}
+
+func (c testWorkerClient) QueryWorker() (workerapi.WorkerStatus, error) {
+	return workerapi.WorkerStatus{Capacity: workerapi.Capacity{CPUSlots: c.capacity.CPUSlots, MemoryBytes: c.capacity.MemoryBytes}}, nil
+}
+
+func (c testWorkerClient) Close() error {
+	*c.closed = true
+	return nil
+}
+
+// Ensure nodes' capacities are read through the worker clients, and read
+// again once they're old
+func Test_StatefulScheduler_ReadsNodeCapacities(t *testing.T) {
+	capacity := sched.Resources{CPUSlots: 4, MemoryBytes: 1024}
+	queries := 0
+	closed := false
+	deps := getDefaultSchedDeps()
+	cl := makeTestCluster("node1")
+	deps.initialCl, deps.clUpdates = cl.nodes, cl.ch
+	deps.config.WorkerClientFactory = func(n cluster.Node) WorkerClient {
+		queries++
+		return testWorkerClient{capacity: capacity, closed: &closed}
+	}
+	s := makeStatefulSchedulerDeps(deps)
+	ns, _ := s.clusterState.getNodeState("node1")
+
+	awaitCapacity := func(expected sched.Resources) {
+		for i := 0; i < 1000 && (ns.fetchingCapacity || !ns.capacityKnown || ns.capacity != expected); i++ {
+			s.step()
+			time.Sleep(time.Millisecond)
+		}
+		if !ns.capacityKnown || ns.capacity != expected || ns.capacityAssumed {
+			t.Fatalf("Expected node1 to have capacity %+v, got %+v", expected, ns)
+		}
+	}
+	awaitCapacity(capacity)
+	if !closed {
+		t.Errorf("Expected the worker client to be closed")
+	}
+
+	s.step()
+	if queries != 1 {
+		t.Errorf("Expected the capacity to be read once, got %v", queries)
+	}
+
+	capacity = sched.Resources{CPUSlots: 8, MemoryBytes: 2048}
+	ns.capacityReadAt = time.Now().Add(-nodeCapacityRefreshInterval)
+	awaitCapacity(capacity)
+}
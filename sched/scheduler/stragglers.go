@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/scootdev/scoot/cloud/cluster"
+	"github.com/scootdev/scoot/sched"
+)
+
+// Tasks that have run for less than this are never stragglers, a backup copy
+// of a short task costs more than waiting for it
+const minStragglerRuntime = 10 * time.Second
+
+// Returns the InProgress tasks of the specified jobs that have run much longer
+// than expected and don't have a backup copy yet, those running longest first.
+// A task is expected to run for its ExpectedDuration or, if it doesn't have
+// one, the median duration of its job's successful tasks once at least half
+// of the job's tasks have succeeded.  It's straggling once it has run for
+// multiplier times longer than that.
+func findStragglers(jobs []*jobState, multiplier float64, now time.Time) []*taskState {
+	var stragglers []*taskState
+	for _, job := range jobs {
+		if job.Killed {
+			continue
+		}
+		median, haveMedian := job.medianTaskDuration()
+		for _, task := range job.Tasks {
+			if task.Status != sched.InProgress || task.Runner == nil || task.Backup != nil {
+				continue
+			}
+			expected := task.Def.ExpectedDuration
+			if expected == 0 {
+				if !haveMedian {
+					continue
+				}
+				expected = median
+			}
+
+			runtime := now.Sub(task.Started)
+			if runtime >= minStragglerRuntime && float64(runtime) > multiplier*float64(expected) {
+				stragglers = append(stragglers, task)
+			}
+		}
+	}
+	sort.Sort(tasksByStarted(stragglers))
+	return stragglers
+}
+
+// Returns the median duration of the job's tasks that ran successfully, false
+// if less than half of its tasks have
+func (j *jobState) medianTaskDuration() (time.Duration, bool) {
+	var durations []time.Duration
+	for _, task := range j.Tasks {
+		// tasks recovered as completed have no duration
+		if task.Status == sched.Completed && !task.Failed && task.Duration > 0 {
+			durations = append(durations, task.Duration)
+		}
+	}
+	if len(durations) == 0 || 2*len(durations) < len(j.Tasks) {
+		return 0, false
+	}
+	sort.Sort(durationsAsc(durations))
+	return durations[len(durations)/2], true
+}
+
+// Returns an idle node, one running no tasks, to run a backup copy of the
+// specified task on.  The node its original copy runs on is never returned.
+// Nodes that recently checked out the task's snapshot are preferred, then
+// the node with the smallest id.
+func getBackupNode(cs *clusterState, task *taskState, originalNode cluster.NodeId) (cluster.Node, bool) {
+	var nodes []*nodeState
+	for _, ns := range cs.nodes {
+		if ns.node.Id() != originalNode && len(ns.runningTasks) == 0 &&
+			ns.capacity.Fits(task.Def.Resources.Normalized()) {
+			nodes = append(nodes, ns)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, false
+	}
+	sort.Sort(nodeStatesById(nodes))
+
+	snapshotId := task.Def.SnapshotID
+	for _, ns := range nodes {
+		if snapshotId != "" && ns.hasSnapshot(snapshotId) {
+			return ns.node, true
+		}
+	}
+	return nodes[0].node, true
+}
+
+// Orders tasks by when their current attempt was started, oldest first
+type tasksByStarted []*taskState
+
+func (t tasksByStarted) Len() int      { return len(t) }
+func (t tasksByStarted) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+func (t tasksByStarted) Less(i, j int) bool {
+	if !t[i].Started.Equal(t[j].Started) {
+		return t[i].Started.Before(t[j].Started)
+	}
+	if t[i].JobId != t[j].JobId {
+		return t[i].JobId < t[j].JobId
+	}
+	return t[i].TaskId < t[j].TaskId
+}
+
+type durationsAsc []time.Duration
+
+func (d durationsAsc) Len() int           { return len(d) }
+func (d durationsAsc) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+func (d durationsAsc) Less(i, j int) bool { return d[i] < d[j] }
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/sched"
+)
+
+// makes a jobState whose tasks ran for the specified durations, a negative
+// duration is a task that has been running for that long
+func makeStragglerJob(jobId string, now time.Time, durations ...time.Duration) *jobState {
+	j := makePolicyJob(jobId, "", 0, len(durations), now)
+	for i, d := range durations {
+		task := j.Tasks[fmt.Sprintf("task%d", i)]
+		if d < 0 {
+			task.Status = sched.InProgress
+			task.Runner = &taskRunner{nodeId: "node1"}
+			task.Started = now.Add(d)
+		} else {
+			task.Status = sched.Completed
+			task.Duration = d
+		}
+	}
+	return j
+}
+
+func Test_FindStragglers_JobMedian(t *testing.T) {
+	now := time.Now()
+	j := makeStragglerJob("job1", now, time.Second, 3*time.Second, -20*time.Second, -11*time.Second)
+
+	stragglers := findStragglers([]*jobState{j}, 5, now)
+	if len(stragglers) != 1 || stragglers[0].TaskId != "task2" {
+		t.Fatalf("Expected only task2 to be straggling, got %v", stragglers)
+	}
+
+	j.Tasks["task2"].Backup = &taskRunner{nodeId: "node2"}
+	if stragglers := findStragglers([]*jobState{j}, 5, now); len(stragglers) != 0 {
+		t.Errorf("Expected a task with a backup to not be straggling, got %v", stragglers)
+	}
+}
+
+func Test_FindStragglers_TooFewTasksFinished(t *testing.T) {
+	now := time.Now()
+	j := makeStragglerJob("job1", now, time.Second, -time.Hour, -time.Hour, -time.Hour)
+
+	if stragglers := findStragglers([]*jobState{j}, 2, now); len(stragglers) != 0 {
+		t.Errorf("Expected no stragglers before half the tasks succeed, got %v", stragglers)
+	}
+
+	j.Tasks["task0"].Failed = true
+	j.Tasks["task1"].Status = sched.Completed
+	j.Tasks["task1"].Failed = true
+	if stragglers := findStragglers([]*jobState{j}, 2, now); len(stragglers) != 0 {
+		t.Errorf("Expected failed tasks to not count towards the median, got %v", stragglers)
+	}
+}
+
+func Test_FindStragglers_ExpectedDuration(t *testing.T) {
+	now := time.Now()
+	long := makeStragglerJob("long", now, -12*time.Second)
+	long.Tasks["task0"].Def.ExpectedDuration = time.Second
+	short := makeStragglerJob("short", now, -5*time.Second)
+	short.Tasks["task0"].Def.ExpectedDuration = time.Millisecond
+	killed := makeStragglerJob("killed", now, -time.Hour)
+	killed.Tasks["task0"].Def.ExpectedDuration = time.Second
+	killed.Killed = true
+
+	stragglers := findStragglers([]*jobState{long, short, killed}, 2, now)
+	if len(stragglers) != 1 || stragglers[0].JobId != "long" {
+		t.Errorf("Expected only the long task to be straggling, got %v", stragglers)
+	}
+}
+
+func Test_GetBackupNode(t *testing.T) {
+	cl := makeTestCluster("node1", "node2", "node3", "node4")
+	cs := newClusterState(cl.nodes, cl.ch)
+	other := sched.TaskDefinition{}
+	other.SnapshotID = "snapshot1"
+	cs.taskScheduled("node1", "job1", "task0", other)
+	cs.taskScheduled("node3", "job3", "task0", other)
+	cs.taskScheduled("node4", "job4", "task0", other)
+	cs.taskCompleted("node4", "job4", "task0")
+
+	task := &taskState{JobId: "job1", TaskId: "task0", Def: other}
+	node, ok := getBackupNode(cs, task, "node1")
+	if !ok || node.Id() != "node4" {
+		t.Errorf("Expected the idle node with the task's snapshot, got %v", node)
+	}
+
+	task.Def.SnapshotID = "snapshot2"
+	node, ok = getBackupNode(cs, task, "node1")
+	if !ok || node.Id() != "node2" {
+		t.Errorf("Expected the idle node with the smallest id, got %v", node)
+	}
+
+	cs.taskScheduled("node2", "job2", "task1", sched.TaskDefinition{})
+	cs.taskScheduled("node4", "job4", "task0", sched.TaskDefinition{})
+	if node, ok := getBackupNode(cs, task, "node1"); ok {
+		t.Errorf("Expected no node when every other node is busy, got %v", node)
+	}
+}
@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"sync"
+
+	"github.com/scootdev/scoot/runner"
+	"github.com/scootdev/scoot/workerapi"
+)
+
+// The copies of one attempt at a task running at once: the original and the
+// backup the scheduler starts if the original is straggling.  Only one copy
+// logs the task's outcome, the first whose outcome is final or, if every
+// copy's run is to be retried, the last to finish.  The others are aborted.
+//
+// Shared by the copies' taskRunners, which run in their own go routines.
+// A nil taskCopies is a single copy that always decides the outcome.
+type taskCopies struct {
+	mu      sync.Mutex
+	copies  []*taskCopy
+	decided *taskRunner // the copy that logs the task's outcome, once known
+}
+
+type taskCopy struct {
+	runner   *taskRunner
+	status   runner.RunStatus // latest status of the copy's run
+	finished bool
+}
+
+func newTaskCopies(original *taskRunner) *taskCopies {
+	c := &taskCopies{}
+	c.copies = append(c.copies, &taskCopy{
+		runner: original,
+		status: runner.RunStatus{State: runner.PENDING},
+	})
+	return c
+}
+
+// Adds another copy, returns false if the task's outcome is already decided
+// and the copy shouldn't be run, or the attempt can't be copied.
+func (c *taskCopies) add(r *taskRunner) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.decided != nil {
+		return false
+	}
+	c.copies = append(c.copies, &taskCopy{
+		runner: r,
+		status: runner.RunStatus{State: runner.PENDING},
+	})
+	return true
+}
+
+// Records the latest status of r's run
+func (c *taskCopies) setStatus(r *taskRunner, st runner.RunStatus) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cp := c.find(r); cp != nil && !cp.finished {
+		cp.status = st
+	}
+}
+
+// Records that r's run ended with the specified status.  Returns true if r
+// decides the task's outcome: its outcome is final and no other copy decided
+// it first, or it's the last copy to finish.  The deciding copy also gets the
+// copies still running, which it must abort, they show as aborted runs.
+func (c *taskCopies) finish(r *taskRunner, st runner.RunStatus, final bool) (bool, []*taskRunner) {
+	if c == nil {
+		return true, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// copies aborted by the deciding copy keep showing as aborted
+	if cp := c.find(r); cp != nil && !cp.finished {
+		cp.status = st
+		cp.finished = true
+	}
+	if c.decided != nil {
+		return false, nil
+	}
+
+	var running []*taskCopy
+	for _, cp := range c.copies {
+		if !cp.finished {
+			running = append(running, cp)
+		}
+	}
+	if !final && len(running) > 0 {
+		return false, nil
+	}
+
+	c.decided = r
+	var toAbort []*taskRunner
+	for _, cp := range running {
+		cp.status = runner.RunStatus{
+			RunID: cp.status.RunID,
+			State: runner.ABORTED,
+			Error: "another copy of the task finished first",
+		}
+		cp.finished = true
+		toAbort = append(toAbort, cp.runner)
+	}
+	return true, toAbort
+}
+
+// Returns true if a copy other than r decided the task's outcome
+func (c *taskCopies) isDecidedByOther(r *taskRunner) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.decided != nil && c.decided != r
+}
+
+// Returns the runs of the copies other than r, in the order they were started
+func (c *taskCopies) otherRuns(r *taskRunner) []workerapi.RunAttempt {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var runs []workerapi.RunAttempt
+	for _, cp := range c.copies {
+		if cp.runner != r {
+			runs = append(runs, workerapi.RunAttempt{WorkerId: cp.runner.nodeId, Status: cp.status, Backup: cp.runner.backup})
+		}
+	}
+	return runs
+}
+
+func (c *taskCopies) find(r *taskRunner) *taskCopy {
+	for _, cp := range c.copies {
+		if cp.runner == r {
+			return cp
+		}
+	}
+	return nil
+}
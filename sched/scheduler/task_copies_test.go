@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/scootdev/scoot/runner"
+)
+
+func Test_TaskCopies_RetryableRunWaitsForOtherCopies(t *testing.T) {
+	original := &taskRunner{nodeId: "node1"}
+	backup := &taskRunner{nodeId: "node2"}
+	c := newTaskCopies(original)
+	if !c.add(backup) {
+		t.Fatalf("Expected a backup to be added before the task is decided")
+	}
+
+	failed := runner.RunStatus{State: runner.FAILED, Error: "flaky"}
+	if decided, _ := c.finish(backup, failed, false); decided {
+		t.Errorf("Expected a retryable run to not decide while another copy runs")
+	}
+	if c.isDecidedByOther(original) {
+		t.Errorf("Expected the task to not be decided")
+	}
+
+	done := runner.RunStatus{State: runner.COMPLETE}
+	decided, toAbort := c.finish(original, done, true)
+	if !decided || len(toAbort) != 0 {
+		t.Errorf("Expected the original to decide with nothing to abort, got %v %v", decided, toAbort)
+	}
+	runs := c.otherRuns(original)
+	if len(runs) != 1 || runs[0].WorkerId != "node2" || runs[0].Status != failed {
+		t.Errorf("Expected the backup's failed run, got %+v", runs)
+	}
+	if c.add(&taskRunner{nodeId: "node3"}) {
+		t.Errorf("Expected no copies to be added once the task is decided")
+	}
+}
+
+func Test_TaskCopies_LastRetryableRunDecides(t *testing.T) {
+	original := &taskRunner{nodeId: "node1"}
+	backup := &taskRunner{nodeId: "node2"}
+	c := newTaskCopies(original)
+	c.add(backup)
+
+	failed := runner.RunStatus{State: runner.FAILED}
+	c.finish(original, failed, false)
+	if decided, _ := c.finish(backup, failed, false); !decided {
+		t.Errorf("Expected the last copy to finish to decide")
+	}
+	if !c.isDecidedByOther(original) || c.isDecidedByOther(backup) {
+		t.Errorf("Expected the backup to have decided")
+	}
+}
+
+func Test_TaskCopies_FinalRunAbortsOtherCopies(t *testing.T) {
+	original := &taskRunner{nodeId: "node1"}
+	backup := &taskRunner{nodeId: "node2"}
+	c := newTaskCopies(original)
+	c.add(backup)
+	c.setStatus(original, runner.RunStatus{RunID: "1", State: runner.RUNNING})
+
+	decided, toAbort := c.finish(backup, runner.RunStatus{State: runner.COMPLETE, ExitCode: 1}, true)
+	if !decided || len(toAbort) != 1 || toAbort[0] != original {
+		t.Fatalf("Expected the backup to decide and abort the original, got %v %v", decided, toAbort)
+	}
+	runs := c.otherRuns(backup)
+	if len(runs) != 1 || runs[0].Status.RunID != "1" || runs[0].Status.State != runner.ABORTED {
+		t.Errorf("Expected the original's run to show as aborted, got %+v", runs)
+	}
+	if decided, _ := c.finish(original, runner.RunStatus{State: runner.COMPLETE}, true); decided {
+		t.Errorf("Expected the aborted copy to not decide")
+	}
+}
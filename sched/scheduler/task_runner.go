@@ -1,9 +1,11 @@
 package scheduler
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/scootdev/scoot/common/stats"
@@ -15,6 +17,12 @@ import (
 
 const DeadLetterExitCode = -200
 
+// Error returned by a taskRunner whose run was aborted because its job was killed
+var errTaskAborted = errors.New("task aborted")
+
+// Error returned by a copy of a task whose outcome was decided by another copy
+var errTaskSuperseded = errors.New("task superseded by another copy")
+
 type taskRunner struct {
 	saga   *saga.Saga
 	runner runner.Service
@@ -26,6 +34,30 @@ type taskRunner struct {
 
 	taskId string
 	task   sched.TaskDefinition
+	nodeId string
+
+	// earlier runs of the task, logged along with the status of this run
+	attempts []workerapi.RunAttempt
+
+	// the copies of this attempt running at once, shared with any backup
+	// copy the scheduler starts.  nil if the attempt is never copied
+	copies *taskCopies
+
+	// true if this is a backup copy, which doesn't log the task's start
+	backup bool
+
+	// set by run() when it returns nil, true if the logged run didn't succeed
+	failed bool
+
+	// set by run() once the task has been run, the runs made by this
+	// attempt, of this copy and of any other copies
+	runs []workerapi.RunAttempt
+
+	// runId & aborted are shared with abort(), which is called from
+	// outside of the go routine executing run()
+	mu      sync.Mutex
+	runId   runner.RunID
+	aborted bool
 }
 
 // Run the task on the specified worker, and update the SagaLog appropriately.  Returns an error if one
@@ -34,9 +66,12 @@ type taskRunner struct {
 // parameters:
 func (r *taskRunner) run() error {
 	log.Println("Starting task", r.taskId, " command:", strings.Join(r.task.Argv, " "))
-	// Log StartTask Message to SagaLog
-	if err := r.logTaskStatus(nil, saga.StartTask); err != nil {
-		return err
+	// Log StartTask Message to SagaLog, the original copy already logged it
+	// for a backup
+	if !r.backup {
+		if err := r.logTaskStatus(nil, saga.StartTask); err != nil {
+			return err
+		}
 	}
 
 	st, err := r.runAndWait(r.taskId, r.task)
@@ -51,23 +86,69 @@ func (r *taskRunner) run() error {
 		}
 	}
 
-	shouldLog := (err == nil)
+	if err != nil && r.isAborted() {
+		if r.copies.isDecidedByOther(r) {
+			// another copy finished first & aborted this one
+			return errTaskSuperseded
+		}
+		// the job was killed, its saga is aborted so no EndTask can be logged
+		log.Printf("Task %v aborted, Saga Id: %v", r.taskId, r.saga.GetState().SagaId())
+		return errTaskAborted
+	}
+
+	// Runs we couldn't get a status for are retried like failed runs,
+	// otherwise the task's retry policy decides
+	retryable := true
+	if st.State.IsDone() || st.State == runner.BADREQUEST {
+		retryable = r.task.Retry.IsRetryable(st)
+	} else if err != nil {
+		st = runner.RunStatus{RunID: st.RunID, State: runner.FAILED, Error: err.Error()}
+	}
+
+	// Only one copy of the task logs its outcome.  A run that would be
+	// retried leaves it to the copies still running.
+	decided, others := r.copies.finish(r, st, !retryable)
+	if !decided {
+		log.Printf("Task %v on node %v superseded by another copy, Saga Id: %v",
+			r.taskId, r.nodeId, r.saga.GetState().SagaId())
+		return errTaskSuperseded
+	}
+	for _, other := range others {
+		log.Printf("Task %v finished on node %v, aborting its copy on node %v", r.taskId, r.nodeId, other.nodeId)
+		if abortErr := other.abort(); abortErr != nil {
+			log.Printf("Error aborting copy of task %v on node %v: %v", r.taskId, other.nodeId, abortErr)
+		}
+	}
+	if r.backup {
+		r.stat.Counter("backupTaskWonCounter").Inc(1)
+	}
+	r.runs = append(r.copies.otherRuns(r), workerapi.RunAttempt{WorkerId: r.nodeId, Status: st, Backup: r.backup})
+
+	if retryable && !r.markCompleteOnFailure {
+		if err == nil {
+			err = fmt.Errorf("exited with retryable exit code %v", st.ExitCode)
+		}
+		// log the run so its outcome shows in the task's history until it's retried
+		attempts := append(r.attempts[:len(r.attempts):len(r.attempts)], r.runs...)
+		if logErr := r.logTaskStatusWithAttempts(&st, attempts, saga.StartTask); logErr != nil {
+			log.Printf("Error logging retried run of task %v: %v", r.taskId, logErr)
+		}
+		r.stat.Counter("retriedTaskCounter").Inc(1)
+		return err
+	}
 
-	if err != nil && r.markCompleteOnFailure {
+	if st.State != runner.COMPLETE {
 		st.Error = err.Error()
 		st.ExitCode = DeadLetterExitCode
 		log.Printf(
-			`Error Running Task %v: dead lettering task after max retries.
+			`Error Running Task %v: dead lettering task after %v attempts.
 				TaskDef: %+v, Saga Id: %v, Error: %v`,
-			r.taskId, r.task, r.saga.GetState().SagaId(), err)
-		shouldLog = true
-	}
-	if !shouldLog {
-		return err
+			r.taskId, len(r.attempts)+1, r.task, r.saga.GetState().SagaId(), err)
 	}
 
 	err = r.logTaskStatus(&st, saga.EndTask)
 	if err == nil {
+		r.failed = !sched.RunSucceeded(st)
 		r.stat.Counter("completedTaskCounter").Inc(1)
 	} else {
 		r.stat.Counter("failedTaskSagaCounter").Inc(1)
@@ -89,20 +170,84 @@ func (r *taskRunner) runAndWait(taskId string, task sched.TaskDefinition) (runne
 	}
 
 	id := st.RunID
+	if r.setRunId(id) {
+		// abort() was called before the run was started
+		r.runner.Abort(id)
+	}
+	r.copies.setStatus(r, st)
 
 	// Wait for the process to start running
 	st, err = r.queryWithTimeout(id, endTime, true)
 	if err != nil || st.State.IsDone() {
 		return st, err
 	}
+	r.copies.setStatus(r, st)
 
 	// It's running, but not done, so we want to log a second StartTask that includes
-	// its status, so a watcher can go investigate
-	r.logTaskStatus(&st, saga.StartTask)
+	// its status, so a watcher can go investigate.  Unless another copy already
+	// logged the task's outcome.
+	if !r.copies.isDecidedByOther(r) {
+		r.logTaskStatus(&st, saga.StartTask)
+	}
 
 	return r.queryWithTimeout(id, endTime, false)
 }
 
+// Returns a copy of this runner that runs the task again on the specified
+// node, as a backup in case this run is straggling.  Returns nil if a copy
+// of the task already finished.
+func (r *taskRunner) makeBackup(rs runner.Service, nodeId string) *taskRunner {
+	backup := &taskRunner{
+		saga:   r.saga,
+		runner: rs,
+		stat:   r.stat,
+
+		markCompleteOnFailure: r.markCompleteOnFailure,
+		defaultTaskTimeout:    r.defaultTaskTimeout,
+		runnerOverhead:        r.runnerOverhead,
+
+		taskId:   r.taskId,
+		task:     r.task,
+		nodeId:   nodeId,
+		attempts: r.attempts,
+		copies:   r.copies,
+		backup:   true,
+	}
+	if !r.copies.add(backup) {
+		return nil
+	}
+	return backup
+}
+
+// Aborts the run of this task.  If the run has not been started yet it is
+// aborted as soon as it starts.  Safe to call concurrently with run()
+func (r *taskRunner) abort() error {
+	r.mu.Lock()
+	r.aborted = true
+	id := r.runId
+	r.mu.Unlock()
+
+	if id == "" {
+		return nil
+	}
+	_, err := r.runner.Abort(id)
+	return err
+}
+
+// Records the RunID of this task's run, returns true if the task has been aborted
+func (r *taskRunner) setRunId(id runner.RunID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runId = id
+	return r.aborted
+}
+
+func (r *taskRunner) isAborted() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.aborted
+}
+
 func (r *taskRunner) queryWithTimeout(id runner.RunID, endTime time.Time, includeRunning bool) (runner.RunStatus, error) {
 	q := runner.Query{Runs: []runner.RunID{id}, States: runner.DONE_MASK}
 	if includeRunning {
@@ -131,11 +276,24 @@ func (r *taskRunner) queryWithTimeout(id runner.RunID, endTime time.Time, includ
 	return st, nil
 }
 
+// Logs the status of this task's run along with its earlier runs and the
+// runs of its other copies
 func (r *taskRunner) logTaskStatus(st *runner.RunStatus, msgType saga.SagaMessageType) error {
+	attempts := append(r.attempts[:len(r.attempts):len(r.attempts)], r.copies.otherRuns(r)...)
+	return r.logTaskStatusWithAttempts(st, attempts, msgType)
+}
+
+func (r *taskRunner) logTaskStatusWithAttempts(
+	st *runner.RunStatus, attempts []workerapi.RunAttempt, msgType saga.SagaMessageType) error {
+	if st == nil && len(attempts) > 0 {
+		// keep the history of a task that's being retried
+		st = &runner.RunStatus{State: runner.PENDING}
+	}
+
 	var statusAsBytes []byte
 	var err error
 	if st != nil {
-		statusAsBytes, err = workerapi.SerializeProcessStatus(*st)
+		statusAsBytes, err = workerapi.SerializeTaskStatus(*st, attempts)
 		if err != nil {
 			r.stat.Counter("failedTaskSerializeCounter").Inc(1)
 			return err
@@ -13,6 +13,7 @@ import (
 	"github.com/scootdev/scoot/runner"
 	"github.com/scootdev/scoot/runner/runners"
 	"github.com/scootdev/scoot/saga"
+	"github.com/scootdev/scoot/saga/sagalogs"
 	"github.com/scootdev/scoot/sched"
 	"github.com/scootdev/scoot/sched/worker/workers"
 	"github.com/scootdev/scoot/workerapi"
@@ -47,7 +48,7 @@ func Test_runTaskAndLog_Successful(t *testing.T) {
 	task := sched.GenTask()
 
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("job1", nil)
+	sagaLogMock.EXPECT().StartSaga("job1", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(TaskMessageMatcher{Type: &sagaStartTask, JobId: "job1", TaskId: "task1", Data: gomock.Any()}).MaxTimes(1)
 	endMessageMatcher := TaskMessageMatcher{JobId: "job1", TaskId: "task1", Data: gomock.Any()}
@@ -66,13 +67,13 @@ func Test_runTaskAndLog_IncludeRunningStatus(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	task := sched.TaskDefinition{
-		runner.Command{
+		Command: runner.Command{
 			Argv: []string{"sleep 500", "complete 0"},
 		},
 	}
 
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("job1", nil)
+	sagaLogMock.EXPECT().StartSaga("job1", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", nil))
 	// Make sure that we include another start task message
 	sagaLogMock.EXPECT().LogMessage(TaskMessageMatcher{Type: &sagaStartTask, JobId: "job1", TaskId: "task1", Data: gomock.Any()})
@@ -94,7 +95,7 @@ func Test_runTaskAndLog_FailedToLogStartTask(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("job1", nil)
+	sagaLogMock.EXPECT().StartSaga("job1", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", nil)).Return(errors.New("test error"))
 	sagaCoord := saga.MakeSagaCoordinator(sagaLogMock)
 	s, _ := sagaCoord.MakeSaga("job1", nil)
@@ -112,7 +113,7 @@ func Test_runTaskAndLog_FailedToLogEndTask(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("job1", nil)
+	sagaLogMock.EXPECT().StartSaga("job1", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(TaskMessageMatcher{Type: &sagaStartTask, JobId: "job1", TaskId: "task1", Data: gomock.Any()}).MaxTimes(1)
 	endMessageMatcher := TaskMessageMatcher{Type: &sagaEndTask, JobId: "job1", TaskId: "task1", Data: gomock.Any()}
@@ -134,8 +135,12 @@ func Test_runTaskAndLog_TaskFailsToRun(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("job1", nil)
+	sagaLogMock.EXPECT().StartSaga("job1", nil, nil)
 	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", nil))
+	// the failed run is logged so it shows in the task's history
+	failedStatus := runner.RunStatus{State: runner.FAILED, Error: "starting error"}
+	expectedStatus, _ := workerapi.SerializeTaskStatus(failedStatus, []workerapi.RunAttempt{{Status: failedStatus}})
+	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", expectedStatus))
 	sagaCoord := saga.MakeSagaCoordinator(sagaLogMock)
 	s, _ := sagaCoord.MakeSaga("job1", nil)
 
@@ -162,8 +167,9 @@ func Test_runTaskAndLog_MarkFailedTaskAsFinished(t *testing.T) {
 
 	// set up a mock saga log that verifies task is started and completed with a failed task
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
-	sagaLogMock.EXPECT().StartSaga("job1", nil)
+	sagaLogMock.EXPECT().StartSaga("job1", nil, nil)
 	var retStatus runner.RunStatus
+	retStatus.State = runner.FAILED
 	retStatus.Error = testErr.Error()
 	retStatus.ExitCode = DeadLetterExitCode
 	expectedProcessStatus, _ := workerapi.SerializeProcessStatus(retStatus)
@@ -222,3 +228,116 @@ func (c TaskMessageMatcher) String() string {
 	}
 	return fmt.Sprintf("{%v %v %v}", c.JobId, c.TaskId, c.Data)
 }
+
+// Runner whose runs end with the same status as soon as they're started
+type fixedStatusRunner struct {
+	runner.Service
+	st runner.RunStatus
+}
+
+func (r *fixedStatusRunner) Run(cmd *runner.Command) (runner.RunStatus, error) {
+	return r.st, nil
+}
+
+func Test_runTaskAndLog_RetryableExitCode(t *testing.T) {
+	task := sched.GenTask()
+	task.Retry = sched.RetryPolicy{RetryableExitCodes: []int{75}}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga("job1", nil, nil)
+	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", nil))
+	st := runner.RunStatus{RunID: "1", State: runner.COMPLETE, ExitCode: 75}
+	expectedStatus, _ := workerapi.SerializeTaskStatus(st, []workerapi.RunAttempt{{WorkerId: "node1", Status: st}})
+	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", expectedStatus))
+	sagaCoord := saga.MakeSagaCoordinator(sagaLogMock)
+	s, _ := sagaCoord.MakeSaga("job1", nil)
+
+	tr := testTaskRunner(s, &fixedStatusRunner{st: st}, "task1", task, false)
+	tr.nodeId = "node1"
+	if err := tr.run(); err == nil {
+		t.Errorf("Expected an error so the task is retried")
+	}
+	if len(tr.runs) != 1 || tr.runs[0].WorkerId != "node1" || tr.runs[0].Status != st {
+		t.Errorf("Expected the run to be recorded as an attempt on node1, got %+v", tr.runs)
+	}
+}
+
+func Test_runTaskAndLog_NotRetryableState(t *testing.T) {
+	task := sched.GenTask()
+	task.Retry = sched.RetryPolicy{RetryableStates: []runner.RunState{runner.TIMEDOUT}}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga("job1", nil, nil)
+	previous := []workerapi.RunAttempt{{WorkerId: "node0", Status: runner.RunStatus{State: runner.TIMEDOUT}}}
+	startStatus, _ := workerapi.SerializeTaskStatus(runner.RunStatus{State: runner.PENDING}, previous)
+	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", startStatus))
+	endStatus, _ := workerapi.SerializeTaskStatus(
+		runner.RunStatus{RunID: "1", State: runner.FAILED, Error: "boom", ExitCode: DeadLetterExitCode}, previous)
+	sagaLogMock.EXPECT().LogMessage(saga.MakeEndTaskMessage("job1", "task1", endStatus))
+	sagaCoord := saga.MakeSagaCoordinator(sagaLogMock)
+	s, _ := sagaCoord.MakeSaga("job1", nil)
+
+	st := runner.RunStatus{RunID: "1", State: runner.FAILED, Error: "boom"}
+	tr := testTaskRunner(s, &fixedStatusRunner{st: st}, "task1", task, false)
+	tr.attempts = previous
+	if err := tr.run(); err != nil {
+		t.Errorf("Expected the task to complete without a retry, got %v", err)
+	}
+	if !tr.failed {
+		t.Errorf("Expected the task to be marked as failed")
+	}
+}
+
+func Test_runTaskAndLog_BackupFinishesFirst(t *testing.T) {
+	task := sched.GenTask()
+	task.Argv = []string{"pause"}
+	s, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga("job1", nil)
+
+	original := testTaskRunner(s, workers.MakeSimWorker(tmp), "task1", task, false)
+	original.nodeId = "node1"
+	original.copies = newTaskCopies(original)
+	errCh := make(chan error)
+	go func() {
+		errCh <- original.run()
+	}()
+
+	// wait for the original to log that it's running
+	for deadline := time.Now().Add(5 * time.Second); s.GetState().GetStartTaskData("task1") == nil; {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the original copy to start running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	st := runner.RunStatus{RunID: "1", State: runner.COMPLETE}
+	backup := original.makeBackup(&fixedStatusRunner{st: st}, "node2")
+	if err := backup.run(); err != nil {
+		t.Fatalf("Expected the backup to log the task's outcome, got %v", err)
+	}
+	if err := <-errCh; err != errTaskSuperseded {
+		t.Errorf("Expected the original copy to be superseded, got %v", err)
+	}
+	if original.makeBackup(&fixedStatusRunner{st: st}, "node3") != nil {
+		t.Errorf("Expected no backup once the task finished")
+	}
+
+	state := s.GetState()
+	if !state.IsTaskCompleted("task1") {
+		t.Fatalf("Expected the task to be completed")
+	}
+	endStatus, _ := workerapi.DeserializeProcessStatus(state.GetEndTaskData("task1"))
+	if endStatus.State != runner.COMPLETE {
+		t.Errorf("Expected the backup's run to be logged, got %+v", endStatus)
+	}
+	attempts, _ := workerapi.DeserializeRunAttempts(state.GetEndTaskData("task1"))
+	if len(attempts) != 1 || attempts[0].WorkerId != "node1" || attempts[0].Status.State != runner.ABORTED || attempts[0].Backup {
+		t.Errorf("Expected the original's run to be logged as aborted, got %+v", attempts)
+	}
+	if len(backup.runs) != 2 || backup.runs[0].Backup || !backup.runs[1].Backup {
+		t.Errorf("Expected only the backup's run to be marked as a backup, got %+v", backup.runs)
+	}
+}
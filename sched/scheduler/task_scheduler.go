@@ -1,7 +1,11 @@
 package scheduler
 
 import (
+	"sort"
+	"time"
+
 	"github.com/scootdev/scoot/cloud/cluster"
+	"github.com/scootdev/scoot/sched"
 )
 
 type taskAssignments struct {
@@ -11,25 +15,132 @@ type taskAssignments struct {
 
 // Returns a list of taskAssigments of task to available node.  Not all
 // tasks are guaranteed to be scheduled.  Does best effort scheduling
-func getTaskAssignments(cs *clusterState, tasks []*taskState) []taskAssignments {
-
-	// for now just assign any task to any available node
+//
+// Tasks are placed in the order given, which is decided by the scheduling
+// policy.  Each is bin packed onto the node with the fewest free cpu slots
+// that can still fit it, which leaves room on other nodes for large tasks.
+// A task is never placed on a node that doesn't have enough resources left
+// for it, a later task of the same or a higher tier that fits is placed
+// instead.  Tasks of lower tiers wait until it's placed, so they can't
+// starve it of the resources it's waiting for.  tier returns a task's tier,
+// if it's nil all tasks are in the same tier.
+//
+// Nodes that recently checked out a task's snapshot are preferred.  While
+// some node in the cluster holds the snapshot, a task that has been ready
+// for less than affinityWait is only placed on such a node, after that it's
+// placed on any node with room.
+func getTaskAssignments(cs *clusterState, tasks []*taskState, tier func(*taskState) int, affinityWait time.Duration, now time.Time) []taskAssignments {
 	var tas []taskAssignments
-	taskIndex := 0
-
-	for _, nodeState := range cs.nodes {
-		if nodeState.runningTask == noTask {
-			if taskIndex < len(tasks) {
-				tas = append(tas, taskAssignments{
-					node: nodeState.node,
-					task: tasks[taskIndex],
-				})
-				taskIndex++
-			} else {
-				// we've scheduled all tasks
+
+	// nodes that can still take a task, along with the resources they're using
+	var nodes []*nodeState
+	used := make(map[cluster.NodeId]sched.Resources)
+	// snapshots held by any node, busy or not
+	held := make(map[string]bool)
+	for _, ns := range cs.nodes {
+		if ns.freeSlots() > 0 {
+			nodes = append(nodes, ns)
+			used[ns.node.Id()] = ns.used()
+		}
+		for _, id := range ns.snapshots {
+			held[id] = true
+		}
+	}
+	if len(nodes) == 0 || len(tasks) == 0 {
+		return tas
+	}
+	sort.Sort(nodeStatesById(nodes))
+
+	// whether a task didn't fit on any node, and the highest tier of those that didn't
+	blocked, blockedTier := false, 0
+	for _, task := range tasks {
+		taskTier := 0
+		if tier != nil {
+			taskTier = tier(task)
+		}
+		if blocked && taskTier < blockedTier {
+			continue
+		}
+
+		needed := task.Def.Resources.Normalized()
+		snapshotId := task.Def.SnapshotID
+		affineOnly := held[snapshotId] && now.Sub(task.ReadySince) < affinityWait
+
+		var best *nodeState
+		bestFree, bestAffine := 0, false
+		fits := false
+		for _, ns := range nodes {
+			total := used[ns.node.Id()].Add(needed)
+			if !ns.capacity.Fits(total) {
+				continue
+			}
+			fits = true
+			affine := snapshotId != "" && ns.hasSnapshot(snapshotId)
+			if affineOnly && !affine {
+				continue
+			}
+			free := ns.capacity.CPUSlots - total.CPUSlots
+			if best == nil || (affine && !bestAffine) || (affine == bestAffine && free < bestFree) {
+				best, bestFree, bestAffine = ns, free, affine
+			}
+		}
+		if best == nil {
+			// waiting on its snapshot's nodes doesn't hold lower tiers back
+			if !fits && (!blocked || taskTier > blockedTier) {
+				blocked, blockedTier = true, taskTier
+			}
+			continue
+		}
+
+		used[best.node.Id()] = used[best.node.Id()].Add(needed)
+		tas = append(tas, taskAssignments{
+			node: best.node,
+			task: task,
+		})
+
+		// stop considering nodes that are full
+		if bestFree == 0 {
+			nodes = removeNodeState(nodes, best)
+			if len(nodes) == 0 {
+				// we've filled every node
 				break
 			}
 		}
 	}
 	return tas
 }
+
+func removeNodeState(nodes []*nodeState, ns *nodeState) []*nodeState {
+	for i, n := range nodes {
+		if n == ns {
+			return append(nodes[:i], nodes[i+1:]...)
+		}
+	}
+	return nodes
+}
+
+type nodeStatesById []*nodeState
+
+func (n nodeStatesById) Len() int           { return len(n) }
+func (n nodeStatesById) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+func (n nodeStatesById) Less(i, j int) bool { return n[i].node.Id() < n[j].node.Id() }
+
+// Orders tasks by the resources they need, largest first.  Cpu slots are
+// compared before memory, tasks of the same size are ordered by id
+type tasksBySizeDesc []*taskState
+
+func (t tasksBySizeDesc) Len() int      { return len(t) }
+func (t tasksBySizeDesc) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+func (t tasksBySizeDesc) Less(i, j int) bool {
+	ri, rj := t[i].Def.Resources.Normalized(), t[j].Def.Resources.Normalized()
+	if ri.CPUSlots != rj.CPUSlots {
+		return ri.CPUSlots > rj.CPUSlots
+	}
+	if ri.MemoryBytes != rj.MemoryBytes {
+		return ri.MemoryBytes > rj.MemoryBytes
+	}
+	if t[i].JobId != t[j].JobId {
+		return t[i].JobId < t[j].JobId
+	}
+	return t[i].TaskId < t[j].TaskId
+}
@@ -1,11 +1,15 @@
 package scheduler
 
 import (
+	"github.com/scootdev/scoot/cloud/cluster"
+	"github.com/scootdev/scoot/runner"
 	"github.com/scootdev/scoot/saga/sagalogs"
 	"github.com/scootdev/scoot/sched"
 	"github.com/scootdev/scoot/tests/testhelpers"
 	"math"
+	"sort"
 	"testing"
+	"time"
 )
 
 func Test_TaskAssignment_NoNodesAvailable(t *testing.T) {
@@ -18,7 +22,7 @@ func Test_TaskAssignment_NoNodesAvailable(t *testing.T) {
 	// create a test cluster with no nodes
 	testCluster := makeTestCluster()
 	cs := newClusterState(testCluster.nodes, testCluster.ch)
-	assignments := getTaskAssignments(cs, jobState.getUnScheduledTasks())
+	assignments := getTaskAssignments(cs, jobState.getUnScheduledTasks(), nil, 0, time.Now())
 
 	if len(assignments) != 0 {
 		t.Errorf("Assignments on a cluster with no nodes should not return any assignments")
@@ -29,7 +33,7 @@ func Test_TaskAssignment_NoTasks(t *testing.T) {
 	// create a test cluster with no nodes
 	testCluster := makeTestCluster("node1", "node2", "node3", "node4", "node5")
 	cs := newClusterState(testCluster.nodes, testCluster.ch)
-	assignments := getTaskAssignments(cs, []*taskState{})
+	assignments := getTaskAssignments(cs, []*taskState{}, nil, 0, time.Now())
 
 	if len(assignments) != 0 {
 		t.Errorf("Assignments on a cluster with no nodes should not return any assignments")
@@ -49,7 +53,7 @@ func Test_TaskAssignments_TasksScheduled(t *testing.T) {
 	testCluster := makeTestCluster("node1", "node2", "node3", "node4", "node5")
 	cs := newClusterState(testCluster.nodes, testCluster.ch)
 	unScheduledTasks := jobState.getUnScheduledTasks()
-	assignments := getTaskAssignments(cs, unScheduledTasks)
+	assignments := getTaskAssignments(cs, unScheduledTasks, nil, 0, time.Now())
 
 	if float64(len(assignments)) != math.Min(float64(len(unScheduledTasks)), float64(len(testCluster.nodes))) {
 		t.Errorf(`Expected as many tasks as possible to be scheduled: NumScheduled %v, 
@@ -59,3 +63,145 @@ func Test_TaskAssignments_TasksScheduled(t *testing.T) {
 			len(unScheduledTasks))
 	}
 }
+
+// makes a taskState needing the specified resources
+func makeSizedTask(taskId string, resources sched.Resources) *taskState {
+	def := sched.GenTask()
+	def.Resources = resources
+	return &taskState{
+		JobId:      "job1",
+		TaskId:     taskId,
+		Def:        def,
+		Status:     sched.NotStarted,
+		ReadySince: time.Now(),
+	}
+}
+
+// Verifies that multiple tasks are packed onto a node with enough capacity,
+// when the largest tasks are placed first
+func Test_TaskAssignments_BinPacked(t *testing.T) {
+	testCluster := makeTestCluster("node1", "node2")
+	cs := newClusterState(testCluster.nodes, testCluster.ch)
+	cs.capacityFetched("node1", sched.Resources{CPUSlots: 4})
+	cs.capacityFetched("node2", sched.Resources{CPUSlots: 8})
+
+	tasks := []*taskState{
+		makeSizedTask("small1", sched.Resources{}),
+		makeSizedTask("small2", sched.Resources{}),
+		makeSizedTask("big", sched.Resources{CPUSlots: 6}),
+		makeSizedTask("medium", sched.Resources{CPUSlots: 3}),
+		makeSizedTask("small3", sched.Resources{}),
+	}
+	sort.Sort(tasksBySizeDesc(tasks))
+	assignments := getTaskAssignments(cs, tasks, nil, 0, time.Now())
+
+	placed := make(map[string]cluster.NodeId)
+	for _, a := range assignments {
+		placed[a.task.TaskId] = a.node.Id()
+	}
+
+	if len(placed) != 5 {
+		t.Fatalf("Expected all 5 tasks to be placed on 12 slots, placed: %v", placed)
+	}
+	if placed["big"] != "node2" {
+		t.Errorf("Expected big task to be placed on node2, placed: %v", placed)
+	}
+	if placed["medium"] != "node1" {
+		t.Errorf("Expected medium task to be placed on node1, placed: %v", placed)
+	}
+}
+
+// Verifies that a task is never placed on a node that can't fit it
+func Test_TaskAssignments_TaskDoesNotFit(t *testing.T) {
+	testCluster := makeTestCluster("node1", "node2")
+	cs := newClusterState(testCluster.nodes, testCluster.ch)
+	cs.capacityFetched("node1", sched.Resources{CPUSlots: 2, MemoryBytes: 1024})
+	cs.capacityFetched("node2", sched.Resources{CPUSlots: 2, MemoryBytes: 1024})
+	cs.taskScheduled("node2", "job0", "running", sched.TaskDefinition{Resources: sched.Resources{CPUSlots: 1}})
+
+	tasks := []*taskState{
+		makeSizedTask("tooManySlots", sched.Resources{CPUSlots: 3}),
+		makeSizedTask("tooMuchMemory", sched.Resources{MemoryBytes: 2048}),
+		makeSizedTask("twoSlots", sched.Resources{CPUSlots: 2}),
+	}
+	assignments := getTaskAssignments(cs, tasks, nil, 0, time.Now())
+
+	if len(assignments) != 1 {
+		t.Fatalf("Expected only 1 task to be placed, assignments: %+v", assignments)
+	}
+	if assignments[0].task.TaskId != "twoSlots" || assignments[0].node.Id() != "node1" {
+		t.Errorf("Expected twoSlots to be placed on the idle node1, not %v on %v",
+			assignments[0].task.TaskId, assignments[0].node.Id())
+	}
+}
+
+// Verifies that a task that doesn't fit holds back tasks of lower tiers, but
+// not those of its own tier
+func Test_TaskAssignments_BlockedTaskHoldsBackLowerTiers(t *testing.T) {
+	testCluster := makeTestCluster("node1", "node2")
+	cs := newClusterState(testCluster.nodes, testCluster.ch)
+	cs.capacityFetched("node1", sched.Resources{CPUSlots: 4})
+	cs.capacityFetched("node2", sched.Resources{CPUSlots: 4})
+	cs.taskScheduled("node1", "job0", "running", sched.TaskDefinition{Resources: sched.Resources{CPUSlots: 2}})
+	cs.taskScheduled("node2", "job0", "running", sched.TaskDefinition{Resources: sched.Resources{CPUSlots: 2}})
+
+	tasks := []*taskState{
+		makeSizedTask("highLarge", sched.Resources{CPUSlots: 4}),
+		makeSizedTask("highSmall", sched.Resources{}),
+		makeSizedTask("lowSmall", sched.Resources{}),
+	}
+	tasks[2].JobId = "low"
+	tier := func(task *taskState) int {
+		if task.JobId == "low" {
+			return 0
+		}
+		return 1
+	}
+	assignments := getTaskAssignments(cs, tasks, tier, 0, time.Now())
+
+	if len(assignments) != 1 || assignments[0].task.TaskId != "highSmall" {
+		t.Fatalf("Expected only highSmall to be placed, assignments: %+v", assignments)
+	}
+
+	// in a single tier, smaller tasks are placed in the room left
+	if assignments := getTaskAssignments(cs, tasks, nil, 0, time.Now()); len(assignments) != 2 {
+		t.Fatalf("Expected both small tasks to be placed, assignments: %+v", assignments)
+	}
+}
+
+// Verifies that a task is placed on a node holding its snapshot when one is free
+func Test_TaskAssignments_PrefersSnapshotAffinity(t *testing.T) {
+	testCluster := makeTestCluster("node1", "node2", "node3")
+	cs := newClusterState(testCluster.nodes, testCluster.ch)
+	cs.nodes["node3"].snapshotUsed("snap1")
+
+	task := makeSizedTask("task1", sched.Resources{})
+	task.Def.SnapshotID = "snap1"
+	assignments := getTaskAssignments(cs, []*taskState{task}, nil, 0, time.Now())
+
+	if len(assignments) != 1 || assignments[0].node.Id() != "node3" {
+		t.Errorf("Expected task1 to be placed on node3 which holds its snapshot, assignments: %+v", assignments)
+	}
+}
+
+// Verifies that a task waits for a busy node holding its snapshot, and falls
+// back to any free node once it has waited long enough
+func Test_TaskAssignments_WaitsForSnapshotAffinity(t *testing.T) {
+	testCluster := makeTestCluster("node1", "node2")
+	cs := newClusterState(testCluster.nodes, testCluster.ch)
+	cs.taskScheduled("node2", "job0", "running", sched.TaskDefinition{Command: runner.Command{SnapshotID: "snap1"}})
+
+	task := makeSizedTask("task1", sched.Resources{})
+	task.Def.SnapshotID = "snap1"
+	wait := time.Minute
+
+	assignments := getTaskAssignments(cs, []*taskState{task}, nil, wait, task.ReadySince.Add(time.Second))
+	if len(assignments) != 0 {
+		t.Errorf("Expected task1 to wait for node2 which holds its snapshot, assignments: %+v", assignments)
+	}
+
+	assignments = getTaskAssignments(cs, []*taskState{task}, nil, wait, task.ReadySince.Add(wait))
+	if len(assignments) != 1 || assignments[0].node.Id() != "node1" {
+		t.Errorf("Expected task1 to be placed on free node1 after waiting, assignments: %+v", assignments)
+	}
+}
@@ -98,6 +98,79 @@ func (c *CloudScootClient) GetStatus(jobId string) (r *scoot.JobStatus, err erro
 	return jobStatus, err
 }
 
+// KillJob API. Kills the specified Job, aborting its running tasks and rolling
+// it back. Returns the JobStatus after the kill if successful, otherwise an error.
+func (c *CloudScootClient) KillJob(jobId string) (r *scoot.JobStatus, err error) {
+	if c.client == nil {
+		c.client, err = createClient(c.addr, c.dialer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jobStatus, err := c.client.KillJob(jobId)
+
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+
+	return jobStatus, err
+}
+
+// WatchJob API. Blocks until the specified Job changes after version, or until
+// timeoutMs elapses. Returns the JobStatus of the tasks that changed if successful,
+// otherwise an error.
+func (c *CloudScootClient) WatchJob(jobId string, version int64, timeoutMs int32) (r *scoot.JobStatus, err error) {
+	if c.client == nil {
+		c.client, err = createClient(c.addr, c.dialer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jobStatus, err := c.client.WatchJob(jobId, version, timeoutMs)
+
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+
+	return jobStatus, err
+}
+
+// ListJobs API. Returns a page of the jobs matching the request's filters,
+// most recently submitted first, if successful, otherwise an error.
+func (c *CloudScootClient) ListJobs(req *scoot.ListJobsRequest) (r *scoot.ListJobsResponse, err error) {
+	if c.client == nil {
+		c.client, err = createClient(c.addr, c.dialer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.client.ListJobs(req)
+
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+
+	return resp, err
+}
+
 // Close any open Transport associated with this ScootClient
 func (c *CloudScootClient) Close() error {
 	if c.client != nil {
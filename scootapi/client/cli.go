@@ -40,8 +40,11 @@ func NewSimpleCLIClient(d dialer.Dialer) (CLIClient, error) {
 
 	c.addCmd(&runJobCmd{})
 	c.addCmd(&getStatusCmd{})
+	c.addCmd(&killJobCmd{})
 	c.addCmd(&smokeTestCmd{})
 	c.addCmd(&watchJobCmd{})
+	c.addCmd(&listJobsCmd{})
+	c.addCmd(&tailTaskCmd{})
 
 	return c, nil
 }
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+	"github.com/spf13/cobra"
+	"log"
+)
+
+type killJobCmd struct {
+	printAsJson bool
+}
+
+func (c *killJobCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "kill_job",
+		Short: "KillJob",
+	}
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out status as JSON")
+	return r
+}
+
+func (c *killJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+
+	log.Println("Killing Scoot Job", args)
+
+	if len(args) == 0 {
+		return errors.New("a job id must be provided")
+	}
+
+	jobId := args[0]
+
+	status, err := cl.scootClient.KillJob(jobId)
+
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error killing job: %v", err.Error())
+		}
+	}
+
+	if c.printAsJson {
+		asJson, err := json.Marshal(status)
+		if err != nil {
+			return fmt.Errorf("Error converting status to JSON: %v", err.Error())
+		}
+		fmt.Printf("%s\n", asJson)
+	} else {
+		fmt.Println("Job Status:", status)
+	}
+
+	return nil
+}
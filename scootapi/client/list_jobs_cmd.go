@@ -0,0 +1,101 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+	"github.com/spf13/cobra"
+)
+
+type listJobsCmd struct {
+	statuses    []string
+	jobType     string
+	tenant      string
+	since       time.Duration
+	limit       int32
+	cursor      string
+	printAsJson bool
+}
+
+func (c *listJobsCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "list_jobs",
+		Short: "List jobs, most recently submitted first",
+	}
+	r.Flags().StringSliceVar(&c.statuses, "status", nil, "only list jobs with these statuses, e.g. IN_PROGRESS,COMPLETED")
+	r.Flags().StringVar(&c.jobType, "job_type", "", "only list jobs of this type")
+	r.Flags().StringVar(&c.tenant, "tenant", "", "only list jobs submitted by this tenant")
+	r.Flags().DurationVar(&c.since, "since", 0, "only list jobs submitted within this long ago")
+	r.Flags().Int32Var(&c.limit, "limit", 0, "max number of jobs to list, or the server default if 0")
+	r.Flags().StringVar(&c.cursor, "cursor", "", "cursor printed by the previous call, to list the next page")
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out jobs as JSON")
+	return r
+}
+
+func (c *listJobsCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+
+	log.Println("Listing Scoot Jobs")
+
+	req := scoot.NewListJobsRequest()
+	for _, s := range c.statuses {
+		status, err := scoot.StatusFromString(s)
+		if err != nil {
+			return err
+		}
+		req.Statuses = append(req.Statuses, status)
+	}
+	if c.jobType != "" {
+		jobType, err := scoot.JobTypeFromString(c.jobType)
+		if err != nil {
+			return err
+		}
+		req.JobType = &jobType
+	}
+	if c.tenant != "" {
+		req.Tenant = &c.tenant
+	}
+	if c.since > 0 {
+		submittedAfterMs := time.Now().Add(-c.since).UnixNano() / int64(time.Millisecond)
+		req.SubmittedAfterMs = &submittedAfterMs
+	}
+	if c.limit != 0 {
+		req.Limit = &c.limit
+	}
+	if c.cursor != "" {
+		req.Cursor = &c.cursor
+	}
+
+	resp, err := cl.scootClient.ListJobs(req)
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error listing jobs: %v", err.Error())
+		}
+	}
+
+	if c.printAsJson {
+		asJson, err := json.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("Error converting jobs to JSON: %v", err.Error())
+		}
+		fmt.Printf("%s\n", asJson)
+		return nil
+	}
+
+	for _, job := range resp.Jobs {
+		submitted := time.Unix(0, job.GetSubmittedMs()*int64(time.Millisecond))
+		fmt.Printf("%s\t%s\t%s\t%s\n", job.ID, job.Status.String(), submitted.Format(time.RFC3339), job.GetTenant())
+	}
+	if resp.IsSetNextCursor() {
+		fmt.Printf("Next page: --cursor %s\n", resp.GetNextCursor())
+	}
+
+	return nil
+}
@@ -15,6 +15,8 @@ import (
 type runJobCmd struct {
 	snapshotId  string
 	jobFilePath string
+	priority    int32
+	tenant      string
 }
 
 func (c *runJobCmd) registerFlags() *cobra.Command {
@@ -24,16 +26,37 @@ func (c *runJobCmd) registerFlags() *cobra.Command {
 	}
 	r.Flags().StringVar(&c.snapshotId, "snapshot_id", scoot.TaskDefinition_SnapshotId_DEFAULT, "snapshot ID to run job against")
 	r.Flags().StringVar(&c.jobFilePath, "job_def", "", "JSON file to read jobs from")
+	r.Flags().Int32Var(&c.priority, "priority", 0, "priority of the job, higher priority jobs are scheduled first")
+	r.Flags().StringVar(&c.tenant, "tenant", "", "tenant submitting the job")
 	return r
 }
 
 // Types to handle JobDefinitions from JSON files
 type JobDef struct {
-	Tasks map[string]TaskDef
+	Tasks    map[string]TaskDef
+	Priority int32
+	Tenant   string
 }
 type TaskDef struct {
-	Args       []string
-	SnapshotID string
+	Args         []string
+	SnapshotID   string
+	EnvVars      map[string]string
+	TimeoutMs    int32
+	Network      bool
+	Outputs      map[string]string
+	CPUSlots     int32
+	MemoryBytes  int64
+	Dependencies []string
+	Retry        *RetryDef
+
+	ExpectedDurationMs int32
+}
+type RetryDef struct {
+	MaxAttempts        int32
+	InitialBackoffMs   int32
+	MaxBackoffMs       int32
+	RetryableStates    []string
+	RetryableExitCodes []int32
 }
 
 func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
@@ -72,9 +95,50 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 			taskDef := scoot.NewTaskDefinition()
 			taskDef.Command = scoot.NewCommand()
 			taskDef.Command.Argv = jsonTask.Args
+			taskDef.Command.EnvVars = jsonTask.EnvVars
+			if jsonTask.TimeoutMs != 0 {
+				timeoutMs := jsonTask.TimeoutMs
+				taskDef.Command.TimeoutMs = &timeoutMs
+			}
+			if jsonTask.Network {
+				network := true
+				taskDef.Command.Network = &network
+			}
+			taskDef.Command.Outputs = jsonTask.Outputs
 			taskDef.SnapshotId = &jsonTask.SnapshotID
+			if jsonTask.CPUSlots != 0 {
+				cpuSlots := jsonTask.CPUSlots
+				taskDef.CpuSlots = &cpuSlots
+			}
+			if jsonTask.MemoryBytes != 0 {
+				memoryBytes := jsonTask.MemoryBytes
+				taskDef.MemoryBytes = &memoryBytes
+			}
+			taskDef.Dependencies = jsonTask.Dependencies
+			if jsonTask.Retry != nil {
+				taskDef.RetryPolicy, err = makeRetryPolicy(jsonTask.Retry)
+				if err != nil {
+					return err
+				}
+			}
+			if jsonTask.ExpectedDurationMs != 0 {
+				expectedDurationMs := jsonTask.ExpectedDurationMs
+				taskDef.ExpectedDurationMs = &expectedDurationMs
+			}
 			jobDef.Tasks[taskName] = taskDef
 		}
+		if jsonJob.Priority != 0 {
+			c.priority = jsonJob.Priority
+		}
+		if jsonJob.Tenant != "" {
+			c.tenant = jsonJob.Tenant
+		}
+	}
+	if c.priority != 0 {
+		jobDef.Priority = &c.priority
+	}
+	if c.tenant != "" {
+		jobDef.Tenant = &c.tenant
 	}
 	jobId, err := cl.scootClient.RunJob(jobDef)
 	if err != nil {
@@ -91,3 +155,26 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 
 	return nil
 }
+
+// Translates a task's retry policy from its JSON form
+func makeRetryPolicy(def *RetryDef) (*scoot.RetryPolicy, error) {
+	policy := scoot.NewRetryPolicy()
+	if def.MaxAttempts != 0 {
+		policy.MaxAttempts = &def.MaxAttempts
+	}
+	if def.InitialBackoffMs != 0 {
+		policy.InitialBackoffMs = &def.InitialBackoffMs
+	}
+	if def.MaxBackoffMs != 0 {
+		policy.MaxBackoffMs = &def.MaxBackoffMs
+	}
+	for _, s := range def.RetryableStates {
+		state, err := scoot.RunStatusStateFromString(s)
+		if err != nil {
+			return nil, err
+		}
+		policy.RetryableStates = append(policy.RetryableStates, state)
+	}
+	policy.RetryableExitCodes = def.RetryableExitCodes
+	return policy, nil
+}
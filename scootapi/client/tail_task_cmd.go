@@ -0,0 +1,127 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scootdev/scoot/runner/runners"
+	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+	"github.com/spf13/cobra"
+)
+
+type tailTaskCmd struct {
+	stderr   bool
+	follow   bool
+	interval time.Duration
+}
+
+func (c *tailTaskCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "tail_task",
+		Short: "Print a task's output, following it as it's written. Args: job id, task id",
+	}
+	r.Flags().BoolVar(&c.stderr, "stderr", false, "Print the task's stderr instead of its stdout")
+	r.Flags().BoolVar(&c.follow, "follow", true, "Keep printing the output until the task is done")
+	r.Flags().DurationVar(&c.interval, "interval", time.Second, "How often to check for more output")
+	return r
+}
+
+func (c *tailTaskCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("a job id and a task id must be provided")
+	}
+	jobId, taskId := args[0], args[1]
+	log.Println("Tailing task", taskId, "of job", jobId)
+
+	// the output of the task's current run, which is on a different worker if it's retried
+	uri, offset := "", int64(0)
+	for {
+		status, err := cl.scootClient.GetStatus(jobId)
+		if err != nil {
+			switch err := err.(type) {
+			case *scoot.InvalidRequest:
+				return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+			default:
+				return fmt.Errorf("Error getting status: %v", err.Error())
+			}
+		}
+		taskStatus, ok := status.TaskStatus[taskId]
+		if !ok {
+			return fmt.Errorf("Job %v has no task %v", jobId, taskId)
+		}
+
+		// once a run against a snapshot completes, its output is in the result snapshot
+		// instead of on the worker, though the worker keeps serving it
+		runStatus := status.TaskData[taskId]
+		if runStatus != nil {
+			if runUri := c.outputUri(runStatus); runUri != uri && strings.HasPrefix(runUri, "http") {
+				if uri != "" {
+					log.Println("Task was run again, tailing its new run")
+				}
+				uri, offset = runUri, 0
+			}
+		}
+		done := taskStatus != scoot.Status_NOT_STARTED && taskStatus != scoot.Status_IN_PROGRESS
+
+		if uri != "" {
+			if offset, err = tailOutput(uri, offset, os.Stdout); err != nil {
+				return err
+			}
+		}
+		if done {
+			if uri == "" && runStatus != nil && runStatus.SnapshotId != nil {
+				return fmt.Errorf("Task %v finished before it could be followed, its output is %v", taskId, c.outputUri(runStatus))
+			}
+			return nil
+		}
+		if !c.follow && uri != "" {
+			return nil
+		}
+		time.Sleep(c.interval)
+	}
+}
+
+func (c *tailTaskCmd) outputUri(st *scoot.RunStatus) string {
+	if c.stderr {
+		return st.GetErrUri()
+	}
+	return st.GetOutUri()
+}
+
+// Writes the output served by a worker at uri after offset, and returns the
+// offset it ended at
+func tailOutput(uri string, offset int64, w io.Writer) (int64, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return offset, err
+	}
+	query := u.Query()
+	query.Set("content", "true")
+	query.Set("offset", strconv.FormatInt(offset, 10))
+	u.RawQuery = query.Encode()
+
+	resp, err := http.Get(u.String())
+	if err != nil {
+		return offset, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return offset, fmt.Errorf("Error reading output %v: %v", uri, resp.Status)
+	}
+	next, err := strconv.ParseInt(resp.Header.Get(runners.OutputOffsetHeader), 10, 64)
+	if err != nil {
+		return offset, fmt.Errorf("Error reading output %v, worker doesn't support tailing it", uri)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return offset, err
+	}
+	return next, nil
+}
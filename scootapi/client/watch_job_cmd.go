@@ -11,7 +11,8 @@ import (
 )
 
 const (
-	jobStatusSleepSeconds time.Duration = 3 * time.Second
+	// how long each WatchJob call waits for the job to change
+	watchJobTimeout time.Duration = 30 * time.Second
 )
 
 type watchJobCmd struct {
@@ -37,17 +38,27 @@ func (c *watchJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string
 
 	jobId := args[0]
 
+	// the first call returns every task, later calls only the tasks that changed
+	version := int64(0)
 	for {
-		jobStatus, err := GetAndPrintStatus(jobId, cl.scootClient)
+		status, err := cl.scootClient.WatchJob(jobId, version, int32(watchJobTimeout/time.Millisecond))
 		if err != nil {
-			return err
+			switch err := err.(type) {
+			case *scoot.InvalidRequest:
+				return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+			default:
+				return fmt.Errorf("Error watching job: %v", err.Error())
+			}
 		}
 
-		if *jobStatus == scoot.Status_COMPLETED || *jobStatus == scoot.Status_ROLLED_BACK {
-			return nil
+		if status.GetVersion() != version {
+			PrintJobStatus(status)
+			version = status.GetVersion()
 		}
 
-		time.Sleep(jobStatusSleepSeconds)
+		if status.Status == scoot.Status_COMPLETED || status.Status == scoot.Status_ROLLED_BACK {
+			return nil
+		}
 	}
 
 }
@@ -86,6 +97,16 @@ func PrintJobStatus(jobStatus *scoot.JobStatus) {
 			if runStatus.SnapshotId != nil {
 				fmt.Printf("\t\tSnapshot: %v\n", *runStatus.SnapshotId)
 			}
+			for i, attempt := range runStatus.Attempts {
+				fmt.Printf("\t\tAttempt %d: %v on %v", i+1, attempt.Status.String(), attempt.WorkerId)
+				if attempt.ExitCode != nil && attempt.Status == scoot.RunStatusState_COMPLETE {
+					fmt.Printf(", ExitCode: %d", *attempt.ExitCode)
+				}
+				if attempt.Error != nil && *attempt.Error != "" {
+					fmt.Printf(", Error: %v", *attempt.Error)
+				}
+				fmt.Printf("\n")
+			}
 			if taskStatus == scoot.Status_COMPLETED {
 				if runStatus.ExitCode != nil {
 					exitCode := *runStatus.ExitCode
@@ -22,6 +22,9 @@ func Usage() {
 	fmt.Fprintln(os.Stderr, "\nFunctions:")
 	fmt.Fprintln(os.Stderr, "  JobId RunJob(JobDefinition job)")
 	fmt.Fprintln(os.Stderr, "  JobStatus GetStatus(string jobId)")
+	fmt.Fprintln(os.Stderr, "  JobStatus KillJob(string jobId)")
+	fmt.Fprintln(os.Stderr, "  JobStatus WatchJob(string jobId, i64 version, i32 timeoutMs)")
+	fmt.Fprintln(os.Stderr, "  ListJobsResponse ListJobs(ListJobsRequest req)")
 	fmt.Fprintln(os.Stderr)
 	os.Exit(0)
 }
@@ -121,19 +124,19 @@ func main() {
 			fmt.Fprintln(os.Stderr, "RunJob requires 1 args")
 			flag.Usage()
 		}
-		arg13 := flag.Arg(1)
-		mbTrans14 := thrift.NewTMemoryBufferLen(len(arg13))
-		defer mbTrans14.Close()
-		_, err15 := mbTrans14.WriteString(arg13)
-		if err15 != nil {
+		arg29 := flag.Arg(1)
+		mbTrans30 := thrift.NewTMemoryBufferLen(len(arg29))
+		defer mbTrans30.Close()
+		_, err31 := mbTrans30.WriteString(arg29)
+		if err31 != nil {
 			Usage()
 			return
 		}
-		factory16 := thrift.NewTSimpleJSONProtocolFactory()
-		jsProt17 := factory16.GetProtocol(mbTrans14)
+		factory32 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt33 := factory32.GetProtocol(mbTrans30)
 		argvalue0 := scoot.NewJobDefinition()
-		err18 := argvalue0.Read(jsProt17)
-		if err18 != nil {
+		err34 := argvalue0.Read(jsProt33)
+		if err34 != nil {
 			Usage()
 			return
 		}
@@ -151,6 +154,64 @@ func main() {
 		fmt.Print(client.GetStatus(value0))
 		fmt.Print("\n")
 		break
+	case "KillJob":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "KillJob requires 1 args")
+			flag.Usage()
+		}
+		argvalue0 := flag.Arg(1)
+		value0 := argvalue0
+		fmt.Print(client.KillJob(value0))
+		fmt.Print("\n")
+		break
+	case "WatchJob":
+		if flag.NArg()-1 != 3 {
+			fmt.Fprintln(os.Stderr, "WatchJob requires 3 args")
+			flag.Usage()
+		}
+		argvalue0 := flag.Arg(1)
+		value0 := argvalue0
+		argvalue1, err35 := (strconv.ParseInt(flag.Arg(2), 10, 64))
+		if err35 != nil {
+			Usage()
+			return
+		}
+		value1 := argvalue1
+		tmp2, err36 := (strconv.Atoi(flag.Arg(3)))
+		if err36 != nil {
+			Usage()
+			return
+		}
+		argvalue2 := int32(tmp2)
+		value2 := argvalue2
+		fmt.Print(client.WatchJob(value0, value1, value2))
+		fmt.Print("\n")
+		break
+	case "ListJobs":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "ListJobs requires 1 args")
+			flag.Usage()
+		}
+		arg37 := flag.Arg(1)
+		mbTrans38 := thrift.NewTMemoryBufferLen(len(arg37))
+		defer mbTrans38.Close()
+		_, err39 := mbTrans38.WriteString(arg37)
+		if err39 != nil {
+			Usage()
+			return
+		}
+		factory40 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt41 := factory40.GetProtocol(mbTrans38)
+		argvalue0 := scoot.NewListJobsRequest()
+		err42 := argvalue0.Read(jsProt41)
+		if err42 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.ListJobs(value0))
+		fmt.Print("\n")
+		break
 	case "":
 		Usage()
 		break
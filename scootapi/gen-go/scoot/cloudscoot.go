@@ -21,6 +21,17 @@ type CloudScoot interface {
 	// Parameters:
 	//  - JobId
 	GetStatus(jobId string) (r *JobStatus, err error)
+	// Parameters:
+	//  - JobId
+	KillJob(jobId string) (r *JobStatus, err error)
+	// Parameters:
+	//  - JobId
+	//  - Version
+	//  - TimeoutMs
+	WatchJob(jobId string, version int64, timeoutMs int32) (r *JobStatus, err error)
+	// Parameters:
+	//  - Req
+	ListJobs(req *ListJobsRequest) (r *ListJobsResponse, err error)
 }
 
 type CloudScootClient struct {
@@ -99,16 +110,16 @@ func (p *CloudScootClient) recvRunJob() (value *JobId, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error7 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error8 error
-		error8, err = error7.Read(iprot)
+		error17 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error18 error
+		error18, err = error17.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error8
+		err = error18
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -183,16 +194,16 @@ func (p *CloudScootClient) recvGetStatus() (value *JobStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error9 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error10 error
-		error10, err = error9.Read(iprot)
+		error19 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error20 error
+		error20, err = error19.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error10
+		err = error20
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -217,6 +228,262 @@ func (p *CloudScootClient) recvGetStatus() (value *JobStatus, err error) {
 	return
 }
 
+// Parameters:
+//  - JobId
+func (p *CloudScootClient) KillJob(jobId string) (r *JobStatus, err error) {
+	if err = p.sendKillJob(jobId); err != nil {
+		return
+	}
+	return p.recvKillJob()
+}
+
+func (p *CloudScootClient) sendKillJob(jobId string) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("KillJob", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootKillJobArgs{
+		JobId: jobId,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvKillJob() (value *JobStatus, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "KillJob" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "KillJob failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "KillJob failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error21 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error22 error
+		error22, err = error21.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error22
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "KillJob failed: invalid message type")
+		return
+	}
+	result := CloudScootKillJobResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - JobId
+//  - Version
+//  - TimeoutMs
+func (p *CloudScootClient) WatchJob(jobId string, version int64, timeoutMs int32) (r *JobStatus, err error) {
+	if err = p.sendWatchJob(jobId, version, timeoutMs); err != nil {
+		return
+	}
+	return p.recvWatchJob()
+}
+
+func (p *CloudScootClient) sendWatchJob(jobId string, version int64, timeoutMs int32) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("WatchJob", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootWatchJobArgs{
+		JobId:     jobId,
+		Version:   version,
+		TimeoutMs: timeoutMs,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvWatchJob() (value *JobStatus, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "WatchJob" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "WatchJob failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "WatchJob failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error23 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error24 error
+		error24, err = error23.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error24
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "WatchJob failed: invalid message type")
+		return
+	}
+	result := CloudScootWatchJobResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *CloudScootClient) ListJobs(req *ListJobsRequest) (r *ListJobsResponse, err error) {
+	if err = p.sendListJobs(req); err != nil {
+		return
+	}
+	return p.recvListJobs()
+}
+
+func (p *CloudScootClient) sendListJobs(req *ListJobsRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("ListJobs", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootListJobsArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvListJobs() (value *ListJobsResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "ListJobs" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ListJobs failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ListJobs failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error25 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error26 error
+		error26, err = error25.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error26
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ListJobs failed: invalid message type")
+		return
+	}
+	result := CloudScootListJobsResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
 type CloudScootProcessor struct {
 	processorMap map[string]thrift.TProcessorFunction
 	handler      CloudScoot
@@ -237,10 +504,13 @@ func (p *CloudScootProcessor) ProcessorMap() map[string]thrift.TProcessorFunctio
 
 func NewCloudScootProcessor(handler CloudScoot) *CloudScootProcessor {
 
-	self11 := &CloudScootProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
-	self11.processorMap["RunJob"] = &cloudScootProcessorRunJob{handler: handler}
-	self11.processorMap["GetStatus"] = &cloudScootProcessorGetStatus{handler: handler}
-	return self11
+	self27 := &CloudScootProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
+	self27.processorMap["RunJob"] = &cloudScootProcessorRunJob{handler: handler}
+	self27.processorMap["GetStatus"] = &cloudScootProcessorGetStatus{handler: handler}
+	self27.processorMap["KillJob"] = &cloudScootProcessorKillJob{handler: handler}
+	self27.processorMap["WatchJob"] = &cloudScootProcessorWatchJob{handler: handler}
+	self27.processorMap["ListJobs"] = &cloudScootProcessorListJobs{handler: handler}
+	return self27
 }
 
 func (p *CloudScootProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
@@ -253,12 +523,12 @@ func (p *CloudScootProcessor) Process(iprot, oprot thrift.TProtocol) (success bo
 	}
 	iprot.Skip(thrift.STRUCT)
 	iprot.ReadMessageEnd()
-	x12 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
+	x28 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
 	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-	x12.Write(oprot)
+	x28.Write(oprot)
 	oprot.WriteMessageEnd()
 	oprot.Flush()
-	return false, x12
+	return false, x28
 
 }
 
@@ -354,49 +624,1071 @@ func (p *cloudScootProcessorGetStatus) Process(seqId int32, iprot, oprot thrift.
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("GetStatus", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if err2 = oprot.WriteMessageBegin("GetStatus", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorKillJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorKillJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootKillJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootKillJobResult{}
+	var retval *JobStatus
+	var err2 error
+	if retval, err2 = p.handler.KillJob(args.JobId); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing KillJob: "+err2.Error())
+			oprot.WriteMessageBegin("KillJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("KillJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorWatchJob struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorWatchJob) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootWatchJobArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("WatchJob", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootWatchJobResult{}
+	var retval *JobStatus
+	var err2 error
+	if retval, err2 = p.handler.WatchJob(args.JobId, args.Version, args.TimeoutMs); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing WatchJob: "+err2.Error())
+			oprot.WriteMessageBegin("WatchJob", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("WatchJob", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type cloudScootProcessorListJobs struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorListJobs) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootListJobsArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("ListJobs", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootListJobsResult{}
+	var retval *ListJobsResponse
+	var err2 error
+	if retval, err2 = p.handler.ListJobs(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListJobs: "+err2.Error())
+			oprot.WriteMessageBegin("ListJobs", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("ListJobs", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+// Attributes:
+//  - Job
+type CloudScootRunJobArgs struct {
+	Job *JobDefinition `thrift:"job,1" json:"job"`
+}
+
+func NewCloudScootRunJobArgs() *CloudScootRunJobArgs {
+	return &CloudScootRunJobArgs{}
+}
+
+var CloudScootRunJobArgs_Job_DEFAULT *JobDefinition
+
+func (p *CloudScootRunJobArgs) GetJob() *JobDefinition {
+	if !p.IsSetJob() {
+		return CloudScootRunJobArgs_Job_DEFAULT
+	}
+	return p.Job
+}
+func (p *CloudScootRunJobArgs) IsSetJob() bool {
+	return p.Job != nil
+}
+
+func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobArgs) readField1(iprot thrift.TProtocol) error {
+	p.Job = &JobDefinition{}
+	if err := p.Job.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Job), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("job", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:job: ", p), err)
+	}
+	if err := p.Job.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Job), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:job: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootRunJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootRunJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Cnsn
+type CloudScootRunJobResult struct {
+	Success *JobId             `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest    `thrift:"ir,1" json:"ir,omitempty"`
+	Cnsn    *CanNotScheduleNow `thrift:"cnsn,2" json:"cnsn,omitempty"`
+}
+
+func NewCloudScootRunJobResult() *CloudScootRunJobResult {
+	return &CloudScootRunJobResult{}
+}
+
+var CloudScootRunJobResult_Success_DEFAULT *JobId
+
+func (p *CloudScootRunJobResult) GetSuccess() *JobId {
+	if !p.IsSetSuccess() {
+		return CloudScootRunJobResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootRunJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootRunJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootRunJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootRunJobResult_Cnsn_DEFAULT *CanNotScheduleNow
+
+func (p *CloudScootRunJobResult) GetCnsn() *CanNotScheduleNow {
+	if !p.IsSetCnsn() {
+		return CloudScootRunJobResult_Cnsn_DEFAULT
+	}
+	return p.Cnsn
+}
+func (p *CloudScootRunJobResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootRunJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootRunJobResult) IsSetCnsn() bool {
+	return p.Cnsn != nil
+}
+
+func (p *CloudScootRunJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobId{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Cnsn = &CanNotScheduleNow{}
+	if err := p.Cnsn.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Cnsn), err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootRunJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRunJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRunJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCnsn() {
+		if err := oprot.WriteFieldBegin("cnsn", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:cnsn: ", p), err)
+		}
+		if err := p.Cnsn.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Cnsn), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:cnsn: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootRunJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootRunJobResult(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+type CloudScootGetStatusArgs struct {
+	JobId string `thrift:"jobId,1" json:"jobId"`
+}
+
+func NewCloudScootGetStatusArgs() *CloudScootGetStatusArgs {
+	return &CloudScootGetStatusArgs{}
+}
+
+func (p *CloudScootGetStatusArgs) GetJobId() string {
+	return p.JobId
+}
+func (p *CloudScootGetStatusArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetStatus_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetStatusArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Err
+type CloudScootGetStatusResult struct {
+	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootGetStatusResult() *CloudScootGetStatusResult {
+	return &CloudScootGetStatusResult{}
+}
+
+var CloudScootGetStatusResult_Success_DEFAULT *JobStatus
+
+func (p *CloudScootGetStatusResult) GetSuccess() *JobStatus {
+	if !p.IsSetSuccess() {
+		return CloudScootGetStatusResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootGetStatusResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootGetStatusResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootGetStatusResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootGetStatusResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootGetStatusResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootGetStatusResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootGetStatusResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootGetStatusResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootGetStatusResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootGetStatusResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetStatus_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetStatusResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetStatusResult(%+v)", *p)
+}
+
+// Attributes:
+//  - JobId
+type CloudScootKillJobArgs struct {
+	JobId string `thrift:"jobId,1" json:"jobId"`
+}
+
+func NewCloudScootKillJobArgs() *CloudScootKillJobArgs {
+	return &CloudScootKillJobArgs{}
+}
+
+func (p *CloudScootKillJobArgs) GetJobId() string {
+	return p.JobId
+}
+func (p *CloudScootKillJobArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("KillJob_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootKillJobArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootKillJobArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Err
+type CloudScootKillJobResult struct {
+	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootKillJobResult() *CloudScootKillJobResult {
+	return &CloudScootKillJobResult{}
+}
+
+var CloudScootKillJobResult_Success_DEFAULT *JobStatus
+
+func (p *CloudScootKillJobResult) GetSuccess() *JobStatus {
+	if !p.IsSetSuccess() {
+		return CloudScootKillJobResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootKillJobResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootKillJobResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootKillJobResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootKillJobResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootKillJobResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootKillJobResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootKillJobResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootKillJobResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootKillJobResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootKillJobResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootKillJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("KillJob_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *CloudScootKillJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
 	}
-	if err != nil {
-		return
+	return err
+}
+
+func (p *CloudScootKillJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
 	}
-	return true, err
+	return err
 }
 
-// HELPER FUNCTIONS AND STRUCTURES
+func (p *CloudScootKillJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
 
-// Attributes:
-//  - Job
-type CloudScootRunJobArgs struct {
-	Job *JobDefinition `thrift:"job,1" json:"job"`
+func (p *CloudScootKillJobResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootKillJobResult(%+v)", *p)
 }
 
-func NewCloudScootRunJobArgs() *CloudScootRunJobArgs {
-	return &CloudScootRunJobArgs{}
+// Attributes:
+//  - JobId
+//  - Version
+//  - TimeoutMs
+type CloudScootWatchJobArgs struct {
+	JobId     string `thrift:"jobId,1" json:"jobId"`
+	Version   int64  `thrift:"version,2" json:"version"`
+	TimeoutMs int32  `thrift:"timeoutMs,3" json:"timeoutMs"`
 }
 
-var CloudScootRunJobArgs_Job_DEFAULT *JobDefinition
+func NewCloudScootWatchJobArgs() *CloudScootWatchJobArgs {
+	return &CloudScootWatchJobArgs{}
+}
 
-func (p *CloudScootRunJobArgs) GetJob() *JobDefinition {
-	if !p.IsSetJob() {
-		return CloudScootRunJobArgs_Job_DEFAULT
-	}
-	return p.Job
+func (p *CloudScootWatchJobArgs) GetJobId() string {
+	return p.JobId
 }
-func (p *CloudScootRunJobArgs) IsSetJob() bool {
-	return p.Job != nil
+
+func (p *CloudScootWatchJobArgs) GetVersion() int64 {
+	return p.Version
 }
 
-func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootWatchJobArgs) GetTimeoutMs() int32 {
+	return p.TimeoutMs
+}
+func (p *CloudScootWatchJobArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -414,6 +1706,14 @@ func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -429,21 +1729,46 @@ func (p *CloudScootRunJobArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobArgs) readField1(iprot thrift.TProtocol) error {
-	p.Job = &JobDefinition{}
-	if err := p.Job.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Job), err)
+func (p *CloudScootWatchJobArgs) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.JobId = v
 	}
 	return nil
 }
 
-func (p *CloudScootRunJobArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunJob_args"); err != nil {
+func (p *CloudScootWatchJobArgs) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Version = v
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobArgs) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.TimeoutMs = v
+	}
+	return nil
+}
+
+func (p *CloudScootWatchJobArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("WatchJob_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -453,79 +1778,105 @@ func (p *CloudScootRunJobArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("job", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:job: ", p), err)
+func (p *CloudScootWatchJobArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
 	}
-	if err := p.Job.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Job), err)
+	if err := oprot.WriteString(string(p.JobId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:job: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
 	}
 	return err
 }
 
-func (p *CloudScootRunJobArgs) String() string {
+func (p *CloudScootWatchJobArgs) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("version", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:version: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Version)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.version (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:version: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootWatchJobArgs) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("timeoutMs", thrift.I32, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:timeoutMs: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.TimeoutMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.timeoutMs (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:timeoutMs: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootWatchJobArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootRunJobArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootWatchJobArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Ir
-//  - Cnsn
-type CloudScootRunJobResult struct {
-	Success *JobId             `thrift:"success,0" json:"success,omitempty"`
-	Ir      *InvalidRequest    `thrift:"ir,1" json:"ir,omitempty"`
-	Cnsn    *CanNotScheduleNow `thrift:"cnsn,2" json:"cnsn,omitempty"`
+//  - Err
+type CloudScootWatchJobResult struct {
+	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootRunJobResult() *CloudScootRunJobResult {
-	return &CloudScootRunJobResult{}
+func NewCloudScootWatchJobResult() *CloudScootWatchJobResult {
+	return &CloudScootWatchJobResult{}
 }
 
-var CloudScootRunJobResult_Success_DEFAULT *JobId
+var CloudScootWatchJobResult_Success_DEFAULT *JobStatus
 
-func (p *CloudScootRunJobResult) GetSuccess() *JobId {
+func (p *CloudScootWatchJobResult) GetSuccess() *JobStatus {
 	if !p.IsSetSuccess() {
-		return CloudScootRunJobResult_Success_DEFAULT
+		return CloudScootWatchJobResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var CloudScootRunJobResult_Ir_DEFAULT *InvalidRequest
+var CloudScootWatchJobResult_Ir_DEFAULT *InvalidRequest
 
-func (p *CloudScootRunJobResult) GetIr() *InvalidRequest {
+func (p *CloudScootWatchJobResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootRunJobResult_Ir_DEFAULT
+		return CloudScootWatchJobResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootRunJobResult_Cnsn_DEFAULT *CanNotScheduleNow
+var CloudScootWatchJobResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootRunJobResult) GetCnsn() *CanNotScheduleNow {
-	if !p.IsSetCnsn() {
-		return CloudScootRunJobResult_Cnsn_DEFAULT
+func (p *CloudScootWatchJobResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootWatchJobResult_Err_DEFAULT
 	}
-	return p.Cnsn
+	return p.Err
 }
-func (p *CloudScootRunJobResult) IsSetSuccess() bool {
+func (p *CloudScootWatchJobResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *CloudScootRunJobResult) IsSetIr() bool {
+func (p *CloudScootWatchJobResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootRunJobResult) IsSetCnsn() bool {
-	return p.Cnsn != nil
+func (p *CloudScootWatchJobResult) IsSetErr() bool {
+	return p.Err != nil
 }
 
-func (p *CloudScootRunJobResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootWatchJobResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -566,15 +1917,15 @@ func (p *CloudScootRunJobResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &JobId{}
+func (p *CloudScootWatchJobResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &JobStatus{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *CloudScootRunJobResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootWatchJobResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -582,16 +1933,16 @@ func (p *CloudScootRunJobResult) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobResult) readField2(iprot thrift.TProtocol) error {
-	p.Cnsn = &CanNotScheduleNow{}
-	if err := p.Cnsn.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Cnsn), err)
+func (p *CloudScootWatchJobResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *CloudScootRunJobResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunJob_result"); err != nil {
+func (p *CloudScootWatchJobResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("WatchJob_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -612,7 +1963,7 @@ func (p *CloudScootRunJobResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootRunJobResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootWatchJobResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -627,7 +1978,7 @@ func (p *CloudScootRunJobResult) writeField0(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *CloudScootRunJobResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootWatchJobResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -642,42 +1993,51 @@ func (p *CloudScootRunJobResult) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *CloudScootRunJobResult) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetCnsn() {
-		if err := oprot.WriteFieldBegin("cnsn", thrift.STRUCT, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:cnsn: ", p), err)
+func (p *CloudScootWatchJobResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
 		}
-		if err := p.Cnsn.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Cnsn), err)
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:cnsn: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *CloudScootRunJobResult) String() string {
+func (p *CloudScootWatchJobResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootRunJobResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootWatchJobResult(%+v)", *p)
 }
 
 // Attributes:
-//  - JobId
-type CloudScootGetStatusArgs struct {
-	JobId string `thrift:"jobId,1" json:"jobId"`
+//  - Req
+type CloudScootListJobsArgs struct {
+	Req *ListJobsRequest `thrift:"req,1" json:"req"`
 }
 
-func NewCloudScootGetStatusArgs() *CloudScootGetStatusArgs {
-	return &CloudScootGetStatusArgs{}
+func NewCloudScootListJobsArgs() *CloudScootListJobsArgs {
+	return &CloudScootListJobsArgs{}
 }
 
-func (p *CloudScootGetStatusArgs) GetJobId() string {
-	return p.JobId
+var CloudScootListJobsArgs_Req_DEFAULT *ListJobsRequest
+
+func (p *CloudScootListJobsArgs) GetReq() *ListJobsRequest {
+	if !p.IsSetReq() {
+		return CloudScootListJobsArgs_Req_DEFAULT
+	}
+	return p.Req
 }
-func (p *CloudScootGetStatusArgs) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootListJobsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootListJobsArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -710,17 +2070,16 @@ func (p *CloudScootGetStatusArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusArgs) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.JobId = v
+func (p *CloudScootListJobsArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &ListJobsRequest{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *CloudScootGetStatusArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetStatus_args"); err != nil {
+func (p *CloudScootListJobsArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListJobs_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -735,79 +2094,79 @@ func (p *CloudScootGetStatusArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusArgs) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("jobId", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobId: ", p), err)
+func (p *CloudScootListJobsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.JobId)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.jobId (1) field write error: ", p), err)
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobId: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
 	}
 	return err
 }
 
-func (p *CloudScootGetStatusArgs) String() string {
+func (p *CloudScootListJobsArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootGetStatusArgs(%+v)", *p)
+	return fmt.Sprintf("CloudScootListJobsArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Ir
 //  - Err
-type CloudScootGetStatusResult struct {
-	Success *JobStatus        `thrift:"success,0" json:"success,omitempty"`
+type CloudScootListJobsResult struct {
+	Success *ListJobsResponse `thrift:"success,0" json:"success,omitempty"`
 	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
 	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
 }
 
-func NewCloudScootGetStatusResult() *CloudScootGetStatusResult {
-	return &CloudScootGetStatusResult{}
+func NewCloudScootListJobsResult() *CloudScootListJobsResult {
+	return &CloudScootListJobsResult{}
 }
 
-var CloudScootGetStatusResult_Success_DEFAULT *JobStatus
+var CloudScootListJobsResult_Success_DEFAULT *ListJobsResponse
 
-func (p *CloudScootGetStatusResult) GetSuccess() *JobStatus {
+func (p *CloudScootListJobsResult) GetSuccess() *ListJobsResponse {
 	if !p.IsSetSuccess() {
-		return CloudScootGetStatusResult_Success_DEFAULT
+		return CloudScootListJobsResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var CloudScootGetStatusResult_Ir_DEFAULT *InvalidRequest
+var CloudScootListJobsResult_Ir_DEFAULT *InvalidRequest
 
-func (p *CloudScootGetStatusResult) GetIr() *InvalidRequest {
+func (p *CloudScootListJobsResult) GetIr() *InvalidRequest {
 	if !p.IsSetIr() {
-		return CloudScootGetStatusResult_Ir_DEFAULT
+		return CloudScootListJobsResult_Ir_DEFAULT
 	}
 	return p.Ir
 }
 
-var CloudScootGetStatusResult_Err_DEFAULT *ScootServerError
+var CloudScootListJobsResult_Err_DEFAULT *ScootServerError
 
-func (p *CloudScootGetStatusResult) GetErr() *ScootServerError {
+func (p *CloudScootListJobsResult) GetErr() *ScootServerError {
 	if !p.IsSetErr() {
-		return CloudScootGetStatusResult_Err_DEFAULT
+		return CloudScootListJobsResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *CloudScootGetStatusResult) IsSetSuccess() bool {
+func (p *CloudScootListJobsResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *CloudScootGetStatusResult) IsSetIr() bool {
+func (p *CloudScootListJobsResult) IsSetIr() bool {
 	return p.Ir != nil
 }
 
-func (p *CloudScootGetStatusResult) IsSetErr() bool {
+func (p *CloudScootListJobsResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *CloudScootGetStatusResult) Read(iprot thrift.TProtocol) error {
+func (p *CloudScootListJobsResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -848,15 +2207,15 @@ func (p *CloudScootGetStatusResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &JobStatus{}
+func (p *CloudScootListJobsResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &ListJobsResponse{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) readField1(iprot thrift.TProtocol) error {
+func (p *CloudScootListJobsResult) readField1(iprot thrift.TProtocol) error {
 	p.Ir = &InvalidRequest{}
 	if err := p.Ir.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
@@ -864,7 +2223,7 @@ func (p *CloudScootGetStatusResult) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) readField2(iprot thrift.TProtocol) error {
+func (p *CloudScootListJobsResult) readField2(iprot thrift.TProtocol) error {
 	p.Err = &ScootServerError{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -872,8 +2231,8 @@ func (p *CloudScootGetStatusResult) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetStatus_result"); err != nil {
+func (p *CloudScootListJobsResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListJobs_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -894,7 +2253,7 @@ func (p *CloudScootGetStatusResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *CloudScootGetStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootListJobsResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -909,7 +2268,7 @@ func (p *CloudScootGetStatusResult) writeField0(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *CloudScootGetStatusResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootListJobsResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetIr() {
 		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
@@ -924,7 +2283,7 @@ func (p *CloudScootGetStatusResult) writeField1(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *CloudScootGetStatusResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *CloudScootListJobsResult) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
@@ -939,9 +2298,9 @@ func (p *CloudScootGetStatusResult) writeField2(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *CloudScootGetStatusResult) String() string {
+func (p *CloudScootListJobsResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("CloudScootGetStatusResult(%+v)", *p)
+	return fmt.Sprintf("CloudScootListJobsResult(%+v)", *p)
 }
@@ -138,6 +138,7 @@ const (
 	Status_COMPLETED    Status = 3
 	Status_ROLLING_BACK Status = 4
 	Status_ROLLED_BACK  Status = 5
+	Status_SKIPPED      Status = 6
 )
 
 func (p Status) String() string {
@@ -152,6 +153,8 @@ func (p Status) String() string {
 		return "ROLLING_BACK"
 	case Status_ROLLED_BACK:
 		return "ROLLED_BACK"
+	case Status_SKIPPED:
+		return "SKIPPED"
 	}
 	return "<UNSET>"
 }
@@ -168,6 +171,8 @@ func StatusFromString(s string) (Status, error) {
 		return Status_ROLLING_BACK, nil
 	case "ROLLED_BACK":
 		return Status_ROLLED_BACK, nil
+	case "SKIPPED":
+		return Status_SKIPPED, nil
 	}
 	return Status(0), fmt.Errorf("not a valid Status string")
 }
@@ -506,106 +511,76 @@ func (p *ScootServerError) Error() string {
 }
 
 // Attributes:
+//  - WorkerId
 //  - Status
 //  - RunId
-//  - OutUri
-//  - ErrUri
-//  - Error
 //  - ExitCode
-//  - SnapshotId
-type RunStatus struct {
-	Status     RunStatusState `thrift:"status,1,required" json:"status"`
-	RunId      string         `thrift:"runId,2,required" json:"runId"`
-	OutUri     *string        `thrift:"outUri,3" json:"outUri,omitempty"`
-	ErrUri     *string        `thrift:"errUri,4" json:"errUri,omitempty"`
-	Error      *string        `thrift:"error,5" json:"error,omitempty"`
-	ExitCode   *int32         `thrift:"exitCode,6" json:"exitCode,omitempty"`
-	SnapshotId *string        `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
-}
-
-func NewRunStatus() *RunStatus {
-	return &RunStatus{}
-}
-
-func (p *RunStatus) GetStatus() RunStatusState {
-	return p.Status
+//  - Error
+type TaskAttempt struct {
+	WorkerId string         `thrift:"workerId,1,required" json:"workerId"`
+	Status   RunStatusState `thrift:"status,2,required" json:"status"`
+	RunId    *string        `thrift:"runId,3" json:"runId,omitempty"`
+	ExitCode *int32         `thrift:"exitCode,4" json:"exitCode,omitempty"`
+	Error    *string        `thrift:"error,5" json:"error,omitempty"`
 }
 
-func (p *RunStatus) GetRunId() string {
-	return p.RunId
+func NewTaskAttempt() *TaskAttempt {
+	return &TaskAttempt{}
 }
 
-var RunStatus_OutUri_DEFAULT string
-
-func (p *RunStatus) GetOutUri() string {
-	if !p.IsSetOutUri() {
-		return RunStatus_OutUri_DEFAULT
-	}
-	return *p.OutUri
+func (p *TaskAttempt) GetWorkerId() string {
+	return p.WorkerId
 }
 
-var RunStatus_ErrUri_DEFAULT string
-
-func (p *RunStatus) GetErrUri() string {
-	if !p.IsSetErrUri() {
-		return RunStatus_ErrUri_DEFAULT
-	}
-	return *p.ErrUri
+func (p *TaskAttempt) GetStatus() RunStatusState {
+	return p.Status
 }
 
-var RunStatus_Error_DEFAULT string
+var TaskAttempt_RunId_DEFAULT string
 
-func (p *RunStatus) GetError() string {
-	if !p.IsSetError() {
-		return RunStatus_Error_DEFAULT
+func (p *TaskAttempt) GetRunId() string {
+	if !p.IsSetRunId() {
+		return TaskAttempt_RunId_DEFAULT
 	}
-	return *p.Error
+	return *p.RunId
 }
 
-var RunStatus_ExitCode_DEFAULT int32
+var TaskAttempt_ExitCode_DEFAULT int32
 
-func (p *RunStatus) GetExitCode() int32 {
+func (p *TaskAttempt) GetExitCode() int32 {
 	if !p.IsSetExitCode() {
-		return RunStatus_ExitCode_DEFAULT
+		return TaskAttempt_ExitCode_DEFAULT
 	}
 	return *p.ExitCode
 }
 
-var RunStatus_SnapshotId_DEFAULT string
+var TaskAttempt_Error_DEFAULT string
 
-func (p *RunStatus) GetSnapshotId() string {
-	if !p.IsSetSnapshotId() {
-		return RunStatus_SnapshotId_DEFAULT
+func (p *TaskAttempt) GetError() string {
+	if !p.IsSetError() {
+		return TaskAttempt_Error_DEFAULT
 	}
-	return *p.SnapshotId
-}
-func (p *RunStatus) IsSetOutUri() bool {
-	return p.OutUri != nil
-}
-
-func (p *RunStatus) IsSetErrUri() bool {
-	return p.ErrUri != nil
+	return *p.Error
 }
-
-func (p *RunStatus) IsSetError() bool {
-	return p.Error != nil
+func (p *TaskAttempt) IsSetRunId() bool {
+	return p.RunId != nil
 }
 
-func (p *RunStatus) IsSetExitCode() bool {
+func (p *TaskAttempt) IsSetExitCode() bool {
 	return p.ExitCode != nil
 }
 
-func (p *RunStatus) IsSetSnapshotId() bool {
-	return p.SnapshotId != nil
+func (p *TaskAttempt) IsSetError() bool {
+	return p.Error != nil
 }
 
-func (p *RunStatus) Read(iprot thrift.TProtocol) error {
+func (p *TaskAttempt) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetWorkerId bool = false
 	var issetStatus bool = false
-	var issetRunId bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -620,12 +595,12 @@ func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetStatus = true
+			issetWorkerId = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
-			issetRunId = true
+			issetStatus = true
 		case 3:
 			if err := p.readField3(iprot); err != nil {
 				return err
@@ -638,14 +613,6 @@ func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField5(iprot); err != nil {
 				return err
 			}
-		case 6:
-			if err := p.readField6(iprot); err != nil {
-				return err
-			}
-		case 7:
-			if err := p.readField7(iprot); err != nil {
-				return err
-			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -658,53 +625,53 @@ func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	if !issetWorkerId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WorkerId is not set"))
+	}
 	if !issetStatus {
 		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
 	}
-	if !issetRunId {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RunId is not set"))
-	}
 	return nil
 }
 
-func (p *RunStatus) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
+func (p *TaskAttempt) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		temp := RunStatusState(v)
-		p.Status = temp
+		p.WorkerId = v
 	}
 	return nil
 }
 
-func (p *RunStatus) readField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *TaskAttempt) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.RunId = v
+		temp := RunStatusState(v)
+		p.Status = temp
 	}
 	return nil
 }
 
-func (p *RunStatus) readField3(iprot thrift.TProtocol) error {
+func (p *TaskAttempt) readField3(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 3: ", err)
 	} else {
-		p.OutUri = &v
+		p.RunId = &v
 	}
 	return nil
 }
 
-func (p *RunStatus) readField4(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *TaskAttempt) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 4: ", err)
 	} else {
-		p.ErrUri = &v
+		p.ExitCode = &v
 	}
 	return nil
 }
 
-func (p *RunStatus) readField5(iprot thrift.TProtocol) error {
+func (p *TaskAttempt) readField5(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 5: ", err)
 	} else {
@@ -713,26 +680,8 @@ func (p *RunStatus) readField5(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *RunStatus) readField6(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 6: ", err)
-	} else {
-		p.ExitCode = &v
-	}
-	return nil
-}
-
-func (p *RunStatus) readField7(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 7: ", err)
-	} else {
-		p.SnapshotId = &v
-	}
-	return nil
-}
-
-func (p *RunStatus) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
+func (p *TaskAttempt) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("TaskAttempt"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -750,12 +699,6 @@ func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField5(oprot); err != nil {
 		return err
 	}
-	if err := p.writeField6(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField7(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -765,63 +708,63 @@ func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *RunStatus) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("status", thrift.I32, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:status: ", p), err)
+func (p *TaskAttempt) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("workerId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:workerId: ", p), err)
 	}
-	if err := oprot.WriteI32(int32(p.Status)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.status (1) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.WorkerId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.workerId (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:status: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:workerId: ", p), err)
 	}
 	return err
 }
 
-func (p *RunStatus) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("runId", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:runId: ", p), err)
+func (p *TaskAttempt) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.RunId)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.runId (2) field write error: ", p), err)
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:runId: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
 	}
 	return err
 }
 
-func (p *RunStatus) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetOutUri() {
-		if err := oprot.WriteFieldBegin("outUri", thrift.STRING, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:outUri: ", p), err)
+func (p *TaskAttempt) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRunId() {
+		if err := oprot.WriteFieldBegin("runId", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:runId: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.OutUri)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.outUri (3) field write error: ", p), err)
+		if err := oprot.WriteString(string(*p.RunId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.runId (3) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:outUri: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:runId: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *RunStatus) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetErrUri() {
-		if err := oprot.WriteFieldBegin("errUri", thrift.STRING, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:errUri: ", p), err)
+func (p *TaskAttempt) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetExitCode() {
+		if err := oprot.WriteFieldBegin("exitCode", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:exitCode: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.ErrUri)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.errUri (4) field write error: ", p), err)
+		if err := oprot.WriteI32(int32(*p.ExitCode)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.exitCode (4) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:errUri: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:exitCode: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *RunStatus) writeField5(oprot thrift.TProtocol) (err error) {
+func (p *TaskAttempt) writeField5(oprot thrift.TProtocol) (err error) {
 	if p.IsSetError() {
 		if err := oprot.WriteFieldBegin("error", thrift.STRING, 5); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:error: ", p), err)
@@ -836,61 +779,68 @@ func (p *RunStatus) writeField5(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *RunStatus) writeField6(oprot thrift.TProtocol) (err error) {
-	if p.IsSetExitCode() {
-		if err := oprot.WriteFieldBegin("exitCode", thrift.I32, 6); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:exitCode: ", p), err)
-		}
-		if err := oprot.WriteI32(int32(*p.ExitCode)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.exitCode (6) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:exitCode: ", p), err)
-		}
+func (p *TaskAttempt) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return err
+	return fmt.Sprintf("TaskAttempt(%+v)", *p)
 }
 
-func (p *RunStatus) writeField7(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSnapshotId() {
-		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 7); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:snapshotId: ", p), err)
-		}
-		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (7) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:snapshotId: ", p), err)
-		}
-	}
-	return err
+// Attributes:
+//  - WallTimeMs
+//  - UserTimeMs
+//  - SysTimeMs
+//  - MaxRssBytes
+//  - BlockInputOps
+//  - BlockOutputOps
+type RunUsage struct {
+	WallTimeMs     int64 `thrift:"wallTimeMs,1,required" json:"wallTimeMs"`
+	UserTimeMs     int64 `thrift:"userTimeMs,2,required" json:"userTimeMs"`
+	SysTimeMs      int64 `thrift:"sysTimeMs,3,required" json:"sysTimeMs"`
+	MaxRssBytes    int64 `thrift:"maxRssBytes,4,required" json:"maxRssBytes"`
+	BlockInputOps  int64 `thrift:"blockInputOps,5,required" json:"blockInputOps"`
+	BlockOutputOps int64 `thrift:"blockOutputOps,6,required" json:"blockOutputOps"`
 }
 
-func (p *RunStatus) String() string {
-	if p == nil {
-		return "<nil>"
-	}
-	return fmt.Sprintf("RunStatus(%+v)", *p)
+func NewRunUsage() *RunUsage {
+	return &RunUsage{}
 }
 
-// Attributes:
-//  - Argv
-type Command struct {
-	Argv []string `thrift:"argv,1" json:"argv"`
+func (p *RunUsage) GetWallTimeMs() int64 {
+	return p.WallTimeMs
 }
 
-func NewCommand() *Command {
-	return &Command{}
+func (p *RunUsage) GetUserTimeMs() int64 {
+	return p.UserTimeMs
 }
 
-func (p *Command) GetArgv() []string {
-	return p.Argv
+func (p *RunUsage) GetSysTimeMs() int64 {
+	return p.SysTimeMs
 }
-func (p *Command) Read(iprot thrift.TProtocol) error {
+
+func (p *RunUsage) GetMaxRssBytes() int64 {
+	return p.MaxRssBytes
+}
+
+func (p *RunUsage) GetBlockInputOps() int64 {
+	return p.BlockInputOps
+}
+
+func (p *RunUsage) GetBlockOutputOps() int64 {
+	return p.BlockOutputOps
+}
+func (p *RunUsage) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetWallTimeMs bool = false
+	var issetUserTimeMs bool = false
+	var issetSysTimeMs bool = false
+	var issetMaxRssBytes bool = false
+	var issetBlockInputOps bool = false
+	var issetBlockOutputOps bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -904,6 +854,32 @@ func (p *Command) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
+			issetWallTimeMs = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetUserTimeMs = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetSysTimeMs = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+			issetMaxRssBytes = true
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+			issetBlockInputOps = true
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+			issetBlockOutputOps = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -916,38 +892,1922 @@ func (p *Command) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return nil
-}
-
-func (p *Command) readField1(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
+	if !issetWallTimeMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WallTimeMs is not set"))
 	}
-	tSlice := make([]string, 0, size)
-	p.Argv = tSlice
-	for i := 0; i < size; i++ {
-		var _elem0 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_elem0 = v
-		}
-		p.Argv = append(p.Argv, _elem0)
+	if !issetUserTimeMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field UserTimeMs is not set"))
 	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+	if !issetSysTimeMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field SysTimeMs is not set"))
+	}
+	if !issetMaxRssBytes {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field MaxRssBytes is not set"))
+	}
+	if !issetBlockInputOps {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field BlockInputOps is not set"))
+	}
+	if !issetBlockOutputOps {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field BlockOutputOps is not set"))
 	}
 	return nil
 }
 
-func (p *Command) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Command"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+func (p *RunUsage) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.WallTimeMs = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.UserTimeMs = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.SysTimeMs = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.MaxRssBytes = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.BlockInputOps = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.BlockOutputOps = v
+	}
+	return nil
+}
+
+func (p *RunUsage) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunUsage"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RunUsage) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("wallTimeMs", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:wallTimeMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.WallTimeMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.wallTimeMs (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:wallTimeMs: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("userTimeMs", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:userTimeMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.UserTimeMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.userTimeMs (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:userTimeMs: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("sysTimeMs", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:sysTimeMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.SysTimeMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.sysTimeMs (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:sysTimeMs: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("maxRssBytes", thrift.I64, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:maxRssBytes: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.MaxRssBytes)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.maxRssBytes (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:maxRssBytes: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("blockInputOps", thrift.I64, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:blockInputOps: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.BlockInputOps)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.blockInputOps (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:blockInputOps: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField6(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("blockOutputOps", thrift.I64, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:blockOutputOps: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.BlockOutputOps)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.blockOutputOps (6) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:blockOutputOps: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RunUsage(%+v)", *p)
+}
+
+// Attributes:
+//  - Status
+//  - RunId
+//  - OutUri
+//  - ErrUri
+//  - Error
+//  - ExitCode
+//  - SnapshotId
+//  - Attempts
+//  - Usage
+type RunStatus struct {
+	Status     RunStatusState `thrift:"status,1,required" json:"status"`
+	RunId      string         `thrift:"runId,2,required" json:"runId"`
+	OutUri     *string        `thrift:"outUri,3" json:"outUri,omitempty"`
+	ErrUri     *string        `thrift:"errUri,4" json:"errUri,omitempty"`
+	Error      *string        `thrift:"error,5" json:"error,omitempty"`
+	ExitCode   *int32         `thrift:"exitCode,6" json:"exitCode,omitempty"`
+	SnapshotId *string        `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
+	Attempts   []*TaskAttempt `thrift:"attempts,8" json:"attempts,omitempty"`
+	Usage      *RunUsage      `thrift:"usage,9" json:"usage,omitempty"`
+}
+
+func NewRunStatus() *RunStatus {
+	return &RunStatus{}
+}
+
+func (p *RunStatus) GetStatus() RunStatusState {
+	return p.Status
+}
+
+func (p *RunStatus) GetRunId() string {
+	return p.RunId
+}
+
+var RunStatus_OutUri_DEFAULT string
+
+func (p *RunStatus) GetOutUri() string {
+	if !p.IsSetOutUri() {
+		return RunStatus_OutUri_DEFAULT
+	}
+	return *p.OutUri
+}
+
+var RunStatus_ErrUri_DEFAULT string
+
+func (p *RunStatus) GetErrUri() string {
+	if !p.IsSetErrUri() {
+		return RunStatus_ErrUri_DEFAULT
+	}
+	return *p.ErrUri
+}
+
+var RunStatus_Error_DEFAULT string
+
+func (p *RunStatus) GetError() string {
+	if !p.IsSetError() {
+		return RunStatus_Error_DEFAULT
+	}
+	return *p.Error
+}
+
+var RunStatus_ExitCode_DEFAULT int32
+
+func (p *RunStatus) GetExitCode() int32 {
+	if !p.IsSetExitCode() {
+		return RunStatus_ExitCode_DEFAULT
+	}
+	return *p.ExitCode
+}
+
+var RunStatus_SnapshotId_DEFAULT string
+
+func (p *RunStatus) GetSnapshotId() string {
+	if !p.IsSetSnapshotId() {
+		return RunStatus_SnapshotId_DEFAULT
+	}
+	return *p.SnapshotId
+}
+
+var RunStatus_Attempts_DEFAULT []*TaskAttempt
+
+func (p *RunStatus) GetAttempts() []*TaskAttempt {
+	return p.Attempts
+}
+
+var RunStatus_Usage_DEFAULT *RunUsage
+
+func (p *RunStatus) GetUsage() *RunUsage {
+	if !p.IsSetUsage() {
+		return RunStatus_Usage_DEFAULT
+	}
+	return p.Usage
+}
+func (p *RunStatus) IsSetOutUri() bool {
+	return p.OutUri != nil
+}
+
+func (p *RunStatus) IsSetErrUri() bool {
+	return p.ErrUri != nil
+}
+
+func (p *RunStatus) IsSetError() bool {
+	return p.Error != nil
+}
+
+func (p *RunStatus) IsSetExitCode() bool {
+	return p.ExitCode != nil
+}
+
+func (p *RunStatus) IsSetSnapshotId() bool {
+	return p.SnapshotId != nil
+}
+
+func (p *RunStatus) IsSetAttempts() bool {
+	return p.Attempts != nil
+}
+
+func (p *RunStatus) IsSetUsage() bool {
+	return p.Usage != nil
+}
+
+func (p *RunStatus) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetStatus bool = false
+	var issetRunId bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetRunId = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	if !issetRunId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RunId is not set"))
+	}
+	return nil
+}
+
+func (p *RunStatus) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		temp := RunStatusState(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *RunStatus) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.RunId = v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.OutUri = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.ErrUri = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Error = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.ExitCode = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.SnapshotId = &v
+	}
+	return nil
+}
+
+func (p *RunStatus) readField8(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*TaskAttempt, 0, size)
+	p.Attempts = tSlice
+	for i := 0; i < size; i++ {
+		_elem0 := &TaskAttempt{}
+		if err := _elem0.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem0), err)
+		}
+		p.Attempts = append(p.Attempts, _elem0)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *RunStatus) readField9(iprot thrift.TProtocol) error {
+	p.Usage = &RunUsage{}
+	if err := p.Usage.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Usage), err)
+	}
+	return nil
+}
+
+func (p *RunStatus) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RunStatus) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:status: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:status: ", p), err)
+	}
+	return err
+}
+
+func (p *RunStatus) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("runId", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:runId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.RunId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.runId (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:runId: ", p), err)
+	}
+	return err
+}
+
+func (p *RunStatus) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOutUri() {
+		if err := oprot.WriteFieldBegin("outUri", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:outUri: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.OutUri)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.outUri (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:outUri: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErrUri() {
+		if err := oprot.WriteFieldBegin("errUri", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:errUri: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.ErrUri)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.errUri (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:errUri: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetError() {
+		if err := oprot.WriteFieldBegin("error", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:error: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Error)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.error (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:error: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetExitCode() {
+		if err := oprot.WriteFieldBegin("exitCode", thrift.I32, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:exitCode: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.ExitCode)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.exitCode (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:exitCode: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotId() {
+		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:snapshotId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:snapshotId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAttempts() {
+		if err := oprot.WriteFieldBegin("attempts", thrift.LIST, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:attempts: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Attempts)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Attempts {
+			if err := v.Write(oprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:attempts: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetUsage() {
+		if err := oprot.WriteFieldBegin("usage", thrift.STRUCT, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:usage: ", p), err)
+		}
+		if err := p.Usage.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Usage), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:usage: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RunStatus(%+v)", *p)
+}
+
+// Attributes:
+//  - Argv
+//  - EnvVars
+//  - TimeoutMs
+//  - Network
+//  - Outputs
+type Command struct {
+	Argv      []string          `thrift:"argv,1" json:"argv"`
+	EnvVars   map[string]string `thrift:"envVars,2" json:"envVars,omitempty"`
+	TimeoutMs *int32            `thrift:"timeoutMs,3" json:"timeoutMs,omitempty"`
+	Network   *bool             `thrift:"network,4" json:"network,omitempty"`
+	Outputs   map[string]string `thrift:"outputs,5" json:"outputs,omitempty"`
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+func (p *Command) GetArgv() []string {
+	return p.Argv
+}
+
+var Command_EnvVars_DEFAULT map[string]string
+
+func (p *Command) GetEnvVars() map[string]string {
+	return p.EnvVars
+}
+
+var Command_TimeoutMs_DEFAULT int32
+
+func (p *Command) GetTimeoutMs() int32 {
+	if !p.IsSetTimeoutMs() {
+		return Command_TimeoutMs_DEFAULT
+	}
+	return *p.TimeoutMs
+}
+
+var Command_Network_DEFAULT bool
+
+func (p *Command) GetNetwork() bool {
+	if !p.IsSetNetwork() {
+		return Command_Network_DEFAULT
+	}
+	return *p.Network
+}
+
+var Command_Outputs_DEFAULT map[string]string
+
+func (p *Command) GetOutputs() map[string]string {
+	return p.Outputs
+}
+func (p *Command) IsSetEnvVars() bool {
+	return p.EnvVars != nil
+}
+
+func (p *Command) IsSetTimeoutMs() bool {
+	return p.TimeoutMs != nil
+}
+
+func (p *Command) IsSetNetwork() bool {
+	return p.Network != nil
+}
+
+func (p *Command) IsSetOutputs() bool {
+	return p.Outputs != nil
+}
+
+func (p *Command) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *Command) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Argv = tSlice
+	for i := 0; i < size; i++ {
+		var _elem1 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem1 = v
+		}
+		p.Argv = append(p.Argv, _elem1)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Command) readField2(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.EnvVars = tMap
+	for i := 0; i < size; i++ {
+		var _key2 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key2 = v
+		}
+		var _val3 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val3 = v
+		}
+		p.EnvVars[_key2] = _val3
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Command) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.TimeoutMs = &v
+	}
+	return nil
+}
+
+func (p *Command) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Network = &v
+	}
+	return nil
+}
+
+func (p *Command) readField5(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Outputs = tMap
+	for i := 0; i < size; i++ {
+		var _key4 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key4 = v
+		}
+		var _val5 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val5 = v
+		}
+		p.Outputs[_key4] = _val5
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Command) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Command"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Command) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("argv", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:argv: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.Argv)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Argv {
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:argv: ", p), err)
+	}
+	return err
+}
+
+func (p *Command) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetEnvVars() {
+		if err := oprot.WriteFieldBegin("envVars", thrift.MAP, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:envVars: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.EnvVars)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.EnvVars {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:envVars: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTimeoutMs() {
+		if err := oprot.WriteFieldBegin("timeoutMs", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:timeoutMs: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.TimeoutMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.timeoutMs (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:timeoutMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNetwork() {
+		if err := oprot.WriteFieldBegin("network", thrift.BOOL, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:network: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.Network)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.network (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:network: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOutputs() {
+		if err := oprot.WriteFieldBegin("outputs", thrift.MAP, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:outputs: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Outputs)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Outputs {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:outputs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Command) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Command(%+v)", *p)
+}
+
+// Attributes:
+//  - MaxAttempts
+//  - InitialBackoffMs
+//  - MaxBackoffMs
+//  - RetryableStates
+//  - RetryableExitCodes
+type RetryPolicy struct {
+	MaxAttempts        *int32           `thrift:"maxAttempts,1" json:"maxAttempts,omitempty"`
+	InitialBackoffMs   *int32           `thrift:"initialBackoffMs,2" json:"initialBackoffMs,omitempty"`
+	MaxBackoffMs       *int32           `thrift:"maxBackoffMs,3" json:"maxBackoffMs,omitempty"`
+	RetryableStates    []RunStatusState `thrift:"retryableStates,4" json:"retryableStates,omitempty"`
+	RetryableExitCodes []int32          `thrift:"retryableExitCodes,5" json:"retryableExitCodes,omitempty"`
+}
+
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{}
+}
+
+var RetryPolicy_MaxAttempts_DEFAULT int32
+
+func (p *RetryPolicy) GetMaxAttempts() int32 {
+	if !p.IsSetMaxAttempts() {
+		return RetryPolicy_MaxAttempts_DEFAULT
+	}
+	return *p.MaxAttempts
+}
+
+var RetryPolicy_InitialBackoffMs_DEFAULT int32
+
+func (p *RetryPolicy) GetInitialBackoffMs() int32 {
+	if !p.IsSetInitialBackoffMs() {
+		return RetryPolicy_InitialBackoffMs_DEFAULT
+	}
+	return *p.InitialBackoffMs
+}
+
+var RetryPolicy_MaxBackoffMs_DEFAULT int32
+
+func (p *RetryPolicy) GetMaxBackoffMs() int32 {
+	if !p.IsSetMaxBackoffMs() {
+		return RetryPolicy_MaxBackoffMs_DEFAULT
+	}
+	return *p.MaxBackoffMs
+}
+
+var RetryPolicy_RetryableStates_DEFAULT []RunStatusState
+
+func (p *RetryPolicy) GetRetryableStates() []RunStatusState {
+	return p.RetryableStates
+}
+
+var RetryPolicy_RetryableExitCodes_DEFAULT []int32
+
+func (p *RetryPolicy) GetRetryableExitCodes() []int32 {
+	return p.RetryableExitCodes
+}
+func (p *RetryPolicy) IsSetMaxAttempts() bool {
+	return p.MaxAttempts != nil
+}
+
+func (p *RetryPolicy) IsSetInitialBackoffMs() bool {
+	return p.InitialBackoffMs != nil
+}
+
+func (p *RetryPolicy) IsSetMaxBackoffMs() bool {
+	return p.MaxBackoffMs != nil
+}
+
+func (p *RetryPolicy) IsSetRetryableStates() bool {
+	return p.RetryableStates != nil
+}
+
+func (p *RetryPolicy) IsSetRetryableExitCodes() bool {
+	return p.RetryableExitCodes != nil
+}
+
+func (p *RetryPolicy) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.MaxAttempts = &v
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.InitialBackoffMs = &v
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.MaxBackoffMs = &v
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField4(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]RunStatusState, 0, size)
+	p.RetryableStates = tSlice
+	for i := 0; i < size; i++ {
+		var _elem6 RunStatusState
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			temp := RunStatusState(v)
+			_elem6 = temp
+		}
+		p.RetryableStates = append(p.RetryableStates, _elem6)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *RetryPolicy) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]int32, 0, size)
+	p.RetryableExitCodes = tSlice
+	for i := 0; i < size; i++ {
+		var _elem7 int32
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem7 = v
+		}
+		p.RetryableExitCodes = append(p.RetryableExitCodes, _elem7)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *RetryPolicy) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RetryPolicy"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RetryPolicy) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxAttempts() {
+		if err := oprot.WriteFieldBegin("maxAttempts", thrift.I32, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:maxAttempts: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.MaxAttempts)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxAttempts (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:maxAttempts: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetInitialBackoffMs() {
+		if err := oprot.WriteFieldBegin("initialBackoffMs", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:initialBackoffMs: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.InitialBackoffMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.initialBackoffMs (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:initialBackoffMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxBackoffMs() {
+		if err := oprot.WriteFieldBegin("maxBackoffMs", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:maxBackoffMs: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.MaxBackoffMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxBackoffMs (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:maxBackoffMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryableStates() {
+		if err := oprot.WriteFieldBegin("retryableStates", thrift.LIST, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:retryableStates: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.I32, len(p.RetryableStates)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.RetryableStates {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:retryableStates: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryableExitCodes() {
+		if err := oprot.WriteFieldBegin("retryableExitCodes", thrift.LIST, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:retryableExitCodes: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.I32, len(p.RetryableExitCodes)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.RetryableExitCodes {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:retryableExitCodes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RetryPolicy(%+v)", *p)
+}
+
+// Attributes:
+//  - Command
+//  - SnapshotId
+//  - CpuSlots
+//  - MemoryBytes
+//  - Dependencies
+//  - RetryPolicy
+//  - ExpectedDurationMs
+type TaskDefinition struct {
+	Command            *Command     `thrift:"command,1,required" json:"command"`
+	SnapshotId         *string      `thrift:"snapshotId,2" json:"snapshotId,omitempty"`
+	CpuSlots           *int32       `thrift:"cpuSlots,3" json:"cpuSlots,omitempty"`
+	MemoryBytes        *int64       `thrift:"memoryBytes,4" json:"memoryBytes,omitempty"`
+	Dependencies       []string     `thrift:"dependencies,5" json:"dependencies,omitempty"`
+	RetryPolicy        *RetryPolicy `thrift:"retryPolicy,6" json:"retryPolicy,omitempty"`
+	ExpectedDurationMs *int32       `thrift:"expectedDurationMs,7" json:"expectedDurationMs,omitempty"`
+}
+
+func NewTaskDefinition() *TaskDefinition {
+	return &TaskDefinition{}
+}
+
+var TaskDefinition_Command_DEFAULT *Command
+
+func (p *TaskDefinition) GetCommand() *Command {
+	if !p.IsSetCommand() {
+		return TaskDefinition_Command_DEFAULT
+	}
+	return p.Command
+}
+
+var TaskDefinition_SnapshotId_DEFAULT string
+
+func (p *TaskDefinition) GetSnapshotId() string {
+	if !p.IsSetSnapshotId() {
+		return TaskDefinition_SnapshotId_DEFAULT
+	}
+	return *p.SnapshotId
+}
+
+var TaskDefinition_CpuSlots_DEFAULT int32
+
+func (p *TaskDefinition) GetCpuSlots() int32 {
+	if !p.IsSetCpuSlots() {
+		return TaskDefinition_CpuSlots_DEFAULT
+	}
+	return *p.CpuSlots
+}
+
+var TaskDefinition_MemoryBytes_DEFAULT int64
+
+func (p *TaskDefinition) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return TaskDefinition_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+
+var TaskDefinition_Dependencies_DEFAULT []string
+
+func (p *TaskDefinition) GetDependencies() []string {
+	return p.Dependencies
+}
+
+var TaskDefinition_RetryPolicy_DEFAULT *RetryPolicy
+
+func (p *TaskDefinition) GetRetryPolicy() *RetryPolicy {
+	if !p.IsSetRetryPolicy() {
+		return TaskDefinition_RetryPolicy_DEFAULT
+	}
+	return p.RetryPolicy
+}
+
+var TaskDefinition_ExpectedDurationMs_DEFAULT int32
+
+func (p *TaskDefinition) GetExpectedDurationMs() int32 {
+	if !p.IsSetExpectedDurationMs() {
+		return TaskDefinition_ExpectedDurationMs_DEFAULT
+	}
+	return *p.ExpectedDurationMs
+}
+func (p *TaskDefinition) IsSetCommand() bool {
+	return p.Command != nil
+}
+
+func (p *TaskDefinition) IsSetSnapshotId() bool {
+	return p.SnapshotId != nil
+}
+
+func (p *TaskDefinition) IsSetCpuSlots() bool {
+	return p.CpuSlots != nil
+}
+
+func (p *TaskDefinition) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *TaskDefinition) IsSetDependencies() bool {
+	return p.Dependencies != nil
+}
+
+func (p *TaskDefinition) IsSetRetryPolicy() bool {
+	return p.RetryPolicy != nil
+}
+
+func (p *TaskDefinition) IsSetExpectedDurationMs() bool {
+	return p.ExpectedDurationMs != nil
+}
+
+func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetCommand bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetCommand = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetCommand {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Command is not set"))
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField1(iprot thrift.TProtocol) error {
+	p.Command = &Command{}
+	if err := p.Command.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Command), err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.SnapshotId = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.CpuSlots = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Dependencies = tSlice
+	for i := 0; i < size; i++ {
+		var _elem8 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem8 = v
+		}
+		p.Dependencies = append(p.Dependencies, _elem8)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField6(iprot thrift.TProtocol) error {
+	p.RetryPolicy = &RetryPolicy{}
+	if err := p.RetryPolicy.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.RetryPolicy), err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.ExpectedDurationMs = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("command", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:command: ", p), err)
+	}
+	if err := p.Command.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Command), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:command: ", p), err)
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotId() {
+		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:snapshotId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:snapshotId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpuSlots() {
+		if err := oprot.WriteFieldBegin("cpuSlots", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:cpuSlots: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.CpuSlots)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpuSlots (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:cpuSlots: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDependencies() {
+		if err := oprot.WriteFieldBegin("dependencies", thrift.LIST, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:dependencies: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Dependencies)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Dependencies {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:dependencies: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryPolicy() {
+		if err := oprot.WriteFieldBegin("retryPolicy", thrift.STRUCT, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:retryPolicy: ", p), err)
+		}
+		if err := p.RetryPolicy.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.RetryPolicy), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:retryPolicy: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetExpectedDurationMs() {
+		if err := oprot.WriteFieldBegin("expectedDurationMs", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:expectedDurationMs: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.ExpectedDurationMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.expectedDurationMs (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:expectedDurationMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TaskDefinition(%+v)", *p)
+}
+
+// Attributes:
+//  - Tasks
+//  - JobType
+//  - Priority
+//  - Tenant
+type JobDefinition struct {
+	Tasks    map[string]*TaskDefinition `thrift:"tasks,1,required" json:"tasks"`
+	JobType  *JobType                   `thrift:"jobType,2" json:"jobType,omitempty"`
+	Priority *int32                     `thrift:"priority,3" json:"priority,omitempty"`
+	Tenant   *string                    `thrift:"tenant,4" json:"tenant,omitempty"`
+}
+
+func NewJobDefinition() *JobDefinition {
+	return &JobDefinition{}
+}
+
+func (p *JobDefinition) GetTasks() map[string]*TaskDefinition {
+	return p.Tasks
+}
+
+var JobDefinition_JobType_DEFAULT JobType
+
+func (p *JobDefinition) GetJobType() JobType {
+	if !p.IsSetJobType() {
+		return JobDefinition_JobType_DEFAULT
+	}
+	return *p.JobType
+}
+
+var JobDefinition_Priority_DEFAULT int32
+
+func (p *JobDefinition) GetPriority() int32 {
+	if !p.IsSetPriority() {
+		return JobDefinition_Priority_DEFAULT
+	}
+	return *p.Priority
+}
+
+var JobDefinition_Tenant_DEFAULT string
+
+func (p *JobDefinition) GetTenant() string {
+	if !p.IsSetTenant() {
+		return JobDefinition_Tenant_DEFAULT
+	}
+	return *p.Tenant
+}
+func (p *JobDefinition) IsSetJobType() bool {
+	return p.JobType != nil
+}
+
+func (p *JobDefinition) IsSetPriority() bool {
+	return p.Priority != nil
+}
+
+func (p *JobDefinition) IsSetTenant() bool {
+	return p.Tenant != nil
+}
+
+func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTasks bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTasks = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTasks {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tasks is not set"))
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField1(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*TaskDefinition, size)
+	p.Tasks = tMap
+	for i := 0; i < size; i++ {
+		var _key9 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key9 = v
+		}
+		_val10 := &TaskDefinition{}
+		if err := _val10.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val10), err)
+		}
+		p.Tasks[_key9] = _val10
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := JobType(v)
+		p.JobType = &temp
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Priority = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Tenant = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobDefinition"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -957,77 +2817,244 @@ func (p *Command) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *Command) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("argv", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:argv: ", p), err)
+func (p *JobDefinition) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tasks", thrift.MAP, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tasks: ", p), err)
 	}
-	if err := oprot.WriteListBegin(thrift.STRING, len(p.Argv)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.Tasks)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
 	}
-	for _, v := range p.Argv {
-		if err := oprot.WriteString(string(v)); err != nil {
+	for k, v := range p.Tasks {
+		if err := oprot.WriteString(string(k)); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
 		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
 	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:argv: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tasks: ", p), err)
 	}
 	return err
 }
 
-func (p *Command) String() string {
+func (p *JobDefinition) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetJobType() {
+		if err := oprot.WriteFieldBegin("jobType", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:jobType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.JobType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.jobType (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:jobType: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPriority() {
+		if err := oprot.WriteFieldBegin("priority", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:priority: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Priority)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.priority (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:priority: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTenant() {
+		if err := oprot.WriteFieldBegin("tenant", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:tenant: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tenant)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tenant (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:tenant: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("Command(%+v)", *p)
+	return fmt.Sprintf("JobDefinition(%+v)", *p)
 }
 
 // Attributes:
-//  - Command
-//  - SnapshotId
-type TaskDefinition struct {
-	Command    *Command `thrift:"command,1,required" json:"command"`
-	SnapshotId *string  `thrift:"snapshotId,2" json:"snapshotId,omitempty"`
+//  - ID
+type JobId struct {
+	ID string `thrift:"id,1,required" json:"id"`
 }
 
-func NewTaskDefinition() *TaskDefinition {
-	return &TaskDefinition{}
+func NewJobId() *JobId {
+	return &JobId{}
+}
+
+func (p *JobId) GetID() string {
+	return p.ID
+}
+func (p *JobId) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetID bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	return nil
+}
+
+func (p *JobId) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
+	}
+	return nil
+}
+
+func (p *JobId) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobId"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobId) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return err
+}
+
+func (p *JobId) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobId(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Status
+//  - TaskStatus
+//  - TaskData
+//  - Version
+type JobStatus struct {
+	ID         string                `thrift:"id,1,required" json:"id"`
+	Status     Status                `thrift:"status,2,required" json:"status"`
+	TaskStatus map[string]Status     `thrift:"taskStatus,3" json:"taskStatus,omitempty"`
+	TaskData   map[string]*RunStatus `thrift:"taskData,4" json:"taskData,omitempty"`
+	Version    *int64                `thrift:"version,5" json:"version,omitempty"`
+}
+
+func NewJobStatus() *JobStatus {
+	return &JobStatus{}
+}
+
+func (p *JobStatus) GetID() string {
+	return p.ID
+}
+
+func (p *JobStatus) GetStatus() Status {
+	return p.Status
+}
+
+var JobStatus_TaskStatus_DEFAULT map[string]Status
+
+func (p *JobStatus) GetTaskStatus() map[string]Status {
+	return p.TaskStatus
 }
 
-var TaskDefinition_Command_DEFAULT *Command
+var JobStatus_TaskData_DEFAULT map[string]*RunStatus
 
-func (p *TaskDefinition) GetCommand() *Command {
-	if !p.IsSetCommand() {
-		return TaskDefinition_Command_DEFAULT
-	}
-	return p.Command
+func (p *JobStatus) GetTaskData() map[string]*RunStatus {
+	return p.TaskData
 }
 
-var TaskDefinition_SnapshotId_DEFAULT string
+var JobStatus_Version_DEFAULT int64
 
-func (p *TaskDefinition) GetSnapshotId() string {
-	if !p.IsSetSnapshotId() {
-		return TaskDefinition_SnapshotId_DEFAULT
+func (p *JobStatus) GetVersion() int64 {
+	if !p.IsSetVersion() {
+		return JobStatus_Version_DEFAULT
 	}
-	return *p.SnapshotId
+	return *p.Version
 }
-func (p *TaskDefinition) IsSetCommand() bool {
-	return p.Command != nil
+func (p *JobStatus) IsSetTaskStatus() bool {
+	return p.TaskStatus != nil
 }
 
-func (p *TaskDefinition) IsSetSnapshotId() bool {
-	return p.SnapshotId != nil
+func (p *JobStatus) IsSetTaskData() bool {
+	return p.TaskData != nil
 }
 
-func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
+func (p *JobStatus) IsSetVersion() bool {
+	return p.Version != nil
+}
+
+func (p *JobStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetCommand bool = false
+	var issetID bool = false
+	var issetStatus bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -1042,11 +3069,24 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetCommand = true
+			issetID = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
+			issetStatus = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1059,31 +3099,100 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetCommand {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Command is not set"))
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
 	}
 	return nil
 }
 
-func (p *TaskDefinition) readField1(iprot thrift.TProtocol) error {
-	p.Command = &Command{}
-	if err := p.Command.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Command), err)
+func (p *JobStatus) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ID = v
 	}
 	return nil
 }
 
-func (p *TaskDefinition) readField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *JobStatus) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.SnapshotId = &v
+		temp := Status(v)
+		p.Status = temp
 	}
 	return nil
 }
 
-func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
+func (p *JobStatus) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]Status, size)
+	p.TaskStatus = tMap
+	for i := 0; i < size; i++ {
+		var _key11 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key11 = v
+		}
+		var _val12 Status
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			temp := Status(v)
+			_val12 = temp
+		}
+		p.TaskStatus[_key11] = _val12
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobStatus) readField4(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*RunStatus, size)
+	p.TaskData = tMap
+	for i := 0; i < size; i++ {
+		var _key13 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key13 = v
+		}
+		_val14 := &RunStatus{}
+		if err := _val14.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val14), err)
+		}
+		p.TaskData[_key13] = _val14
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobStatus) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Version = &v
+	}
+	return nil
+}
+
+func (p *JobStatus) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobStatus"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1092,6 +3201,15 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1101,76 +3219,220 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *TaskDefinition) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("command", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:command: ", p), err)
+func (p *JobStatus) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
 	}
-	if err := p.Command.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Command), err)
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:command: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
 	}
 	return err
 }
 
-func (p *TaskDefinition) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSnapshotId() {
-		if err := oprot.WriteFieldBegin("snapshotId", thrift.STRING, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:snapshotId: ", p), err)
+func (p *JobStatus) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	}
+	return err
+}
+
+func (p *JobStatus) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskStatus() {
+		if err := oprot.WriteFieldBegin("taskStatus", thrift.MAP, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:taskStatus: ", p), err)
 		}
-		if err := oprot.WriteString(string(*p.SnapshotId)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.snapshotId (2) field write error: ", p), err)
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.I32, len(p.TaskStatus)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.TaskStatus {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:snapshotId: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:taskStatus: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *TaskDefinition) String() string {
+func (p *JobStatus) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskData() {
+		if err := oprot.WriteFieldBegin("taskData", thrift.MAP, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:taskData: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.TaskData)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.TaskData {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := v.Write(oprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:taskData: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobStatus) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetVersion() {
+		if err := oprot.WriteFieldBegin("version", thrift.I64, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:version: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.Version)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.version (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:version: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobStatus) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TaskDefinition(%+v)", *p)
+	return fmt.Sprintf("JobStatus(%+v)", *p)
 }
 
 // Attributes:
-//  - Tasks
+//  - Statuses
 //  - JobType
-type JobDefinition struct {
-	Tasks   map[string]*TaskDefinition `thrift:"tasks,1,required" json:"tasks"`
-	JobType *JobType                   `thrift:"jobType,2" json:"jobType,omitempty"`
+//  - Tenant
+//  - SubmittedAfterMs
+//  - SubmittedBeforeMs
+//  - Limit
+//  - Cursor
+type ListJobsRequest struct {
+	Statuses          []Status `thrift:"statuses,1" json:"statuses,omitempty"`
+	JobType           *JobType `thrift:"jobType,2" json:"jobType,omitempty"`
+	Tenant            *string  `thrift:"tenant,3" json:"tenant,omitempty"`
+	SubmittedAfterMs  *int64   `thrift:"submittedAfterMs,4" json:"submittedAfterMs,omitempty"`
+	SubmittedBeforeMs *int64   `thrift:"submittedBeforeMs,5" json:"submittedBeforeMs,omitempty"`
+	Limit             *int32   `thrift:"limit,6" json:"limit,omitempty"`
+	Cursor            *string  `thrift:"cursor,7" json:"cursor,omitempty"`
+}
+
+func NewListJobsRequest() *ListJobsRequest {
+	return &ListJobsRequest{}
+}
+
+var ListJobsRequest_Statuses_DEFAULT []Status
+
+func (p *ListJobsRequest) GetStatuses() []Status {
+	return p.Statuses
+}
+
+var ListJobsRequest_JobType_DEFAULT JobType
+
+func (p *ListJobsRequest) GetJobType() JobType {
+	if !p.IsSetJobType() {
+		return ListJobsRequest_JobType_DEFAULT
+	}
+	return *p.JobType
+}
+
+var ListJobsRequest_Tenant_DEFAULT string
+
+func (p *ListJobsRequest) GetTenant() string {
+	if !p.IsSetTenant() {
+		return ListJobsRequest_Tenant_DEFAULT
+	}
+	return *p.Tenant
+}
+
+var ListJobsRequest_SubmittedAfterMs_DEFAULT int64
+
+func (p *ListJobsRequest) GetSubmittedAfterMs() int64 {
+	if !p.IsSetSubmittedAfterMs() {
+		return ListJobsRequest_SubmittedAfterMs_DEFAULT
+	}
+	return *p.SubmittedAfterMs
+}
+
+var ListJobsRequest_SubmittedBeforeMs_DEFAULT int64
+
+func (p *ListJobsRequest) GetSubmittedBeforeMs() int64 {
+	if !p.IsSetSubmittedBeforeMs() {
+		return ListJobsRequest_SubmittedBeforeMs_DEFAULT
+	}
+	return *p.SubmittedBeforeMs
+}
+
+var ListJobsRequest_Limit_DEFAULT int32
+
+func (p *ListJobsRequest) GetLimit() int32 {
+	if !p.IsSetLimit() {
+		return ListJobsRequest_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+
+var ListJobsRequest_Cursor_DEFAULT string
+
+func (p *ListJobsRequest) GetCursor() string {
+	if !p.IsSetCursor() {
+		return ListJobsRequest_Cursor_DEFAULT
+	}
+	return *p.Cursor
+}
+func (p *ListJobsRequest) IsSetStatuses() bool {
+	return p.Statuses != nil
+}
+
+func (p *ListJobsRequest) IsSetJobType() bool {
+	return p.JobType != nil
 }
 
-func NewJobDefinition() *JobDefinition {
-	return &JobDefinition{}
+func (p *ListJobsRequest) IsSetTenant() bool {
+	return p.Tenant != nil
 }
 
-func (p *JobDefinition) GetTasks() map[string]*TaskDefinition {
-	return p.Tasks
+func (p *ListJobsRequest) IsSetSubmittedAfterMs() bool {
+	return p.SubmittedAfterMs != nil
 }
 
-var JobDefinition_JobType_DEFAULT JobType
+func (p *ListJobsRequest) IsSetSubmittedBeforeMs() bool {
+	return p.SubmittedBeforeMs != nil
+}
 
-func (p *JobDefinition) GetJobType() JobType {
-	if !p.IsSetJobType() {
-		return JobDefinition_JobType_DEFAULT
-	}
-	return *p.JobType
+func (p *ListJobsRequest) IsSetLimit() bool {
+	return p.Limit != nil
 }
-func (p *JobDefinition) IsSetJobType() bool {
-	return p.JobType != nil
+
+func (p *ListJobsRequest) IsSetCursor() bool {
+	return p.Cursor != nil
 }
 
-func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
+func (p *ListJobsRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetTasks bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -1184,11 +3446,30 @@ func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-			issetTasks = true
 		case 2:
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1201,39 +3482,33 @@ func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetTasks {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tasks is not set"))
-	}
 	return nil
 }
 
-func (p *JobDefinition) readField1(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
+func (p *ListJobsRequest) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
 	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
+		return thrift.PrependError("error reading list begin: ", err)
 	}
-	tMap := make(map[string]*TaskDefinition, size)
-	p.Tasks = tMap
+	tSlice := make([]Status, 0, size)
+	p.Statuses = tSlice
 	for i := 0; i < size; i++ {
-		var _key1 string
-		if v, err := iprot.ReadString(); err != nil {
+		var _elem15 Status
+		if v, err := iprot.ReadI32(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_key1 = v
-		}
-		_val2 := &TaskDefinition{}
-		if err := _val2.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val2), err)
+			temp := Status(v)
+			_elem15 = temp
 		}
-		p.Tasks[_key1] = _val2
+		p.Statuses = append(p.Statuses, _elem15)
 	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *JobDefinition) readField2(iprot thrift.TProtocol) error {
+func (p *ListJobsRequest) readField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
@@ -1243,8 +3518,53 @@ func (p *JobDefinition) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("JobDefinition"); err != nil {
+func (p *ListJobsRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Tenant = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.SubmittedAfterMs = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.SubmittedBeforeMs = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Limit = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Cursor = &v
+	}
+	return nil
+}
+
+func (p *ListJobsRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListJobsRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1253,6 +3573,21 @@ func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1262,31 +3597,30 @@ func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *JobDefinition) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("tasks", thrift.MAP, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tasks: ", p), err)
-	}
-	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.Tasks)); err != nil {
-		return thrift.PrependError("error writing map begin: ", err)
-	}
-	for k, v := range p.Tasks {
-		if err := oprot.WriteString(string(k)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+func (p *ListJobsRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetStatuses() {
+		if err := oprot.WriteFieldBegin("statuses", thrift.LIST, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:statuses: ", p), err)
 		}
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		if err := oprot.WriteListBegin(thrift.I32, len(p.Statuses)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Statuses {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:statuses: ", p), err)
 		}
-	}
-	if err := oprot.WriteMapEnd(); err != nil {
-		return thrift.PrependError("error writing map end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tasks: ", p), err)
 	}
 	return err
 }
 
-func (p *JobDefinition) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *ListJobsRequest) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetJobType() {
 		if err := oprot.WriteFieldBegin("jobType", thrift.I32, 2); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:jobType: ", p), err)
@@ -1301,154 +3635,168 @@ func (p *JobDefinition) writeField2(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *JobDefinition) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *ListJobsRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTenant() {
+		if err := oprot.WriteFieldBegin("tenant", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:tenant: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tenant)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tenant (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:tenant: ", p), err)
+		}
 	}
-	return fmt.Sprintf("JobDefinition(%+v)", *p)
-}
-
-// Attributes:
-//  - ID
-type JobId struct {
-	ID string `thrift:"id,1,required" json:"id"`
-}
-
-func NewJobId() *JobId {
-	return &JobId{}
-}
-
-func (p *JobId) GetID() string {
-	return p.ID
+	return err
 }
-func (p *JobId) Read(iprot thrift.TProtocol) error {
-	if _, err := iprot.ReadStructBegin(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
-	}
-
-	var issetID bool = false
 
-	for {
-		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
-		if err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
-		}
-		if fieldTypeId == thrift.STOP {
-			break
+func (p *ListJobsRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSubmittedAfterMs() {
+		if err := oprot.WriteFieldBegin("submittedAfterMs", thrift.I64, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:submittedAfterMs: ", p), err)
 		}
-		switch fieldId {
-		case 1:
-			if err := p.readField1(iprot); err != nil {
-				return err
-			}
-			issetID = true
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
+		if err := oprot.WriteI64(int64(*p.SubmittedAfterMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.submittedAfterMs (4) field write error: ", p), err)
 		}
-		if err := iprot.ReadFieldEnd(); err != nil {
-			return err
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:submittedAfterMs: ", p), err)
 		}
 	}
-	if err := iprot.ReadStructEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-	}
-	if !issetID {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
-	}
-	return nil
+	return err
 }
 
-func (p *JobId) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.ID = v
+func (p *ListJobsRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSubmittedBeforeMs() {
+		if err := oprot.WriteFieldBegin("submittedBeforeMs", thrift.I64, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:submittedBeforeMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.SubmittedBeforeMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.submittedBeforeMs (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:submittedBeforeMs: ", p), err)
+		}
 	}
-	return nil
+	return err
 }
 
-func (p *JobId) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("JobId"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if err := p.writeField1(oprot); err != nil {
-		return err
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
-	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
+func (p *ListJobsRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I32, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:limit: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:limit: ", p), err)
+		}
 	}
-	return nil
+	return err
 }
 
-func (p *JobId) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.ID)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+func (p *ListJobsRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCursor() {
+		if err := oprot.WriteFieldBegin("cursor", thrift.STRING, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:cursor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Cursor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cursor (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:cursor: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *JobId) String() string {
+func (p *ListJobsRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("JobId(%+v)", *p)
+	return fmt.Sprintf("ListJobsRequest(%+v)", *p)
 }
 
 // Attributes:
 //  - ID
 //  - Status
-//  - TaskStatus
-//  - TaskData
-type JobStatus struct {
-	ID         string                `thrift:"id,1,required" json:"id"`
-	Status     Status                `thrift:"status,2,required" json:"status"`
-	TaskStatus map[string]Status     `thrift:"taskStatus,3" json:"taskStatus,omitempty"`
-	TaskData   map[string]*RunStatus `thrift:"taskData,4" json:"taskData,omitempty"`
+//  - JobType
+//  - Tenant
+//  - Priority
+//  - SubmittedMs
+type JobSummary struct {
+	ID          string   `thrift:"id,1,required" json:"id"`
+	Status      Status   `thrift:"status,2,required" json:"status"`
+	JobType     *JobType `thrift:"jobType,3" json:"jobType,omitempty"`
+	Tenant      *string  `thrift:"tenant,4" json:"tenant,omitempty"`
+	Priority    *int32   `thrift:"priority,5" json:"priority,omitempty"`
+	SubmittedMs *int64   `thrift:"submittedMs,6" json:"submittedMs,omitempty"`
 }
 
-func NewJobStatus() *JobStatus {
-	return &JobStatus{}
+func NewJobSummary() *JobSummary {
+	return &JobSummary{}
 }
 
-func (p *JobStatus) GetID() string {
+func (p *JobSummary) GetID() string {
 	return p.ID
 }
 
-func (p *JobStatus) GetStatus() Status {
-	return p.Status
+func (p *JobSummary) GetStatus() Status {
+	return p.Status
+}
+
+var JobSummary_JobType_DEFAULT JobType
+
+func (p *JobSummary) GetJobType() JobType {
+	if !p.IsSetJobType() {
+		return JobSummary_JobType_DEFAULT
+	}
+	return *p.JobType
+}
+
+var JobSummary_Tenant_DEFAULT string
+
+func (p *JobSummary) GetTenant() string {
+	if !p.IsSetTenant() {
+		return JobSummary_Tenant_DEFAULT
+	}
+	return *p.Tenant
 }
 
-var JobStatus_TaskStatus_DEFAULT map[string]Status
+var JobSummary_Priority_DEFAULT int32
 
-func (p *JobStatus) GetTaskStatus() map[string]Status {
-	return p.TaskStatus
+func (p *JobSummary) GetPriority() int32 {
+	if !p.IsSetPriority() {
+		return JobSummary_Priority_DEFAULT
+	}
+	return *p.Priority
 }
 
-var JobStatus_TaskData_DEFAULT map[string]*RunStatus
+var JobSummary_SubmittedMs_DEFAULT int64
 
-func (p *JobStatus) GetTaskData() map[string]*RunStatus {
-	return p.TaskData
+func (p *JobSummary) GetSubmittedMs() int64 {
+	if !p.IsSetSubmittedMs() {
+		return JobSummary_SubmittedMs_DEFAULT
+	}
+	return *p.SubmittedMs
 }
-func (p *JobStatus) IsSetTaskStatus() bool {
-	return p.TaskStatus != nil
+func (p *JobSummary) IsSetJobType() bool {
+	return p.JobType != nil
 }
 
-func (p *JobStatus) IsSetTaskData() bool {
-	return p.TaskData != nil
+func (p *JobSummary) IsSetTenant() bool {
+	return p.Tenant != nil
 }
 
-func (p *JobStatus) Read(iprot thrift.TProtocol) error {
+func (p *JobSummary) IsSetPriority() bool {
+	return p.Priority != nil
+}
+
+func (p *JobSummary) IsSetSubmittedMs() bool {
+	return p.SubmittedMs != nil
+}
+
+func (p *JobSummary) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1483,6 +3831,14 @@ func (p *JobStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField4(iprot); err != nil {
 				return err
 			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1504,7 +3860,7 @@ func (p *JobStatus) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *JobStatus) readField1(iprot thrift.TProtocol) error {
+func (p *JobSummary) readField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
@@ -1513,7 +3869,7 @@ func (p *JobStatus) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *JobStatus) readField2(iprot thrift.TProtocol) error {
+func (p *JobSummary) readField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
@@ -1523,63 +3879,45 @@ func (p *JobStatus) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *JobStatus) readField3(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
-	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
-	}
-	tMap := make(map[string]Status, size)
-	p.TaskStatus = tMap
-	for i := 0; i < size; i++ {
-		var _key3 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_key3 = v
-		}
-		var _val4 Status
-		if v, err := iprot.ReadI32(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			temp := Status(v)
-			_val4 = temp
-		}
-		p.TaskStatus[_key3] = _val4
-	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
+func (p *JobSummary) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		temp := JobType(v)
+		p.JobType = &temp
 	}
 	return nil
 }
 
-func (p *JobStatus) readField4(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
-	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
+func (p *JobSummary) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Tenant = &v
 	}
-	tMap := make(map[string]*RunStatus, size)
-	p.TaskData = tMap
-	for i := 0; i < size; i++ {
-		var _key5 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_key5 = v
-		}
-		_val6 := &RunStatus{}
-		if err := _val6.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val6), err)
-		}
-		p.TaskData[_key5] = _val6
+	return nil
+}
+
+func (p *JobSummary) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Priority = &v
 	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
+	return nil
+}
+
+func (p *JobSummary) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.SubmittedMs = &v
 	}
 	return nil
 }
 
-func (p *JobStatus) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("JobStatus"); err != nil {
+func (p *JobSummary) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobSummary"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1594,6 +3932,12 @@ func (p *JobStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField4(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1603,7 +3947,7 @@ func (p *JobStatus) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *JobStatus) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *JobSummary) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
 	}
@@ -1616,7 +3960,7 @@ func (p *JobStatus) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *JobStatus) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *JobSummary) writeField2(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
 	}
@@ -1629,61 +3973,231 @@ func (p *JobStatus) writeField2(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *JobStatus) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTaskStatus() {
-		if err := oprot.WriteFieldBegin("taskStatus", thrift.MAP, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:taskStatus: ", p), err)
+func (p *JobSummary) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetJobType() {
+		if err := oprot.WriteFieldBegin("jobType", thrift.I32, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:jobType: ", p), err)
 		}
-		if err := oprot.WriteMapBegin(thrift.STRING, thrift.I32, len(p.TaskStatus)); err != nil {
-			return thrift.PrependError("error writing map begin: ", err)
+		if err := oprot.WriteI32(int32(*p.JobType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.jobType (3) field write error: ", p), err)
 		}
-		for k, v := range p.TaskStatus {
-			if err := oprot.WriteString(string(k)); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
-			}
-			if err := oprot.WriteI32(int32(v)); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
-			}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:jobType: ", p), err)
 		}
-		if err := oprot.WriteMapEnd(); err != nil {
-			return thrift.PrependError("error writing map end: ", err)
+	}
+	return err
+}
+
+func (p *JobSummary) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTenant() {
+		if err := oprot.WriteFieldBegin("tenant", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:tenant: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tenant)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tenant (4) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:taskStatus: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:tenant: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobStatus) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTaskData() {
-		if err := oprot.WriteFieldBegin("taskData", thrift.MAP, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:taskData: ", p), err)
+func (p *JobSummary) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPriority() {
+		if err := oprot.WriteFieldBegin("priority", thrift.I32, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:priority: ", p), err)
 		}
-		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.TaskData)); err != nil {
-			return thrift.PrependError("error writing map begin: ", err)
+		if err := oprot.WriteI32(int32(*p.Priority)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.priority (5) field write error: ", p), err)
 		}
-		for k, v := range p.TaskData {
-			if err := oprot.WriteString(string(k)); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:priority: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSubmittedMs() {
+		if err := oprot.WriteFieldBegin("submittedMs", thrift.I64, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:submittedMs: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.SubmittedMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.submittedMs (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:submittedMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobSummary) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("JobSummary(%+v)", *p)
+}
+
+// Attributes:
+//  - Jobs
+//  - NextCursor
+type ListJobsResponse struct {
+	Jobs       []*JobSummary `thrift:"jobs,1,required" json:"jobs"`
+	NextCursor *string       `thrift:"nextCursor,2" json:"nextCursor,omitempty"`
+}
+
+func NewListJobsResponse() *ListJobsResponse {
+	return &ListJobsResponse{}
+}
+
+func (p *ListJobsResponse) GetJobs() []*JobSummary {
+	return p.Jobs
+}
+
+var ListJobsResponse_NextCursor_DEFAULT string
+
+func (p *ListJobsResponse) GetNextCursor() string {
+	if !p.IsSetNextCursor() {
+		return ListJobsResponse_NextCursor_DEFAULT
+	}
+	return *p.NextCursor
+}
+func (p *ListJobsResponse) IsSetNextCursor() bool {
+	return p.NextCursor != nil
+}
+
+func (p *ListJobsResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetJobs bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
 			}
-			if err := v.Write(oprot); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			issetJobs = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
 			}
 		}
-		if err := oprot.WriteMapEnd(); err != nil {
-			return thrift.PrependError("error writing map end: ", err)
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetJobs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Jobs is not set"))
+	}
+	return nil
+}
+
+func (p *ListJobsResponse) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*JobSummary, 0, size)
+	p.Jobs = tSlice
+	for i := 0; i < size; i++ {
+		_elem16 := &JobSummary{}
+		if err := _elem16.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem16), err)
+		}
+		p.Jobs = append(p.Jobs, _elem16)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *ListJobsResponse) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.NextCursor = &v
+	}
+	return nil
+}
+
+func (p *ListJobsResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListJobsResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ListJobsResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("jobs", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:jobs: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Jobs)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Jobs {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:jobs: ", p), err)
+	}
+	return err
+}
+
+func (p *ListJobsResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNextCursor() {
+		if err := oprot.WriteFieldBegin("nextCursor", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:nextCursor: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.NextCursor)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.nextCursor (2) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:taskData: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:nextCursor: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *JobStatus) String() string {
+func (p *ListJobsResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("JobStatus(%+v)", *p)
+	return fmt.Sprintf("ListJobsResponse(%+v)", *p)
 }
@@ -27,6 +27,25 @@ enum RunStatusState {
   BADREQUEST = 7   # Invalid or error'd request. Original worker state not affected. Retry may work after mutation.
 }
 
+// An earlier run of a task that was retried.
+struct TaskAttempt {
+  1: required string workerId  # Worker the run was on.
+  2: required RunStatusState status
+  3: optional string runId
+  4: optional i32 exitCode
+  5: optional string error
+}
+
+// Resources used by a run's process and the children it waited for.
+struct RunUsage {
+  1: required i64 wallTimeMs
+  2: required i64 userTimeMs      # CPU time in user mode.
+  3: required i64 sysTimeMs       # CPU time in the kernel.
+  4: required i64 maxRssBytes     # Largest resident set of the process or one of its children.
+  5: required i64 blockInputOps   # Times the filesystem had to read from disk.
+  6: required i64 blockOutputOps  # Times the filesystem had to write to disk.
+}
+
 // Note, each worker has its own runId space which is unrelated to any external ids.
 struct RunStatus {
   1: required RunStatusState status
@@ -36,21 +55,45 @@ struct RunStatus {
   5: optional string error
   6: optional i32 exitCode
   7: optional string snapshotId
+  8: optional list<TaskAttempt> attempts  # Earlier runs of the task, oldest first.
+  9: optional RunUsage usage              # Unset if the task's process wasn't run or measured.
 }
 
 
 struct Command {
   1: list<string> argv
+  2: optional map<string, string> envVars,  # Environment variables set for the task's process.
+  3: optional i32 timeoutMs,                # Kill the task if it hasn't completed in time, defaults to the scheduler's timeout.
+  4: optional bool network,                 # Give the task network access on workers that sandbox tasks. Defaults to false.
+  5: optional map<string, string> outputs,  # Files to copy from the checkout into the task's result snapshot, if it completes.
+                                            # Keys are paths relative to the checkout and may contain '*' wildcards,
+                                            # values are the dirs in the snapshot their matches are copied to, "" for its root.
+}
+
+# Decides whether a task whose run didn't succeed is run again, and when.
+struct RetryPolicy {
+  1: optional i32 maxAttempts,       # Most times the task is run, including the first. Defaults to the scheduler's setting.
+  2: optional i32 initialBackoffMs,  # Wait before the first retry, doubled for each retry after. Retries immediately if unset.
+  3: optional i32 maxBackoffMs,      # Longest wait before a retry, unbounded if unset.
+  4: optional list<RunStatusState> retryableStates,  # States of a run that are retried. Every state but COMPLETE if unset.
+  5: optional list<i32> retryableExitCodes,         # Exit codes of a COMPLETE run that are retried, none if unset.
 }
 
 struct TaskDefinition {
   1: required Command command,
   2: optional string snapshotId,
+  3: optional i32 cpuSlots,     # Number of cpu slots the task needs on a worker, defaults to 1.
+  4: optional i64 memoryBytes,  # Memory the task needs on a worker, unset if it has no requirement.
+  5: optional list<string> dependencies, # Ids of tasks in the same job that must succeed before this task runs.
+  6: optional RetryPolicy retryPolicy,   # Defaults to retrying runs that don't complete, without backoff.
+  7: optional i32 expectedDurationMs,    # How long the task usually runs, a backup copy is started if it runs much longer.
 }
 
 struct JobDefinition {
   1: required map<string, TaskDefinition> tasks,
   2: optional JobType jobType,
+  3: optional i32 priority,     # Jobs with a higher priority are scheduled first, defaults to 0.
+  4: optional string tenant,    # Tenant submitting the job, tenants share the cluster fairly.
 }
 
 struct JobId {
@@ -75,6 +118,9 @@ enum Status {
   # Job/Task finished unsuccessfully all compensating actions
   # have been applied.
   ROLLED_BACK=5,
+
+  # Task was not run because a task it depends on failed.
+  SKIPPED=6,
 }
 
 struct JobStatus {
@@ -82,6 +128,31 @@ struct JobStatus {
   2: required Status status,
   3: optional map<string, Status> taskStatus,
   4: optional map<string, RunStatus> taskData,
+  5: optional i64 version,  # Pass to WatchJob to wait for changes made after this status.
+}
+
+struct ListJobsRequest {
+  1: optional list<Status> statuses,   # Only jobs with one of these statuses, any status if unset.
+  2: optional JobType jobType,         # Only jobs of this type.
+  3: optional string tenant,           # Only jobs submitted by this tenant.
+  4: optional i64 submittedAfterMs,    # Only jobs submitted at or after this unix time, in ms.
+  5: optional i64 submittedBeforeMs,   # Only jobs submitted before this unix time, in ms.
+  6: optional i32 limit,               # Max number of jobs returned, defaults to 100.
+  7: optional string cursor,           # nextCursor of the previous page, unset for the first page.
+}
+
+struct JobSummary {
+  1: required string id,
+  2: required Status status,
+  3: optional JobType jobType,
+  4: optional string tenant,
+  5: optional i32 priority,
+  6: optional i64 submittedMs,         # Unix time the job was submitted, in ms.
+}
+
+struct ListJobsResponse {
+  1: required list<JobSummary> jobs,   # Most recently submitted first.
+  2: optional string nextCursor,       # Set if there are more jobs, pass it to get the next page.
 }
 
 service CloudScoot {
@@ -93,4 +164,22 @@ service CloudScoot {
     1: InvalidRequest ir,
     2: ScootServerError err,
   )
+  # Stops scheduling the job's remaining tasks, aborts its running tasks
+  # and rolls the job back.  Returns the job's status after the kill.
+  JobStatus KillJob(1: string jobId) throws (
+    1: InvalidRequest ir,
+    2: ScootServerError err,
+  )
+  # Blocks until the job changes after the specified version, or until
+  # timeoutMs elapses.  Returns the job's status with only the tasks that
+  # changed since version, version 0 returns every task.
+  JobStatus WatchJob(1: string jobId, 2: i64 version, 3: i32 timeoutMs) throws (
+    1: InvalidRequest ir,
+    2: ScootServerError err,
+  )
+  # Lists the jobs matching the request's filters, a page at a time.
+  ListJobsResponse ListJobs(1: ListJobsRequest req) throws (
+    1: InvalidRequest ir,
+    2: ScootServerError err,
+  )
 }
@@ -41,3 +41,7 @@ func NewCanNotScheduleNow(untilRetry time.Duration, errMsg string) error {
 func (e *CanNotScheduleNow) Error() string {
 	return e.errMsg
 }
+
+// How long a client is asked to wait before retrying a request a scheduler
+// turned down because it isn't the saga log leader, while one is elected
+var notLeaderRetryAfterMs = int64(1000)
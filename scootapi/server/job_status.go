@@ -5,6 +5,7 @@ import (
 	s "github.com/scootdev/scoot/saga"
 	"github.com/scootdev/scoot/sched"
 	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+	"github.com/scootdev/scoot/workerapi"
 	"github.com/scootdev/scoot/workerapi/gen-go/worker"
 )
 
@@ -16,16 +17,7 @@ func GetJobStatus(jobId string, sc s.SagaCoordinator) (*scoot.JobStatus, error)
 		js.ID = ""
 		js.Status = scoot.Status_NOT_STARTED
 
-		if err != nil {
-			switch err.(type) {
-			case s.InvalidRequestError:
-				err = scoot.NewInvalidRequest()
-			case s.InternalLogError:
-				err = scoot.NewScootServerError()
-			}
-		}
-
-		return js, err
+		return js, translateSagaLogError(err)
 	}
 
 	// No Logged Saga Messages.  Job NotStarted yet
@@ -40,6 +32,17 @@ func GetJobStatus(jobId string, sc s.SagaCoordinator) (*scoot.JobStatus, error)
 	return convertSagaStateToJobStatus(state), nil
 }
 
+// Translates errors returned by the SagaLog to the errors returned by the API
+func translateSagaLogError(err error) error {
+	switch err.(type) {
+	case s.InvalidRequestError:
+		return scoot.NewInvalidRequest()
+	case s.InternalLogError:
+		return scoot.NewScootServerError()
+	}
+	return err
+}
+
 // Converts a SagaState to a corresponding JobStatus
 func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 
@@ -49,11 +52,13 @@ func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 	js.Status = scoot.Status_NOT_STARTED
 	js.TaskStatus = make(map[string]scoot.Status)
 	js.TaskData = make(map[string]*scoot.RunStatus)
-	if job, err := sched.DeserializeJob(sagaState.Job()); err == nil {
+	job, err := sched.DeserializeJob(sagaState.Job())
+	if err == nil {
 		for id, _ := range job.Def.Tasks {
 			js.TaskStatus[id] = scoot.Status_NOT_STARTED
 		}
 	}
+	failed := make(map[string]bool)
 
 	// NotStarted Tasks will not have a logged value
 	for _, id := range sagaState.GetTaskIds() {
@@ -72,6 +77,11 @@ func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 				if thriftJobStatus, err := workerRunStatusToScootRunStatus(sagaState.GetEndTaskData(id)); err == nil {
 					js.TaskData[id] = thriftJobStatus
 				}
+				if endData := sagaState.GetEndTaskData(id); endData != nil {
+					if st, err := workerapi.DeserializeProcessStatus(endData); err == nil && !sched.RunSucceeded(st) {
+						failed[id] = true
+					}
+				}
 			} else if sagaState.IsTaskStarted(id) {
 				taskStatus = scoot.Status_IN_PROGRESS
 				if startData := sagaState.GetStartTaskData(id); startData != nil {
@@ -86,24 +96,40 @@ func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 		js.TaskStatus[id] = taskStatus
 	}
 
+	// Tasks depending on a failed task are never run
+	if job != nil && !sagaState.IsSagaAborted() {
+		for id := range job.Def.SkippedTasks(failed) {
+			if js.TaskStatus[id] == scoot.Status_NOT_STARTED {
+				js.TaskStatus[id] = scoot.Status_SKIPPED
+			}
+		}
+	}
+
+	js.Status = sagaStatus(sagaState.IsSagaCompleted(), sagaState.IsSagaAborted())
+
+	return js
+}
+
+// Returns the status of a job given whether its saga has completed and
+// whether it was aborted
+func sagaStatus(completed bool, aborted bool) scoot.Status {
+	switch {
 	// Saga Completed Successfully
-	if sagaState.IsSagaCompleted() && !sagaState.IsSagaAborted() {
-		js.Status = scoot.Status_COMPLETED
+	case completed && !aborted:
+		return scoot.Status_COMPLETED
 
-		// Saga Completed Unsuccessfully was Aborted & Rolled Back
-	} else if sagaState.IsSagaCompleted() && sagaState.IsSagaAborted() {
-		js.Status = scoot.Status_ROLLED_BACK
+	// Saga Completed Unsuccessfully was Aborted & Rolled Back
+	case completed && aborted:
+		return scoot.Status_ROLLED_BACK
 
-		// Saga In Progress
-	} else if !sagaState.IsSagaCompleted() && !sagaState.IsSagaAborted() {
-		js.Status = scoot.Status_IN_PROGRESS
+	// Saga in Progress - Aborted and Rolling Back
+	case aborted:
+		return scoot.Status_ROLLING_BACK
 
-		// Saga in Progress - Aborted and Rolling Back
-	} else if !sagaState.IsSagaCompleted() && sagaState.IsSagaAborted() {
-		js.Status = scoot.Status_ROLLING_BACK
+	// Saga In Progress
+	default:
+		return scoot.Status_IN_PROGRESS
 	}
-
-	return js
 }
 
 // this is a thrift to thrift structure translation.  We are doing this because we get invalid
@@ -131,6 +157,30 @@ func workerRunStatusToScootRunStatus(resultsFromSaga []byte) (*scoot.RunStatus,
 		Error:      workerRunStatus.Error,
 		SnapshotId: workerRunStatus.SnapshotId,
 	}
+	if u := workerRunStatus.Usage; u != nil {
+		scootRunStatus.Usage = &scoot.RunUsage{
+			WallTimeMs:     u.WallTimeMs,
+			UserTimeMs:     u.UserTimeMs,
+			SysTimeMs:      u.SysTimeMs,
+			MaxRssBytes:    u.MaxRssBytes,
+			BlockInputOps:  u.BlockInputOps,
+			BlockOutputOps: u.BlockOutputOps,
+		}
+	}
+
+	for _, a := range workerRunStatus.Attempts {
+		attemptStatus, err := scoot.RunStatusStateFromString(a.Status.String())
+		if err != nil {
+			return nil, err
+		}
+		scootRunStatus.Attempts = append(scootRunStatus.Attempts, &scoot.TaskAttempt{
+			WorkerId: a.WorkerId,
+			Status:   attemptStatus,
+			RunId:    a.RunId,
+			ExitCode: a.ExitCode,
+			Error:    a.Error,
+		})
+	}
 
 	return &scootRunStatus, nil
 }
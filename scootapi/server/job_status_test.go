@@ -2,15 +2,19 @@ package server
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/scootdev/scoot/common/thrifthelpers"
 	"github.com/scootdev/scoot/runner"
+	"github.com/scootdev/scoot/runner/execer"
 	s "github.com/scootdev/scoot/saga"
 	"github.com/scootdev/scoot/saga/sagalogs"
+	"github.com/scootdev/scoot/sched"
 	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
 	"github.com/scootdev/scoot/workerapi"
 	"github.com/scootdev/scoot/workerapi/gen-go/worker"
@@ -230,3 +234,98 @@ func TestRunStatusRoundTrip(t *testing.T) {
 		t.Fatalf("runStatus.OutUri: %v (expected %v)", *runStatus.OutUri, stdoutRef)
 	}
 }
+
+func TestJobStatus_SkippedTasks(t *testing.T) {
+	sagaCoord := s.MakeSagaCoordinator(sagalogs.MakeInMemorySagaLog())
+
+	job := sched.Job{
+		Id: "job1",
+		Def: sched.JobDefinition{
+			Tasks: map[string]sched.TaskDefinition{
+				"build":  {},
+				"test":   {Dependencies: []string{"build"}},
+				"deploy": {Dependencies: []string{"test"}},
+			},
+		},
+	}
+	jobAsBytes, err := job.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saga, err := sagaCoord.MakeSaga(job.Id, jobAsBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusAsBytes, err := workerapi.SerializeProcessStatus(runner.CompleteStatus("run1", "", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	saga.StartTask("build", nil)
+	saga.EndTask("build", statusAsBytes)
+
+	jobStatus, err := GetJobStatus(job.Id, sagaCoord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]scoot.Status{
+		"build":  scoot.Status_COMPLETED,
+		"test":   scoot.Status_SKIPPED,
+		"deploy": scoot.Status_SKIPPED,
+	}
+	for id, status := range expected {
+		if jobStatus.TaskStatus[id] != status {
+			t.Errorf("Expected task %v to be %v, got %v", id, status, jobStatus.TaskStatus[id])
+		}
+	}
+}
+
+func TestJobStatus_Attempts(t *testing.T) {
+	sagaCoord := sagalogs.MakeInMemorySagaCoordinator()
+	saga, _ := sagaCoord.MakeSaga("job1", nil)
+
+	attempts := []workerapi.RunAttempt{
+		{WorkerId: "node1", Status: runner.RunStatus{RunID: "3", State: runner.FAILED, Error: "lost worker"}},
+	}
+	st := runner.RunStatus{RunID: "7", State: runner.COMPLETE, ExitCode: 0}
+	statusAsBytes, _ := workerapi.SerializeTaskStatus(st, attempts)
+	saga.StartTask("task1", nil)
+	saga.EndTask("task1", statusAsBytes)
+
+	jobStatus, err := GetJobStatus("job1", sagaCoord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runStatus := jobStatus.TaskData["task1"]
+	if len(runStatus.Attempts) != 1 {
+		t.Fatalf("Expected 1 earlier attempt, got %+v", runStatus.Attempts)
+	}
+	attempt := runStatus.Attempts[0]
+	if attempt.WorkerId != "node1" || attempt.Status != scoot.RunStatusState_FAILED ||
+		attempt.GetRunId() != "3" || attempt.GetError() != "lost worker" {
+		t.Errorf("Unexpected attempt %+v", attempt)
+	}
+}
+
+func TestJobStatus_Usage(t *testing.T) {
+	sagaCoord := sagalogs.MakeInMemorySagaCoordinator()
+	saga, _ := sagaCoord.MakeSaga("job1", nil)
+
+	st := runner.RunStatus{RunID: "7", State: runner.COMPLETE, ExitCode: 0,
+		Usage: execer.Usage{WallTime: 2 * time.Second, UserTime: time.Second, MaxRSS: 1024}}
+	statusAsBytes, _ := workerapi.SerializeTaskStatus(st, nil)
+	saga.StartTask("task1", nil)
+	saga.EndTask("task1", statusAsBytes)
+
+	jobStatus, err := GetJobStatus("job1", sagaCoord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &scoot.RunUsage{WallTimeMs: 2000, UserTimeMs: 1000, MaxRssBytes: 1024}
+	if usage := jobStatus.TaskData["task1"].Usage; !reflect.DeepEqual(usage, expected) {
+		t.Errorf("Expected usage %+v, got %+v", expected, usage)
+	}
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"github.com/scootdev/scoot/saga"
+	"github.com/scootdev/scoot/sched/scheduler"
+	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the KillJob API
+func killJob(jobId string, sched scheduler.Scheduler, sc saga.SagaCoordinator) (*scoot.JobStatus, error) {
+	if jobId == "" {
+		return nil, newInvalidRequest("a job id must be provided")
+	}
+
+	err := sched.KillJob(jobId)
+	if err != nil {
+		switch err.(type) {
+		case *scheduler.JobNotInProgressError:
+			return nil, newInvalidRequest(err.Error())
+		case *scheduler.NotLeaderError:
+			sse := scoot.NewScootServerError()
+			sse.RetryAfterMs = &notLeaderRetryAfterMs
+			return nil, sse
+		default:
+			return nil, scoot.NewScootServerError()
+		}
+	}
+
+	return GetJobStatus(jobId, sc)
+}
+
+func newInvalidRequest(msg string) *scoot.InvalidRequest {
+	ir := scoot.NewInvalidRequest()
+	ir.Message = &msg
+	return ir
+}
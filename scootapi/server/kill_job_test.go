@@ -0,0 +1,82 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/scootdev/scoot/saga/sagalogs"
+	"github.com/scootdev/scoot/sched/scheduler"
+	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+)
+
+func Test_KillJob_EmptyJobId(t *testing.T) {
+	status, err := killJob("", CreateSchedulerMock(t), sagalogs.MakeInMemorySagaCoordinator())
+
+	if _, ok := err.(*scoot.InvalidRequest); !ok {
+		t.Errorf("expected InvalidRequest killing a job with no id, not %v", err)
+	}
+	if status != nil {
+		t.Errorf("expected status to be nil when error occurs not %v", status)
+	}
+}
+
+func Test_KillJob_JobNotInProgress(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := scheduler.NewMockScheduler(mockCtrl)
+	s.EXPECT().KillJob("job1").Return(&scheduler.JobNotInProgressError{JobId: "job1"})
+
+	_, err := killJob("job1", s, sagalogs.MakeInMemorySagaCoordinator())
+
+	if _, ok := err.(*scoot.InvalidRequest); !ok {
+		t.Errorf("expected InvalidRequest killing a job that is not in progress, not %v", err)
+	}
+}
+
+func Test_KillJob_SchedulerError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := scheduler.NewMockScheduler(mockCtrl)
+	s.EXPECT().KillJob("job1").Return(errors.New("test error"))
+
+	_, err := killJob("job1", s, sagalogs.MakeInMemorySagaCoordinator())
+
+	if _, ok := err.(*scoot.ScootServerError); !ok {
+		t.Errorf("expected ScootServerError when the scheduler fails to kill a job, not %v", err)
+	}
+}
+
+func Test_KillJob_NotLeader(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := scheduler.NewMockScheduler(mockCtrl)
+	s.EXPECT().KillJob("job1").Return(&scheduler.NotLeaderError{})
+
+	_, err := killJob("job1", s, sagalogs.MakeInMemorySagaCoordinator())
+
+	sse, ok := err.(*scoot.ScootServerError)
+	if !ok || sse.GetRetryAfterMs() <= 0 {
+		t.Errorf("expected ScootServerError with a retry delay when the scheduler isn't the leader, not %v", err)
+	}
+}
+
+func Test_KillJob_ReturnsRollingBackStatus(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+	saga, _ := sc.MakeSaga("job1", nil)
+	saga.StartTask("task1", nil)
+	saga.AbortSaga()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := scheduler.NewMockScheduler(mockCtrl)
+	s.EXPECT().KillJob("job1").Return(nil)
+
+	status, err := killJob("job1", s, sc)
+	if err != nil {
+		t.Fatalf("unexpected error killing job: %v", err)
+	}
+	if status.Status != scoot.Status_ROLLING_BACK {
+		t.Errorf("expected killed job to be ROLLING_BACK, not %v", status.Status)
+	}
+}
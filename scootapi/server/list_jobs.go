@@ -0,0 +1,195 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scootdev/scoot/saga"
+	"github.com/scootdev/scoot/sched"
+	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+)
+
+// Number of jobs returned by ListJobs when the request doesn't set a limit,
+// and the most a request may ask for
+const (
+	defaultListJobsLimit = 100
+	maxListJobsLimit     = 1000
+)
+
+// Implementation of the ListJobs API.  Jobs are returned most recently
+// submitted first.
+func listJobs(req *scoot.ListJobsRequest, sc saga.SagaCoordinator) (*scoot.ListJobsResponse, error) {
+	if req == nil {
+		req = scoot.NewListJobsRequest()
+	}
+
+	limit := defaultListJobsLimit
+	if req.IsSetLimit() {
+		if req.GetLimit() <= 0 || req.GetLimit() > maxListJobsLimit {
+			return nil, newInvalidRequest(fmt.Sprintf("limit must be between 1 and %v", maxListJobsLimit))
+		}
+		limit = int(req.GetLimit())
+	}
+
+	var after *listJobsCursor
+	if req.IsSetCursor() {
+		c, err := parseListJobsCursor(req.GetCursor())
+		if err != nil {
+			return nil, newInvalidRequest(err.Error())
+		}
+		after = &c
+	}
+
+	// one more than the limit, to know if there's a next page
+	filter := saga.SagaFilter{NewestFirst: true, Limit: limit + 1}
+	if req.IsSetSubmittedAfterMs() {
+		filter.StartedAfter = msToTime(req.GetSubmittedAfterMs())
+	}
+	if req.IsSetSubmittedBeforeMs() {
+		filter.StartedBefore = msToTime(req.GetSubmittedBeforeMs())
+	}
+	filter.Match = func(info saga.SagaInfo) bool {
+		if after != nil && !after.precedes(info) {
+			return false
+		}
+		return sagaInfoMatches(info, req)
+	}
+
+	infos, err := sc.ListSagas(filter)
+	if err != nil {
+		return nil, translateSagaLogError(err)
+	}
+
+	resp := scoot.NewListJobsResponse()
+	resp.Jobs = make([]*scoot.JobSummary, 0)
+	for i, info := range infos {
+		if i == limit {
+			// there's at least one more job, continue after the last one listed
+			cursor := makeListJobsCursor(infos[i-1]).String()
+			resp.NextCursor = &cursor
+			break
+		}
+
+		// sagas without labels are only filtered once their job is read
+		summary := makeJobSummary(info)
+		if !jobSummaryMatches(summary, req) {
+			continue
+		}
+		resp.Jobs = append(resp.Jobs, summary)
+	}
+
+	return resp, nil
+}
+
+// Returns true if the saga passes the request's status filter, and its
+// job type & tenant filters if the saga has labels to check them against
+func sagaInfoMatches(info saga.SagaInfo, req *scoot.ListJobsRequest) bool {
+	if len(req.Statuses) > 0 {
+		status := sagaStatus(info.Completed, info.Aborted)
+		found := false
+		for _, s := range req.Statuses {
+			found = found || s == status
+		}
+		if !found {
+			return false
+		}
+	}
+	if info.Labels == nil {
+		return true
+	}
+	if req.IsSetJobType() && info.Labels[sched.JobTypeLabel] != req.GetJobType().String() {
+		return false
+	}
+	if req.IsSetTenant() && info.Labels[sched.TenantLabel] != req.GetTenant() {
+		return false
+	}
+	return true
+}
+
+// Summarizes a saga as a job.  Fields that can't be read from the job
+// definition are left unset.
+func makeJobSummary(info saga.SagaInfo) *scoot.JobSummary {
+	summary := scoot.NewJobSummary()
+	summary.ID = info.SagaId
+	summary.Status = sagaStatus(info.Completed, info.Aborted)
+	submittedMs := info.StartTime.UnixNano() / int64(time.Millisecond)
+	summary.SubmittedMs = &submittedMs
+
+	job, err := sched.DeserializeJob(info.Job)
+	if err != nil {
+		return summary
+	}
+	if jobType, err := scoot.JobTypeFromString(job.Def.JobType); err == nil {
+		summary.JobType = &jobType
+	}
+	if job.Def.Tenant != "" {
+		tenant := job.Def.Tenant
+		summary.Tenant = &tenant
+	}
+	priority := int32(job.Def.Priority)
+	summary.Priority = &priority
+	return summary
+}
+
+// Returns true if the job passes the request's status, job type & tenant filters
+func jobSummaryMatches(summary *scoot.JobSummary, req *scoot.ListJobsRequest) bool {
+	if len(req.Statuses) > 0 {
+		found := false
+		for _, status := range req.Statuses {
+			found = found || status == summary.Status
+		}
+		if !found {
+			return false
+		}
+	}
+	if req.IsSetJobType() && (!summary.IsSetJobType() || summary.GetJobType() != req.GetJobType()) {
+		return false
+	}
+	if req.IsSetTenant() && summary.GetTenant() != req.GetTenant() {
+		return false
+	}
+	return true
+}
+
+func msToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+// Position of the last job returned in a page, the next page starts at
+// the job submitted before it.  Serialized as "<startUnixNano>:<sagaId>".
+type listJobsCursor struct {
+	startNanos int64
+	sagaId     string
+}
+
+func makeListJobsCursor(info saga.SagaInfo) listJobsCursor {
+	return listJobsCursor{startNanos: info.StartTime.UnixNano(), sagaId: info.SagaId}
+}
+
+func parseListJobsCursor(s string) (listJobsCursor, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return listJobsCursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	startNanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return listJobsCursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	return listJobsCursor{startNanos: startNanos, sagaId: parts[1]}, nil
+}
+
+func (c listJobsCursor) String() string {
+	return fmt.Sprintf("%d:%s", c.startNanos, c.sagaId)
+}
+
+// Returns true if the job at the cursor is listed before the saga, i.e.
+// the saga was submitted before it
+func (c listJobsCursor) precedes(info saga.SagaInfo) bool {
+	startNanos := info.StartTime.UnixNano()
+	if startNanos != c.startNanos {
+		return startNanos < c.startNanos
+	}
+	return info.SagaId < c.sagaId
+}
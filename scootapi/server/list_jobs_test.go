@@ -0,0 +1,149 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/scootdev/scoot/saga"
+	"github.com/scootdev/scoot/saga/sagalogs"
+	"github.com/scootdev/scoot/sched"
+	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+)
+
+func startListJob(t *testing.T, sc saga.SagaCoordinator, jobId string, def sched.JobDefinition) *saga.Saga {
+	job := sched.Job{Id: jobId, Def: def}
+	jobAsBytes, err := job.Serialize()
+	if err != nil {
+		t.Fatalf("Unexpected error serializing job: %v", err)
+	}
+	s, err := sc.MakeSagaWithLabels(jobId, jobAsBytes, job.Labels())
+	if err != nil {
+		t.Fatalf("Unexpected error starting saga: %v", err)
+	}
+	return s
+}
+
+func listedJobIds(resp *scoot.ListJobsResponse) []string {
+	var ids []string
+	for _, job := range resp.Jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+func Test_ListJobs_Filters(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+
+	startListJob(t, sc, "job1", sched.JobDefinition{JobType: "IRON_TESTS", Tenant: "tenant1", Priority: 2})
+	done := startListJob(t, sc, "job2", sched.JobDefinition{Tenant: "tenant2"})
+	done.EndSaga()
+	startListJob(t, sc, "job3", sched.JobDefinition{Tenant: "tenant1"})
+
+	resp, err := listJobs(scoot.NewListJobsRequest(), sc)
+	if err != nil {
+		t.Fatalf("Unexpected error listing jobs: %v", err)
+	}
+	if fmt.Sprint(listedJobIds(resp)) != "[job3 job2 job1]" {
+		t.Errorf("Expected all jobs newest first, got %v", listedJobIds(resp))
+	}
+	if resp.IsSetNextCursor() {
+		t.Errorf("Expected no next cursor, got %v", resp.GetNextCursor())
+	}
+	job1 := resp.Jobs[2]
+	if job1.Status != scoot.Status_IN_PROGRESS || job1.GetJobType() != scoot.JobType_IRON_TESTS ||
+		job1.GetTenant() != "tenant1" || job1.GetPriority() != 2 || job1.GetSubmittedMs() == 0 {
+		t.Errorf("Unexpected summary of job1: %+v", job1)
+	}
+
+	req := scoot.NewListJobsRequest()
+	req.Statuses = []scoot.Status{scoot.Status_COMPLETED}
+	resp, _ = listJobs(req, sc)
+	if fmt.Sprint(listedJobIds(resp)) != "[job2]" {
+		t.Errorf("Expected only completed jobs, got %v", listedJobIds(resp))
+	}
+
+	req = scoot.NewListJobsRequest()
+	tenant := "tenant1"
+	req.Tenant = &tenant
+	resp, _ = listJobs(req, sc)
+	if fmt.Sprint(listedJobIds(resp)) != "[job3 job1]" {
+		t.Errorf("Expected only tenant1's jobs, got %v", listedJobIds(resp))
+	}
+
+	req = scoot.NewListJobsRequest()
+	jobType := scoot.JobType_IRON_TESTS
+	req.JobType = &jobType
+	resp, _ = listJobs(req, sc)
+	if fmt.Sprint(listedJobIds(resp)) != "[job1]" {
+		t.Errorf("Expected only IRON_TESTS jobs, got %v", listedJobIds(resp))
+	}
+}
+
+func Test_ListJobs_Pagination(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+	for i := 0; i < 5; i++ {
+		startListJob(t, sc, fmt.Sprintf("job%d", i), sched.JobDefinition{})
+	}
+
+	var pages [][]string
+	req := scoot.NewListJobsRequest()
+	limit := int32(2)
+	req.Limit = &limit
+	for {
+		resp, err := listJobs(req, sc)
+		if err != nil {
+			t.Fatalf("Unexpected error listing jobs: %v", err)
+		}
+		pages = append(pages, listedJobIds(resp))
+		if !resp.IsSetNextCursor() {
+			break
+		}
+		req.Cursor = resp.NextCursor
+	}
+
+	expected := "[[job4 job3] [job2 job1] [job0]]"
+	if fmt.Sprint(pages) != expected {
+		t.Errorf("Expected pages %v, got %v", expected, pages)
+	}
+}
+
+// Jobs submitted before sagas had labels are filtered once their job is read
+func Test_ListJobs_Unlabeled(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+	for i, tenant := range []string{"tenant1", "tenant2", "tenant1"} {
+		job := sched.Job{Id: fmt.Sprintf("job%d", i), Def: sched.JobDefinition{Tenant: tenant}}
+		jobAsBytes, _ := job.Serialize()
+		if _, err := sc.MakeSaga(job.Id, jobAsBytes); err != nil {
+			t.Fatalf("Unexpected error starting saga: %v", err)
+		}
+	}
+
+	req := scoot.NewListJobsRequest()
+	tenant := "tenant1"
+	req.Tenant = &tenant
+	resp, err := listJobs(req, sc)
+	if err != nil {
+		t.Fatalf("Unexpected error listing jobs: %v", err)
+	}
+	if fmt.Sprint(listedJobIds(resp)) != "[job2 job0]" {
+		t.Errorf("Expected only tenant1's jobs, got %v", listedJobIds(resp))
+	}
+}
+
+func Test_ListJobs_InvalidRequest(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+
+	req := scoot.NewListJobsRequest()
+	cursor := "not a cursor"
+	req.Cursor = &cursor
+	if _, err := listJobs(req, sc); err == nil {
+		t.Errorf("Expected an invalid cursor to be rejected")
+	}
+
+	req = scoot.NewListJobsRequest()
+	limit := int32(maxListJobsLimit + 1)
+	req.Limit = &limit
+	if _, err := listJobs(req, sc); err == nil {
+		t.Errorf("Expected a limit over %v to be rejected", maxListJobsLimit)
+	}
+}
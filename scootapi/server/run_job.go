@@ -2,15 +2,18 @@ package server
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/scootdev/scoot/common/stats"
+	"github.com/scootdev/scoot/runner"
 	"github.com/scootdev/scoot/sched"
 	"github.com/scootdev/scoot/sched/scheduler"
 	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
 )
 
 // Implementation of the RunJob API
-func runJob(scheduler scheduler.Scheduler, def *scoot.JobDefinition, stat stats.StatsReceiver) (*scoot.JobId, error) {
+func runJob(s scheduler.Scheduler, def *scoot.JobDefinition, stat stats.StatsReceiver) (*scoot.JobId, error) {
 	stat.Counter("runJobRequestsCounter").Inc(1)
 
 	jobDef, err := thriftJobToScoot(def)
@@ -24,10 +27,14 @@ func runJob(scheduler scheduler.Scheduler, def *scoot.JobDefinition, stat stats.
 		return nil, err
 	}
 
-	id, err := scheduler.ScheduleJob(jobDef)
+	id, err := s.ScheduleJob(jobDef)
 
 	if err != nil {
-		return nil, scoot.NewCanNotScheduleNow()
+		cnsn := scoot.NewCanNotScheduleNow()
+		if _, ok := err.(*scheduler.NotLeaderError); ok {
+			cnsn.RetryAfterMs = &notLeaderRetryAfterMs
+		}
+		return nil, cnsn
 	}
 
 	return &scoot.JobId{ID: id}, nil
@@ -50,24 +57,74 @@ func thriftJobToScoot(def *scoot.JobDefinition) (result sched.JobDefinition, err
 			return result, fmt.Errorf("nil command")
 		}
 		task.Command.Argv = t.Command.Argv
+		task.Command.EnvVars = t.Command.EnvVars
+		task.Command.Timeout = time.Duration(t.Command.GetTimeoutMs()) * time.Millisecond
+		task.Command.Network = t.Command.GetNetwork()
+		task.Command.Outputs = t.Command.Outputs
 		if t.SnapshotId != nil {
 			task.SnapshotID = *t.SnapshotId
 		}
+		task.Resources.CPUSlots = int(t.GetCpuSlots())
+		task.Resources.MemoryBytes = t.GetMemoryBytes()
+		task.Dependencies = t.Dependencies
+		if t.RetryPolicy != nil {
+			task.Retry = thriftRetryPolicyToScoot(t.RetryPolicy)
+		}
+		task.ExpectedDuration = time.Duration(t.GetExpectedDurationMs()) * time.Millisecond
 		result.Tasks[taskId] = task
 	}
 
 	if def.JobType != nil {
 		result.JobType = def.JobType.String()
 	}
+	result.Priority = int(def.GetPriority())
+	result.Tenant = def.GetTenant()
 
 	return result, nil
 }
 
+// Translates a thrift retry policy to the scheduler's
+func thriftRetryPolicyToScoot(policy *scoot.RetryPolicy) (result sched.RetryPolicy) {
+	result.MaxAttempts = int(policy.GetMaxAttempts())
+	result.InitialBackoff = time.Duration(policy.GetInitialBackoffMs()) * time.Millisecond
+	result.MaxBackoff = time.Duration(policy.GetMaxBackoffMs()) * time.Millisecond
+	for _, state := range policy.RetryableStates {
+		result.RetryableStates = append(result.RetryableStates, thriftRunStateToScoot(state))
+	}
+	for _, code := range policy.RetryableExitCodes {
+		result.RetryableExitCodes = append(result.RetryableExitCodes, int(code))
+	}
+	return result
+}
+
+func thriftRunStateToScoot(state scoot.RunStatusState) runner.RunState {
+	switch state {
+	case scoot.RunStatusState_PENDING:
+		return runner.PENDING
+	case scoot.RunStatusState_RUNNING:
+		return runner.RUNNING
+	case scoot.RunStatusState_COMPLETE:
+		return runner.COMPLETE
+	case scoot.RunStatusState_FAILED:
+		return runner.FAILED
+	case scoot.RunStatusState_ABORTED:
+		return runner.ABORTED
+	case scoot.RunStatusState_TIMEDOUT:
+		return runner.TIMEDOUT
+	case scoot.RunStatusState_BADREQUEST:
+		return runner.BADREQUEST
+	}
+	return runner.UNKNOWN
+}
+
 // Validate a job, returning an *InvalidJobRequest if invalid.
 func validateJob(job sched.JobDefinition) error {
 	if len(job.Tasks) == 0 {
 		return NewInvalidJobRequest("invalid job. Must have at least 1 task; was empty")
 	}
+	if job.Priority < 0 {
+		return NewInvalidJobRequest(fmt.Sprintf("invalid job priority %v. Must not be negative", job.Priority))
+	}
 	for id, task := range job.Tasks {
 		if id == "" {
 			return NewInvalidJobRequest("invalid task id \"\".")
@@ -75,6 +132,29 @@ func validateJob(job sched.JobDefinition) error {
 		if len(task.Command.Argv) == 0 {
 			return NewInvalidJobRequest("invalid task.Command.Argv. Must have at least one argument; was empty")
 		}
+		if task.Command.Timeout < 0 {
+			return NewInvalidJobRequest("invalid task.Command.Timeout. Must not be negative")
+		}
+		for name := range task.Command.EnvVars {
+			if name == "" || strings.Contains(name, "=") {
+				return NewInvalidJobRequest(fmt.Sprintf("invalid task.Command.EnvVars name %q. Must be non-empty and not contain '='", name))
+			}
+		}
+		if err := runner.ValidateOutputs(task.Command.Outputs); err != nil {
+			return NewInvalidJobRequest(fmt.Sprintf("invalid task.Command.Outputs. %v", err))
+		}
+		if task.Resources.CPUSlots < 0 || task.Resources.MemoryBytes < 0 {
+			return NewInvalidJobRequest("invalid task resources. CpuSlots and MemoryBytes must not be negative")
+		}
+		if task.ExpectedDuration < 0 {
+			return NewInvalidJobRequest("invalid task expectedDurationMs. Must not be negative")
+		}
+		if err := task.Retry.Validate(); err != nil {
+			return NewInvalidJobRequest(fmt.Sprintf("invalid task retry policy. %v", err))
+		}
+	}
+	if err := job.ValidateDependencies(); err != nil {
+		return NewInvalidJobRequest(fmt.Sprintf("invalid task dependencies. %v", err))
 	}
 	return nil
 }
@@ -4,9 +4,12 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/scootdev/scoot/common/stats"
+	"github.com/scootdev/scoot/runner"
+	"github.com/scootdev/scoot/sched"
 	"github.com/scootdev/scoot/sched/scheduler"
 	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
 	"github.com/scootdev/scoot/tests/testhelpers"
@@ -86,6 +89,132 @@ func Test_RunJob_NoCommand(t *testing.T) {
 	}
 }
 
+// Jobs with a negative priority should return InvalidJobRequest error
+func Test_RunJob_NegativePriority(t *testing.T) {
+	jobDef := testhelpers.GenJobDefinition(testhelpers.NewRand(), -1, "")
+	priority := int32(-1)
+	jobDef.Priority = &priority
+
+	jobId, err := runJob(CreateSchedulerMock(t), jobDef, stats.NilStatsReceiver())
+
+	if !IsInvalidJobRequest(err) {
+		t.Errorf("expected error to be InvalidJobRequest not %v", reflect.TypeOf(err))
+	}
+
+	if jobId != nil {
+		t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
+	}
+}
+
+// Jobs whose task dependencies form a cycle should return InvalidJobRequest error
+func Test_RunJob_DependencyCycle(t *testing.T) {
+	jobDef := scoot.NewJobDefinition()
+	rand := testhelpers.NewRand()
+	task1 := testhelpers.GenTask(rand, "")
+	task1.Dependencies = []string{"2"}
+	task2 := testhelpers.GenTask(rand, "")
+	task2.Dependencies = []string{"1"}
+	jobDef.Tasks = map[string]*scoot.TaskDefinition{
+		"1": task1,
+		"2": task2,
+	}
+
+	jobId, err := runJob(CreateSchedulerMock(t), jobDef, stats.NilStatsReceiver())
+
+	if !IsInvalidJobRequest(err) {
+		t.Errorf("expected error to be InvalidJobRequest not %v", reflect.TypeOf(err))
+	}
+
+	if jobId != nil {
+		t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
+	}
+}
+
+// Jobs with a negative task timeout should return InvalidJobRequest error
+func Test_RunJob_NegativeTimeout(t *testing.T) {
+	jobDef := scoot.NewJobDefinition()
+	task := testhelpers.GenTask(testhelpers.NewRand(), "")
+	timeoutMs := int32(-1)
+	task.Command.TimeoutMs = &timeoutMs
+	jobDef.Tasks = map[string]*scoot.TaskDefinition{
+		"1": task,
+	}
+
+	jobId, err := runJob(CreateSchedulerMock(t), jobDef, stats.NilStatsReceiver())
+
+	if !IsInvalidJobRequest(err) {
+		t.Errorf("expected error to be InvalidJobRequest not %v", reflect.TypeOf(err))
+	}
+
+	if jobId != nil {
+		t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
+	}
+}
+
+// Env vars, timeouts, network access & outputs should be passed through to the scheduler
+func Test_RunJob_EnvVarsAndTimeout(t *testing.T) {
+	jobDef := scoot.NewJobDefinition()
+	task := testhelpers.GenTask(testhelpers.NewRand(), "")
+	task.Command.EnvVars = map[string]string{"FOO": "bar"}
+	timeoutMs := int32(1500)
+	task.Command.TimeoutMs = &timeoutMs
+	network := true
+	task.Command.Network = &network
+	task.Command.Outputs = map[string]string{"reports/*.xml": "reports"}
+	jobDef.Tasks = map[string]*scoot.TaskDefinition{
+		"1": task,
+	}
+
+	var scheduled sched.JobDefinition
+	scheduler := CreateSchedulerMock(t)
+	scheduler.EXPECT().ScheduleJob(gomock.Any()).Do(func(def sched.JobDefinition) {
+		scheduled = def
+	}).Return("testJobId", nil)
+
+	if _, err := runJob(scheduler, jobDef, stats.NilStatsReceiver()); err != nil {
+		t.Fatalf("expected job to be successfully scheduled.  Instead error returned: %v", err)
+	}
+
+	cmd := scheduled.Tasks["1"].Command
+	if !reflect.DeepEqual(cmd.EnvVars, task.Command.EnvVars) {
+		t.Errorf("expected env vars %v, got %v", task.Command.EnvVars, cmd.EnvVars)
+	}
+	if cmd.Timeout != 1500*time.Millisecond {
+		t.Errorf("expected timeout of 1.5s, got %v", cmd.Timeout)
+	}
+	if !cmd.Network {
+		t.Errorf("expected network access to be passed through")
+	}
+	if !reflect.DeepEqual(cmd.Outputs, task.Command.Outputs) {
+		t.Errorf("expected outputs %v, got %v", task.Command.Outputs, cmd.Outputs)
+	}
+}
+
+// Outputs outside of the checkout or snapshot should return InvalidJobRequest error
+func Test_RunJob_InvalidOutputs(t *testing.T) {
+	for _, outputs := range []map[string]string{
+		{"../secrets": ""},
+		{"/etc/passwd": ""},
+		{"reports/[": "reports"},
+		{"reports": "../elsewhere"},
+	} {
+		jobDef := scoot.NewJobDefinition()
+		task := testhelpers.GenTask(testhelpers.NewRand(), "")
+		task.Command.Outputs = outputs
+		jobDef.Tasks = map[string]*scoot.TaskDefinition{
+			"1": task,
+		}
+
+		jobId, err := runJob(CreateSchedulerMock(t), jobDef, stats.NilStatsReceiver())
+		if !IsInvalidJobRequest(err) {
+			t.Errorf("expected outputs %v to be an InvalidJobRequest, got %v", outputs, err)
+		}
+		if jobId != nil {
+			t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
+		}
+	}
+}
+
 func Test_RunJob_ValidJob(t *testing.T) {
 	jobDef := testhelpers.GenJobDefinition(testhelpers.NewRand(), -1, "")
 
@@ -119,3 +248,74 @@ func Test_RunJob_SchedulerError(t *testing.T) {
 		t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
 	}
 }
+
+func Test_RunJob_NotLeader(t *testing.T) {
+	jobDef := testhelpers.GenJobDefinition(testhelpers.NewRand(), -1, "")
+
+	s := CreateSchedulerMock(t)
+	s.EXPECT().ScheduleJob(gomock.Any()).Return("", &scheduler.NotLeaderError{})
+
+	_, err := runJob(s, jobDef, stats.NilStatsReceiver())
+
+	cnsn, ok := err.(*scoot.CanNotScheduleNow)
+	if !ok || cnsn.GetRetryAfterMs() <= 0 {
+		t.Errorf("expected CanNotScheduleNow with a retry delay when the scheduler isn't the leader, not %v", err)
+	}
+}
+
+// Retry policies should be passed through to the scheduler
+func Test_RunJob_RetryPolicy(t *testing.T) {
+	jobDef := scoot.NewJobDefinition()
+	task := testhelpers.GenTask(testhelpers.NewRand(), "")
+	maxAttempts, initialBackoffMs := int32(3), int32(500)
+	task.RetryPolicy = &scoot.RetryPolicy{
+		MaxAttempts:        &maxAttempts,
+		InitialBackoffMs:   &initialBackoffMs,
+		RetryableStates:    []scoot.RunStatusState{scoot.RunStatusState_FAILED, scoot.RunStatusState_TIMEDOUT},
+		RetryableExitCodes: []int32{75},
+	}
+	jobDef.Tasks = map[string]*scoot.TaskDefinition{
+		"1": task,
+	}
+
+	var scheduled sched.JobDefinition
+	scheduler := CreateSchedulerMock(t)
+	scheduler.EXPECT().ScheduleJob(gomock.Any()).Do(func(def sched.JobDefinition) {
+		scheduled = def
+	}).Return("testJobId", nil)
+
+	if _, err := runJob(scheduler, jobDef, stats.NilStatsReceiver()); err != nil {
+		t.Fatalf("expected job to be successfully scheduled.  Instead error returned: %v", err)
+	}
+
+	expected := sched.RetryPolicy{
+		MaxAttempts:        3,
+		InitialBackoff:     500 * time.Millisecond,
+		RetryableStates:    []runner.RunState{runner.FAILED, runner.TIMEDOUT},
+		RetryableExitCodes: []int{75},
+	}
+	if !reflect.DeepEqual(scheduled.Tasks["1"].Retry, expected) {
+		t.Errorf("expected retry policy %+v, got %+v", expected, scheduled.Tasks["1"].Retry)
+	}
+}
+
+func Test_RunJob_InvalidRetryPolicy(t *testing.T) {
+	jobDef := scoot.NewJobDefinition()
+	task := testhelpers.GenTask(testhelpers.NewRand(), "")
+	task.RetryPolicy = &scoot.RetryPolicy{
+		RetryableStates: []scoot.RunStatusState{scoot.RunStatusState_COMPLETE},
+	}
+	jobDef.Tasks = map[string]*scoot.TaskDefinition{
+		"1": task,
+	}
+
+	jobId, err := runJob(CreateSchedulerMock(t), jobDef, stats.NilStatsReceiver())
+
+	if !IsInvalidJobRequest(err) {
+		t.Errorf("expected error to be InvalidJobRequest not %v", reflect.TypeOf(err))
+	}
+
+	if jobId != nil {
+		t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
+	}
+}
@@ -45,3 +45,24 @@ func (h *Handler) GetStatus(jobId string) (*scoot.JobStatus, error) {
 	h.stat.Counter("jobStatusRpmCounter").Inc(1)
 	return GetJobStatus(jobId, h.sagaCoord)
 }
+
+// Implements KillJob Cloud Scoot API
+func (h *Handler) KillJob(jobId string) (*scoot.JobStatus, error) {
+	defer h.stat.Latency("killJobLatency_ms").Time().Stop()
+	h.stat.Counter("killJobRpmCounter").Inc(1)
+	return killJob(jobId, h.scheduler, h.sagaCoord)
+}
+
+// Implements WatchJob Cloud Scoot API
+func (h *Handler) WatchJob(jobId string, version int64, timeoutMs int32) (*scoot.JobStatus, error) {
+	defer h.stat.Latency("watchJobLatency_ms").Time().Stop()
+	h.stat.Counter("watchJobRpmCounter").Inc(1)
+	return watchJob(jobId, version, timeoutMs, h.sagaCoord)
+}
+
+// Implements ListJobs Cloud Scoot API
+func (h *Handler) ListJobs(req *scoot.ListJobsRequest) (*scoot.ListJobsResponse, error) {
+	defer h.stat.Latency("listJobsLatency_ms").Time().Stop()
+	h.stat.Counter("listJobsRpmCounter").Inc(1)
+	return listJobs(req, h.sagaCoord)
+}
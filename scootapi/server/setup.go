@@ -6,6 +6,7 @@ import (
 
 	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/scootdev/scoot/cloud/cluster"
+	"github.com/scootdev/scoot/common/dialer"
 	"github.com/scootdev/scoot/common/endpoints"
 	"github.com/scootdev/scoot/common/stats"
 	"github.com/scootdev/scoot/config/jsonconfig"
@@ -16,6 +17,8 @@ import (
 	"github.com/scootdev/scoot/sched/scheduler"
 	"github.com/scootdev/scoot/scootapi"
 	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+	"github.com/scootdev/scoot/snapshot/bundlestore"
+	"github.com/scootdev/scoot/snapshot/git/gitdb"
 )
 
 type servers struct {
@@ -45,13 +48,39 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 
 		func(
 			cl *cluster.Cluster,
+			sl saga.SagaLog,
 			sc saga.SagaCoordinator,
 			rf func(cluster.Node) runner.Service,
 			config scheduler.SchedulerConfig,
+			pinner scheduler.SnapshotPinner,
+			wf scheduler.WorkerClientFactory,
 			stat stats.StatsReceiver) scheduler.Scheduler {
+			config.SnapshotPinner = pinner
+			config.WorkerClientFactory = wf
+			// with a replicated saga log only the leader of its group
+			// schedules, the API is served either way
+			if replicated, ok := sl.(saga.ReplicatedSagaLog); ok {
+				return scheduler.NewLeaderSchedulerFromCluster(cl, replicated, sc, rf, config, stat)
+			}
 			return scheduler.NewStatefulSchedulerFromCluster(cl, sc, rf, config, stat)
 		},
 
+		// pins the bundles of jobs' snapshots in the bundlestore the API server serves,
+		// when it collects bundles and we have the admin token pinning needs
+		func(config scheduler.SchedulerConfig) scheduler.SnapshotPinner {
+			if !config.PinSnapshots {
+				return nil
+			}
+			if bundlestore.AdminTokenFromEnv() == "" {
+				log.Printf("Not pinning snapshots, %s is unset", bundlestore.AdminTokenEnvVar)
+				return nil
+			}
+			resolver := dialer.NewCompositeResolver(
+				dialer.NewEnvResolver("SCOOT_BUNDLESTORE_URL"),
+				scootapi.NewBundlestoreResolver())
+			return &gitdb.BundlePinner{Pinner: bundlestore.MakeHTTPPinner(resolver)}
+		},
+
 		func(
 			s scheduler.Scheduler,
 			sc saga.SagaCoordinator,
@@ -86,9 +115,11 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 
 	schema := jsonconfig.Schema(map[string]jsonconfig.Implementations{
 		"SagaLog": {
-			"memory": &scootconfig.InMemorySagaLogConfig{},
-			"file":   &scootconfig.FileSagaLogConfig{},
-			"":       &scootconfig.InMemorySagaLogConfig{},
+			"memory":     &scootconfig.InMemorySagaLogConfig{},
+			"file":       &scootconfig.FileSagaLogConfig{},
+			"kv":         &scootconfig.KVSagaLogConfig{},
+			"replicated": &scootconfig.ReplicatedSagaLogConfig{},
+			"":           &scootconfig.InMemorySagaLogConfig{},
 		},
 		"Cluster": {
 			"memory": &scootconfig.ClusterMemoryConfig{},
@@ -106,10 +137,12 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 		"SchedulerConfig": {
 			"stateful": &scootconfig.StatefulSchedulerConfig{},
 			"": &scootconfig.StatefulSchedulerConfig{
-				Type:                 "stateful",
-				MaxRetriesPerTask:    0,
-				DefaultTaskTimeoutMs: 30 * 60 * 1000, // 30m
-				RunnerOverheadMs:     10 * 60 * 1000, // 10m
+				Type:                   "stateful",
+				MaxRetriesPerTask:      0,
+				DefaultTaskTimeoutMs:   30 * 60 * 1000, // 30m
+				RunnerOverheadMs:       10 * 60 * 1000, // 10m
+				SnapshotAffinityWaitMs: 5 * 1000,       // 5s
+				StragglerMultiplier:    3,
 			},
 		},
 	})
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/scootdev/scoot/saga"
+	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+)
+
+// Longest a WatchJob call blocks for, also used when the caller doesn't
+// specify a timeout
+const maxWatchJobTimeout = time.Minute
+
+// Implementation of the WatchJob API
+func watchJob(jobId string, version int64, timeoutMs int32, sc saga.SagaCoordinator) (*scoot.JobStatus, error) {
+	if jobId == "" {
+		return nil, newInvalidRequest("a job id must be provided")
+	}
+	if version < 0 {
+		return nil, newInvalidRequest("version must not be negative")
+	}
+	if timeoutMs < 0 {
+		return nil, newInvalidRequest("timeoutMs must not be negative")
+	}
+
+	timeout := time.Duration(timeoutMs) * time.Millisecond
+	if timeout == 0 || timeout > maxWatchJobTimeout {
+		timeout = maxWatchJobTimeout
+	}
+
+	result, err := sc.WatchSaga(jobId, int(version), timeout)
+	if err != nil {
+		return nil, translateSagaLogError(err)
+	}
+
+	newVersion := int64(result.Version)
+	if result.State == nil {
+		js := scoot.NewJobStatus()
+		js.ID = jobId
+		js.Status = scoot.Status_NOT_STARTED
+		js.Version = &newVersion
+		return js, nil
+	}
+
+	js := convertSagaStateToJobStatus(result.State)
+	js.Version = &newVersion
+	if result.PrevState != nil {
+		removeUnchangedTasks(js, convertSagaStateToJobStatus(result.PrevState))
+	}
+	return js, nil
+}
+
+// Removes the tasks whose status & data are the same in js and prev from js
+func removeUnchangedTasks(js *scoot.JobStatus, prev *scoot.JobStatus) {
+	for id, status := range js.TaskStatus {
+		prevStatus, ok := prev.TaskStatus[id]
+		if ok && prevStatus == status && reflect.DeepEqual(js.TaskData[id], prev.TaskData[id]) {
+			delete(js.TaskStatus, id)
+			delete(js.TaskData, id)
+		}
+	}
+}
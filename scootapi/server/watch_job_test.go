@@ -0,0 +1,74 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/saga/sagalogs"
+	"github.com/scootdev/scoot/sched"
+	"github.com/scootdev/scoot/scootapi/gen-go/scoot"
+)
+
+func Test_WatchJob_ReturnsChangedTasks(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+
+	job := sched.Job{
+		Id: "job1",
+		Def: sched.JobDefinition{
+			Tasks: map[string]sched.TaskDefinition{
+				"task1": {},
+				"task2": {},
+			},
+		},
+	}
+	jobAsBytes, _ := job.Serialize()
+	saga, _ := sc.MakeSaga(job.Id, jobAsBytes)
+
+	status, err := watchJob(job.Id, 0, 0, sc)
+	if err != nil {
+		t.Fatalf("Unexpected error watching job: %v", err)
+	}
+	if len(status.TaskStatus) != 2 {
+		t.Errorf("Expected every task to be returned for version 0, got %v", status.TaskStatus)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		saga.StartTask("task2", nil)
+	}()
+
+	status, err = watchJob(job.Id, status.GetVersion(), 60*1000, sc)
+	if err != nil {
+		t.Fatalf("Unexpected error watching job: %v", err)
+	}
+	if len(status.TaskStatus) != 1 || status.TaskStatus["task2"] != scoot.Status_IN_PROGRESS {
+		t.Errorf("Expected only task2 to be returned as in progress, got %v", status.TaskStatus)
+	}
+	if status.Status != scoot.Status_IN_PROGRESS {
+		t.Errorf("Expected job to be in progress, got %v", status.Status)
+	}
+
+	prevVersion := status.GetVersion()
+	status, err = watchJob(job.Id, prevVersion, 10, sc)
+	if err != nil {
+		t.Fatalf("Unexpected error watching job: %v", err)
+	}
+	if len(status.TaskStatus) != 0 || status.GetVersion() != prevVersion {
+		t.Errorf("Expected no changes once the watch timed out, got version %v: %v",
+			status.GetVersion(), status.TaskStatus)
+	}
+}
+
+func Test_WatchJob_InvalidRequest(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+
+	if _, err := watchJob("", 0, 0, sc); err == nil {
+		t.Errorf("Expected an error watching a job without an id")
+	}
+	if _, err := watchJob("job1", -1, 0, sc); err == nil {
+		t.Errorf("Expected an error watching a negative version")
+	}
+	if _, err := watchJob("job1", 0, -1, sc); err == nil {
+		t.Errorf("Expected an error watching with a negative timeout")
+	}
+}
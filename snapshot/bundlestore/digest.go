@@ -0,0 +1,72 @@
+package bundlestore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// Bundles are uploaded to and downloaded from Server with their sha256 in
+// DigestHeader, sent as a trailer so bundles can be streamed. The receiver
+// checks it, so a bundle corrupted or truncated on the way isn't used.
+const DigestHeader = "X-Scoot-Bundle-Sha256"
+
+// DigestMismatchError is returned when a bundle's contents don't match its digest.
+type DigestMismatchError struct {
+	Name     string
+	Expected string
+	Actual   string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("bundle %s is corrupt: expected sha256 %s, got %s", e.Name, e.Expected, e.Actual)
+}
+
+// The expected digest of a bundle whose digest should have been sent but wasn't
+const missingDigest = "(missing)"
+
+// digestReader hashes what's read through it, and calls atEOF with the digest
+// when it gets to the end. An error from atEOF is returned instead of io.EOF.
+type digestReader struct {
+	r     io.Reader
+	h     hash.Hash
+	atEOF func(digest string) error
+}
+
+func newDigestReader(r io.Reader, atEOF func(digest string) error) *digestReader {
+	return &digestReader{r: r, h: sha256.New(), atEOF: atEOF}
+}
+
+func (d *digestReader) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	d.h.Write(p[:n])
+	if err == io.EOF {
+		if checkErr := d.atEOF(d.digest()); checkErr != nil {
+			return n, checkErr
+		}
+	}
+	return n, err
+}
+
+func (d *digestReader) digest() string {
+	return hex.EncodeToString(d.h.Sum(nil))
+}
+
+// checkDigest returns a func for digestReader that checks the bundle's digest
+// is the one returned by expected, if it returns one.
+func checkDigest(name string, expected func() string) func(string) error {
+	return func(digest string) error {
+		if e := expected(); e != "" && e != digest {
+			return &DigestMismatchError{Name: name, Expected: e, Actual: digest}
+		}
+		return nil
+	}
+}
+
+// digestReadCloser is a digestReader that closes the reader it reads.
+type digestReadCloser struct {
+	*digestReader
+	io.Closer
+}
@@ -0,0 +1,150 @@
+package bundlestore
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/scootdev/scoot/common/stats"
+)
+
+// Reads data, and then fails
+type failingReader struct {
+	data io.Reader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection dropped")
+	}
+	return n, err
+}
+
+func TestUploadDigest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, _ := MakeFileStore(dir)
+	mux := http.NewServeMux()
+	mux.Handle("/bundle/", MakeServer(store, stats.NilStatsReceiver()))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	rootUri := server.URL + "/bundle/"
+	httpStore := MakeHTTPStore(rootUri)
+
+	// a good upload is stored, and read back with its digest checked
+	if err := httpStore.Write(bundleName(1), bytes.NewBufferString("bundle")); err != nil {
+		t.Fatal(err)
+	}
+	r, err := httpStore.OpenForRead(bundleName(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadAll(r); err != nil || string(data) != "bundle" {
+		t.Fatalf("Expected to read the bundle, got %q %v", data, err)
+	}
+	r.Close()
+
+	// an upload that doesn't match its digest isn't stored
+	req, _ := http.NewRequest("POST", rootUri+bundleName(2), bytes.NewBufferString("bundle"))
+	req.Header.Set(DigestHeader, "0123")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected a corrupt upload to be rejected, got %v", resp.Status)
+	}
+
+	// nor is one that's cut off
+	if err := httpStore.Write(bundleName(3), &failingReader{bytes.NewBufferString("bundle")}); err == nil {
+		t.Fatalf("Expected a dropped upload to fail")
+	}
+
+	for _, name := range []string{bundleName(2), bundleName(3)} {
+		if ok, _ := store.Exists(name); ok {
+			t.Fatalf("Expected %v not to be stored", name)
+		}
+	}
+	if fis, _ := ioutil.ReadDir(dir); len(fis) != 1 {
+		t.Fatalf("Expected only the good bundle to be in the store, got %v", fis)
+	}
+}
+
+func TestDownloadDigest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Trailer", DigestHeader)
+		io.WriteString(w, "truncated")
+		w.Header().Set(DigestHeader, "0123")
+	}))
+	defer server.Close()
+
+	r, err := MakeHTTPStore(server.URL).OpenForRead(bundleName(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := ioutil.ReadAll(r); err == nil {
+		t.Fatalf("Expected reading a corrupt bundle to fail")
+	} else if _, ok := err.(*DigestMismatchError); !ok {
+		t.Fatalf("Expected a DigestMismatchError, got %v", err)
+	}
+}
+
+// A FakeStore whose bundles fail part way through being read
+type failingStore struct {
+	*FakeStore
+}
+
+func (f *failingStore) OpenForRead(name string) (io.ReadCloser, error) {
+	r, err := f.FakeStore.OpenForRead(name)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(&failingReader{r}), nil
+}
+
+func TestDownloadFailsPartWay(t *testing.T) {
+	store := &failingStore{&FakeStore{files: map[string][]byte{bundleName(1): []byte("bundle")}}}
+	mux := http.NewServeMux()
+	mux.Handle("/bundle/", MakeServer(store, stats.NilStatsReceiver()))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r, err := MakeHTTPStore(server.URL + "/bundle/").OpenForRead(bundleName(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := ioutil.ReadAll(r); err == nil {
+		t.Fatalf("Expected reading a bundle the server failed to send to fail")
+	}
+}
+
+func TestDownloadMissingDigest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Trailer", DigestHeader)
+		io.WriteString(w, "truncated")
+	}))
+	defer server.Close()
+
+	r, err := MakeHTTPStore(server.URL).OpenForRead(bundleName(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := ioutil.ReadAll(r); err == nil {
+		t.Fatalf("Expected reading a bundle without its digest to fail")
+	} else if _, ok := err.(*DigestMismatchError); !ok {
+		t.Fatalf("Expected a DigestMismatchError, got %v", err)
+	}
+}
@@ -3,10 +3,12 @@ package bundlestore
 import (
 	"errors"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/scootdev/scoot/os/temp"
 )
@@ -24,13 +26,22 @@ func MakeFileStore(dir string) (*FileStore, error) {
 	return &FileStore{dir}, nil
 }
 
+// FileStore keeps bundles as files in a dir. A bundle's modification time is
+// when it was last written or read, so that unused bundles can be collected.
 type FileStore struct {
 	bundleDir string
 }
 
 func (s *FileStore) OpenForRead(name string) (io.ReadCloser, error) {
 	bundlePath := filepath.Join(s.bundleDir, name)
-	return os.Open(bundlePath)
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Touch(name); err != nil {
+		log.Printf("Couldn't record access to bundle %s: %v", name, err)
+	}
+	return f, nil
 }
 
 func (s *FileStore) Exists(name string) (bool, error) {
@@ -53,14 +64,59 @@ func (s *FileStore) Write(name string, data io.Reader) error {
 	}
 	bundlePath := filepath.Join(s.bundleDir, name)
 	log.Printf("Writing %s to %s", name, bundlePath)
-	f, err := os.Create(bundlePath)
+
+	// Write to a temp file and rename it, so that a failed write (e.g. a
+	// dropped upload) doesn't leave a truncated bundle behind.
+	f, err := ioutil.TempFile(s.bundleDir, "."+name+".")
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-
-	if _, err := io.Copy(f, data); err != nil {
+	defer os.Remove(f.Name())
+	_, err = io.Copy(f, data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
 		return err
 	}
-	return nil
+	return os.Rename(f.Name(), bundlePath)
+}
+
+func (s *FileStore) List() ([]BundleInfo, error) {
+	fis, err := ioutil.ReadDir(s.bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	bundles := []BundleInfo{}
+	for _, fi := range fis {
+		// bundles being written are in dot files
+		if fi.Mode().IsRegular() && !strings.HasPrefix(fi.Name(), ".") {
+			bundles = append(bundles, BundleInfo{Name: fi.Name(), Size: fi.Size(), LastAccess: fi.ModTime()})
+		}
+	}
+	return bundles, nil
+}
+
+func (s *FileStore) Delete(name string) error {
+	if strings.Contains(name, "/") {
+		return errors.New("'/' not allowed in name when deleting bundles.")
+	}
+	log.Printf("Deleting %s from %s", name, s.bundleDir)
+	err := os.Remove(filepath.Join(s.bundleDir, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Touch records that the bundle was just accessed.
+func (s *FileStore) Touch(name string) error {
+	if strings.Contains(name, "/") {
+		return errors.New("'/' not allowed in name when touching bundles.")
+	}
+	now := time.Now()
+	return os.Chtimes(filepath.Join(s.bundleDir, name), now, now)
 }
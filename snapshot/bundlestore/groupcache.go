@@ -47,6 +47,8 @@ func MakeGroupcacheStore(underlying Store, cfg *GroupcacheConfig, stat stats.Sta
 			if err != nil {
 				return err
 			}
+			defer reader.Close()
+			// a corrupt bundle fails ReadAll, so it isn't cached
 			data, err := ioutil.ReadAll(reader)
 			if err != nil {
 				return err
@@ -131,6 +133,13 @@ func (s *groupcacheStore) OpenForRead(name string) (io.ReadCloser, error) {
 		return nil, err
 	}
 	s.stat.Counter("readOkCounter").Inc(1)
+	// reads served from the cache don't reach the underlying store, so tell it
+	// the bundle is still in use
+	if t, ok := s.underlying.(AccessTracker); ok {
+		if err := t.Touch(name); err != nil {
+			log.Printf("Couldn't record access to bundle %s: %v", name, err)
+		}
+	}
 	return ioutil.NopCloser(bytes.NewReader(data)), nil
 }
 
@@ -162,3 +171,14 @@ func (s *groupcacheStore) Write(name string, data io.Reader) error {
 	s.stat.Counter("writeOkCounter").Inc(1)
 	return nil
 }
+
+func (s *groupcacheStore) List() ([]BundleInfo, error) {
+	return s.underlying.List()
+}
+
+// Delete deletes the bundle from the underlying store. Bundles are immutable,
+// so it's fine that copies in the cache live on until they're evicted.
+func (s *groupcacheStore) Delete(name string) error {
+	s.stat.Counter("deleteCounter").Inc(1)
+	return s.underlying.Delete(name)
+}
@@ -1,6 +1,7 @@
 package bundlestore
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -10,6 +11,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/scootdev/scoot/common/dialer"
 )
 
 func MakeHTTPStore(rootURI string) Store {
@@ -17,12 +20,20 @@ func MakeHTTPStore(rootURI string) Store {
 		rootURI = rootURI + "/"
 	}
 	client := &http.Client{Timeout: 30 * time.Second}
-	return &httpStore{rootURI, client}
+	return &httpStore{rootURI, client, AdminTokenFromEnv()}
 }
 
 type httpStore struct {
 	rootURI string
 	client  *http.Client
+	// sent with deletes, which the server only allows with the admin token
+	adminToken AdminToken
+}
+
+func setAdminToken(req *http.Request, token AdminToken) {
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+string(token))
+	}
 }
 
 func (s *httpStore) OpenForRead(name string) (io.ReadCloser, error) {
@@ -36,7 +47,16 @@ func (s *httpStore) OpenForRead(name string) (io.ReadCloser, error) {
 	log.Printf("Fetch result %s %v", uri, resp.StatusCode)
 
 	if resp.StatusCode == http.StatusOK {
-		return resp.Body, nil
+		// the digest is checked once the bundle has been read.  A server that
+		// announced it but didn't send it failed part way through.
+		_, announced := resp.Trailer[http.CanonicalHeaderKey(DigestHeader)]
+		expected := func() string {
+			if digest := resp.Trailer.Get(DigestHeader); digest != "" || !announced {
+				return digest
+			}
+			return missingDigest
+		}
+		return &digestReadCloser{newDigestReader(resp.Body, checkDigest(name, expected)), resp.Body}, nil
 	}
 
 	resp.Body.Close()
@@ -66,7 +86,19 @@ func (s *httpStore) Write(name string, data io.Reader) error {
 	}
 	uri := s.rootURI + name
 	log.Printf("Posting %s", uri)
-	resp, err := s.client.Post(uri, "text/plain", data)
+	req, err := http.NewRequest("POST", uri, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	// send the digest as a trailer once we've sent the bundle
+	req.Trailer = http.Header{DigestHeader: nil}
+	req.Body = ioutil.NopCloser(newDigestReader(data, func(digest string) error {
+		req.Trailer.Set(DigestHeader, digest)
+		return nil
+	}))
+	req.ContentLength = -1
+	resp, err := s.client.Do(req)
 	if err == nil {
 		defer resp.Body.Close()
 		if resp.StatusCode != http.StatusOK {
@@ -77,3 +109,86 @@ func (s *httpStore) Write(name string, data io.Reader) error {
 	log.Printf("Posted %s, err: %v", uri, err)
 	return err
 }
+
+func (s *httpStore) List() ([]BundleInfo, error) {
+	log.Printf("Listing %s", s.rootURI)
+	resp, err := s.client.Get(s.rootURI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return nil, errors.New(resp.Status + ": " + string(data))
+	}
+	var bundles []BundleInfo
+	if err := json.NewDecoder(resp.Body).Decode(&bundles); err != nil {
+		return nil, fmt.Errorf("could not decode bundle list: %v", err)
+	}
+	return bundles, nil
+}
+
+func (s *httpStore) Delete(name string) error {
+	if strings.Contains(name, "/") {
+		return errors.New("'/' not allowed in name when deleting bundles.")
+	}
+	uri := s.rootURI + name
+	log.Printf("Deleting %s", uri)
+	req, err := http.NewRequest("DELETE", uri, nil)
+	if err != nil {
+		return err
+	}
+	setAdminToken(req, s.adminToken)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return errors.New(resp.Status + ": " + string(data))
+	}
+	return nil
+}
+
+// MakeHTTPPinner makes a Pinner that pins bundles through the admin endpoint
+// of the server at the URI resolver resolves to, resolved on each Pin.  It
+// sends the AdminToken from the environment.
+func MakeHTTPPinner(resolver dialer.Resolver) Pinner {
+	return &httpPinner{resolver, &http.Client{Timeout: 30 * time.Second}, AdminTokenFromEnv()}
+}
+
+type httpPinner struct {
+	resolver   dialer.Resolver
+	client     *http.Client
+	adminToken AdminToken
+}
+
+func (p *httpPinner) Pin(name string, d time.Duration) error {
+	rootURI, err := p.resolver.Resolve()
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(rootURI, "/") {
+		rootURI = rootURI + "/"
+	}
+	uri := rootURI + adminPinPath + name
+	if d > 0 {
+		uri += "?for=" + d.String()
+	}
+	req, err := http.NewRequest("POST", uri, nil)
+	if err != nil {
+		return err
+	}
+	setAdminToken(req, p.adminToken)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return errors.New(resp.Status + ": " + string(data))
+	}
+	return nil
+}
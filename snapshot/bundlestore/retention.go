@@ -0,0 +1,270 @@
+package bundlestore
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scootdev/scoot/common/stats"
+)
+
+// Retention: deleting bundles that haven't been used in a while, so the store
+// doesn't grow forever.
+
+// ErrGCDisabled is returned by Collect when there's no TTL to collect with.
+var ErrGCDisabled = errors.New("bundle GC is disabled, no TTL is configured")
+
+// AccessTracker is implemented by Stores that track when bundles were last
+// accessed. Stores that serve reads without reading from the store they wrap
+// (like the groupcache store) Touch it, so the bundles they serve aren't collected.
+type AccessTracker interface {
+	Touch(name string) error
+}
+
+// RetentionPolicy says which bundles to keep.
+type RetentionPolicy struct {
+	// Bundles that haven't been accessed in TTL are collected, unless they're
+	// pinned. Zero means bundles are kept forever.
+	TTL time.Duration
+
+	// If non-zero, collect this often in the background.
+	Interval time.Duration
+}
+
+// GCResult describes a collection, or a preview of one.
+type GCResult struct {
+	// Whether this is a preview, in which case nothing was deleted.
+	DryRun bool `json:"dryRun"`
+	// Bundles that were (or would be) deleted
+	Deleted      []BundleInfo `json:"deleted"`
+	DeletedBytes int64        `json:"deletedBytes"`
+	// Bundles that would have been deleted, but are pinned
+	Pinned []string `json:"pinned"`
+	// Number of bundles kept because they were accessed recently
+	Kept int `json:"kept"`
+}
+
+// Pinner keeps bundles from being collected.
+type Pinner interface {
+	// Pin keeps the bundle from being collected for d, or until it's unpinned
+	// if d is zero. A longer pin of the bundle isn't shortened.
+	Pin(name string, d time.Duration) error
+}
+
+// Collector deletes bundles from a Store according to a RetentionPolicy.
+type Collector struct {
+	store  Store
+	policy RetentionPolicy
+	stat   stats.StatsReceiver
+
+	// Only one collection runs at a time.
+	gcMu sync.Mutex
+}
+
+// NewCollector creates a Collector, which collects in the background if the
+// policy has an Interval.
+func NewCollector(store Store, policy RetentionPolicy, stat stats.StatsReceiver) *Collector {
+	c := &Collector{
+		store:  store,
+		policy: policy,
+		stat:   stat.Scope("bundlestoreGC"),
+	}
+	if policy.TTL > 0 && policy.Interval > 0 {
+		go c.loop()
+	}
+	return c
+}
+
+// Pins are kept in the store, so every server sharing it sees them and they
+// survive restarts. A pin is an empty record named pin.<bundle>.<until>, where
+// until is in unix nanoseconds and 0 is forever; a bundle is pinned until the
+// latest of its records. Records are only listed, never read, so they work
+// behind stores that cache reads.
+const pinPrefix = "pin."
+
+func pinRecordName(name string, until time.Time) string {
+	nanos := int64(0)
+	if !until.IsZero() {
+		nanos = until.UnixNano()
+	}
+	return pinPrefix + name + "." + strconv.FormatInt(nanos, 10)
+}
+
+// Parses a pin record's name into the bundle it pins and when it's pinned until.
+func parsePinRecord(record string) (name string, until time.Time, ok bool) {
+	if !strings.HasPrefix(record, pinPrefix) {
+		return "", time.Time{}, false
+	}
+	record = strings.TrimPrefix(record, pinPrefix)
+	i := strings.LastIndex(record, ".")
+	if i < 0 {
+		return "", time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(record[i+1:], 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	if nanos != 0 {
+		until = time.Unix(0, nanos)
+	}
+	return record[:i], until, true
+}
+
+// Splits what a store lists into its bundles and its pin records.
+func splitPinRecords(infos []BundleInfo) (bundles []BundleInfo, records []BundleInfo) {
+	bundles = []BundleInfo{}
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name, pinPrefix) {
+			records = append(records, info)
+		} else {
+			bundles = append(bundles, info)
+		}
+	}
+	return bundles, records
+}
+
+// Pin keeps the bundle from being collected for d, or until it's unpinned if
+// d is zero. Holders of pins that should end with them, e.g. while a job runs,
+// should pin for a while and renew it, so the pin lapses if the holder dies.
+// The bundle's new record replaces its earlier ones, so renewing a pin doesn't
+// grow the store.
+func (c *Collector) Pin(name string, d time.Duration) error {
+	until := time.Time{}
+	if d > 0 {
+		until = time.Now().Add(d)
+	}
+	c.stat.Counter("pinCounter").Inc(1)
+
+	infos, err := c.store.List()
+	if err != nil {
+		return err
+	}
+	_, records := splitPinRecords(infos)
+	var earlier []string
+	for _, r := range records {
+		pinned, prevUntil, ok := parsePinRecord(r.Name)
+		if !ok || pinned != name {
+			continue
+		}
+		if prevUntil.IsZero() || (!until.IsZero() && !prevUntil.Before(until)) {
+			// a longer pin is already there
+			return nil
+		}
+		earlier = append(earlier, r.Name)
+	}
+
+	if err := c.store.Write(pinRecordName(name, until), bytes.NewReader(nil)); err != nil {
+		return err
+	}
+	for _, r := range earlier {
+		if err := c.store.Delete(r); err != nil {
+			log.Printf("Couldn't delete replaced pin %s: %v", r, err)
+		}
+	}
+	return nil
+}
+
+// Unpin lets the bundle be collected again, removing all of its pins.
+func (c *Collector) Unpin(name string) error {
+	infos, err := c.store.List()
+	if err != nil {
+		return err
+	}
+	_, records := splitPinRecords(infos)
+	for _, r := range records {
+		if pinned, _, ok := parsePinRecord(r.Name); ok && pinned == name {
+			if err := c.store.Delete(r.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Reads which bundles the records pin. Unless dryRun, records that have
+// expired are deleted.
+func (c *Collector) readPins(records []BundleInfo, now time.Time, dryRun bool) map[string]bool {
+	pinned := make(map[string]bool)
+	for _, r := range records {
+		name, until, ok := parsePinRecord(r.Name)
+		if !ok {
+			continue
+		}
+		if until.IsZero() || now.Before(until) {
+			pinned[name] = true
+		} else if !dryRun {
+			if err := c.store.Delete(r.Name); err != nil {
+				log.Printf("Couldn't delete expired pin %s: %v", r.Name, err)
+			}
+		}
+	}
+	return pinned
+}
+
+// Collect deletes the bundles that haven't been accessed within the TTL and
+// aren't pinned. If dryRun, it only reports what it would delete.
+func (c *Collector) Collect(dryRun bool) (*GCResult, error) {
+	if c.policy.TTL <= 0 {
+		return nil, ErrGCDisabled
+	}
+	c.gcMu.Lock()
+	defer c.gcMu.Unlock()
+	if !dryRun {
+		defer c.stat.Latency("gcLatency_ms").Time().Stop()
+		c.stat.Counter("gcCounter").Inc(1)
+	}
+
+	infos, err := c.store.List()
+	if err != nil {
+		c.stat.Counter("gcErrorCounter").Inc(1)
+		return nil, err
+	}
+	now := time.Now()
+	bundles, records := splitPinRecords(infos)
+	pins := c.readPins(records, now, dryRun)
+	result := &GCResult{DryRun: dryRun, Deleted: []BundleInfo{}, Pinned: []string{}}
+	size := int64(0)
+	for _, b := range bundles {
+		size += b.Size
+		if now.Sub(b.LastAccess) < c.policy.TTL {
+			result.Kept++
+			continue
+		}
+		if pins[b.Name] {
+			result.Pinned = append(result.Pinned, b.Name)
+			continue
+		}
+		if !dryRun {
+			if err := c.store.Delete(b.Name); err != nil {
+				log.Printf("Couldn't collect bundle %s: %v", b.Name, err)
+				c.stat.Counter("gcErrorCounter").Inc(1)
+				continue
+			}
+		}
+		result.Deleted = append(result.Deleted, b)
+		result.DeletedBytes += b.Size
+	}
+
+	if !dryRun {
+		log.Printf("Collected %d bundles (%d bytes), kept %d, %d pinned",
+			len(result.Deleted), result.DeletedBytes, result.Kept, len(result.Pinned))
+		c.stat.Counter("gcDeletedCounter").Inc(int64(len(result.Deleted)))
+		c.stat.Counter("gcDeletedBytesCounter").Inc(result.DeletedBytes)
+		c.stat.Gauge("bundlesGauge").Update(int64(len(bundles) - len(result.Deleted)))
+		c.stat.Gauge("bundleBytesGauge").Update(size - result.DeletedBytes)
+		c.stat.Gauge("pinnedGauge").Update(int64(len(result.Pinned)))
+	}
+	return result, nil
+}
+
+func (c *Collector) loop() {
+	for range time.Tick(c.policy.Interval) {
+		if _, err := c.Collect(false); err != nil {
+			log.Printf("Error collecting bundles: %v", err)
+		}
+	}
+}
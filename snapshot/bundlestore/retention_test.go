@@ -0,0 +1,238 @@
+package bundlestore
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/scootdev/scoot/common/dialer"
+	"github.com/scootdev/scoot/common/stats"
+)
+
+func bundleName(n int) string {
+	return "bs-" + string(bytes.Repeat([]byte{byte('0' + n)}, 40)) + ".bundle"
+}
+
+// Makes a FileStore with bundles 1-4, all last accessed a day ago
+func setupRetention(t *testing.T) (*FileStore, string) {
+	dir, err := ioutil.TempDir("", "bundles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, _ := MakeFileStore(dir)
+	dayAgo := time.Now().Add(-24 * time.Hour)
+	for i := 1; i <= 4; i++ {
+		if err := store.Write(bundleName(i), bytes.NewBufferString("data")); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(filepath.Join(dir, bundleName(i)), dayAgo, dayAgo)
+	}
+	return store, dir
+}
+
+func names(bundles []BundleInfo) []string {
+	result := []string{}
+	for _, b := range bundles {
+		result = append(result, b.Name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestCollect(t *testing.T) {
+	store, dir := setupRetention(t)
+	defer os.RemoveAll(dir)
+	gc := NewCollector(store, RetentionPolicy{TTL: time.Hour}, stats.NilStatsReceiver())
+
+	// 1 is read, 2 is pinned, 3 is rewritten and 4 is left alone
+	r, err := store.OpenForRead(bundleName(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	if err := gc.Pin(bundleName(2), 0); err != nil {
+		t.Fatal(err)
+	}
+	store.Write(bundleName(3), bytes.NewBufferString("data"))
+
+	preview, err := gc.Collect(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &GCResult{
+		DryRun:       true,
+		Deleted:      preview.Deleted,
+		DeletedBytes: 4,
+		Pinned:       []string{bundleName(2)},
+		Kept:         2,
+	}
+	if !reflect.DeepEqual(preview, expected) || !reflect.DeepEqual(names(preview.Deleted), []string{bundleName(4)}) {
+		t.Fatalf("Expected %+v, got %+v", expected, preview)
+	}
+	if ok, _ := store.Exists(bundleName(4)); !ok {
+		t.Fatalf("Expected a preview not to delete anything")
+	}
+
+	// pins are kept in the store, so another collector sees them
+	if other, _ := NewCollector(store, RetentionPolicy{TTL: time.Hour}, stats.NilStatsReceiver()).Collect(true); !reflect.DeepEqual(other, preview) {
+		t.Fatalf("Expected another collector to see the pin, got %+v", other)
+	}
+
+	if err := gc.Unpin(bundleName(2)); err != nil {
+		t.Fatal(err)
+	}
+	result, err := gc.Collect(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted := names(result.Deleted); !reflect.DeepEqual(deleted, []string{bundleName(2), bundleName(4)}) {
+		t.Fatalf("Expected the unpinned bundles to be deleted, got %v", deleted)
+	}
+	bundles, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining := names(bundles); !reflect.DeepEqual(remaining, []string{bundleName(1), bundleName(3)}) {
+		t.Fatalf("Expected the recently used bundles to remain, got %v", remaining)
+	}
+
+	// pins expire
+	store.Delete(bundleName(1))
+	if err := gc.Pin(bundleName(3), time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	dayAgo := time.Now().Add(-24 * time.Hour)
+	os.Chtimes(filepath.Join(dir, bundleName(3)), dayAgo, dayAgo)
+	time.Sleep(2 * time.Millisecond)
+	if result, _ := gc.Collect(false); len(result.Deleted) != 1 {
+		t.Fatalf("Expected the bundle to be deleted once its pin expired, got %+v", result)
+	}
+	if infos, _ := store.List(); len(infos) != 0 {
+		t.Fatalf("Expected the expired pin to be deleted, got %v", infos)
+	}
+
+	if _, err := NewCollector(store, RetentionPolicy{}, stats.NilStatsReceiver()).Collect(true); err != ErrGCDisabled {
+		t.Fatalf("Expected GC without a TTL to be disabled, got %v", err)
+	}
+}
+
+func TestPin_ReplacesEarlierPins(t *testing.T) {
+	store, dir := setupRetention(t)
+	defer os.RemoveAll(dir)
+	// pins are renewed without a TTL, where nothing collects expired ones
+	gc := NewCollector(store, RetentionPolicy{}, stats.NilStatsReceiver())
+	pins := func() []string {
+		infos, err := store.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, records := splitPinRecords(infos)
+		return names(records)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := gc.Pin(bundleName(1), time.Duration(i+1)*time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if records := pins(); len(records) != 1 {
+		t.Fatalf("Expected renewing a pin to replace it, got %v", records)
+	}
+	if _, until, _ := parsePinRecord(pins()[0]); until.Sub(time.Now()) < 2*time.Hour {
+		t.Fatalf("Expected the longest pin to be kept, got %v", until)
+	}
+
+	// a shorter pin doesn't shorten a longer one, a pin forever replaces it
+	if err := gc.Pin(bundleName(1), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := gc.Pin(bundleName(2), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := gc.Pin(bundleName(1), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := gc.Pin(bundleName(1), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	records := pins()
+	if len(records) != 2 || records[0] != pinRecordName(bundleName(1), time.Time{}) {
+		t.Fatalf("Expected the pin forever to be kept, got %v", records)
+	}
+}
+
+func TestServerGC(t *testing.T) {
+	store, dir := setupRetention(t)
+	defer os.RemoveAll(dir)
+	gc := NewCollector(store, RetentionPolicy{TTL: time.Hour}, stats.NilStatsReceiver())
+	mux := http.NewServeMux()
+	mux.Handle("/bundle/", MakeServerWithCollector(store, gc, "secret", stats.NilStatsReceiver()))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	rootUri := server.URL + "/bundle/"
+
+	token := "secret"
+	do := func(method, path string, expectedCode int) *http.Response {
+		req, _ := http.NewRequest(method, rootUri+path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != expectedCode {
+			t.Fatalf("%s %s: expected %v, got %v", method, path, expectedCode, resp.Status)
+		}
+		return resp
+	}
+	gcResult := func(method string) *GCResult {
+		resp := do(method, "admin/gc", http.StatusOK)
+		defer resp.Body.Close()
+		result := &GCResult{}
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	// admin requests need the token
+	token = "wrong"
+	do("GET", "admin/gc", http.StatusForbidden).Body.Close()
+	do("POST", "admin/pin/"+bundleName(1), http.StatusForbidden).Body.Close()
+	do("DELETE", bundleName(1), http.StatusForbidden).Body.Close()
+	token = "secret"
+
+	// the clients send the token from the environment
+	os.Setenv(AdminTokenEnvVar, "secret")
+	defer os.Unsetenv(AdminTokenEnvVar)
+	if err := MakeHTTPPinner(dialer.NewConstantResolver(rootUri)).Pin(bundleName(1), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	do("POST", "admin/pin/"+bundleName(2)+"?for=soon", http.StatusBadRequest).Body.Close()
+	do("POST", "admin/pin/not-a-bundle", http.StatusBadRequest).Body.Close()
+	if result := gcResult("GET"); !result.DryRun || len(result.Deleted) != 3 || len(result.Pinned) != 1 {
+		t.Fatalf("Expected a preview deleting 3 bundles, got %+v", result)
+	}
+	if result := gcResult("POST"); result.DryRun || len(result.Deleted) != 3 {
+		t.Fatalf("Expected a collection deleting 3 bundles, got %+v", result)
+	}
+
+	// List and Delete through an httpStore
+	httpStore := MakeHTTPStore(rootUri)
+	if bundles, err := httpStore.List(); err != nil || !reflect.DeepEqual(names(bundles), []string{bundleName(1)}) {
+		t.Fatalf("Expected only the pinned bundle to remain, got %v %v", bundles, err)
+	}
+	if err := httpStore.Delete(bundleName(1)); err != nil {
+		t.Fatal(err)
+	}
+	if bundles, err := httpStore.List(); err != nil || len(bundles) != 0 {
+		t.Fatalf("Expected no bundles, got %v %v", bundles, err)
+	}
+}
@@ -0,0 +1,313 @@
+package bundlestore
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Config configures a Store that keeps bundles in a bucket of an object
+// store speaking the S3 protocol (S3 itself, or a compatible one like minio or ceph).
+type S3Config struct {
+	// Where the object store is, e.g. https://s3.us-west-2.amazonaws.com.
+	// Objects are addressed path-style, as <Endpoint>/<Bucket>/<key>.
+	Endpoint string
+	Region   string
+	Bucket   string
+	// Prepended to bundle names to make their keys, e.g. "bundles/"
+	Prefix string
+
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// Bundles bigger than this are uploaded in parts of this size. Defaults to DefaultS3PartSize.
+	PartSize int64
+}
+
+// DefaultS3PartSize is the size of the parts large bundles are uploaded in.
+const DefaultS3PartSize = 16 * 1024 * 1024
+
+// S3 requires parts but the last to be at least 5MiB
+const s3MinPartSize = 5 * 1024 * 1024
+
+// Object metadata holding the sha256 of a bundle uploaded in one piece, checked when it's read
+const s3DigestHeader = "X-Amz-Meta-Sha256"
+
+// MakeS3Store makes a Store that keeps bundles in the object store described by cfg.
+//
+// The object store doesn't record reads, so the last access of a bundle is
+// when it was written. Prefer the bucket's lifecycle rules to a Collector.
+func MakeS3Store(cfg *S3Config) (Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.Region == "" {
+		return nil, errors.New("an S3 store needs an endpoint, region and bucket")
+	}
+	s := &s3Store{cfg: *cfg, now: time.Now}
+	s.cfg.Endpoint = strings.TrimSuffix(s.cfg.Endpoint, "/")
+	if s.cfg.PartSize == 0 {
+		s.cfg.PartSize = DefaultS3PartSize
+	}
+	if s.cfg.PartSize < s3MinPartSize {
+		return nil, fmt.Errorf("S3 part size must be at least %d, was %d", s3MinPartSize, s.cfg.PartSize)
+	}
+	// bundles are streamed, so limit how long to wait for a response to start, not to finish
+	s.client = &http.Client{Transport: &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		ResponseHeaderTimeout: 30 * time.Second,
+	}}
+	return s, nil
+}
+
+type s3Store struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// do sends a signed request for the key (or the bucket, if key is ""), and
+// returns the response if it has one of the ok statuses.
+func (s *s3Store) do(method string, key string, query string, body []byte, header http.Header, ok ...int) (*http.Response, error) {
+	path := "/" + s.cfg.Bucket + "/" + key
+	uri := s.cfg.Endpoint + awsURIEscape(path, false)
+	if query != "" {
+		uri += "?" + query
+	}
+	req, err := http.NewRequest(method, uri, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	signV4(req, hashHex(body), s.cfg.AccessKeyID, s.cfg.SecretAccessKey, s.cfg.Region, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range ok {
+		if resp.StatusCode == code {
+			return resp, nil
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, os.ErrNotExist
+	}
+	return nil, s3ErrorFrom(method, path, resp)
+}
+
+// s3Error is the error document S3 responds to failed requests with
+type s3Error struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string
+	Message string
+}
+
+func s3ErrorFrom(method string, path string, resp *http.Response) error {
+	data, _ := ioutil.ReadAll(resp.Body)
+	var e s3Error
+	if err := xml.Unmarshal(data, &e); err == nil && e.Code != "" {
+		return fmt.Errorf("%s %s: %s: %s: %s", method, path, resp.Status, e.Code, e.Message)
+	}
+	return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, data)
+}
+
+func (s *s3Store) key(name string) (string, error) {
+	if strings.Contains(name, "/") {
+		return "", errors.New("'/' not allowed in bundle names.")
+	}
+	return s.cfg.Prefix + name, nil
+}
+
+func (s *s3Store) OpenForRead(name string) (io.ReadCloser, error) {
+	key, err := s.key(name)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do("GET", key, "", nil, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	expected := func() string { return resp.Header.Get(s3DigestHeader) }
+	return &digestReadCloser{newDigestReader(resp.Body, checkDigest(name, expected)), resp.Body}, nil
+}
+
+func (s *s3Store) Exists(name string) (bool, error) {
+	key, err := s.key(name)
+	if err != nil {
+		return false, err
+	}
+	resp, err := s.do("HEAD", key, "", nil, nil, http.StatusOK)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+func (s *s3Store) Write(name string, data io.Reader) error {
+	key, err := s.key(name)
+	if err != nil {
+		return err
+	}
+	log.Printf("Writing %s to %s/%s", name, s.cfg.Endpoint, s.cfg.Bucket)
+	buf := make([]byte, s.cfg.PartSize)
+	n, err := io.ReadFull(data, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		header := http.Header{s3DigestHeader: {hashHex(buf[:n])}}
+		resp, err := s.do("PUT", key, "", buf[:n], header, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return s.writeMultipart(key, buf, data)
+}
+
+type initiateMultipartUploadResult struct {
+	UploadId string
+}
+
+type completedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+// writeMultipart uploads buf, which is full, and the rest of data in parts
+func (s *s3Store) writeMultipart(key string, buf []byte, data io.Reader) (err error) {
+	resp, err := s.do("POST", key, "uploads", nil, nil, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	var initiated initiateMultipartUploadResult
+	err = xml.NewDecoder(resp.Body).Decode(&initiated)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("could not start uploading %s: %v", key, err)
+	}
+	uploadQuery := "uploadId=" + awsURIEscape(initiated.UploadId, true)
+	defer func() {
+		if err != nil {
+			if resp, abortErr := s.do("DELETE", key, uploadQuery, nil, nil, http.StatusNoContent, http.StatusOK); abortErr == nil {
+				resp.Body.Close()
+			} else {
+				log.Printf("Couldn't abort uploading %s: %v", key, abortErr)
+			}
+		}
+	}()
+
+	complete := completeMultipartUpload{}
+	n := len(buf)
+	for partNumber := 1; n > 0; partNumber++ {
+		query := "partNumber=" + strconv.Itoa(partNumber) + "&" + uploadQuery
+		resp, err := s.do("PUT", key, query, buf[:n], nil, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		complete.Parts = append(complete.Parts, completedPart{partNumber, resp.Header.Get("ETag")})
+
+		n, err = io.ReadFull(data, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+	}
+
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+	resp, err = s.do("POST", key, uploadQuery, body, nil, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// completing can fail after the response has started, with an error document
+	result, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var e s3Error
+	if xml.Unmarshal(result, &e) == nil {
+		return fmt.Errorf("could not complete uploading %s: %s: %s", key, e.Code, e.Message)
+	}
+	return nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string
+		Size         int64
+		LastModified time.Time
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+func (s *s3Store) List() ([]BundleInfo, error) {
+	bundles := []BundleInfo{}
+	token := ""
+	for {
+		query := "list-type=2&prefix=" + awsURIEscape(s.cfg.Prefix, true)
+		if token != "" {
+			query += "&continuation-token=" + awsURIEscape(token, true)
+		}
+		resp, err := s.do("GET", "", query, nil, nil, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not list bucket %s: %v", s.cfg.Bucket, err)
+		}
+		for _, c := range result.Contents {
+			name := strings.TrimPrefix(c.Key, s.cfg.Prefix)
+			// keys further under the prefix aren't our bundles
+			if !strings.Contains(name, "/") {
+				bundles = append(bundles, BundleInfo{Name: name, Size: c.Size, LastAccess: c.LastModified})
+			}
+		}
+		if !result.IsTruncated {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	return bundles, nil
+}
+
+func (s *s3Store) Delete(name string) error {
+	key, err := s.key(name)
+	if err != nil {
+		return err
+	}
+	log.Printf("Deleting %s from %s/%s", name, s.cfg.Endpoint, s.cfg.Bucket)
+	resp, err := s.do("DELETE", key, "", nil, nil, http.StatusNoContent, http.StatusOK)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -1,37 +1,76 @@
 package bundlestore
 
 import (
+	"crypto/subtle"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/scootdev/scoot/common/stats"
 )
 
 type Server struct {
-	store Store
-	stat  stats.StatsReceiver
+	store      Store
+	gc         *Collector
+	adminToken AdminToken
+	stat       stats.StatsReceiver
 }
 
+// Admin endpoints, under the bundle path. GET adminGCPath previews a
+// collection and POST runs one. POST adminPinPath<bundle>?for=<duration> pins a
+// bundle (forever if for isn't given) and DELETE unpins it.
+// They, and deleting bundles, need the server's AdminToken.
+const (
+	adminGCPath  = "admin/gc"
+	adminPinPath = "admin/pin/"
+)
+
+// MakeServer makes a Server that never collects bundles, and has no admin token.
 func MakeServer(s Store, stat stats.StatsReceiver) *Server {
-	return &Server{s, stat.Scope("bundlestoreServer")}
+	return MakeServerWithCollector(s, NewCollector(s, RetentionPolicy{}, stat), "", stat)
+}
+
+// MakeServerWithCollector makes a Server that serves gc's admin endpoints to
+// requests with the admin token.
+func MakeServerWithCollector(s Store, gc *Collector, token AdminToken, stat stats.StatsReceiver) *Server {
+	return &Server{s, gc, token, stat.Scope("bundlestoreServer")}
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	s.stat.Counter("serveCounter").Inc(1)
-	switch req.Method {
-	case "POST":
+	path := strings.TrimPrefix(req.URL.Path, "/bundle/")
+	switch {
+	case path == adminGCPath:
+		if !s.checkAdmin(w, req) {
+			return
+		}
+		s.HandleGC(w, req)
+	case strings.HasPrefix(path, adminPinPath):
+		if !s.checkAdmin(w, req) {
+			return
+		}
+		s.HandlePin(w, req)
+	case req.Method == "POST":
 		s.HandleUpload(w, req)
-	case "HEAD":
+	case req.Method == "DELETE":
+		if !s.checkAdmin(w, req) {
+			return
+		}
+		s.HandleDelete(w, req)
+	case path == "" && req.Method == "GET":
+		s.HandleList(w, req)
+	case req.Method == "HEAD":
 		fallthrough
-	case "GET":
+	case req.Method == "GET":
 		s.HandleDownload(w, req)
 	default:
 		// TODO(dbentley): do we need to support HEAD?
-		http.Error(w, "only support POST and GET", http.StatusMethodNotAllowed)
+		http.Error(w, "only support POST, GET and DELETE", http.StatusMethodNotAllowed)
 		return
 	}
 	s.stat.Counter("serveOkCounter").Inc(1)
@@ -46,7 +85,15 @@ func (s *Server) HandleUpload(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	bundleData := req.Body
+	// the store fails the write if the bundle doesn't match the digest the
+	// client sent, so a corrupt bundle is never stored
+	expected := func() string {
+		if digest := req.Header.Get(DigestHeader); digest != "" {
+			return digest
+		}
+		return req.Trailer.Get(DigestHeader)
+	}
+	bundleData := newDigestReader(req.Body, checkDigest(bundleName, expected))
 
 	exists, err := s.store.Exists(bundleName)
 	if err != nil {
@@ -59,6 +106,11 @@ func (s *Server) HandleUpload(w http.ResponseWriter, req *http.Request) {
 	}
 
 	if err := s.store.Write(bundleName, bundleData); err != nil {
+		if _, ok := err.(*DigestMismatchError); ok {
+			s.stat.Counter("uploadCorruptCounter").Inc(1)
+			http.Error(w, fmt.Sprintf("Error writing Bundle: %s", err), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, fmt.Sprintf("Error writing Bundle: %s", err), http.StatusInternalServerError)
 		return
 	}
@@ -81,8 +133,23 @@ func (s *Server) HandleDownload(w http.ResponseWriter, req *http.Request) {
 		http.NotFound(w, req)
 		return
 	}
-	if _, err := io.Copy(w, r); err != nil {
-		http.Error(w, fmt.Sprintf("Error writing Bundle: %s", err), http.StatusInternalServerError)
+	// send the digest after the bundle, so the client can check it got all of it
+	w.Header().Set("Trailer", DigestHeader)
+	data := newDigestReader(r, func(digest string) error {
+		w.Header().Set(DigestHeader, digest)
+		return nil
+	})
+	if _, err := io.Copy(w, data); err != nil {
+		// we've already sent part of the bundle, so break the connection
+		// rather than let it look complete.  If it can't be broken the
+		// digest is still missing, which the client treats as corruption.
+		log.Printf("Error writing Bundle %s: %s", bundleName, err)
+		r.Close()
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+			}
+		}
 		return
 	}
 	if err := r.Close(); err != nil {
@@ -92,6 +159,103 @@ func (s *Server) HandleDownload(w http.ResponseWriter, req *http.Request) {
 	s.stat.Counter("downloadOkCounter").Inc(1)
 }
 
+func (s *Server) HandleList(w http.ResponseWriter, req *http.Request) {
+	s.stat.Counter("listCounter").Inc(1)
+	bundles, err := s.store.List()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error listing bundles: %s", err), http.StatusInternalServerError)
+		return
+	}
+	// pins are kept alongside the bundles, but aren't bundles
+	bundles, _ = splitPinRecords(bundles)
+	writeJSON(w, bundles)
+}
+
+func (s *Server) HandleDelete(w http.ResponseWriter, req *http.Request) {
+	log.Printf("Deleting %v, %v", req.Host, req.URL)
+	s.stat.Counter("deleteCounter").Inc(1)
+	bundleName := strings.TrimPrefix(req.URL.Path, "/bundle/")
+	if err := s.checkBundleName(bundleName); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.store.Delete(bundleName); err != nil {
+		http.Error(w, fmt.Sprintf("Error deleting Bundle: %s", err), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "Successfully deleted bundle %s\n", bundleName)
+}
+
+// HandleGC previews a collection for GET, and runs one for POST.
+func (s *Server) HandleGC(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" && req.Method != "POST" {
+		http.Error(w, "only support POST and GET", http.StatusMethodNotAllowed)
+		return
+	}
+	result, err := s.gc.Collect(req.Method == "GET")
+	if err == ErrGCDisabled {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Error collecting bundles: %s", err), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, result)
+}
+
+// HandlePin pins a bundle for POST, and unpins it for DELETE.
+func (s *Server) HandlePin(w http.ResponseWriter, req *http.Request) {
+	bundleName := strings.TrimPrefix(req.URL.Path, "/bundle/"+adminPinPath)
+	if err := s.checkBundleName(bundleName); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch req.Method {
+	case "POST":
+		d := time.Duration(0)
+		if f := req.URL.Query().Get("for"); f != "" {
+			var err error
+			if d, err = time.ParseDuration(f); err != nil || d <= 0 {
+				http.Error(w, fmt.Sprintf("Invalid pin duration %q", f), http.StatusBadRequest)
+				return
+			}
+		}
+		if err := s.gc.Pin(bundleName, d); err != nil {
+			http.Error(w, fmt.Sprintf("Error pinning Bundle: %s", err), http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, "Pinned bundle %s\n", bundleName)
+	case "DELETE":
+		if err := s.gc.Unpin(bundleName); err != nil {
+			http.Error(w, fmt.Sprintf("Error unpinning Bundle: %s", err), http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, "Unpinned bundle %s\n", bundleName)
+	default:
+		http.Error(w, "only support POST and DELETE", http.StatusMethodNotAllowed)
+	}
+}
+
+// Checks the request has the admin token, refusing it if not.  Without a
+// token, the server refuses all admin requests.
+func (s *Server) checkAdmin(w http.ResponseWriter, req *http.Request) bool {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if s.adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+		log.Printf("Refusing admin request without the admin token %v, %v", req.Method, req.URL)
+		s.stat.Counter("adminRefusedCounter").Inc(1)
+		http.Error(w, "admin requests need the admin token", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // TODO(dbentley): comprehensive check if it's a legal bundle name. See README.md.
 func (s *Server) checkBundleName(name string) error {
 	bundleRE := "^bs-[a-z0-9]{40}.bundle"
@@ -41,6 +41,19 @@ func (f *FakeStore) Write(name string, data io.Reader) error {
 	}
 }
 
+func (f *FakeStore) List() ([]BundleInfo, error) {
+	bundles := []BundleInfo{}
+	for name, data := range f.files {
+		bundles = append(bundles, BundleInfo{Name: name, Size: int64(len(data))})
+	}
+	return bundles, nil
+}
+
+func (f *FakeStore) Delete(name string) error {
+	delete(f.files, name)
+	return nil
+}
+
 //TODO: an end-end test that uses real a real store and real bundles.
 
 func TestServer(t *testing.T) {
@@ -17,10 +17,31 @@ func MakeFileStoreInEnvOrTemp(tmp *temp.TempDir) (*FileStore, error) {
 	return MakeFileStoreInTemp(tmp)
 }
 
-func DefaultStore(store *FileStore) Store {
+// AdminTokenEnvVar holds the AdminToken, for servers and for the clients making
+// admin requests.
+const AdminTokenEnvVar = "SCOOT_BUNDLESTORE_ADMIN_TOKEN"
+
+// AdminToken is the secret that requests to collect, pin or delete bundles carry
+// as a bearer token.
+type AdminToken string
+
+// AdminTokenFromEnv reads the AdminToken from the environment, it's empty if unset.
+func AdminTokenFromEnv() AdminToken {
+	return AdminToken(os.Getenv(AdminTokenEnvVar))
+}
+
+// Backend is the Store bundles are kept in, which a server may put a cache in front of.
+// It's a FileStore unless configured otherwise.
+type Backend Store
+
+func DefaultBackend(store *FileStore) Backend {
 	return store
 }
 
+func DefaultStore(backend Backend) Store {
+	return backend
+}
+
 // Module returns a module that supports serving Bundlestore
 func Module() ice.Module {
 	return module{}
@@ -31,6 +52,10 @@ type module struct{}
 // Install installs functions for serving Bundlestore
 func (m module) Install(b *ice.MagicBag) {
 	b.Put(MakeFileStoreInEnvOrTemp)
-	b.Put(MakeServer)
+	b.Put(func() RetentionPolicy { return RetentionPolicy{} })
+	b.Put(NewCollector)
+	b.Put(AdminTokenFromEnv)
+	b.Put(MakeServerWithCollector)
+	b.Put(DefaultBackend)
 	b.Put(DefaultStore)
 }
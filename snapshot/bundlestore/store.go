@@ -2,6 +2,7 @@ package bundlestore
 
 import (
 	"io"
+	"time"
 )
 
 // Read-only operations on store, limited for now to a couple essential functions.
@@ -11,12 +12,18 @@ type StoreRead interface {
 
 	// Open the bundle for streaming read. It is the caller's responsibility to call Close().
 	OpenForRead(name string) (io.ReadCloser, error)
+
+	// List the bundles in the store.
+	List() ([]BundleInfo, error)
 }
 
 // Write operations on store, limited to a one-shot writing operation since bundles are immutable.
 type StoreWrite interface {
 	// Does a streaming write of the given bundle. There is no concept of partial writes (partial=failed).
 	Write(name string, data io.Reader) error
+
+	// Deletes the bundle, to reclaim its space. Deleting a bundle that doesn't exist isn't an error.
+	Delete(name string) error
 }
 
 // Combines read and write operations on store. This is what most of the code will use.
@@ -24,3 +31,11 @@ type Store interface {
 	StoreRead
 	StoreWrite
 }
+
+// BundleInfo describes a bundle in a store.
+type BundleInfo struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	// When the bundle was last written or read, as far as the store knows.
+	LastAccess time.Time `json:"lastAccess"`
+}
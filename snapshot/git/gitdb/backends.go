@@ -29,6 +29,46 @@ type snapshot interface {
 	Download(db *DB) error
 }
 
+// download is a Download in progress, that requests for the same sha wait for
+type download struct {
+	id     snap.ID
+	doneCh chan struct{}
+	err    error
+}
+
+// download makes sure v is present, downloading it if it isn't. Only one
+// snapshot is downloaded for a sha at a time; requests for it while it's being
+// downloaded wait for that download instead of starting their own.
+func (db *DB) download(v snapshot) error {
+	if err := db.shaPresent(v.SHA()); err == nil {
+		return nil
+	}
+
+	db.mu.Lock()
+	d, inProgress := db.downloads[v.SHA()]
+	if !inProgress {
+		d = &download{id: v.ID(), doneCh: make(chan struct{})}
+		db.downloads[v.SHA()] = d
+	}
+	db.mu.Unlock()
+
+	if inProgress {
+		<-d.doneCh
+		if d.err == nil || d.id == v.ID() {
+			return d.err
+		}
+		// the other snapshot's backend couldn't get the sha, but ours might
+		return v.Download(db)
+	}
+
+	d.err = v.Download(db)
+	db.mu.Lock()
+	delete(db.downloads, v.SHA())
+	db.mu.Unlock()
+	close(d.doneCh)
+	return d.err
+}
+
 // parseID parses ID into a snapshot
 func (db *DB) parseID(id snap.ID) (snapshot, error) {
 	if id == "" {
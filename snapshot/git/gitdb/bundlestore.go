@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path"
 	"strings"
+	"time"
 
 	snap "github.com/scootdev/scoot/snapshot"
 	"github.com/scootdev/scoot/snapshot/bundlestore"
@@ -228,7 +229,7 @@ func (s *bundlestoreSnapshot) Download(db *DB) error {
 	// large (say, a half hour) that it's reasonable to assume its easy to get.
 	// Now we've got the bundle for C3, which depends on C2, but we only have C1, so we have to
 	// update our stream.
-	if err := db.stream.updateStream(s.streamName, db); err != nil {
+	if err := db.stream.updateStream(s.streamName, "", db); err != nil {
 		return err
 	}
 
@@ -243,6 +244,9 @@ func (s *bundlestoreSnapshot) Download(db *DB) error {
 	return db.shaPresent(s.sha)
 }
 
+// How many times to try downloading a bundle that arrives corrupt
+const bundleDownloadAttempts = 3
+
 func (s *bundlestoreSnapshot) downloadBundle(db *DB) (filename string, err error) {
 	d, err := db.tmp.TempDir("bundle-")
 	if err != nil {
@@ -250,23 +254,71 @@ func (s *bundlestoreSnapshot) downloadBundle(db *DB) (filename string, err error
 	}
 	bundleName := makeBundleName(s.bundleKey)
 	bundleFilename := path.Join(d.Dir, bundleName)
-	f, err := os.Create(bundleFilename)
+
+	for i := 1; i <= bundleDownloadAttempts; i++ {
+		err = s.downloadBundleTo(db, bundleName, bundleFilename)
+		if _, corrupt := err.(*bundlestore.DigestMismatchError); !corrupt {
+			break
+		}
+		log.Printf("Downloaded a corrupt bundle (attempt %d of %d): %v", i, bundleDownloadAttempts, err)
+	}
 	if err != nil {
 		return "", err
 	}
+	return bundleFilename, nil
+}
+
+func (s *bundlestoreSnapshot) downloadBundleTo(db *DB, bundleName string, filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
 	defer f.Close()
 
 	r, err := db.bundles.cfg.Store.OpenForRead(bundleName)
 	if err != nil {
-		return "", err
-	}
-	if _, err := io.Copy(f, r); err != nil {
-		return "", err
+		return err
 	}
-
-	return f.Name(), nil
+	defer r.Close()
+	_, err = io.Copy(f, r)
+	return err
 }
 
 func makeBundleName(key string) string {
 	return fmt.Sprintf("bs-%s.bundle", key)
 }
+
+// BundleName returns the name of the bundle the snapshot with id was uploaded
+// to Bundlestore as, or false if it isn't a bundlestore snapshot.
+func BundleName(id snap.ID) (string, bool) {
+	parts := strings.Split(string(id), "-")
+	if len(parts) != 5 || parts[0] != bundlestoreIDText || !kinds[snapshotKind(parts[1])] {
+		return "", false
+	}
+	return makeBundleName(parts[2]), true
+}
+
+// BundlePinner pins the bundles of bundlestore snapshots, so that they aren't
+// collected while they're in use.
+type BundlePinner struct {
+	Pinner bundlestore.Pinner
+}
+
+// PinSnapshots pins the bundles of the bundlestore snapshots in ids for d.
+// Other snapshots aren't kept in Bundlestore, so there's nothing to pin.
+func (p *BundlePinner) PinSnapshots(ids []string, d time.Duration) error {
+	pinned := make(map[string]bool)
+	var err error
+	for _, id := range ids {
+		name, ok := BundleName(snap.ID(id))
+		if !ok || pinned[name] {
+			continue
+		}
+		pinned[name] = true
+		if pinErr := p.Pinner.Pin(name, d); pinErr != nil {
+			log.Printf("Couldn't pin bundle %s of snapshot %s: %v", name, id, pinErr)
+			err = pinErr
+		}
+	}
+	return err
+}
@@ -15,7 +15,7 @@ func (db *DB) readFileAll(id snap.ID, path string) (string, error) {
 		return "", err
 	}
 
-	if err := v.Download(db); err != nil {
+	if err := db.download(v); err != nil {
 		return "", err
 	}
 
@@ -28,20 +28,12 @@ func (db *DB) readFileAll(id snap.ID, path string) (string, error) {
 
 // checkout creates a checkout of id.
 func (db *DB) checkout(id snap.ID) (path string, err error) {
-	defer func() {
-		// If we're returning our repo dir, we need to keep the work tree locked.
-		// Otherwise, we can unlock it.
-		if path != db.dataRepo.Dir() {
-			db.workTreeLock.Unlock()
-		}
-	}()
-
 	v, err := db.parseID(id)
 	if err != nil {
 		return "", err
 	}
 
-	if err := v.Download(db); err != nil {
+	if err := db.download(v); err != nil {
 		return "", err
 	}
 
@@ -50,8 +42,17 @@ func (db *DB) checkout(id snap.ID) (path string, err error) {
 		// For FSSnapshots, we make a "bare checkout".
 		return db.checkoutFSSnapshot(v.SHA())
 	case kindGitCommitSnapshot:
-		// For GitCommitSnapshot's, we use dataRepo's work tree.
-		return db.checkoutGitCommitSnapshot(v.SHA())
+		// For GitCommitSnapshot's, we use a work tree of our own until it's
+		// released.
+		wt, err := db.acquireWorkTree()
+		if err != nil {
+			return "", err
+		}
+		if err := checkoutGitCommitSnapshot(wt, v.SHA()); err != nil {
+			db.releaseWorkTree(wt.Dir())
+			return "", err
+		}
+		return wt.Dir(), nil
 	default:
 		return "", fmt.Errorf("cannot checkout value kind %v; id %v", v.Kind(), v.ID())
 	}
@@ -89,15 +90,64 @@ func (db *DB) checkoutFSSnapshot(sha string) (path string, err error) {
 		return "", err
 	}
 
+	db.mu.Lock()
 	db.checkouts[coDir.Dir] = true
+	db.mu.Unlock()
 
 	return coDir.Dir, nil
 }
 
-// checkoutGitCommitSnapshot checks out a commit into our work tree.
-// We could use multiple work trees, except our internal git doesn't yet have work-tree support.
-// TODO(dbentley): migrate to work-trees.
-func (db *DB) checkoutGitCommitSnapshot(sha string) (path string, err error) {
+// acquireWorkTree returns a work tree no other checkout is using.  When
+// they're all in use, dataRepo is cloned into a new one, which shares its
+// objects instead of copying them.
+// We could use git work-trees, except our internal git doesn't yet support them.
+func (db *DB) acquireWorkTree() (*repo.Repository, error) {
+	db.mu.Lock()
+	if n := len(db.freeWorkTrees); n > 0 {
+		wt := db.freeWorkTrees[n-1]
+		db.freeWorkTrees = db.freeWorkTrees[:n-1]
+		db.busyWorkTrees[wt.Dir()] = wt
+		db.mu.Unlock()
+		return wt, nil
+	}
+	db.mu.Unlock()
+
+	dir, err := db.tmp.TempDir("worktree")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.dataRepo.Run("clone", "--shared", "--no-checkout", db.dataRepo.Dir(), dir.Dir); err != nil {
+		os.RemoveAll(dir.Dir)
+		return nil, fmt.Errorf("Unable to clone a work tree: %v", err)
+	}
+	wt, err := repo.NewRepository(dir.Dir)
+	if err != nil {
+		os.RemoveAll(dir.Dir)
+		return nil, err
+	}
+
+	db.mu.Lock()
+	db.busyWorkTrees[wt.Dir()] = wt
+	db.mu.Unlock()
+	return wt, nil
+}
+
+// releaseWorkTree makes the work tree at path available to other checkouts,
+// returns false if path isn't a work tree in use
+func (db *DB) releaseWorkTree(path string) bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	wt, ok := db.busyWorkTrees[path]
+	if !ok {
+		return false
+	}
+	delete(db.busyWorkTrees, path)
+	db.freeWorkTrees = append(db.freeWorkTrees, wt)
+	return true
+}
+
+// checkoutGitCommitSnapshot checks out a commit into a work tree.
+func checkoutGitCommitSnapshot(wt *repo.Repository, sha string) error {
 	cmds := [][]string{
 		// -d removes directories. -x ignores gitignore and removes everything.
 		// -f is force. -f the second time removes directories even if they're git repos themselves
@@ -106,29 +156,27 @@ func (db *DB) checkoutGitCommitSnapshot(sha string) (path string, err error) {
 	}
 
 	for _, argv := range cmds {
-		if _, err := db.dataRepo.Run(argv...); err != nil {
-			return "", fmt.Errorf("Unable to run git %v: %v", argv, err)
+		if _, err := wt.Run(argv...); err != nil {
+			return fmt.Errorf("Unable to run git %v: %v", argv, err)
 		}
 	}
 
-	return db.dataRepo.Dir(), nil
+	return nil
 }
 
 func (db *DB) releaseCheckout(path string) error {
-	if path == db.dataRepo.Dir() {
-		db.workTreeLock.Unlock()
+	if db.releaseWorkTree(path) {
 		return nil
 	}
 
-	if exists := db.checkouts[path]; !exists {
-		return nil
-	}
-	err := os.RemoveAll(path)
-	if err == nil {
+	db.mu.Lock()
+	exists := db.checkouts[path]
+	delete(db.checkouts, path)
+	db.mu.Unlock()
+	if !exists {
 		return nil
 	}
-	delete(db.checkouts, path)
-	return err
+	return os.RemoveAll(path)
 }
 
 func (db *DB) exportGitCommit(id snap.ID, externalRepo *repo.Repository) (string, error) {
@@ -137,7 +185,7 @@ func (db *DB) exportGitCommit(id snap.ID, externalRepo *repo.Repository) (string
 		return "", err
 	}
 
-	if err := v.Download(db); err != nil {
+	if err := db.download(v); err != nil {
 		return "", err
 	}
 
@@ -145,6 +193,9 @@ func (db *DB) exportGitCommit(id snap.ID, externalRepo *repo.Repository) (string
 		return "", fmt.Errorf("cannot export non-GitCommitSnapshot %v: %v", id, v.Kind())
 	}
 
+	db.refsLock.Lock()
+	defer db.refsLock.Unlock()
+
 	if err := moveCommit(db.dataRepo, externalRepo, v.SHA()); err != nil {
 		return "", err
 	}
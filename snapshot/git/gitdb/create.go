@@ -55,6 +55,8 @@ func (db *DB) ingestGitCommit(ingestRepo *repo.Repository, commitish string) (sn
 		return &localSnapshot{sha: sha, kind: kindGitCommitSnapshot}, nil
 	}
 
+	db.refsLock.Lock()
+	defer db.refsLock.Unlock()
 	if err := moveCommit(ingestRepo, db.dataRepo, sha); err != nil {
 		return nil, err
 	}
@@ -49,15 +49,18 @@ func makeDB(dataRepo *repo.Repository, initer RepoIniter, tmp *temp.TempDir, str
 		panic(fmt.Errorf("exactly one of dataRepo and initer must be non-nil in call to makeDB: %v %v", dataRepo, initer))
 	}
 	result := &DB{
-		reqCh:      make(chan req),
 		initDoneCh: make(chan struct{}),
+		closeCh:    make(chan struct{}),
 		dataRepo:   dataRepo,
 		tmp:        tmp,
 		checkouts:  make(map[string]bool),
+		downloads:  make(map[string]*download),
 		local:      &localBackend{},
-		stream:     &streamBackend{cfg: stream},
+		stream:     newStreamBackend(stream),
 		tags:       &tagsBackend{cfg: tags},
 		bundles:    &bundlestoreBackend{cfg: bundles},
+
+		busyWorkTrees: make(map[string]*repo.Repository),
 	}
 
 	switch autoUploadDest {
@@ -70,7 +73,7 @@ func makeDB(dataRepo *repo.Repository, initer RepoIniter, tmp *temp.TempDir, str
 		panic(fmt.Errorf("unknown GitDB AutoUpload destination: %v", autoUploadDest))
 	}
 
-	go result.loop(initer)
+	go result.start(initer)
 	return result
 }
 
@@ -79,23 +82,37 @@ type RepoIniter interface {
 }
 
 // DB stores its data in a Git Repo
+//
+// DB serves requests concurrently. Reading objects and checking them out with
+// a private index are safe to run alongside each other; the operations that
+// mutate shared git state (refs) are serialized by the locks below, and each
+// work tree is checked out into by one request at a time.
 type DB struct {
-	// DB uses a goroutine to serve requests, with requests of type req
-	reqCh chan req
-
-	// Our init can fail, and if it did, err will be non-nil, so before sending
-	// to reqCh, read from initDoneCh (which will be closed after initialization is done)
+	// Our init can fail, and if it did, err will be non-nil, so before using
+	// dataRepo, read from initDoneCh (which will be closed after initialization is done)
 	// and test if err is non-nil
 	initDoneCh chan struct{}
 	err        error
 
-	// must hold workTreeLock before sending a checkoutReq to reqCh
-	workTreeLock sync.Mutex
+	// closed by Close, to stop polling the stream
+	closeCh chan struct{}
+
+	// held while updating refs: fetching, tagging, and the temporary refs used
+	// to move commits and create bundles
+	refsLock sync.Mutex
+
+	mu        sync.Mutex
+	checkouts map[string]bool      // checkouts stores bare checkouts, but not the git worktree
+	downloads map[string]*download // downloads in progress, by sha
+
+	// work trees GitCommitSnapshots are checked out into, each is used by
+	// one checkout at a time.  The first is dataRepo's, the others are
+	// clones sharing its objects, made while all of them are in use
+	freeWorkTrees []*repo.Repository
+	busyWorkTrees map[string]*repo.Repository // by dir
 
-	// All data below here should be accessed only by the loop() goroutine
 	dataRepo   *repo.Repository
 	tmp        *temp.TempDir
-	checkouts  map[string]bool // checkouts stores bare checkouts, but not the git worktree
 	local      *localBackend
 	stream     *streamBackend
 	tags       *tagsBackend
@@ -103,14 +120,19 @@ type DB struct {
 	autoUpload uploader // This is one of our backends that we use to upload automatically
 }
 
-// req is a request interface
-type req interface {
-	req()
-}
-
 // Close stops the DB
 func (db *DB) Close() {
-	close(db.reqCh)
+	close(db.closeCh)
+}
+
+// start initializes the DB, and then polls the stream if it's configured to
+func (db *DB) start(initer RepoIniter) {
+	if db.init(initer); db.err != nil {
+		return
+	}
+	if cfg := db.stream.cfg; cfg != nil && cfg.PollInterval > 0 {
+		db.stream.poll(db, db.closeCh)
+	}
 }
 
 // initialize our repo (if necessary)
@@ -119,71 +141,19 @@ func (db *DB) init(initer RepoIniter) {
 	if initer != nil {
 		db.dataRepo, db.err = initer.Init()
 	}
-}
-
-// loop loops serving requests serially
-func (db *DB) loop(initer RepoIniter) {
-	if db.init(initer); db.err != nil {
-		// we couldn't create our repo, so all operations will fail before
-		// sending to reqCh, so we can stop serving
-		return
-	}
-
-	for db.reqCh != nil {
-		req, ok := <-db.reqCh
-		if !ok {
-			db.reqCh = nil
-			continue
-		}
-		switch req := req.(type) {
-		case ingestReq:
-			s, err := db.ingestDir(req.dir)
-			if err == nil && db.autoUpload != nil {
-				s, err = db.autoUpload.upload(s, db)
-			}
-			if err != nil {
-				req.resultCh <- idAndError{err: err}
-			} else {
-				req.resultCh <- idAndError{id: s.ID()}
-			}
-		case ingestGitCommitReq:
-			s, err := db.ingestGitCommit(req.ingestRepo, req.commitish)
-			if err == nil && db.autoUpload != nil {
-				s, err = db.autoUpload.upload(s, db)
-			}
-
-			if err != nil {
-				req.resultCh <- idAndError{err: err}
-			} else {
-				req.resultCh <- idAndError{id: s.ID()}
-			}
-		case readFileAllReq:
-			data, err := db.readFileAll(req.id, req.path)
-			req.resultCh <- stringAndError{str: data, err: err}
-		case checkoutReq:
-			path, err := db.checkout(req.id)
-			req.resultCh <- stringAndError{str: path, err: err}
-		case releaseCheckoutReq:
-			req.resultCh <- db.releaseCheckout(req.path)
-		case exportGitCommitReq:
-			sha, err := db.exportGitCommit(req.id, req.exportRepo)
-			req.resultCh <- stringAndError{str: sha, err: err}
-		default:
-			panic(fmt.Errorf("unknown reqtype: %T %v", req, req))
-		}
+	if db.err == nil {
+		db.freeWorkTrees = []*repo.Repository{db.dataRepo}
 	}
 }
 
-type ingestReq struct {
-	dir      string
-	resultCh chan idAndError
-}
-
-func (r ingestReq) req() {}
-
-type idAndError struct {
-	id  snap.ID
-	err error
+// upload uploads s if we auto upload, returning the snapshot to give out
+func (db *DB) upload(s snapshot) (snapshot, error) {
+	if db.autoUpload == nil {
+		return s, nil
+	}
+	db.refsLock.Lock()
+	defer db.refsLock.Unlock()
+	return db.autoUpload.upload(s, db)
 }
 
 // IngestDir ingests a directory directly.
@@ -191,42 +161,29 @@ func (db *DB) IngestDir(dir string) (snap.ID, error) {
 	if <-db.initDoneCh; db.err != nil {
 		return "", db.err
 	}
-	resultCh := make(chan idAndError)
-	db.reqCh <- ingestReq{dir: dir, resultCh: resultCh}
-	result := <-resultCh
-	return result.id, result.err
-}
-
-type ingestGitCommitReq struct {
-	ingestRepo *repo.Repository
-	commitish  string
-	resultCh   chan idAndError
+	s, err := db.ingestDir(dir)
+	if err == nil {
+		s, err = db.upload(s)
+	}
+	if err != nil {
+		return "", err
+	}
+	return s.ID(), nil
 }
 
-func (r ingestGitCommitReq) req() {}
-
 // IngestGitCommit ingests the commit identified by commitish from ingestRepo
 func (db *DB) IngestGitCommit(ingestRepo *repo.Repository, commitish string) (snap.ID, error) {
 	if <-db.initDoneCh; db.err != nil {
 		return "", db.err
 	}
-	resultCh := make(chan idAndError)
-	db.reqCh <- ingestGitCommitReq{ingestRepo: ingestRepo, commitish: commitish, resultCh: resultCh}
-	result := <-resultCh
-	return result.id, result.err
-}
-
-type readFileAllReq struct {
-	id       snap.ID
-	path     string
-	resultCh chan stringAndError
-}
-
-func (r readFileAllReq) req() {}
-
-type stringAndError struct {
-	str string
-	err error
+	s, err := db.ingestGitCommit(ingestRepo, commitish)
+	if err == nil {
+		s, err = db.upload(s)
+	}
+	if err != nil {
+		return "", err
+	}
+	return s.ID(), nil
 }
 
 // ReadFileAll reads the contents of the file path in FSSnapshot ID, or errors
@@ -234,75 +191,35 @@ func (db *DB) ReadFileAll(id snap.ID, path string) ([]byte, error) {
 	if <-db.initDoneCh; db.err != nil {
 		return nil, db.err
 	}
-	resultCh := make(chan stringAndError)
-	db.reqCh <- readFileAllReq{id: id, path: path, resultCh: resultCh}
-	result := <-resultCh
-	return []byte(result.str), result.err
+	data, err := db.readFileAll(id, path)
+	return []byte(data), err
 }
 
-type checkoutReq struct {
-	id       snap.ID
-	resultCh chan stringAndError
-}
-
-func (r checkoutReq) req() {}
-
 // Checkout puts the snapshot identified by id in the local filesystem, returning
 // the path where it lives or an error.
 func (db *DB) Checkout(id snap.ID) (path string, err error) {
 	if <-db.initDoneCh; db.err != nil {
 		return "", db.err
 	}
-	db.workTreeLock.Lock()
-	resultCh := make(chan stringAndError)
-	db.reqCh <- checkoutReq{id: id, resultCh: resultCh}
-	result := <-resultCh
-	return result.str, result.err
+	return db.checkout(id)
 }
 
-type releaseCheckoutReq struct {
-	path     string
-	resultCh chan error
-}
-
-func (r releaseCheckoutReq) req() {}
-
 // ReleaseCheckout releases a path from a previous Checkout. This allows Scoot to reuse
 // the path. Scoot will not touch path after Checkout until ReleaseCheckout.
 func (db *DB) ReleaseCheckout(path string) error {
 	if <-db.initDoneCh; db.err != nil {
 		return db.err
 	}
-	resultCh := make(chan error)
-	db.reqCh <- releaseCheckoutReq{path: path, resultCh: resultCh}
-	return <-resultCh
+	return db.releaseCheckout(path)
 }
 
-type exportGitCommitReq struct {
-	id         snap.ID
-	exportRepo *repo.Repository
-	resultCh   chan stringAndError
-}
-
-func (r exportGitCommitReq) req() {}
-
 func (db *DB) ExportGitCommit(id snap.ID, exportRepo *repo.Repository) (string, error) {
 	if <-db.initDoneCh; db.err != nil {
 		return "", db.err
 	}
-	resultCh := make(chan stringAndError)
-	db.reqCh <- exportGitCommitReq{id: id, exportRepo: exportRepo, resultCh: resultCh}
-	result := <-resultCh
-	return result.str, result.err
+	return db.exportGitCommit(id, exportRepo)
 }
 
-type downloadReq struct {
-	id       snap.ID
-	resultCh chan idAndError
-}
-
-func (r downloadReq) req() {}
-
 func (db *DB) IDForStreamCommitSHA(streamName string, sha string) snap.ID {
 	s := &streamSnapshot{sha: sha, kind: kindGitCommitSnapshot, streamName: streamName}
 	return s.ID()
@@ -3,13 +3,17 @@ package gitdb
 import (
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/scootdev/scoot/common/stats"
 	"github.com/scootdev/scoot/os/temp"
 	snap "github.com/scootdev/scoot/snapshot"
 	"github.com/scootdev/scoot/snapshot/bundlestore"
@@ -106,6 +110,56 @@ func TestIngestCommit(t *testing.T) {
 
 }
 
+// Commits can be checked out at once, each into its own work tree
+func TestConcurrentCommitCheckouts(t *testing.T) {
+	commit1ID, err := commitText(fixture.external, "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit2ID, err := commitText(fixture.external, "second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id1, err := fixture.simpleDB.IngestGitCommit(fixture.external, commit1ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id2, err := fixture.simpleDB.IngestGitCommit(fixture.external, commit2ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	co1, err := fixture.simpleDB.Checkout(id1)
+	if err != nil {
+		t.Fatalf("error checking out %v, %v", id1, err)
+	}
+	done := make(chan error)
+	go func() {
+		co2, err := fixture.simpleDB.Checkout(id2)
+		if err == nil {
+			err = assertFileContents(co2, "file.txt", "second")
+			fixture.simpleDB.ReleaseCheckout(co2)
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected a second checkout not to wait for the first to be released")
+	}
+
+	// the first checkout is untouched by the second
+	if err := assertFileContents(co1, "file.txt", "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.simpleDB.ReleaseCheckout(co1); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestStream(t *testing.T) {
 	// Create a commit in upstream, then check it out in our DB and compare contents.
 
@@ -129,6 +183,101 @@ func TestStream(t *testing.T) {
 
 }
 
+func TestStreamFetchCoalescing(t *testing.T) {
+	dataRepo, err := createRepo(fixture.tmp, "coalescing-data-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dataRepo.Run("remote", "add", "upstream", fixture.upstream.Dir()); err != nil {
+		t.Fatal(err)
+	}
+	stat := stats.DefaultStatsReceiver()
+	streamCfg := &StreamConfig{
+		Name:             "sm",
+		Remote:           "upstream",
+		RefSpec:          "refs/remotes/upstream/master",
+		MinFetchInterval: 500 * time.Millisecond,
+		Stat:             stat,
+	}
+	db := MakeDBFromRepo(dataRepo, fixture.tmp, streamCfg, nil, nil, AutoUploadNone)
+	defer db.Close()
+	fetches := stat.Counter("gitdb", "stream", "fetchCounter")
+
+	if err := db.stream.updateStream("sm", "", db); err != nil {
+		t.Fatal(err)
+	}
+	if fetches.Count() != 1 {
+		t.Fatalf("expected 1 fetch, got %d", fetches.Count())
+	}
+
+	// requests for new commits that come in within the interval all wait for one fetch
+	var shas []string
+	for _, text := range []string{"coalesced_first", "coalesced_second"} {
+		sha, err := commitText(fixture.upstream, text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		shas = append(shas, sha)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 3*len(shas))
+	for i := 0; i < 3; i++ {
+		for _, sha := range shas {
+			wg.Add(1)
+			go func(sha string) {
+				defer wg.Done()
+				errs <- db.download(&streamSnapshot{sha: sha, kind: kindGitCommitSnapshot, streamName: "sm"})
+			}(sha)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fetches.Count() != 2 {
+		t.Fatalf("expected the requests to share a fetch, got %d fetches", fetches.Count())
+	}
+	if head, _ := fixture.upstream.RunSha("rev-parse", "master"); db.stream.head != head {
+		t.Fatalf("expected the stream head to be %v, got %v", head, db.stream.head)
+	}
+}
+
+func TestStreamFetchBackoff(t *testing.T) {
+	dataRepo, err := createRepo(fixture.tmp, "backoff-data-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stat := stats.DefaultStatsReceiver()
+	streamCfg := &StreamConfig{Name: "sm", Remote: "nonexistent", RefSpec: "refs/remotes/nonexistent/master", Stat: stat}
+	db := MakeDBFromRepo(dataRepo, fixture.tmp, streamCfg, nil, nil, AutoUploadNone)
+	defer db.Close()
+	db.stream.backoffMin = 100 * time.Millisecond
+	fetches := stat.Counter("gitdb", "stream", "fetchCounter")
+
+	if err := db.stream.updateStream("sm", "", db); err == nil {
+		t.Fatal("expected fetching from a nonexistent remote to fail")
+	}
+	if err := db.stream.updateStream("sm", "", db); err == nil {
+		t.Fatal("expected to back off after a failed fetch")
+	}
+	if fetches.Count() != 1 {
+		t.Fatalf("expected 1 fetch while backing off, got %d", fetches.Count())
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	db.stream.updateStream("sm", "", db)
+	if fetches.Count() != 2 {
+		t.Fatalf("expected to fetch again after backing off, got %d fetches", fetches.Count())
+	}
+	// the backoff doubles
+	if backoff := db.stream.retryAt.Sub(db.stream.lastStart); backoff < 200*time.Millisecond {
+		t.Fatalf("expected the backoff to double, got %v", backoff)
+	}
+}
+
 func TestInit(t *testing.T) {
 	// This test doesn't use our fixture DBs because it has such specific git setup
 	// Our git repos are:
@@ -391,6 +540,115 @@ func TestBundlestore(t *testing.T) {
 	}
 }
 
+// blockingStore blocks reading the bundle named block until unblockCh is closed
+type blockingStore struct {
+	bundlestore.Store
+	block     string
+	openedCh  chan struct{}
+	unblockCh chan struct{}
+	mu        sync.Mutex
+	opens     map[string]int
+}
+
+func (s *blockingStore) OpenForRead(name string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	s.opens[name]++
+	s.mu.Unlock()
+	if name == s.block {
+		close(s.openedCh)
+		<-s.unblockCh
+	}
+	return s.Store.OpenForRead(name)
+}
+
+func TestConcurrentDownloads(t *testing.T) {
+	tmp, err := fixture.tmp.TempDir("concurrent-bundles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileStore, err := bundlestore.MakeFileStore(tmp.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorDataRepo, err := createRepo(fixture.tmp, "concurrent-author-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorDB := MakeDBFromRepo(authorDataRepo, fixture.tmp, nil, nil, &BundlestoreConfig{Store: fileStore}, AutoUploadBundlestore)
+	defer authorDB.Close()
+
+	ingest := func(text string) snap.ID {
+		dir, err := fixture.tmp.TempDir("concurrent-ingest")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writeFileText(dir.Dir, "file.txt", text); err != nil {
+			t.Fatal(err)
+		}
+		id, err := authorDB.IngestDir(dir.Dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	slowID, fastID := ingest("slow"), ingest("fast")
+	slow, err := authorDB.parseID(slowID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &blockingStore{
+		Store:     fileStore,
+		block:     makeBundleName(slow.(*bundlestoreSnapshot).bundleKey),
+		openedCh:  make(chan struct{}),
+		unblockCh: make(chan struct{}),
+		opens:     make(map[string]int),
+	}
+	consumerDataRepo, err := createRepo(fixture.tmp, "concurrent-consumer-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	consumerDB := MakeDBFromRepo(consumerDataRepo, fixture.tmp, nil, nil, &BundlestoreConfig{Store: store}, AutoUploadNone)
+	defer consumerDB.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := consumerDB.ReadFileAll(slowID, "file.txt")
+			if err == nil && string(data) != "slow" {
+				err = fmt.Errorf("expected slow, got %q", data)
+			}
+			errs <- err
+		}()
+	}
+	<-store.openedCh
+
+	// while the slow snapshot is being downloaded, others can be read and checked out
+	if data, err := consumerDB.ReadFileAll(fastID, "file.txt"); err != nil || string(data) != "fast" {
+		t.Fatalf("expected fast, got %q %v", data, err)
+	}
+	if err := assertSnapshotContents(consumerDB, fastID, "file.txt", "fast"); err != nil {
+		t.Fatal(err)
+	}
+
+	// give the other readers of the slow snapshot time to start waiting for its download
+	time.Sleep(100 * time.Millisecond)
+	close(store.unblockCh)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if opens := store.opens[store.block]; opens != 1 {
+		t.Fatalf("expected the slow bundle to be downloaded once, got %d", opens)
+	}
+}
+
 type dbFixture struct {
 	tmp *temp.TempDir
 	// simpleDB is the simplest DB; no auto-upload
@@ -612,3 +870,13 @@ func TestMain(m *testing.M) {
 	fixture.close()
 	os.Exit(result)
 }
+
+func TestBundleName(t *testing.T) {
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	if name, ok := BundleName(snap.ID("bs-gc-" + sha + "-master-" + sha)); !ok || name != "bs-"+sha+".bundle" {
+		t.Fatalf("Expected the bundle of a bundlestore snapshot, got %q %v", name, ok)
+	}
+	if name, ok := BundleName(snap.ID("local-gc-" + sha)); ok {
+		t.Fatalf("Expected a local snapshot not to have a bundle, got %q", name)
+	}
+}
@@ -92,6 +92,8 @@ func (s *tagsSnapshot) Download(db *DB) error {
 		return fmt.Errorf("cannot download %v: tags backend named %s is not registered (expected %v)", s.ID(), s.name, db.tags.cfg.Remote)
 	}
 
+	db.refsLock.Lock()
+	defer db.refsLock.Unlock()
 	if _, err := db.dataRepo.Run("fetch", db.tags.cfg.Remote, makeTag(db.tags.cfg.Prefix, s.SHA())); err != nil {
 		return err
 	}
@@ -3,7 +3,11 @@ package gitdb
 import (
 	"errors"
 	"fmt"
+	"log"
+	"sync"
+	"time"
 
+	"github.com/scootdev/scoot/common/stats"
 	snap "github.com/scootdev/scoot/snapshot"
 )
 
@@ -23,13 +27,51 @@ type StreamConfig struct {
 
 	// Name of ref to follow in data repo (e.g. refs/remotes/upstream/master)
 	RefSpec string
+
+	// Minimum time between the starts of two fetches; requests that come in
+	// sooner wait, and are served by a single fetch. Zero means no minimum.
+	MinFetchInterval time.Duration
+
+	// If non-zero, fetch this often in the background, so that new commits in
+	// the stream are usually present by the time they're asked for
+	PollInterval time.Duration
+
+	// Where to record fetch counts and latencies. nil means they're not recorded.
+	Stat stats.StatsReceiver
 }
 
 const streamIDText = "stream"
 const streamIDFmt = "%s-%s-%s-%s"
 
+// After a fetch fails, don't fetch again for fetchBackoffMin, doubling with
+// each consecutive failure up to fetchBackoffMax
+const (
+	fetchBackoffMin = time.Second
+	fetchBackoffMax = time.Minute
+)
+
 type streamBackend struct {
-	cfg *StreamConfig
+	cfg  *StreamConfig
+	stat stats.StatsReceiver
+
+	backoffMin time.Duration
+	backoffMax time.Duration
+
+	mu         sync.Mutex
+	fetchingCh chan struct{} // closed when the fetch in progress is done, nil if there isn't one
+	lastStart  time.Time     // when the last fetch started
+	lastErr    error         // how it ended
+	failures   int           // number of consecutive failed fetches
+	retryAt    time.Time     // don't fetch again before this after failing
+	head       string        // sha RefSpec pointed to after the last successful fetch
+}
+
+func newStreamBackend(cfg *StreamConfig) *streamBackend {
+	stat := stats.NilStatsReceiver()
+	if cfg != nil && cfg.Stat != nil {
+		stat = cfg.Stat.Scope("gitdb", "stream")
+	}
+	return &streamBackend{cfg: cfg, stat: stat, backoffMin: fetchBackoffMin, backoffMax: fetchBackoffMax}
 }
 
 func (b *streamBackend) parseID(id snap.ID, kind snapshotKind, extraParts []string) (*streamSnapshot, error) {
@@ -68,27 +110,131 @@ func (s *streamSnapshot) Download(db *DB) error {
 		return nil
 	}
 
-	// TODO(dbentley): what if we've already fetched recently? We should figure out some way to
-	// prevent that
-
-	if db.stream == nil {
-		return fmt.Errorf("cannot download snapshot %s: no streams configured", s.ID())
-	}
-
-	if err := db.stream.updateStream(s.streamName, db); err != nil {
+	if err := db.stream.updateStream(s.streamName, s.SHA(), db); err != nil {
 		return err
 	}
 
 	return db.shaPresent(s.SHA())
 }
 
-// updateStream updates the named stream
-func (b *streamBackend) updateStream(name string, db *DB) error {
+// updateStream updates the named stream, so that it has everything that was in it
+// when updateStream was called. If sha isn't empty, updateStream returns as soon
+// as sha is present.
+//
+// Concurrent callers share fetches: whoever comes in while a fetch is running
+// waits for it, and then for at most one more fetch (started after they came
+// in) if it didn't get them what they wanted. After a failed fetch, callers get
+// its error instead of fetching again until the backoff is over.
+func (b *streamBackend) updateStream(name string, sha string, db *DB) error {
+	if b.cfg == nil {
+		return errors.New("cannot update stream: no stream configured")
+	}
 	if name != b.cfg.Name {
-		return fmt.Errorf("cannot update stream %s: does not match stream %s", name, db.stream.cfg.Name)
+		return fmt.Errorf("cannot update stream %s: does not match stream %s", name, b.cfg.Name)
 	}
 
-	// TODO(dbentley): keep stats about fetching (when we do it, last time we did it, etc.)
-	_, err := db.dataRepo.Run("fetch", b.cfg.Remote)
+	requested := time.Now()
+	b.mu.Lock()
+	for b.fetchingCh != nil {
+		b.stat.Counter("fetchCoalescedCounter").Inc(1)
+		fetchingCh := b.fetchingCh
+		b.mu.Unlock()
+		<-fetchingCh
+		if sha != "" && db.shaPresent(sha) == nil {
+			return nil
+		}
+		b.mu.Lock()
+		if !b.lastStart.Before(requested) {
+			// this fetch started after we asked, so it's as good as our own
+			err := b.lastErr
+			b.mu.Unlock()
+			return err
+		}
+	}
+	if b.failures > 0 && requested.Before(b.retryAt) {
+		err := b.lastErr
+		b.mu.Unlock()
+		b.stat.Counter("fetchBackoffCounter").Inc(1)
+		return fmt.Errorf("not fetching stream %s again until %v, last fetch failed: %v", name, b.retryAt, err)
+	}
+	fetchingCh := make(chan struct{})
+	b.fetchingCh = fetchingCh
+	wait := b.lastStart.Add(b.cfg.MinFetchInterval).Sub(time.Now())
+	b.mu.Unlock()
+
+	// requests that come in while we wait out the interval are served by our fetch
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	b.mu.Lock()
+	b.lastStart = time.Now()
+	b.mu.Unlock()
+
+	err := b.fetch(db)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastErr = err
+	if err != nil {
+		b.failures++
+		backoff := b.backoffMin << uint(b.failures-1)
+		if backoff > b.backoffMax || backoff <= 0 {
+			backoff = b.backoffMax
+		}
+		b.retryAt = time.Now().Add(backoff)
+	} else {
+		b.failures = 0
+	}
+	b.fetchingCh = nil
+	close(fetchingCh)
 	return err
 }
+
+// fetch fetches the stream's remote and records its new head
+func (b *streamBackend) fetch(db *DB) error {
+	b.stat.Counter("fetchCounter").Inc(1)
+	defer b.stat.Latency("fetchLatency_ms").Time().Stop()
+
+	db.refsLock.Lock()
+	_, err := db.dataRepo.Run("fetch", b.cfg.Remote)
+	db.refsLock.Unlock()
+	if err != nil {
+		b.stat.Counter("fetchFailureCounter").Inc(1)
+		log.Printf("gitdb: failed to fetch stream %s from %s: %v", b.cfg.Name, b.cfg.Remote, err)
+		return err
+	}
+
+	if b.cfg.RefSpec == "" {
+		return nil
+	}
+	// the remote might not have the ref yet, which doesn't make the fetch a failure
+	head, err := db.dataRepo.RunSha("rev-parse", b.cfg.RefSpec)
+	if err != nil {
+		log.Printf("gitdb: stream %s has no %s after fetching: %v", b.cfg.Name, b.cfg.RefSpec, err)
+		return nil
+	}
+	b.mu.Lock()
+	if head != b.head {
+		log.Printf("gitdb: stream %s is now at %s", b.cfg.Name, head)
+		b.stat.Counter("headChangeCounter").Inc(1)
+		b.head = head
+	}
+	b.mu.Unlock()
+	return nil
+}
+
+// poll updates the stream every PollInterval until closeCh is closed
+func (b *streamBackend) poll(db *DB, closeCh <-chan struct{}) {
+	ticker := time.NewTicker(b.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closeCh:
+			return
+		case <-ticker.C:
+			if err := b.updateStream(b.cfg.Name, "", db); err != nil {
+				log.Printf("gitdb: polling stream %s: %v", b.cfg.Name, err)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/scootdev/scoot/common/thrifthelpers"
 	"github.com/scootdev/scoot/runner"
+	"github.com/scootdev/scoot/runner/execer"
 	"github.com/scootdev/scoot/workerapi/gen-go/worker"
 )
 
@@ -14,7 +15,14 @@ import (
 
 //TODO: test workerStatus.
 type WorkerStatus struct {
-	Runs []runner.RunStatus
+	Runs     []runner.RunStatus
+	Capacity Capacity
+}
+
+// The resources a worker offers to the runs it's given
+type Capacity struct {
+	CPUSlots    int   // 0 means a single slot
+	MemoryBytes int64 // 0 means unbounded
 }
 
 func ThriftWorkerStatusToDomain(thrift *worker.WorkerStatus) WorkerStatus {
@@ -22,7 +30,11 @@ func ThriftWorkerStatusToDomain(thrift *worker.WorkerStatus) WorkerStatus {
 	for _, r := range thrift.Runs {
 		runs = append(runs, ThriftRunStatusToDomain(r))
 	}
-	return WorkerStatus{runs}
+	capacity := Capacity{
+		CPUSlots:    int(thrift.GetCpuSlots()),
+		MemoryBytes: thrift.GetMemoryBytes(),
+	}
+	return WorkerStatus{runs, capacity}
 }
 
 func DomainWorkerStatusToThrift(domain WorkerStatus) *worker.WorkerStatus {
@@ -31,6 +43,14 @@ func DomainWorkerStatusToThrift(domain WorkerStatus) *worker.WorkerStatus {
 	for _, r := range domain.Runs {
 		thrift.Runs = append(thrift.Runs, DomainRunStatusToThrift(r))
 	}
+	if domain.Capacity.CPUSlots != 0 {
+		cpuSlots := int32(domain.Capacity.CPUSlots)
+		thrift.CpuSlots = &cpuSlots
+	}
+	if domain.Capacity.MemoryBytes != 0 {
+		memoryBytes := domain.Capacity.MemoryBytes
+		thrift.MemoryBytes = &memoryBytes
+	}
 	return thrift
 }
 
@@ -51,7 +71,15 @@ func ThriftRunCommandToDomain(thrift *worker.RunCommand) *runner.Command {
 	if thrift.SnapshotId != nil {
 		snapshotID = *thrift.SnapshotId
 	}
-	return &runner.Command{Argv: argv, EnvVars: env, Timeout: timeout, SnapshotID: snapshotID}
+	return &runner.Command{
+		Argv:       argv,
+		EnvVars:    env,
+		Timeout:    timeout,
+		SnapshotID: snapshotID,
+		Network:    thrift.GetNetwork(),
+		Outputs:    thrift.Outputs,
+		Priority:   int(thrift.GetPriority()),
+	}
 }
 
 func DomainRunCommandToThrift(domain *runner.Command) *worker.RunCommand {
@@ -62,6 +90,15 @@ func DomainRunCommandToThrift(domain *runner.Command) *worker.RunCommand {
 	thrift.Argv = domain.Argv
 	snapID := domain.SnapshotID
 	thrift.SnapshotId = &snapID
+	if domain.Network {
+		network := true
+		thrift.Network = &network
+	}
+	thrift.Outputs = domain.Outputs
+	if domain.Priority != 0 {
+		priority := int32(domain.Priority)
+		thrift.Priority = &priority
+	}
 	return thrift
 }
 
@@ -101,6 +138,16 @@ func ThriftRunStatusToDomain(thrift *worker.RunStatus) runner.RunStatus {
 	if thrift.SnapshotId != nil {
 		domain.SnapshotID = *thrift.SnapshotId
 	}
+	if thrift.Usage != nil {
+		domain.Usage = execer.Usage{
+			WallTime:       time.Duration(thrift.Usage.WallTimeMs) * time.Millisecond,
+			UserTime:       time.Duration(thrift.Usage.UserTimeMs) * time.Millisecond,
+			SysTime:        time.Duration(thrift.Usage.SysTimeMs) * time.Millisecond,
+			MaxRSS:         execer.Memory(thrift.Usage.MaxRssBytes),
+			BlockInputOps:  thrift.Usage.BlockInputOps,
+			BlockOutputOps: thrift.Usage.BlockOutputOps,
+		}
+	}
 	return domain
 }
 
@@ -138,6 +185,16 @@ func DomainRunStatusToThrift(domain runner.RunStatus) *worker.RunStatus {
 	exitCode := int32(domain.ExitCode)
 	thrift.ExitCode = &exitCode
 	thrift.SnapshotId = copyString(domain.SnapshotID)
+	if domain.Usage != (execer.Usage{}) {
+		thrift.Usage = &worker.RunUsage{
+			WallTimeMs:     int64(domain.Usage.WallTime / time.Millisecond),
+			UserTimeMs:     int64(domain.Usage.UserTime / time.Millisecond),
+			SysTimeMs:      int64(domain.Usage.SysTime / time.Millisecond),
+			MaxRssBytes:    int64(domain.Usage.MaxRSS),
+			BlockInputOps:  domain.Usage.BlockInputOps,
+			BlockOutputOps: domain.Usage.BlockOutputOps,
+		}
+	}
 	return thrift
 }
 
@@ -153,3 +210,59 @@ func SerializeProcessStatus(processStatus runner.RunStatus) ([]byte, error) {
 
 	return asBytes, err
 }
+
+// Deserializes a RunStatus serialized by SerializeProcessStatus
+func DeserializeProcessStatus(asBytes []byte) (runner.RunStatus, error) {
+	runStatus := worker.NewRunStatus()
+	if err := thrifthelpers.JsonDeserialize(runStatus, asBytes); err != nil {
+		return runner.RunStatus{}, err
+	}
+	return ThriftRunStatusToDomain(runStatus), nil
+}
+
+// An earlier run of a task that was retried, and the worker it ran on
+type RunAttempt struct {
+	WorkerId string
+	Status   runner.RunStatus
+	Backup   bool // a run of a backup copy, not an attempt of its own
+}
+
+// Serializes the status of a task's latest run along with its earlier runs,
+// for the scheduler to log.  DeserializeProcessStatus reads back the latest run.
+func SerializeTaskStatus(processStatus runner.RunStatus, attempts []RunAttempt) ([]byte, error) {
+	runStatus := DomainRunStatusToThrift(processStatus)
+	for _, a := range attempts {
+		attemptStatus := DomainRunStatusToThrift(a.Status)
+		runStatus.Attempts = append(runStatus.Attempts, &worker.RunAttempt{
+			WorkerId: a.WorkerId,
+			Status:   attemptStatus.Status,
+			RunId:    copyString(attemptStatus.RunId),
+			ExitCode: attemptStatus.ExitCode,
+			Error:    attemptStatus.Error,
+		})
+		if a.Backup {
+			runStatus.Attempts[len(runStatus.Attempts)-1].Backup = &a.Backup
+		}
+	}
+	return thrifthelpers.JsonSerialize(runStatus)
+}
+
+// Deserializes the earlier runs serialized by SerializeTaskStatus
+func DeserializeRunAttempts(asBytes []byte) ([]RunAttempt, error) {
+	runStatus := worker.NewRunStatus()
+	if err := thrifthelpers.JsonDeserialize(runStatus, asBytes); err != nil {
+		return nil, err
+	}
+
+	var attempts []RunAttempt
+	for _, a := range runStatus.Attempts {
+		attemptStatus := ThriftRunStatusToDomain(&worker.RunStatus{
+			Status:   a.Status,
+			RunId:    a.GetRunId(),
+			ExitCode: a.ExitCode,
+			Error:    a.Error,
+		})
+		attempts = append(attempts, RunAttempt{WorkerId: a.WorkerId, Status: attemptStatus, Backup: a.GetBackup()})
+	}
+	return attempts, nil
+}
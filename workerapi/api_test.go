@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/scootdev/scoot/runner"
+	"github.com/scootdev/scoot/runner/execer"
 	"github.com/scootdev/scoot/workerapi/gen-go/worker"
 )
 
@@ -13,9 +14,12 @@ var someCmd = []string{"", "x"}
 var someEnv = map[string]string{"a": "1", "b": "2"}
 var zero = int32(0)
 var nonzero = int32(12345)
+var nonzero64 = int64(1 << 32)
 var emptystr = ""
 var nonemptystr = "abcdef"
 var deadbeefID = "snap-id-deadbeef"
+var network = true
+var someOutputs = map[string]string{"test-reports/*.xml": "reports", "coverage.out": ""}
 
 var cmdFromThrift = func(x interface{}) interface{} { return ThriftRunCommandToDomain(x.(*worker.RunCommand)) }
 var cmdToThrift = func(x interface{}) interface{} { return DomainRunCommandToThrift(x.(*runner.Command)) }
@@ -44,9 +48,11 @@ var tests = []struct {
 	},
 	{
 		2, cmdFromThrift, cmdToThrift,
-		&worker.RunCommand{Argv: someCmd, Env: someEnv, SnapshotId: &nonemptystr, TimeoutMs: &nonzero},
+		&worker.RunCommand{Argv: someCmd, Env: someEnv, SnapshotId: &nonemptystr, TimeoutMs: &nonzero, Network: &network,
+			Outputs: someOutputs, Priority: &nonzero},
 		&runner.Command{Argv: someCmd, EnvVars: someEnv,
-			Timeout: time.Duration(nonzero) * time.Millisecond, SnapshotID: nonemptystr},
+			Timeout: time.Duration(nonzero) * time.Millisecond, SnapshotID: nonemptystr, Network: true,
+			Outputs: someOutputs, Priority: int(nonzero)},
 	},
 
 	//RunStatus
@@ -120,6 +126,15 @@ var tests = []struct {
 		runner.RunStatus{RunID: "id", State: runner.BADREQUEST,
 			StdoutRef: nonemptystr, StderrRef: nonemptystr, ExitCode: int(nonzero), Error: nonemptystr},
 	},
+	{
+		16, rsFromThrift, rsToThrift,
+		&worker.RunStatus{Status: worker.Status_COMPLETE, RunId: "id", ExitCode: &zero,
+			Usage: &worker.RunUsage{WallTimeMs: 3000, UserTimeMs: 1500, SysTimeMs: 250,
+				MaxRssBytes: nonzero64, BlockInputOps: 7, BlockOutputOps: 9}},
+		runner.RunStatus{RunID: "id", State: runner.COMPLETE,
+			Usage: execer.Usage{WallTime: 3 * time.Second, UserTime: 1500 * time.Millisecond, SysTime: 250 * time.Millisecond,
+				MaxRSS: execer.Memory(nonzero64), BlockInputOps: 7, BlockOutputOps: 9}},
+	},
 
 	//WorkerStatus
 	{
@@ -138,6 +153,11 @@ var tests = []struct {
 				StdoutRef: nonemptystr, StderrRef: nonemptystr, ExitCode: int(nonzero), Error: nonemptystr},
 		}},
 	},
+	{
+		15, wsFromThrift, wsToThrift,
+		&worker.WorkerStatus{Runs: []*worker.RunStatus{}, CpuSlots: &nonzero, MemoryBytes: &nonzero64},
+		WorkerStatus{Runs: []runner.RunStatus{}, Capacity: Capacity{CPUSlots: int(nonzero), MemoryBytes: nonzero64}},
+	},
 }
 
 func TestTranslation(t *testing.T) {
@@ -152,3 +172,24 @@ func TestTranslation(t *testing.T) {
 		}
 	}
 }
+
+func TestSerializeTaskStatus(t *testing.T) {
+	latest := runner.RunStatus{RunID: "2", State: runner.COMPLETE, ExitCode: 1}
+	attempts := []RunAttempt{
+		{WorkerId: "node1", Status: runner.RunStatus{RunID: "1", State: runner.FAILED, Error: "lost worker"}},
+		{WorkerId: "node2", Status: runner.RunStatus{RunID: "5", State: runner.COMPLETE, ExitCode: 75}},
+		{WorkerId: "node3", Status: runner.RunStatus{RunID: "7", State: runner.ABORTED}, Backup: true},
+	}
+
+	asBytes, err := SerializeTaskStatus(latest, attempts)
+	if err != nil {
+		t.Fatalf("Unexpected error serializing: %v", err)
+	}
+
+	if st, err := DeserializeProcessStatus(asBytes); err != nil || !reflect.DeepEqual(st, latest) {
+		t.Errorf("Expected latest run %v, got %v, err: %v", latest, st, err)
+	}
+	if read, err := DeserializeRunAttempts(asBytes); err != nil || !reflect.DeepEqual(read, attempts) {
+		t.Errorf("Expected attempts %v, got %v, err: %v", attempts, read, err)
+	}
+}
@@ -88,6 +88,624 @@ func (p *Status) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// Attributes:
+//  - WorkerId
+//  - Status
+//  - RunId
+//  - ExitCode
+//  - Error
+type RunAttempt struct {
+	WorkerId string  `thrift:"workerId,1,required" json:"workerId"`
+	Status   Status  `thrift:"status,2,required" json:"status"`
+	RunId    *string `thrift:"runId,3" json:"runId,omitempty"`
+	ExitCode *int32  `thrift:"exitCode,4" json:"exitCode,omitempty"`
+	Error    *string `thrift:"error,5" json:"error,omitempty"`
+	Backup   *bool   `thrift:"backup,6" json:"backup,omitempty"`
+}
+
+func NewRunAttempt() *RunAttempt {
+	return &RunAttempt{}
+}
+
+func (p *RunAttempt) GetWorkerId() string {
+	return p.WorkerId
+}
+
+func (p *RunAttempt) GetStatus() Status {
+	return p.Status
+}
+
+var RunAttempt_RunId_DEFAULT string
+
+func (p *RunAttempt) GetRunId() string {
+	if !p.IsSetRunId() {
+		return RunAttempt_RunId_DEFAULT
+	}
+	return *p.RunId
+}
+
+var RunAttempt_ExitCode_DEFAULT int32
+
+func (p *RunAttempt) GetExitCode() int32 {
+	if !p.IsSetExitCode() {
+		return RunAttempt_ExitCode_DEFAULT
+	}
+	return *p.ExitCode
+}
+
+var RunAttempt_Error_DEFAULT string
+
+func (p *RunAttempt) GetError() string {
+	if !p.IsSetError() {
+		return RunAttempt_Error_DEFAULT
+	}
+	return *p.Error
+}
+
+var RunAttempt_Backup_DEFAULT bool
+
+func (p *RunAttempt) GetBackup() bool {
+	if !p.IsSetBackup() {
+		return RunAttempt_Backup_DEFAULT
+	}
+	return *p.Backup
+}
+func (p *RunAttempt) IsSetRunId() bool {
+	return p.RunId != nil
+}
+
+func (p *RunAttempt) IsSetExitCode() bool {
+	return p.ExitCode != nil
+}
+
+func (p *RunAttempt) IsSetError() bool {
+	return p.Error != nil
+}
+
+func (p *RunAttempt) IsSetBackup() bool {
+	return p.Backup != nil
+}
+
+func (p *RunAttempt) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetWorkerId bool = false
+	var issetStatus bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetWorkerId = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetWorkerId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WorkerId is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	return nil
+}
+
+func (p *RunAttempt) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.WorkerId = v
+	}
+	return nil
+}
+
+func (p *RunAttempt) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		p.Status = temp
+	}
+	return nil
+}
+
+func (p *RunAttempt) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.RunId = &v
+	}
+	return nil
+}
+
+func (p *RunAttempt) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.ExitCode = &v
+	}
+	return nil
+}
+
+func (p *RunAttempt) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Error = &v
+	}
+	return nil
+}
+
+func (p *RunAttempt) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Backup = &v
+	}
+	return nil
+}
+
+func (p *RunAttempt) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunAttempt"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RunAttempt) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("workerId", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:workerId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.WorkerId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.workerId (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:workerId: ", p), err)
+	}
+	return err
+}
+
+func (p *RunAttempt) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	}
+	return err
+}
+
+func (p *RunAttempt) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRunId() {
+		if err := oprot.WriteFieldBegin("runId", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:runId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.RunId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.runId (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:runId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunAttempt) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetExitCode() {
+		if err := oprot.WriteFieldBegin("exitCode", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:exitCode: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.ExitCode)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.exitCode (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:exitCode: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunAttempt) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetError() {
+		if err := oprot.WriteFieldBegin("error", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:error: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Error)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.error (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:error: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunAttempt) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetBackup() {
+		if err := oprot.WriteFieldBegin("backup", thrift.BOOL, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:backup: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.Backup)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.backup (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:backup: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunAttempt) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RunAttempt(%+v)", *p)
+}
+
+// Attributes:
+//  - WallTimeMs
+//  - UserTimeMs
+//  - SysTimeMs
+//  - MaxRssBytes
+//  - BlockInputOps
+//  - BlockOutputOps
+type RunUsage struct {
+	WallTimeMs     int64 `thrift:"wallTimeMs,1,required" json:"wallTimeMs"`
+	UserTimeMs     int64 `thrift:"userTimeMs,2,required" json:"userTimeMs"`
+	SysTimeMs      int64 `thrift:"sysTimeMs,3,required" json:"sysTimeMs"`
+	MaxRssBytes    int64 `thrift:"maxRssBytes,4,required" json:"maxRssBytes"`
+	BlockInputOps  int64 `thrift:"blockInputOps,5,required" json:"blockInputOps"`
+	BlockOutputOps int64 `thrift:"blockOutputOps,6,required" json:"blockOutputOps"`
+}
+
+func NewRunUsage() *RunUsage {
+	return &RunUsage{}
+}
+
+func (p *RunUsage) GetWallTimeMs() int64 {
+	return p.WallTimeMs
+}
+
+func (p *RunUsage) GetUserTimeMs() int64 {
+	return p.UserTimeMs
+}
+
+func (p *RunUsage) GetSysTimeMs() int64 {
+	return p.SysTimeMs
+}
+
+func (p *RunUsage) GetMaxRssBytes() int64 {
+	return p.MaxRssBytes
+}
+
+func (p *RunUsage) GetBlockInputOps() int64 {
+	return p.BlockInputOps
+}
+
+func (p *RunUsage) GetBlockOutputOps() int64 {
+	return p.BlockOutputOps
+}
+func (p *RunUsage) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetWallTimeMs bool = false
+	var issetUserTimeMs bool = false
+	var issetSysTimeMs bool = false
+	var issetMaxRssBytes bool = false
+	var issetBlockInputOps bool = false
+	var issetBlockOutputOps bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetWallTimeMs = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetUserTimeMs = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetSysTimeMs = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+			issetMaxRssBytes = true
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+			issetBlockInputOps = true
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+			issetBlockOutputOps = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetWallTimeMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WallTimeMs is not set"))
+	}
+	if !issetUserTimeMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field UserTimeMs is not set"))
+	}
+	if !issetSysTimeMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field SysTimeMs is not set"))
+	}
+	if !issetMaxRssBytes {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field MaxRssBytes is not set"))
+	}
+	if !issetBlockInputOps {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field BlockInputOps is not set"))
+	}
+	if !issetBlockOutputOps {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field BlockOutputOps is not set"))
+	}
+	return nil
+}
+
+func (p *RunUsage) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.WallTimeMs = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.UserTimeMs = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.SysTimeMs = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.MaxRssBytes = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.BlockInputOps = v
+	}
+	return nil
+}
+
+func (p *RunUsage) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.BlockOutputOps = v
+	}
+	return nil
+}
+
+func (p *RunUsage) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RunUsage"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *RunUsage) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("wallTimeMs", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:wallTimeMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.WallTimeMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.wallTimeMs (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:wallTimeMs: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("userTimeMs", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:userTimeMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.UserTimeMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.userTimeMs (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:userTimeMs: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("sysTimeMs", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:sysTimeMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.SysTimeMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.sysTimeMs (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:sysTimeMs: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("maxRssBytes", thrift.I64, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:maxRssBytes: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.MaxRssBytes)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.maxRssBytes (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:maxRssBytes: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("blockInputOps", thrift.I64, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:blockInputOps: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.BlockInputOps)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.blockInputOps (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:blockInputOps: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) writeField6(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("blockOutputOps", thrift.I64, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:blockOutputOps: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.BlockOutputOps)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.blockOutputOps (6) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:blockOutputOps: ", p), err)
+	}
+	return err
+}
+
+func (p *RunUsage) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RunUsage(%+v)", *p)
+}
+
 // Attributes:
 //  - Status
 //  - RunId
@@ -96,14 +714,18 @@ func (p *Status) UnmarshalText(text []byte) error {
 //  - Error
 //  - ExitCode
 //  - SnapshotId
+//  - Attempts
+//  - Usage
 type RunStatus struct {
-	Status     Status  `thrift:"status,1,required" json:"status"`
-	RunId      string  `thrift:"runId,2,required" json:"runId"`
-	OutUri     *string `thrift:"outUri,3" json:"outUri,omitempty"`
-	ErrUri     *string `thrift:"errUri,4" json:"errUri,omitempty"`
-	Error      *string `thrift:"error,5" json:"error,omitempty"`
-	ExitCode   *int32  `thrift:"exitCode,6" json:"exitCode,omitempty"`
-	SnapshotId *string `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
+	Status     Status        `thrift:"status,1,required" json:"status"`
+	RunId      string        `thrift:"runId,2,required" json:"runId"`
+	OutUri     *string       `thrift:"outUri,3" json:"outUri,omitempty"`
+	ErrUri     *string       `thrift:"errUri,4" json:"errUri,omitempty"`
+	Error      *string       `thrift:"error,5" json:"error,omitempty"`
+	ExitCode   *int32        `thrift:"exitCode,6" json:"exitCode,omitempty"`
+	SnapshotId *string       `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
+	Attempts   []*RunAttempt `thrift:"attempts,8" json:"attempts,omitempty"`
+	Usage      *RunUsage     `thrift:"usage,9" json:"usage,omitempty"`
 }
 
 func NewRunStatus() *RunStatus {
@@ -162,6 +784,21 @@ func (p *RunStatus) GetSnapshotId() string {
 	}
 	return *p.SnapshotId
 }
+
+var RunStatus_Attempts_DEFAULT []*RunAttempt
+
+func (p *RunStatus) GetAttempts() []*RunAttempt {
+	return p.Attempts
+}
+
+var RunStatus_Usage_DEFAULT *RunUsage
+
+func (p *RunStatus) GetUsage() *RunUsage {
+	if !p.IsSetUsage() {
+		return RunStatus_Usage_DEFAULT
+	}
+	return p.Usage
+}
 func (p *RunStatus) IsSetOutUri() bool {
 	return p.OutUri != nil
 }
@@ -182,6 +819,14 @@ func (p *RunStatus) IsSetSnapshotId() bool {
 	return p.SnapshotId != nil
 }
 
+func (p *RunStatus) IsSetAttempts() bool {
+	return p.Attempts != nil
+}
+
+func (p *RunStatus) IsSetUsage() bool {
+	return p.Usage != nil
+}
+
 func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -229,6 +874,14 @@ func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField7(iprot); err != nil {
 				return err
 			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -314,6 +967,34 @@ func (p *RunStatus) readField7(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *RunStatus) readField8(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*RunAttempt, 0, size)
+	p.Attempts = tSlice
+	for i := 0; i < size; i++ {
+		_elem0 := &RunAttempt{}
+		if err := _elem0.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem0), err)
+		}
+		p.Attempts = append(p.Attempts, _elem0)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *RunStatus) readField9(iprot thrift.TProtocol) error {
+	p.Usage = &RunUsage{}
+	if err := p.Usage.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Usage), err)
+	}
+	return nil
+}
+
 func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -339,6 +1020,12 @@ func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField7(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -449,6 +1136,44 @@ func (p *RunStatus) writeField7(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RunStatus) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAttempts() {
+		if err := oprot.WriteFieldBegin("attempts", thrift.LIST, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:attempts: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Attempts)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Attempts {
+			if err := v.Write(oprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:attempts: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunStatus) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetUsage() {
+		if err := oprot.WriteFieldBegin("usage", thrift.STRUCT, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:usage: ", p), err)
+		}
+		if err := p.Usage.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Usage), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:usage: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *RunStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -458,8 +1183,12 @@ func (p *RunStatus) String() string {
 
 // Attributes:
 //  - Runs
+//  - CpuSlots
+//  - MemoryBytes
 type WorkerStatus struct {
-	Runs []*RunStatus `thrift:"runs,1,required" json:"runs"`
+	Runs        []*RunStatus `thrift:"runs,1,required" json:"runs"`
+	CpuSlots    *int32       `thrift:"cpuSlots,2" json:"cpuSlots,omitempty"`
+	MemoryBytes *int64       `thrift:"memoryBytes,3" json:"memoryBytes,omitempty"`
 }
 
 func NewWorkerStatus() *WorkerStatus {
@@ -469,6 +1198,32 @@ func NewWorkerStatus() *WorkerStatus {
 func (p *WorkerStatus) GetRuns() []*RunStatus {
 	return p.Runs
 }
+
+var WorkerStatus_CpuSlots_DEFAULT int32
+
+func (p *WorkerStatus) GetCpuSlots() int32 {
+	if !p.IsSetCpuSlots() {
+		return WorkerStatus_CpuSlots_DEFAULT
+	}
+	return *p.CpuSlots
+}
+
+var WorkerStatus_MemoryBytes_DEFAULT int64
+
+func (p *WorkerStatus) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return WorkerStatus_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+func (p *WorkerStatus) IsSetCpuSlots() bool {
+	return p.CpuSlots != nil
+}
+
+func (p *WorkerStatus) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
 func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -490,6 +1245,14 @@ func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetRuns = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -516,11 +1279,11 @@ func (p *WorkerStatus) readField1(iprot thrift.TProtocol) error {
 	tSlice := make([]*RunStatus, 0, size)
 	p.Runs = tSlice
 	for i := 0; i < size; i++ {
-		_elem0 := &RunStatus{}
-		if err := _elem0.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem0), err)
+		_elem1 := &RunStatus{}
+		if err := _elem1.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem1), err)
 		}
-		p.Runs = append(p.Runs, _elem0)
+		p.Runs = append(p.Runs, _elem1)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -528,6 +1291,24 @@ func (p *WorkerStatus) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *WorkerStatus) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.CpuSlots = &v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
 func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("WorkerStatus"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -535,6 +1316,12 @@ func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -565,6 +1352,36 @@ func (p *WorkerStatus) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *WorkerStatus) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpuSlots() {
+		if err := oprot.WriteFieldBegin("cpuSlots", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:cpuSlots: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.CpuSlots)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpuSlots (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:cpuSlots: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *WorkerStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -577,11 +1394,17 @@ func (p *WorkerStatus) String() string {
 //  - Env
 //  - SnapshotId
 //  - TimeoutMs
+//  - Network
+//  - Outputs
+//  - Priority
 type RunCommand struct {
 	Argv       []string          `thrift:"argv,1,required" json:"argv"`
 	Env        map[string]string `thrift:"env,2" json:"env,omitempty"`
 	SnapshotId *string           `thrift:"snapshotId,3" json:"snapshotId,omitempty"`
 	TimeoutMs  *int32            `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
+	Network    *bool             `thrift:"network,5" json:"network,omitempty"`
+	Outputs    map[string]string `thrift:"outputs,6" json:"outputs,omitempty"`
+	Priority   *int32            `thrift:"priority,7" json:"priority,omitempty"`
 }
 
 func NewRunCommand() *RunCommand {
@@ -615,6 +1438,30 @@ func (p *RunCommand) GetTimeoutMs() int32 {
 	}
 	return *p.TimeoutMs
 }
+
+var RunCommand_Network_DEFAULT bool
+
+func (p *RunCommand) GetNetwork() bool {
+	if !p.IsSetNetwork() {
+		return RunCommand_Network_DEFAULT
+	}
+	return *p.Network
+}
+
+var RunCommand_Outputs_DEFAULT map[string]string
+
+func (p *RunCommand) GetOutputs() map[string]string {
+	return p.Outputs
+}
+
+var RunCommand_Priority_DEFAULT int32
+
+func (p *RunCommand) GetPriority() int32 {
+	if !p.IsSetPriority() {
+		return RunCommand_Priority_DEFAULT
+	}
+	return *p.Priority
+}
 func (p *RunCommand) IsSetEnv() bool {
 	return p.Env != nil
 }
@@ -627,6 +1474,18 @@ func (p *RunCommand) IsSetTimeoutMs() bool {
 	return p.TimeoutMs != nil
 }
 
+func (p *RunCommand) IsSetNetwork() bool {
+	return p.Network != nil
+}
+
+func (p *RunCommand) IsSetOutputs() bool {
+	return p.Outputs != nil
+}
+
+func (p *RunCommand) IsSetPriority() bool {
+	return p.Priority != nil
+}
+
 func (p *RunCommand) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -660,6 +1519,18 @@ func (p *RunCommand) Read(iprot thrift.TProtocol) error {
 			if err := p.readField4(iprot); err != nil {
 				return err
 			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -686,13 +1557,13 @@ func (p *RunCommand) readField1(iprot thrift.TProtocol) error {
 	tSlice := make([]string, 0, size)
 	p.Argv = tSlice
 	for i := 0; i < size; i++ {
-		var _elem1 string
+		var _elem2 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_elem1 = v
+			_elem2 = v
 		}
-		p.Argv = append(p.Argv, _elem1)
+		p.Argv = append(p.Argv, _elem2)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -708,19 +1579,19 @@ func (p *RunCommand) readField2(iprot thrift.TProtocol) error {
 	tMap := make(map[string]string, size)
 	p.Env = tMap
 	for i := 0; i < size; i++ {
-		var _key2 string
+		var _key3 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_key2 = v
+			_key3 = v
 		}
-		var _val3 string
+		var _val4 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_val3 = v
+			_val4 = v
 		}
-		p.Env[_key2] = _val3
+		p.Env[_key3] = _val4
 	}
 	if err := iprot.ReadMapEnd(); err != nil {
 		return thrift.PrependError("error reading map end: ", err)
@@ -746,6 +1617,52 @@ func (p *RunCommand) readField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *RunCommand) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Network = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField6(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]string, size)
+	p.Outputs = tMap
+	for i := 0; i < size; i++ {
+		var _key5 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key5 = v
+		}
+		var _val6 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val6 = v
+		}
+		p.Outputs[_key5] = _val6
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *RunCommand) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Priority = &v
+	}
+	return nil
+}
+
 func (p *RunCommand) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("RunCommand"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -762,6 +1679,15 @@ func (p *RunCommand) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField4(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -848,6 +1774,62 @@ func (p *RunCommand) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RunCommand) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNetwork() {
+		if err := oprot.WriteFieldBegin("network", thrift.BOOL, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:network: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.Network)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.network (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:network: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetOutputs() {
+		if err := oprot.WriteFieldBegin("outputs", thrift.MAP, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:outputs: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Outputs)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Outputs {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:outputs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPriority() {
+		if err := oprot.WriteFieldBegin("priority", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:priority: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Priority)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.priority (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:priority: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *RunCommand) String() string {
 	if p == nil {
 		return "<nil>"
@@ -131,19 +131,19 @@ func main() {
 			fmt.Fprintln(os.Stderr, "Run requires 1 args")
 			flag.Usage()
 		}
-		arg14 := flag.Arg(1)
-		mbTrans15 := thrift.NewTMemoryBufferLen(len(arg14))
-		defer mbTrans15.Close()
-		_, err16 := mbTrans15.WriteString(arg14)
-		if err16 != nil {
+		arg17 := flag.Arg(1)
+		mbTrans18 := thrift.NewTMemoryBufferLen(len(arg17))
+		defer mbTrans18.Close()
+		_, err19 := mbTrans18.WriteString(arg17)
+		if err19 != nil {
 			Usage()
 			return
 		}
-		factory17 := thrift.NewTSimpleJSONProtocolFactory()
-		jsProt18 := factory17.GetProtocol(mbTrans15)
+		factory20 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt21 := factory20.GetProtocol(mbTrans18)
 		argvalue0 := worker.NewRunCommand()
-		err19 := argvalue0.Read(jsProt18)
-		if err19 != nil {
+		err22 := argvalue0.Read(jsProt21)
+		if err22 != nil {
 			Usage()
 			return
 		}
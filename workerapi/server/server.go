@@ -30,14 +30,16 @@ func MakeServer(
 type handler struct {
 	stat        stats.StatsReceiver
 	run         runner.Service
+	capacity    domain.Capacity
 	timeLastRpc time.Time
 	mu          sync.Mutex
 }
 
-// Creates a new Handler which combines a runner.Service to do work and a StatsReceiver
-func NewHandler(stat stats.StatsReceiver, run runner.Service) worker.Worker {
+// Creates a new Handler which combines a runner.Service to do work and a StatsReceiver.
+// The capacity is advertised to schedulers through QueryWorker
+func NewHandler(stat stats.StatsReceiver, run runner.Service, capacity domain.Capacity) worker.Worker {
 	scopedStat := stat.Scope("handler")
-	h := &handler{stat: scopedStat, run: run}
+	h := &handler{stat: scopedStat, run: run, capacity: capacity}
 	go h.stats()
 	return h
 }
@@ -90,14 +92,11 @@ func (h *handler) updateTimeLastRpc() {
 func (h *handler) QueryWorker() (*worker.WorkerStatus, error) {
 	h.stat.Counter("workerQueries").Inc(1)
 	h.updateTimeLastRpc()
-	ws := worker.NewWorkerStatus()
 	st, err := h.run.StatusAll()
 	if err != nil {
 		return nil, err
 	}
-	for _, process := range st {
-		ws.Runs = append(ws.Runs, domain.DomainRunStatusToThrift(process))
-	}
+	ws := domain.DomainWorkerStatusToThrift(domain.WorkerStatus{Runs: st, Capacity: h.capacity})
 	return ws, nil
 }
 
@@ -12,6 +12,8 @@ import (
 	"github.com/scootdev/scoot/runner/execer"
 	"github.com/scootdev/scoot/runner/execer/execers"
 	osexec "github.com/scootdev/scoot/runner/execer/os"
+	"github.com/scootdev/scoot/runner/runners"
+	domain "github.com/scootdev/scoot/workerapi"
 	"github.com/scootdev/scoot/workerapi/gen-go/worker"
 )
 
@@ -46,8 +48,12 @@ func (m module) Install(b *ice.MagicBag) {
 		func(m execer.Memory, s stats.StatsReceiver) execer.Execer {
 			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), osexec.NewBoundedExecer(m, s))
 		},
-		func(stat stats.StatsReceiver, r runner.Service) worker.Worker {
-			return NewHandler(stat, r)
+		// The worker runs a command in each of its slots, within the memory cap if there is one
+		func(m execer.Memory, slots runners.Slots) domain.Capacity {
+			return domain.Capacity{CPUSlots: int(slots), MemoryBytes: int64(m)}
+		},
+		func(stat stats.StatsReceiver, r runner.Service, c domain.Capacity) worker.Worker {
+			return NewHandler(stat, r, c)
 		},
 		func(
 			handler worker.Worker,
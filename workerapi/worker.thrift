@@ -9,6 +9,27 @@ enum Status {
   BADREQUEST = 7   # Invalid or error'd request. Original worker state not affected. Retry may work after mutation.
 }
 
+// An earlier run of a task that was retried.  Workers don't return these, the
+// scheduler logs a task's earlier runs along with the status of its latest run.
+struct RunAttempt {
+  1: required string workerId  # Worker the run was on.
+  2: required Status status
+  3: optional string runId
+  4: optional i32 exitCode
+  5: optional string error
+  6: optional bool backup     # Run of a backup copy, not a separate attempt.
+}
+
+// Resources used by a run's process and the children it waited for.
+struct RunUsage {
+  1: required i64 wallTimeMs
+  2: required i64 userTimeMs      # CPU time in user mode.
+  3: required i64 sysTimeMs       # CPU time in the kernel.
+  4: required i64 maxRssBytes     # Largest resident set of the process or one of its children.
+  5: required i64 blockInputOps   # Times the filesystem had to read from disk.
+  6: required i64 blockOutputOps  # Times the filesystem had to write to disk.
+}
+
 // Note, each worker has its own runId space which is unrelated to any external ids.
 struct RunStatus {
   1: required Status status
@@ -18,11 +39,14 @@ struct RunStatus {
   5: optional string error
   6: optional i32 exitCode
   7: optional string snapshotId
+  8: optional list<RunAttempt> attempts  # Earlier runs of the same task, oldest first. Only set by the scheduler.
+  9: optional RunUsage usage             # Unset if the run's process wasn't run or measured.
 }
 
-// TODO: add useful load information when it comes time to have multiple runs.
 struct WorkerStatus {
   1: required list<RunStatus> runs  # All runs excepting what's been Erase()'d
+  2: optional i32 cpuSlots          # Number of cpu slots the worker offers to runs, unset means 1. Each run takes at least one.
+  3: optional i64 memoryBytes       # Memory the worker offers to runs, unset means unbounded.
 }
 
 struct RunCommand {
@@ -30,6 +54,9 @@ struct RunCommand {
   2: optional map<string,string> env  # Mapping of env name to value.
   3: optional string snapshotId       # Scheme'd id, could be a patchId, sha1, etc.
   4: optional i32 timeoutMs           # Kill the job if it hasn't completed in time (Status.TIMEOUT).
+  5: optional bool network            # Allow network access if the worker runs commands in a sandbox.
+  6: optional map<string,string> outputs  # Checkout paths, possibly with '*' wildcards, to the result snapshot dirs they're copied to.
+  7: optional i32 priority            # Higher priority commands are run first if the worker has to queue them. Defaults to 0.
 }
 
 //TODO: add a method to kill the worker if we can articulate unrecoverable issues.